@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	coreexec "os/exec"
@@ -131,9 +132,12 @@ func showStep(w http.ResponseWriter, cl cloud.Cloud, idx int, step *testlib.Step
 		return
 	}
 
+	outln("<h3>Diff</h3>")
+	outln(diffTable(result))
+
 	outln("<h3>Got graph</h3>")
 	outln("")
-	svg, err := dotSVG(graphviz.Do(result.Got))
+	svg, err := dotSVG(graphviz.Do(result.Got, graphviz.ColorByOpOption()))
 	if err == nil {
 		outln(svg)
 	} else {
@@ -144,7 +148,7 @@ func showStep(w http.ResponseWriter, cl cloud.Cloud, idx int, step *testlib.Step
 
 	outln("<h3>Want graph</h3>")
 	outln("")
-	svg, err = dotSVG(graphviz.Do(result.Want))
+	svg, err = dotSVG(graphviz.Do(result.Want, graphviz.ColorByOpOption()))
 	if err == nil {
 		outln(svg)
 	} else {
@@ -153,6 +157,17 @@ func showStep(w http.ResponseWriter, cl cloud.Cloud, idx int, step *testlib.Step
 	}
 	outln("")
 
+	outln("<h3>Diff graph</h3>")
+	outln("")
+	svg, err = dotSVG(graphviz.DoDiff(result.Got, result.Want, graphviz.LegendOption()))
+	if err == nil {
+		outln(svg)
+	} else {
+		klog.Infof("dotSVG(Diff) = _, %v", err)
+		outf("dotSVG() = %v", err)
+	}
+	outln("")
+
 	var viz exec.GraphvizTracer
 	ex, err := exec.NewSerialExecutor(cl, result.Actions, exec.DryRunOption(false), exec.TracerOption(&viz))
 	if err != nil {
@@ -181,12 +196,32 @@ func showStep(w http.ResponseWriter, cl cloud.Cloud, idx int, step *testlib.Step
 	} else {
 		outln("<ol>")
 		for _, item := range execResult.Pending {
-			outf("<li>%+v</li>", item.Metadata())
+			outf("<li>%+v", item.Metadata())
+			if prereqs := exec.Prerequisites(item, execResult.Pending); len(prereqs) > 0 {
+				outf("<br />Blocked on: %v", prereqs)
+			}
+			outln("</li>")
 		}
 		outln("</ol>")
 	}
 }
 
+// diffTable returns an HTML table with the planned operation and the changed
+// fields for each resource that will be changed by the plan, sorted by
+// resource.
+func diffTable(result *plan.Result) string {
+	var b strings.Builder
+	b.WriteString("<table border=\"1\">\n")
+	b.WriteString("<tr><th>Resource</th><th>Operation</th><th>Changes</th></tr>\n")
+	for _, l := range result.PlanLines() {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(l.ID), l.Operation, html.EscapeString(l.Details))
+	}
+	b.WriteString("</table>")
+
+	return b.String()
+}
+
 func dotSVG(text string) (string, error) {
 	klog.Infof("dotSVG: %q", text)
 	cmd := coreexec.Command(flags.dotExec, "-Tsvg")
@@ -51,10 +51,7 @@ func expectActions(got []exec.Action, want []exec.ActionMetadata) error {
 }
 
 func resourceSelfLink(id *cloud.ResourceID) string {
-	apiGroup := meta.APIGroupCompute
-	relName := cloud.RelativeResourceName(id.ProjectID, id.Resource, id.Key)
-	prefix := fmt.Sprintf("https://%s.googleapis.com/v1", apiGroup)
-	return prefix + "/" + relName
+	return cloud.LegacySelfLinkForID(id, meta.VersionGA)
 }
 
 func checkGCEHealthCheck(t *testing.T, ctx context.Context, cloud cloud.Cloud, hcID *cloud.ResourceID, hcInterval int) {
@@ -26,7 +26,7 @@ import (
 func checkPostAccess(traits *FieldTraits, v reflect.Value) error {
 	acc := newAcceptorFuncs()
 	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
-		if p.Equal(Path{}.Pointer().Field("ServerResponse")) {
+		if isServerResponse(p) {
 			return false, nil
 		}
 
@@ -43,6 +43,9 @@ func checkPostAccess(traits *FieldTraits, v reflect.Value) error {
 			fv := v.Field(i)
 			fp := p.Field(ft.Name)
 
+			if err := traits.checkAllowedValue(fp, fv); err != nil {
+				return false, err
+			}
 			switch fType {
 			case FieldTypeSystem:
 				if !fv.IsZero() {
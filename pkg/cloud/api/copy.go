@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"sync"
 )
 
 type missingFieldOnCopy struct {
@@ -30,6 +31,12 @@ type missingFieldOnCopy struct {
 // copierOption are options that customize the behavior of the internal copier.
 type copierOption func(*copier)
 
+// copierNoCache makes the copier compute the struct copy plans on each copy
+// instead of using the cache shared by all copiers. This is for testing.
+func copierNoCache() copierOption {
+	return func(c *copier) { c.noCache = true }
+}
+
 // copierLogS configures logging for the copier to use the given func f.
 func copierLogS(f func(msg string, kv ...any)) copierOption {
 	return func(c *copier) { c.logSFn = f }
@@ -47,10 +54,60 @@ type copier struct {
 	// logSFn is an optional structured log function, matching the
 	// signature from klog/v2.
 	logSFn func(msg string, kv ...any)
+	// noCache disables structCopyPlans.
+	noCache bool
 
 	missing []missingFieldOnCopy
 }
 
+// structCopyPlan is how the fields of a src struct type are copied to a dest
+// struct type. Computing this with reflection is expensive for large types
+// (e.g. BackendService), so the plans are cached by type in structCopyPlans.
+type structCopyPlan struct {
+	fields []fieldCopyPlan
+}
+
+type fieldCopyPlan struct {
+	name string
+	// src is the index of the field in the src struct.
+	src int
+	// dest is the index of the field in the dest struct (see
+	// reflect.Value.FieldByIndex). dest is nil if the field does not exist
+	// in dest.
+	dest []int
+}
+
+type structCopyPlanKey struct {
+	dest, src reflect.Type
+}
+
+// structCopyPlans is a map[structCopyPlanKey]*structCopyPlan.
+var structCopyPlans sync.Map
+
+func newStructCopyPlan(dest, src reflect.Type) *structCopyPlan {
+	plan := &structCopyPlan{}
+	for i := 0; i < src.NumField(); i++ {
+		f := fieldCopyPlan{name: src.Field(i).Name, src: i}
+		if df, ok := dest.FieldByName(f.name); ok {
+			f.dest = df.Index
+		}
+		plan.fields = append(plan.fields, f)
+	}
+	return plan
+}
+
+func (c *copier) structCopyPlan(dest, src reflect.Type) *structCopyPlan {
+	if c.noCache {
+		return newStructCopyPlan(dest, src)
+	}
+	key := structCopyPlanKey{dest: dest, src: src}
+	if plan, ok := structCopyPlans.Load(key); ok {
+		return plan.(*structCopyPlan)
+	}
+	plan, _ := structCopyPlans.LoadOrStore(key, newStructCopyPlan(dest, src))
+	return plan.(*structCopyPlan)
+}
+
 func (c *copier) logS(msg string, kv ...any) {
 	if c.logSFn == nil {
 		return
@@ -144,34 +201,35 @@ func (c *copier) doStruct(p Path, dest, src reflect.Value) error {
 	}
 	// Copy over fields that are present in both src and dest. Fields in dest
 	// that don't exist in src are left alone.
-	for i := 0; i < src.Type().NumField(); i++ {
-		srcFieldT := src.Type().Field(i)
-		fieldName := srcFieldT.Name
-		destField := dest.FieldByName(fieldName)
-		_, ok := dest.Type().FieldByName(fieldName)
+	for _, f := range c.structCopyPlan(dest.Type(), src.Type()).fields {
+		fieldName := f.name
+		srcField := src.Field(f.src)
 
-		if !ok {
+		if f.dest == nil {
 			// Only non-zero fields are counted towards
 			// the missing fields. Fields explicitly named
 			// in NullFields or ForceSendFields are
 			// handled by copyMetaFields() below.
-			if !src.Field(i).IsZero() {
+			if !srcField.IsZero() {
 				c.missing = append(c.missing, missingFieldOnCopy{
 					Path:  p.Field(fieldName),
-					Value: src.Field(i).Interface(),
+					Value: srcField.Interface(),
 				})
 				c.logS("copyStruct missing field", "path", p, "fieldName", fieldName)
 			}
 			continue
 		}
 
-		// ServerResponse should be skipped.
-		if (p.Equal(Path{}) || p.Equal(Path{}.Pointer())) && fieldName == "ServerResponse" {
+		// ServerResponse should be skipped. Nested structs that are also
+		// returned by a method (e.g. SecurityPolicyRule from GetRule) have
+		// a ServerResponse as well.
+		if fieldName == "ServerResponse" {
 			continue
 		}
 
+		destField := dest.FieldByIndex(f.dest)
 		if fieldName == "NullFields" || fieldName == "ForceSendFields" {
-			err := c.doMetaFields(p.Field(fieldName), destField, src.Field(i), dest, src)
+			err := c.doMetaFields(p.Field(fieldName), destField, srcField, dest, src)
 			if err != nil {
 				return err
 			}
@@ -179,7 +237,7 @@ func (c *copier) doStruct(p Path, dest, src reflect.Value) error {
 		}
 
 		c.logS("copyStruct", "path", p, "fieldName", fieldName)
-		if err := c.doValues(p.Field(fieldName), destField, src.Field(i)); err != nil {
+		if err := c.doValues(p.Field(fieldName), destField, srcField); err != nil {
 			return err
 		}
 	}
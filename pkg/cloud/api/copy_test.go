@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	"google.golang.org/api/compute/v1"
 )
 
 func testCopier(t *testing.T) *copier {
@@ -554,3 +556,87 @@ func TestCopyMetaFields(t *testing.T) {
 		})
 	}
 }
+
+func copyTestBackendServices() (*compute.BackendService, *alpha.BackendService) {
+	ga := &compute.BackendService{
+		Name:                "bs",
+		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+		Backends: []*compute.Backend{
+			{Group: "ig1", BalancingMode: "UTILIZATION", MaxUtilization: 0.8},
+			{Group: "ig2", CapacityScaler: 1},
+		},
+		HealthChecks:     []string{"hc1", "hc2"},
+		CdnPolicy:        &compute.BackendServiceCdnPolicy{CacheMode: "CACHE_ALL_STATIC"},
+		Metadatas:        map[string]string{"k": "v"},
+		TimeoutSec:       30,
+		ForceSendFields:  []string{"TimeoutSec"},
+		NullFields:       []string{"Description"},
+		SecuritySettings: &compute.SecuritySettings{SubjectAltNames: []string{"san"}},
+	}
+	a := &alpha.BackendService{
+		Name:                     "bs",
+		Backends:                 []*alpha.Backend{{Group: "ig1"}},
+		IpAddressSelectionPolicy: "IPV4_ONLY",
+		VpcNetworkScope:          "GLOBAL_VPC_NETWORK",
+		ForceSendFields:          []string{"VpcNetworkScope"},
+	}
+	return ga, a
+}
+
+func TestCopierCache(t *testing.T) {
+	t.Parallel()
+
+	ga, alphaBS := copyTestBackendServices()
+	for _, tc := range []struct {
+		name    string
+		src     any
+		newDest func() any
+	}{
+		{name: "ga to alpha", src: ga, newDest: func() any { return &alpha.BackendService{} }},
+		{name: "alpha to ga", src: alphaBS, newDest: func() any { return &compute.BackendService{} }},
+		{name: "ga to ga", src: ga, newDest: func() any { return &compute.BackendService{} }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			type result struct {
+				dest    any
+				missing []missingFieldOnCopy
+			}
+			run := func(opts ...copierOption) result {
+				dest := tc.newDest()
+				c := newCopier(opts...)
+				if err := c.do(reflect.ValueOf(dest), reflect.ValueOf(tc.src)); err != nil {
+					t.Fatalf("do() = %v, want nil", err)
+				}
+				return result{dest: dest, missing: c.missing}
+			}
+			uncached := run(copierNoCache())
+			// Run twice so that the second copy uses the cached plan.
+			for i := 0; i < 2; i++ {
+				cached := run()
+				if diff := cmp.Diff(cached, uncached, cmp.AllowUnexported(result{}, missingFieldOnCopy{})); diff != "" {
+					t.Errorf("cached copy %d: diff -got,+want: %s", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCopierBackendService(b *testing.B) {
+	ga, _ := copyTestBackendServices()
+	for _, bc := range []struct {
+		name string
+		opts []copierOption
+	}{
+		{name: "cached"},
+		{name: "uncached", opts: []copierOption{copierNoCache()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c := newCopier(bc.opts...)
+				if err := c.do(reflect.ValueOf(&alpha.BackendService{}), reflect.ValueOf(ga)); err != nil {
+					b.Fatalf("do() = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
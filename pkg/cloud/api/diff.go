@@ -21,12 +21,38 @@ import (
 	"reflect"
 )
 
-// TODO: how to diff force send fields? null fields? and zero values?
+// DiffOption is an option to Resource.Diff().
+type DiffOption func(*diffConfig)
+
+type diffConfig struct {
+	metaFieldsAsSets bool
+	// ignore are paths that are skipped in the diff (see
+	// Resource.DiffExcept).
+	ignore []Path
+}
+
+// CompareMetaFieldsAsSets compares the NullFields and ForceSendFields
+// meta-fields of the objects as unordered sets. Entries for ServerDefault and
+// ServerPopulated fields are not compared. By default, differences that are
+// only in the meta-fields are ignored.
+func CompareMetaFieldsAsSets() DiffOption {
+	return func(c *diffConfig) { c.metaFieldsAsSets = true }
+}
+
+// ignorePaths skips differences at or under any of the paths.
+func ignorePaths(paths []Path) DiffOption {
+	return func(c *diffConfig) { c.ignore = append(c.ignore, paths...) }
+}
 
 // diff returns a diff between A and B.
 //
+// NullFields and ForceSendFields are ignored unless CompareMetaFieldsAsSets()
+// is given. Objects obtained from the API do not set these fields in the same
+// way as objects constructed locally, so comparing them directly would result
+// in spurious diffs.
+//
 // TODO: the behavior of this is not symmetric -- diff(A,B) != diff(B,A).
-func diff[T any](a, b *T, trait *FieldTraits) (*DiffResult, error) {
+func diff[T any](a, b *T, trait *FieldTraits, opts ...DiffOption) (*DiffResult, error) {
 	if trait == nil {
 		trait = &FieldTraits{}
 	}
@@ -34,10 +60,25 @@ func diff[T any](a, b *T, trait *FieldTraits) (*DiffResult, error) {
 		traits: trait,
 		result: &DiffResult{},
 	}
+	for _, o := range opts {
+		o(&d.config)
+	}
 	err := d.do(Path{}, reflect.ValueOf(a), reflect.ValueOf(b))
 	if err != nil {
 		return nil, err
 	}
+	var spec []DiffItem
+	for _, item := range d.result.Items {
+		if trait.IsStatus(item.Path) {
+			d.result.StatusItems = append(d.result.StatusItems, item)
+			continue
+		}
+		if trait.IsImmutable(item.Path) {
+			item.Class = DiffItemRequiresRecreate
+		}
+		spec = append(spec, item)
+	}
+	d.result.Items = spec
 	return d.result, nil
 }
 
@@ -54,13 +95,58 @@ func diffStructs[A any, B any](a *A, b *B) (*DiffResult, error) {
 }
 
 // DiffResult gives a list of elements that differ.
+//
+// Items are ordered by a depth-first traversal of A. Slices and maps of
+// different lengths are reported as a single item at the Path of the slice or
+// map; otherwise each differing element is reported individually at its
+// index or key.
 type DiffResult struct {
+	// Items are the differences in the spec of the resource. These drive
+	// the changes to the resource.
 	Items []DiffItem
+	// StatusItems are the differences in fields marked FieldTraits.Status.
+	// These are informational only and are not considered by HasDiff(),
+	// RequiresRecreate() or FieldMask().
+	StatusItems []DiffItem
 }
 
-// HasDiff is true if the result is has a diff.
+// HasDiff is true if the result is has a diff in the spec of the resource.
 func (r *DiffResult) HasDiff() bool { return len(r.Items) > 0 }
 
+// RequiresRecreate is true if any of the items in the diff cannot be changed
+// in place.
+func (r *DiffResult) RequiresRecreate() bool {
+	for _, item := range r.Items {
+		if item.Class == DiffItemRequiresRecreate {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldMask returns the names of the top-level fields that contain a
+// difference. Names are returned in the order they first appear in the diff
+// and are not repeated. This can be used to restrict an operation (e.g. Patch)
+// to the fields that have changed.
+func (r *DiffResult) FieldMask() []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, item := range r.Items {
+		for _, elem := range item.Path {
+			if elem[0] != pathField {
+				continue
+			}
+			name := elem[1:]
+			if !seen[name] {
+				seen[name] = true
+				ret = append(ret, name)
+			}
+			break
+		}
+	}
+	return ret
+}
+
 func (r *DiffResult) add(state DiffItemState, p Path, a, b reflect.Value) {
 
 	di := DiffItem{
@@ -103,20 +189,61 @@ var (
 	DiffItemOnlyInB DiffItemState = "OnlyInB"
 )
 
+// DiffItemClass classifies the impact of a DiffItem on the resource.
+type DiffItemClass int
+
+const (
+	// DiffItemInPlace means the element can be changed without recreating
+	// the resource (e.g. with an Update or Patch).
+	DiffItemInPlace DiffItemClass = iota
+	// DiffItemRequiresRecreate means the element is at a path marked
+	// FieldTraits.Immutable and the resource must be recreated to change
+	// it.
+	DiffItemRequiresRecreate
+)
+
+// String implements Stringer.
+func (c DiffItemClass) String() string {
+	switch c {
+	case DiffItemInPlace:
+		return "InPlace"
+	case DiffItemRequiresRecreate:
+		return "RequiresRecreate"
+	}
+	return fmt.Sprintf("DiffItemClass(%d)", int(c))
+}
+
 // DiffItem is an element that is different.
 type DiffItem struct {
+	// State describes how the element differs.
 	State DiffItemState
-	Path  Path
-	A     any
-	B     any
+	// Class of the change. This is derived from the FieldTraits of the
+	// resource.
+	Class DiffItemClass
+	// Path to the element from the root of the object.
+	Path Path
+	// A is the value at Path in A, or nil if it does not exist.
+	A any
+	// B is the value at Path in B, or nil if it does not exist.
+	B any
 }
 
 type differ[T any] struct {
 	traits *FieldTraits
 	result *DiffResult
+	config diffConfig
 }
 
 func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
+	for _, ignore := range d.config.ignore {
+		if p.HasPrefix(ignore) {
+			return nil
+		}
+	}
+	if d.traits.isServerPopulated(p, av, bv) {
+		return nil
+	}
+
 	// cmpZero applies to pointer, slice and map values. Returns true if no
 	// further diff'ing is required for the values.
 	cmpZero := func() bool {
@@ -135,7 +262,7 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 
 	switch {
 	case isBasicV(av):
-		if !av.Equal(bv) {
+		if !av.Equal(bv) && !d.traits.isServerDefault(p, av, bv) {
 			d.result.add(DiffItemDifferent, p, av, bv)
 		}
 		return nil
@@ -151,11 +278,14 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 			afv := av.Field(i)
 			aft := av.Type().Field(i)
 
+			fp := p.Field(aft.Name)
+
 			if aft.Name == "NullFields" || aft.Name == "ForceSendFields" {
+				if d.config.metaFieldsAsSets {
+					d.diffMetaField(p, aft.Name, afv, bv.FieldByName(aft.Name))
+				}
 				continue
 			}
-
-			fp := p.Field(aft.Name)
 			switch d.traits.FieldType(fp) {
 			case FieldTypeOutputOnly, FieldTypeSystem:
 				continue
@@ -163,7 +293,7 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 
 			bfv := bv.FieldByName(aft.Name)
 			if !bfv.IsValid() {
-				d.result.add(DiffItemOnlyInA, p, av, bv)
+				d.result.add(DiffItemOnlyInA, fp, afv, bfv)
 				continue
 			}
 			if err := d.do(fp, afv, bfv); err != nil {
@@ -211,7 +341,8 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 			mp := p.MapIndex(amk)
 
 			if !bmv.IsValid() {
-				d.result.add(DiffItemDifferent, mp, amv, bmv)
+				d.result.add(DiffItemOnlyInA, mp, amv, bmv)
+				continue
 			}
 			if err := d.do(mp, amv, bmv); err != nil {
 				return fmt.Errorf("differ map %p: %w", mp, err)
@@ -222,3 +353,37 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 
 	return fmt.Errorf("differ: invalid type: %s", av.Type())
 }
+
+// diffMetaField compares the []string meta-fields av and bv of the struct at
+// p as sets. Fields that are filled in by the server (ServerDefault and
+// ServerPopulated) are skipped: the server does not list them in the
+// meta-fields of the objects it returns, so they would always differ from the
+// meta-fields of a locally constructed object.
+func (d *differ[T]) diffMetaField(p Path, name string, av, bv reflect.Value) {
+	fp := p.Field(name)
+	toSet := func(v reflect.Value) map[string]bool {
+		ret := map[string]bool{}
+		if !v.IsValid() || v.Kind() != reflect.Slice {
+			return ret
+		}
+		for i := 0; i < v.Len(); i++ {
+			fn := v.Index(i).String()
+			if d.traits.isServerSet(p.Field(fn)) {
+				continue
+			}
+			ret[fn] = true
+		}
+		return ret
+	}
+	as, bs := toSet(av), toSet(bv)
+	if len(as) != len(bs) {
+		d.result.add(DiffItemDifferent, fp, av, bv)
+		return
+	}
+	for k := range as {
+		if !bs[k] {
+			d.result.add(DiffItemDifferent, fp, av, bv)
+			return
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// DiffAllResult is the result of DiffAll. Each list is sorted by ResourceID.
+type DiffAllResult struct {
+	// Created are the resources that are in want but not in got.
+	Created []*cloud.ResourceID
+	// Deleted are the resources that are in got but not in want.
+	Deleted []*cloud.ResourceID
+	// Changed are the resources in both got and want that differ.
+	Changed []ResourceDiff
+	// Unchanged are the resources in both got and want that are the same.
+	Unchanged []*cloud.ResourceID
+}
+
+// ResourceDiff is the diff for a single resource.
+type ResourceDiff struct {
+	ID   *cloud.ResourceID
+	Diff *DiffResult
+}
+
+// DiffAll compares the got and want resources, matching them by ResourceID.
+// This is the per-resource part of planning, without the references between
+// resources. It is an error for got or want to contain the same ResourceID
+// more than once.
+func DiffAll[GA any, Alpha any, Beta any](got, want []Resource[GA, Alpha, Beta], opts ...DiffOption) (*DiffAllResult, error) {
+	gotMap, err := resourcesByID(got)
+	if err != nil {
+		return nil, fmt.Errorf("DiffAll: got: %w", err)
+	}
+	wantMap, err := resourcesByID(want)
+	if err != nil {
+		return nil, fmt.Errorf("DiffAll: want: %w", err)
+	}
+
+	ret := &DiffAllResult{}
+	for k, w := range wantMap {
+		g, ok := gotMap[k]
+		if !ok {
+			ret.Created = append(ret.Created, w.ResourceID())
+			continue
+		}
+		diff, err := g.Diff(w, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("DiffAll: %v: %w", w.ResourceID(), err)
+		}
+		if diff.HasDiff() {
+			ret.Changed = append(ret.Changed, ResourceDiff{ID: w.ResourceID(), Diff: diff})
+		} else {
+			ret.Unchanged = append(ret.Unchanged, w.ResourceID())
+		}
+	}
+	for k, g := range gotMap {
+		if _, ok := wantMap[k]; !ok {
+			ret.Deleted = append(ret.Deleted, g.ResourceID())
+		}
+	}
+
+	sortIDs(ret.Created)
+	sortIDs(ret.Deleted)
+	sortIDs(ret.Unchanged)
+	sort.Slice(ret.Changed, func(i, j int) bool { return ret.Changed[i].ID.String() < ret.Changed[j].ID.String() })
+
+	return ret, nil
+}
+
+func resourcesByID[GA any, Alpha any, Beta any](l []Resource[GA, Alpha, Beta]) (map[cloud.ResourceMapKey]Resource[GA, Alpha, Beta], error) {
+	ret := map[cloud.ResourceMapKey]Resource[GA, Alpha, Beta]{}
+	for _, r := range l {
+		k := r.ResourceID().MapKey()
+		if _, ok := ret[k]; ok {
+			return nil, fmt.Errorf("duplicate resource %v", r.ResourceID())
+		}
+		ret[k] = r
+	}
+	return ret, nil
+}
+
+func sortIDs(l []*cloud.ResourceID) {
+	sort.Slice(l, func(i, j int) bool { return l[i].String() < l[j].String() })
+}
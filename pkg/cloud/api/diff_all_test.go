@@ -0,0 +1,108 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+type diffAllResource = Resource[compute.BackendService, PlaceholderType, PlaceholderType]
+
+func diffAllID(name string) *cloud.ResourceID {
+	return &cloud.ResourceID{ProjectID: "proj-1", Resource: "backendServices", Key: meta.GlobalKey(name)}
+}
+
+func newDiffAllResource(t *testing.T, name string, timeout int64) diffAllResource {
+	t.Helper()
+	r := NewResource[compute.BackendService, PlaceholderType, PlaceholderType](diffAllID(name), nil)
+	if err := r.Access(func(x *compute.BackendService) {
+		x.Name = name
+		x.TimeoutSec = timeout
+	}); err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	fr, err := r.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	return fr
+}
+
+func TestDiffAll(t *testing.T) {
+	t.Parallel()
+
+	got := []diffAllResource{
+		newDiffAllResource(t, "changed", 30),
+		newDiffAllResource(t, "removed", 30),
+		newDiffAllResource(t, "same", 30),
+	}
+	want := []diffAllResource{
+		newDiffAllResource(t, "added", 30),
+		newDiffAllResource(t, "changed", 60),
+		newDiffAllResource(t, "same", 30),
+	}
+
+	result, err := DiffAll(got, want)
+	if err != nil {
+		t.Fatalf("DiffAll() = %v, want nil", err)
+	}
+
+	names := func(ids []*cloud.ResourceID) []string {
+		var ret []string
+		for _, id := range ids {
+			ret = append(ret, id.Key.Name)
+		}
+		return ret
+	}
+	if diff := cmp.Diff(names(result.Created), []string{"added"}); diff != "" {
+		t.Errorf("Created: diff -got,+want: %s", diff)
+	}
+	if diff := cmp.Diff(names(result.Deleted), []string{"removed"}); diff != "" {
+		t.Errorf("Deleted: diff -got,+want: %s", diff)
+	}
+	if diff := cmp.Diff(names(result.Unchanged), []string{"same"}); diff != "" {
+		t.Errorf("Unchanged: diff -got,+want: %s", diff)
+	}
+	if len(result.Changed) != 1 || result.Changed[0].ID.Key.Name != "changed" {
+		t.Fatalf("Changed = %+v, want [changed]", result.Changed)
+	}
+	items := result.Changed[0].Diff.Items
+	wantPath := Path{}.Pointer().Field("TimeoutSec")
+	if len(items) != 1 || !items[0].Path.Equal(wantPath) {
+		t.Errorf("Changed[0].Diff.Items = %+v, want a single diff at %s", items, wantPath)
+	}
+}
+
+func TestDiffAllDuplicate(t *testing.T) {
+	t.Parallel()
+
+	dup := []diffAllResource{
+		newDiffAllResource(t, "bs", 30),
+		newDiffAllResource(t, "bs", 60),
+	}
+	if _, err := DiffAll(dup, nil); err == nil {
+		t.Errorf("DiffAll(dup, nil) = nil, want error")
+	}
+	if _, err := DiffAll(nil, dup); err == nil {
+		t.Errorf("DiffAll(nil, dup) = nil, want error")
+	}
+}
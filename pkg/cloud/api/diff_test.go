@@ -17,8 +17,10 @@ limitations under the License.
 package api
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
 )
 
@@ -292,3 +294,470 @@ func TestDiffForStructWithUnexportedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffItems(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I  int
+		LS []string
+	}
+	type st struct {
+		I   int
+		St  sti
+		PSt *sti
+		LS  []string
+		LSt []sti
+		M   map[string]string
+		MSt map[string]sti
+	}
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []DiffItem
+	}{
+		{
+			name: "no diff",
+			a:    st{I: 1, LS: []string{"a"}, M: map[string]string{"a": "b"}},
+			b:    st{I: 1, LS: []string{"a"}, M: map[string]string{"a": "b"}},
+		},
+		{
+			name: "nested struct",
+			a:    st{St: sti{I: 1}},
+			b:    st{St: sti{I: 2}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("St").Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "nested pointer to struct",
+			a:    st{PSt: &sti{LS: []string{"a"}}},
+			b:    st{PSt: &sti{LS: []string{"b"}}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("PSt").Pointer().Field("LS").Index(0), A: "a", B: "b"},
+			},
+		},
+		{
+			name: "pointer only in A",
+			a:    st{PSt: &sti{I: 1}},
+			b:    st{},
+			want: []DiffItem{
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("PSt"), A: &sti{I: 1}, B: (*sti)(nil)},
+			},
+		},
+		{
+			name: "slice element",
+			a:    st{LS: []string{"a", "b"}},
+			b:    st{LS: []string{"a", "c"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("LS").Index(1), A: "b", B: "c"},
+			},
+		},
+		{
+			name: "slice of structs",
+			a:    st{LSt: []sti{{I: 1}, {I: 2}}},
+			b:    st{LSt: []sti{{I: 1}, {I: 3}}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("LSt").Index(1).Field("I"), A: 2, B: 3},
+			},
+		},
+		{
+			name: "slice length",
+			a:    st{LS: []string{"a"}},
+			b:    st{LS: []string{"a", "b"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("LS"), A: []string{"a"}, B: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "slice only in B",
+			a:    st{},
+			b:    st{LS: []string{"a"}},
+			want: []DiffItem{
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("LS"), A: []string(nil), B: []string{"a"}},
+			},
+		},
+		{
+			name: "map value",
+			a:    st{M: map[string]string{"k": "a"}},
+			b:    st{M: map[string]string{"k": "b"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("M").MapIndex("k"), A: "a", B: "b"},
+			},
+		},
+		{
+			name: "map key only in A",
+			a:    st{M: map[string]string{"k1": "a"}},
+			b:    st{M: map[string]string{"k2": "a"}},
+			want: []DiffItem{
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("M").MapIndex("k1"), A: "a"},
+			},
+		},
+		{
+			name: "map of structs",
+			a:    st{MSt: map[string]sti{"k": {I: 1}}},
+			b:    st{MSt: map[string]sti{"k": {I: 2}}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("MSt").MapIndex("k").Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "map size",
+			a:    st{M: map[string]string{"k": "a"}},
+			b:    st{M: map[string]string{"k": "a", "l": "b"}},
+			want: []DiffItem{
+				{
+					State: DiffItemDifferent,
+					Path:  Path{}.Pointer().Field("M"),
+					A:     map[string]string{"k": "a"},
+					B:     map[string]string{"k": "a", "l": "b"},
+				},
+			},
+		},
+		{
+			name: "multiple items",
+			a:    st{I: 1, St: sti{I: 1}},
+			b:    st{I: 2, St: sti{I: 2}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("I"), A: 1, B: 2},
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("St").Field("I"), A: 1, B: 2},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, nil)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("diff().Items: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
+func TestDiffItemClass(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+		S string
+	}
+	type st struct {
+		I   int
+		S   string
+		PSt *sti
+	}
+
+	dt := &FieldTraits{}
+	dt.Immutable(Path{}.Pointer().Field("S"))
+	dt.Immutable(Path{}.Pointer().Field("PSt").Pointer().Field("S"))
+
+	for _, tc := range []struct {
+		name         string
+		a            st
+		b            st
+		want         []DiffItem
+		wantRecreate bool
+	}{
+		{
+			name: "no diff",
+			a:    st{S: "a"},
+			b:    st{S: "a"},
+		},
+		{
+			name: "mutable field",
+			a:    st{I: 1, PSt: &sti{I: 1}},
+			b:    st{I: 2, PSt: &sti{I: 2}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Class: DiffItemInPlace, Path: Path{}.Pointer().Field("I"), A: 1, B: 2},
+				{State: DiffItemDifferent, Class: DiffItemInPlace, Path: Path{}.Pointer().Field("PSt").Pointer().Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "immutable field",
+			a:    st{I: 1, S: "a"},
+			b:    st{I: 2, S: "b"},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Class: DiffItemInPlace, Path: Path{}.Pointer().Field("I"), A: 1, B: 2},
+				{State: DiffItemDifferent, Class: DiffItemRequiresRecreate, Path: Path{}.Pointer().Field("S"), A: "a", B: "b"},
+			},
+			wantRecreate: true,
+		},
+		{
+			name: "nested immutable field",
+			a:    st{PSt: &sti{S: "a"}},
+			b:    st{PSt: &sti{S: "b"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Class: DiffItemRequiresRecreate, Path: Path{}.Pointer().Field("PSt").Pointer().Field("S"), A: "a", B: "b"},
+			},
+			wantRecreate: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, dt)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("diff().Items: -got,+want: %s", d)
+			}
+			if got := r.RequiresRecreate(); got != tc.wantRecreate {
+				t.Errorf("RequiresRecreate() = %t, want %t", got, tc.wantRecreate)
+			}
+		})
+	}
+}
+
+func TestDiffStatusItems(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		Health string
+	}
+	type st struct {
+		I      int
+		Status *sti
+	}
+
+	dt := &FieldTraits{}
+	dt.Immutable(Path{}.Pointer().Field("Status"))
+	dt.Status(Path{}.Pointer().Field("Status"))
+
+	for _, tc := range []struct {
+		name       string
+		a          st
+		b          st
+		want       []DiffItem
+		wantStatus []DiffItem
+	}{
+		{
+			name: "no diff",
+			a:    st{I: 1, Status: &sti{Health: "OK"}},
+			b:    st{I: 1, Status: &sti{Health: "OK"}},
+		},
+		{
+			name: "status only",
+			a:    st{I: 1, Status: &sti{Health: "OK"}},
+			b:    st{I: 1, Status: &sti{Health: "UNHEALTHY"}},
+			wantStatus: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("Status").Pointer().Field("Health"), A: "OK", B: "UNHEALTHY"},
+			},
+		},
+		{
+			name: "spec and status",
+			a:    st{I: 1, Status: &sti{Health: "OK"}},
+			b:    st{I: 2},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("I"), A: 1, B: 2},
+			},
+			wantStatus: []DiffItem{
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("Status"), A: &sti{Health: "OK"}, B: (*sti)(nil)},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, dt)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("diff().Items: -got,+want: %s", d)
+			}
+			if d := cmp.Diff(r.StatusItems, tc.wantStatus); d != "" {
+				t.Errorf("diff().StatusItems: -got,+want: %s", d)
+			}
+			if got, want := r.HasDiff(), len(tc.want) > 0; got != want {
+				t.Errorf("HasDiff() = %t, want %t", got, want)
+			}
+			// Status is also Immutable but a status change must not cause a
+			// recreate.
+			if r.RequiresRecreate() {
+				t.Errorf("RequiresRecreate() = true, want false")
+			}
+		})
+	}
+}
+
+func TestDiffServerDefault(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		TimeoutSec int64
+		S          string
+	}
+
+	dt := &FieldTraits{}
+	dt.ServerDefault(Path{}.Pointer().Field("TimeoutSec"), 30)
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []DiffItem
+	}{
+		{
+			name: "got has default, want is unset",
+			a:    st{TimeoutSec: 30},
+			b:    st{},
+		},
+		{
+			name: "got is unset, want has default",
+			a:    st{},
+			b:    st{TimeoutSec: 30},
+		},
+		{
+			name: "got is not the default",
+			a:    st{TimeoutSec: 10},
+			b:    st{},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("TimeoutSec"), A: int64(10), B: int64(0)},
+			},
+		},
+		{
+			name: "want is set to a different value",
+			a:    st{TimeoutSec: 30},
+			b:    st{TimeoutSec: 60},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("TimeoutSec"), A: int64(30), B: int64(60)},
+			},
+		},
+		{
+			name: "field without a default",
+			a:    st{S: "x"},
+			b:    st{},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("S"), A: "x", B: ""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, dt)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("diff().Items: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
+func TestDiffServerPopulated(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+	}
+	type st struct {
+		PSt *sti
+		LS  []string
+		S   string
+	}
+
+	dt := &FieldTraits{}
+	dt.ServerPopulated(Path{}.Pointer().Field("PSt"))
+	dt.ServerPopulated(Path{}.Pointer().Field("LS"))
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []DiffItem
+	}{
+		{
+			name: "got is populated, want is unset",
+			a:    st{PSt: &sti{I: 1}, LS: []string{"x"}},
+			b:    st{},
+		},
+		{
+			name: "got is unset, want is set",
+			a:    st{},
+			b:    st{PSt: &sti{I: 1}},
+		},
+		{
+			name: "both set and different",
+			a:    st{PSt: &sti{I: 1}, LS: []string{"x"}},
+			b:    st{PSt: &sti{I: 2}, LS: []string{"x"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("PSt").Pointer().Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "field not server populated",
+			a:    st{S: "x"},
+			b:    st{},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("S"), A: "x", B: ""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, dt)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("diff().Items: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
+func TestDiffResultFieldMask(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+		S string
+	}
+	type st struct {
+		I   int
+		S   string
+		PSt *sti
+		LS  []string
+	}
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []string
+	}{
+		{
+			name: "no diff",
+			a:    st{I: 1},
+			b:    st{I: 1},
+		},
+		{
+			name: "single field",
+			a:    st{S: "a"},
+			b:    st{S: "b"},
+			want: []string{"S"},
+		},
+		{
+			name: "nested fields are collapsed",
+			a:    st{PSt: &sti{I: 1, S: "a"}},
+			b:    st{PSt: &sti{I: 2, S: "b"}},
+			want: []string{"PSt"},
+		},
+		{
+			name: "multiple fields",
+			a:    st{I: 1, LS: []string{"a"}},
+			b:    st{I: 2, LS: []string{"b"}},
+			want: []string{"I", "LS"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, nil)
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if got := r.FieldMask(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FieldMask() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -27,7 +27,9 @@ limitations under the License.
 //
 // Every Resource is composed of three struct types: GA, Alpha and Beta types.
 // If a version is not used or is missing, the type should be set to
-// PlaceholderType. GA CANNOT be a PlaceholderType.
+// PlaceholderType. GA CANNOT be a PlaceholderType unless the TypeTrait
+// implements PlaceholderGATrait (for resources that only exist in Alpha or
+// Beta).
 
 // See MutableResource.ImpliedVersion() for resource version conversion
 // semantics.
@@ -78,4 +80,7 @@ limitations under the License.
 //	// finished. This allows for any additional fixup of the fields after
 //	// conversion.
 //	func (*myTypeTrait) CopyHelperGAtoAlpha(...) { ... }
+//
+//	// Validate is called by Freeze() to check resource-level invariants.
+//	func (*myTypeTrait) Validate(v meta.Version, obj any) error { ... }
 package api
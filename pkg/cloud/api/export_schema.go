@@ -0,0 +1,75 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// FieldSchema describes the traits of a field in a resource.
+type FieldSchema struct {
+	// Path to the field (see Path.DisplayString).
+	Path string `json:"path"`
+	// Type of the field. This is empty for fields that only have other traits.
+	Type FieldType `json:"type,omitempty"`
+	// Immutable is true if the field cannot be changed in place.
+	Immutable bool `json:"immutable,omitempty"`
+	// AllowedValues for the field, if restricted.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+}
+
+// Schema describes the traits of the fields in a resource. Fields without
+// any traits (i.e. Ordinary fields) are not listed.
+type Schema struct {
+	Fields []FieldSchema `json:"fields"`
+}
+
+// Schema returns a description of the traits, sorted by field path.
+func (dt *FieldTraits) Schema() *Schema {
+	byPath := map[string]*FieldSchema{}
+	get := func(p Path) *FieldSchema {
+		s := p.DisplayString()
+		if byPath[s] == nil {
+			byPath[s] = &FieldSchema{Path: s}
+		}
+		return byPath[s]
+	}
+	for _, f := range dt.fields {
+		get(f.path).Type = f.fType
+	}
+	for _, p := range dt.immutable {
+		get(p).Immutable = true
+	}
+	for _, a := range dt.allowed {
+		fs := get(a.path)
+		fs.AllowedValues = append(fs.AllowedValues, a.values...)
+	}
+
+	ret := &Schema{Fields: []FieldSchema{}}
+	for _, fs := range byPath {
+		ret.Fields = append(ret.Fields, *fs)
+	}
+	sort.Slice(ret.Fields, func(i, j int) bool { return ret.Fields[i].Path < ret.Fields[j].Path })
+	return ret
+}
+
+// ExportSchema returns the Schema of the traits as JSON. This is intended for
+// generating documentation and UIs.
+func (dt *FieldTraits) ExportSchema() ([]byte, error) {
+	return json.MarshalIndent(dt.Schema(), "", "  ")
+}
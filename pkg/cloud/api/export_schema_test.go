@@ -0,0 +1,61 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+)
+
+func TestFieldTraitsExportSchema(t *testing.T) {
+	t.Parallel()
+
+	dt := &FieldTraits{}
+	dt.OutputOnly(Path{}.Pointer().Field("B"))
+	dt.NonZeroValue(Path{}.Pointer().Field("A"))
+	dt.Immutable(Path{}.Pointer().Field("A"))
+	dt.AllowedValues(Path{}.Pointer().Field("A"), []string{"X", "Y"})
+	dt.Immutable(Path{}.Pointer().Field("S").Pointer().Field("C"))
+
+	got, err := dt.ExportSchema()
+	if err != nil {
+		t.Fatalf("ExportSchema() = %v, want nil", err)
+	}
+	const want = `{
+  "fields": [
+    {
+      "path": "A",
+      "type": "NonZeroValue",
+      "immutable": true,
+      "allowedValues": [
+        "X",
+        "Y"
+      ]
+    },
+    {
+      "path": "B",
+      "type": "OutputOnly"
+    },
+    {
+      "path": "S.C",
+      "immutable": true
+    }
+  ]
+}`
+	if string(got) != want {
+		t.Errorf("ExportSchema() = %s, want %s", got, want)
+	}
+}
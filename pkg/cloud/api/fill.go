@@ -109,8 +109,7 @@ func (f *filler) doPointer(p Path, v reflect.Value) (bool, error) {
 // isNoFillPath returns true for paths that should be ignored for
 // standard GCP resources.
 func isNoFillPath(p Path) bool {
-	if p.Equal(Path{}.Field("ServerResponse")) ||
-		p.Equal(Path{}.Pointer().Field("ServerResponse")) {
+	if isServerResponse(p) {
 		return true
 	}
 	if len(p) > 0 && (p[len(p)-1] == ".NullFields" || p[len(p)-1] == ".ForceSendFields") {
@@ -160,7 +159,7 @@ func (f *filler) doMap(p Path, v reflect.Value) (bool, error) {
 func fillNullAndForceSend(traits *FieldTraits, v reflect.Value) error {
 	acc := newAcceptorFuncs()
 	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
-		if p.Equal(Path{}.Pointer().Field("ServerResponse")) {
+		if isServerResponse(p) {
 			return false, nil
 		}
 		acc, err := newMetafieldAccessor(v)
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ResourcesFromList converts objects returned by a List (or AggregatedList)
+// call to Resources. T must be one of the GA, Alpha or Beta types; the
+// Resources will have the corresponding Version. The ResourceID of each
+// Resource is parsed from the SelfLink of the object.
+//
+//	objs, err := gcp.BackendServices().List(ctx, filter.None)
+//	resources, err := api.ResourcesFromList[compute.BackendService, alpha.BackendService, beta.BackendService](tt, objs)
+func ResourcesFromList[GA any, Alpha any, Beta any, T any](
+	tt TypeTrait[GA, Alpha, Beta],
+	objs []*T,
+) ([]Resource[GA, Alpha, Beta], error) {
+	var ret []Resource[GA, Alpha, Beta]
+	for i, obj := range objs {
+		if obj == nil {
+			return nil, fmt.Errorf("ResourcesFromList: objs[%d] is nil", i)
+		}
+		id, err := selfLinkID(obj)
+		if err != nil {
+			return nil, fmt.Errorf("ResourcesFromList: objs[%d]: %w", i, err)
+		}
+		mr := NewResource(id, tt)
+		if err := accessFrom(mr, obj); err != nil {
+			return nil, fmt.Errorf("ResourcesFromList: %v: %w", id, err)
+		}
+		r, err := mr.Freeze()
+		if err != nil {
+			return nil, fmt.Errorf("ResourcesFromList: %v: %w", id, err)
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// ResourcesFromPages converts the objects from all pages of a paginated List
+// call to Resources. pages iterates over the pages, calling f on each (this
+// matches the signature of the Pages() method on the List calls in the
+// compute client). items returns the objects in a page. See
+// ResourcesFromList().
+//
+//	call := svc.BackendServices.List(project)
+//	resources, err := api.ResourcesFromPages(tt,
+//		func(f func(*compute.BackendServiceList) error) error { return call.Pages(ctx, f) },
+//		func(p *compute.BackendServiceList) []*compute.BackendService { return p.Items })
+func ResourcesFromPages[GA any, Alpha any, Beta any, T any, Page any](
+	tt TypeTrait[GA, Alpha, Beta],
+	pages func(f func(Page) error) error,
+	items func(Page) []*T,
+) ([]Resource[GA, Alpha, Beta], error) {
+	var ret []Resource[GA, Alpha, Beta]
+	n := 0
+	err := pages(func(page Page) error {
+		resources, err := ResourcesFromList(tt, items(page))
+		if err != nil {
+			return fmt.Errorf("page %d: %w", n, err)
+		}
+		n++
+		ret = append(ret, resources...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ResourcesFromPages: %w", err)
+	}
+	return ret, nil
+}
+
+// selfLinkID returns the ResourceID from the SelfLink field of obj.
+func selfLinkID(obj any) (*cloud.ResourceID, error) {
+	v := reflect.ValueOf(obj).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not a struct", obj)
+	}
+	f := v.FieldByName("SelfLink")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return nil, fmt.Errorf("%T does not have a SelfLink", obj)
+	}
+	if f.String() == "" {
+		return nil, fmt.Errorf("SelfLink is empty")
+	}
+	return cloud.ParseResourceURL(f.String())
+}
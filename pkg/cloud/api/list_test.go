@@ -0,0 +1,200 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// bsTrait has the same kinds of FieldTraits as the BackendService node:
+// objects returned by the server set OutputOnly fields and leave NonZeroValue
+// fields unset.
+type bsTrait struct {
+	BaseTypeTrait[compute.BackendService, alpha.BackendService, beta.BackendService]
+}
+
+func (*bsTrait) FieldTraits(meta.Version) *FieldTraits {
+	dt := NewFieldTraits()
+	dt.OutputOnly(Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(Path{}.Pointer().Field("SelfLink"))
+	dt.NonZeroValue(Path{}.Pointer().Field("Protocol"))
+	return dt
+}
+
+func TestResourcesFromList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+	for _, name := range []string{"bs1", "bs2"} {
+		mock.BackendServices().Insert(ctx, meta.GlobalKey(name), &compute.BackendService{Port: 80})
+	}
+	mock.BetaBackendServices().Insert(ctx, meta.GlobalKey("bs3"), &beta.BackendService{Port: 80})
+
+	tt := &bsTrait{}
+
+	objs, err := mock.BackendServices().List(ctx, filter.None)
+	if err != nil {
+		t.Fatalf("List() = %v, want nil", err)
+	}
+	resources, err := ResourcesFromList[compute.BackendService, alpha.BackendService, beta.BackendService](tt, objs)
+	if err != nil {
+		t.Fatalf("ResourcesFromList() = %v, want nil", err)
+	}
+
+	got := map[string]meta.Version{}
+	for _, r := range resources {
+		id := r.ResourceID()
+		if id.ProjectID != "proj-1" || id.Resource != "backendServices" || id.Key.Type() != meta.Global {
+			t.Errorf("ResourceID() = %+v, want global backendServices in proj-1", id)
+		}
+		ga, err := r.ToGA()
+		if err != nil {
+			t.Fatalf("ToGA() = %v, want nil", err)
+		}
+		if ga.Port != 80 {
+			t.Errorf("%v: Port = %d, want 80", id, ga.Port)
+		}
+		got[id.Key.Name] = r.Version()
+	}
+	want := map[string]meta.Version{
+		"bs1": meta.VersionGA,
+		"bs2": meta.VersionGA,
+		"bs3": meta.VersionGA,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("resources: -got,+want: %s", diff)
+	}
+
+	betaObjs, err := mock.BetaBackendServices().List(ctx, filter.None)
+	if err != nil {
+		t.Fatalf("List() = %v, want nil", err)
+	}
+	betaResources, err := ResourcesFromList[compute.BackendService, alpha.BackendService, beta.BackendService](tt, betaObjs)
+	if err != nil {
+		t.Fatalf("ResourcesFromList() = %v, want nil", err)
+	}
+	if len(betaResources) != 3 {
+		t.Errorf("len(ResourcesFromList(beta)) = %d, want 3", len(betaResources))
+	}
+
+	// Objects without a SelfLink cannot be converted.
+	_, err = ResourcesFromList[compute.BackendService, alpha.BackendService, beta.BackendService](tt, []*compute.BackendService{{Name: "x"}})
+	if err == nil {
+		t.Errorf("ResourcesFromList(no SelfLink) = nil, want error")
+	}
+}
+
+func TestResourcesFromPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+	for _, name := range []string{"bs1", "bs2", "bs3"} {
+		mock.BackendServices().Insert(ctx, meta.GlobalKey(name), &compute.BackendService{Port: 80})
+	}
+	objs, err := mock.BackendServices().List(ctx, filter.None)
+	if err != nil {
+		t.Fatalf("List() = %v, want nil", err)
+	}
+
+	items := func(p *compute.BackendServiceList) []*compute.BackendService { return p.Items }
+	// pagesOf returns the pages func for the given pages.
+	pagesOf := func(pages ...*compute.BackendServiceList) func(func(*compute.BackendServiceList) error) error {
+		return func(f func(*compute.BackendServiceList) error) error {
+			for _, p := range pages {
+				if err := f(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		pages     func(func(*compute.BackendServiceList) error) error
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:  "no pages",
+			pages: pagesOf(),
+		},
+		{
+			name:      "one page",
+			pages:     pagesOf(&compute.BackendServiceList{Items: objs}),
+			wantNames: []string{"bs1", "bs2", "bs3"},
+		},
+		{
+			name: "multiple pages",
+			pages: pagesOf(
+				&compute.BackendServiceList{Items: objs[:1], NextPageToken: "p2"},
+				&compute.BackendServiceList{Items: objs[1:], NextPageToken: "p3"},
+				&compute.BackendServiceList{},
+			),
+			wantNames: []string{"bs1", "bs2", "bs3"},
+		},
+		{
+			name: "invalid object in page",
+			pages: pagesOf(
+				&compute.BackendServiceList{Items: objs[:1]},
+				&compute.BackendServiceList{Items: []*compute.BackendService{{Name: "x"}}},
+			),
+			wantErr: true,
+		},
+		{
+			name: "pages error",
+			pages: func(func(*compute.BackendServiceList) error) error {
+				return fmt.Errorf("injected error")
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, err := ResourcesFromPages[compute.BackendService, alpha.BackendService, beta.BackendService](&bsTrait{}, tc.pages, items)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ResourcesFromPages() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var gotNames []string
+			for _, r := range resources {
+				gotNames = append(gotNames, r.ResourceID().Key.Name)
+			}
+			sort.Strings(gotNames)
+			if diff := cmp.Diff(gotNames, tc.wantNames); diff != "" {
+				t.Errorf("names: -got,+want: %s", diff)
+			}
+		})
+	}
+}
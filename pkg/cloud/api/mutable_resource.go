@@ -100,7 +100,15 @@ func NewResource[GA any, Alpha any, Beta any](
 		resourceID: resourceID,
 	}
 
-	// Set .Name from the ResourceID.
+	obj.setNameFromID()
+
+	return obj
+}
+
+// setNameFromID sets .Name in all versions from the ResourceID.
+func (u *mutableResource[GA, Alpha, Beta]) setNameFromID() {
+	u.invalidateCache()
+
 	setName := func(v reflect.Value) {
 		if ft, ok := v.Type().FieldByName("Name"); !ok || ft.Type.Kind() != reflect.String {
 			return
@@ -109,13 +117,11 @@ func NewResource[GA any, Alpha any, Beta any](
 		if !f.IsValid() {
 			panic(fmt.Sprintf("type does not have .Name (%T)", v.Type()))
 		}
-		f.Set(reflect.ValueOf(resourceID.Key.Name))
+		f.Set(reflect.ValueOf(u.resourceID.Key.Name))
 	}
-	setName(reflect.ValueOf(&obj.ga).Elem())
-	setName(reflect.ValueOf(&obj.alpha).Elem())
-	setName(reflect.ValueOf(&obj.beta).Elem())
-
-	return obj
+	setName(reflect.ValueOf(&u.ga).Elem())
+	setName(reflect.ValueOf(&u.alpha).Elem())
+	setName(reflect.ValueOf(&u.beta).Elem())
 }
 
 // MutableResource wraps the multi-versioned concrete resources.
@@ -125,6 +131,10 @@ type MutableResource[GA any, Alpha any, Beta any] interface {
 	// to work.
 	CheckSchema() error
 
+	// ExportSchema returns the FieldTraits of the given version as JSON (see
+	// FieldTraits.ExportSchema).
+	ExportSchema(ver meta.Version) ([]byte, error)
+
 	// ResourceID is the resource ID of this resource.
 	ResourceID() *cloud.ResourceID
 
@@ -134,12 +144,43 @@ type MutableResource[GA any, Alpha any, Beta any] interface {
 	// configuration.
 	ImpliedVersion() (meta.Version, error)
 
+	// PinVersion forces ImpliedVersion() and Freeze() to use ver instead
+	// of the version implied by the fields that are set. ImpliedVersion()
+	// will return an error if the resource cannot be converted to ver.
+	PinVersion(ver meta.Version)
+
 	// Access the mutable resource.
 	Access(f func(x *GA)) error
+	// AccessGA is the same as Access.
+	AccessGA(f func(x *GA)) error
 	// AccessAlpha resource.
 	AccessAlpha(f func(x *Alpha)) error
 	// AccessBeta resource.
 	AccessBeta(f func(x *Beta)) error
+	// AccessVersion accesses the resource for the version v. f is called with
+	// the *GA, *Alpha or *Beta for v. This is for generic code that selects
+	// the version at runtime; it returns an error if v is not valid for this
+	// resource.
+	AccessVersion(v meta.Version, f func(x any)) error
+	// AccessWithChanges is the same as Access but returns the paths of the
+	// fields that were modified by f. This can be used to audit changes to
+	// the resource.
+	AccessWithChanges(f func(x *GA)) ([]Path, error)
+	// StripOutputOnly zeroes the fields marked OutputOnly in the FieldTraits
+	// (e.g. SelfLink, Id, CreationTimestamp) in all versions of the
+	// resource. Use this to re-insert a resource that was fetched from the
+	// Cloud.
+	StripOutputOnly() error
+
+	// GetByPath returns the value of the field at path p. The
+	// version used is the first of GA, Beta, Alpha that has the
+	// field.
+	GetByPath(p Path) (any, error)
+	// SetByPath sets the field at path p to value. The version is
+	// chosen the same way as GetByPath and the change is made
+	// using Access*(). Nil pointers along the path are allocated.
+	// value must be assignable to the type of the field.
+	SetByPath(p Path, value any) error
 
 	// ToGA returns the GA version of this resource. Use error.As
 	// ConversionError to get the specific details.
@@ -180,46 +221,97 @@ type mutableResource[GA any, Alpha any, Beta any] struct {
 
 	resourceID *cloud.ResourceID
 	errors     [conversionContextCount]conversionErrors
+	// pinnedVersion overrides the implied version if non-empty.
+	pinnedVersion meta.Version
+	// implied caches the result of ImpliedVersion(). It is invalidated on
+	// any mutation of the resource.
+	implied impliedVersionCache
 }
 
-func (u *mutableResource[GA, Alpha, Beta]) CheckSchema() error {
-	if isPlaceholderType(u.ga) {
-		return fmt.Errorf("GA has unsupported type (type is %T)", u)
-	}
+// impliedVersionCache holds the memoized result of ImpliedVersion().
+type impliedVersionCache struct {
+	valid bool
+	ver   meta.Version
+	err   error
+}
 
-	err := checkSchema(reflect.TypeOf(&u.ga))
-	if err != nil {
-		return err
+// invalidateCache must be called whenever the contents of the resource may
+// have changed.
+func (u *mutableResource[GA, Alpha, Beta]) invalidateCache() {
+	u.implied = impliedVersionCache{}
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) ExportSchema(ver meta.Version) ([]byte, error) {
+	return u.typeTrait.FieldTraits(ver).ExportSchema()
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) CheckSchema() error {
+	// GA can only be a PlaceholderType if allowed by the TypeTrait. In this
+	// case, there is no GA type to check the other versions against.
+	gaPlaceholder := isPlaceholderType(u.ga)
+	if gaPlaceholder {
+		if !allowPlaceholderGA(u.typeTrait) {
+			return fmt.Errorf("GA has unsupported type (type is %T)", u)
+		}
+		if isPlaceholderType(u.alpha) && isPlaceholderType(u.beta) {
+			return fmt.Errorf("all versions are PlaceholderType (type is %T)", u)
+		}
+	} else {
+		if err := checkSchema(reflect.TypeOf(&u.ga)); err != nil {
+			return err
+		}
+		if err := u.checkFieldTraits(meta.VersionGA, reflect.TypeOf(&u.ga)); err != nil {
+			return err
+		}
 	}
-	ga, _ := u.ToGA()
+	ga := &u.ga
 
 	if !isPlaceholderType(u.alpha) {
-		err = checkSchema(reflect.TypeOf(&u.alpha))
+		err := checkSchema(reflect.TypeOf(&u.alpha))
 		if err != nil {
 			return err
 		}
-		alpha, _ := u.ToAlpha()
-		err = checkSubsetOf(ga, alpha)
-		if err != nil {
-			return fmt.Errorf("checkSubsetOf(%T, %T) = %v, want nil", ga, alpha, err)
+		if err := u.checkFieldTraits(meta.VersionAlpha, reflect.TypeOf(&u.alpha)); err != nil {
+			return err
+		}
+		if !gaPlaceholder {
+			alpha, _ := u.ToAlpha()
+			err = checkSubsetOf(ga, alpha)
+			if err != nil {
+				return fmt.Errorf("checkSubsetOf(%T, %T) = %v, want nil", ga, alpha, err)
+			}
 		}
 	}
 	if !isPlaceholderType(u.beta) {
-		err = checkSchema(reflect.TypeOf(&u.beta))
+		err := checkSchema(reflect.TypeOf(&u.beta))
 		if err != nil {
 			return err
 		}
-		beta, _ := u.ToBeta()
-		err = checkSubsetOf(ga, beta)
-
-		if err != nil {
-			return fmt.Errorf("checkSubsetOf(%T, %T) = %v, want nil", ga, beta, err)
+		if err := u.checkFieldTraits(meta.VersionBeta, reflect.TypeOf(&u.beta)); err != nil {
+			return err
+		}
+		if !gaPlaceholder {
+			beta, _ := u.ToBeta()
+			err = checkSubsetOf(ga, beta)
+			if err != nil {
+				return fmt.Errorf("checkSubsetOf(%T, %T) = %v, want nil", ga, beta, err)
+			}
 		}
 	}
 
 	return nil
 }
 
+// checkFieldTraits validates that every path registered in the FieldTraits for
+// ver references a field that exists in t. This catches typos in trait paths
+// that would otherwise be silently ignored.
+func (u *mutableResource[GA, Alpha, Beta]) checkFieldTraits(ver meta.Version, t reflect.Type) error {
+	if err := u.typeTrait.FieldTraits(ver).CheckSchema(t); err != nil {
+		return fmt.Errorf("FieldTraits(%s): %w", ver, err)
+	}
+	return nil
+}
+
 func checkSubsetOf[T1 any, T2 any](t1 *T1, t2 *T2) error {
 
 	return CheckStructuralSubset(reflect.TypeOf(t1), reflect.TypeOf(t2))
@@ -232,6 +324,8 @@ const (
 )
 
 func (u *mutableResource[GA, Alpha, Beta]) postAccess(srcVer meta.Version, flags int) error {
+	u.invalidateCache()
+
 	type convert struct {
 		dest       reflect.Value
 		copyHelper func() error
@@ -312,10 +406,17 @@ func (u *mutableResource[GA, Alpha, Beta]) postAccess(srcVer meta.Version, flags
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) Access(f func(x *GA)) error {
+	if isPlaceholderType(u.ga) {
+		return useOfPlaceholderTypeError{msg: u.resourceID.String()}
+	}
 	f(&u.ga)
 	return u.postAccess(meta.VersionGA, 0)
 }
 
+func (u *mutableResource[GA, Alpha, Beta]) AccessGA(f func(x *GA)) error {
+	return u.Access(f)
+}
+
 func (u *mutableResource[GA, Alpha, Beta]) AccessAlpha(f func(x *Alpha)) error {
 	f(&u.alpha)
 	return u.postAccess(meta.VersionAlpha, 0)
@@ -326,6 +427,184 @@ func (u *mutableResource[GA, Alpha, Beta]) AccessBeta(f func(x *Beta)) error {
 	return u.postAccess(meta.VersionBeta, 0)
 }
 
+func (u *mutableResource[GA, Alpha, Beta]) AccessVersion(v meta.Version, f func(x any)) error {
+	switch v {
+	case meta.VersionGA:
+		return u.Access(func(x *GA) { f(x) })
+	case meta.VersionAlpha:
+		if isPlaceholderType(u.alpha) {
+			return fmt.Errorf("AccessVersion: %s is not supported by %T", v, u.ga)
+		}
+		return u.AccessAlpha(func(x *Alpha) { f(x) })
+	case meta.VersionBeta:
+		if isPlaceholderType(u.beta) {
+			return fmt.Errorf("AccessVersion: %s is not supported by %T", v, u.ga)
+		}
+		return u.AccessBeta(func(x *Beta) { f(x) })
+	}
+	return fmt.Errorf("AccessVersion: invalid version %q", v)
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) AccessWithChanges(f func(x *GA)) ([]Path, error) {
+	var before GA
+	if err := newCopier().do(reflect.ValueOf(&before), reflect.ValueOf(&u.ga)); err != nil {
+		return nil, fmt.Errorf("AccessWithChanges: %w", err)
+	}
+	if err := u.Access(f); err != nil {
+		return nil, err
+	}
+	result, err := diff(&before, &u.ga, nil)
+	if err != nil {
+		return nil, fmt.Errorf("AccessWithChanges: %w", err)
+	}
+	var ret []Path
+	for _, item := range result.Items {
+		ret = append(ret, item.Path)
+	}
+	return ret, nil
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) StripOutputOnly() error {
+	u.invalidateCache()
+
+	for _, x := range []struct {
+		ver meta.Version
+		v   reflect.Value
+	}{
+		{meta.VersionGA, reflect.ValueOf(&u.ga)},
+		{meta.VersionAlpha, reflect.ValueOf(&u.alpha)},
+		{meta.VersionBeta, reflect.ValueOf(&u.beta)},
+	} {
+		if isPlaceholderType(x.v.Elem().Interface()) {
+			continue
+		}
+		for _, f := range u.typeTrait.FieldTraits(x.ver).fields {
+			if f.fType != FieldTypeOutputOnly {
+				continue
+			}
+			// Fields that are not resolved are under a nil pointer or an
+			// empty slice, so are already zero.
+			for _, fv := range f.path.resolveAll(x.v) {
+				if !fv.CanSet() {
+					return fmt.Errorf("StripOutputOnly: %s %s is not settable", x.ver, f.path)
+				}
+				fv.Set(reflect.Zero(fv.Type()))
+			}
+		}
+	}
+	return nil
+}
+
+// versionForPath returns the first version of GA, Beta, Alpha with a type
+// that has the field referenced by p.
+func (u *mutableResource[GA, Alpha, Beta]) versionForPath(p Path) (meta.Version, reflect.Type, error) {
+	if !isPlaceholderType(u.ga) {
+		if t, err := p.ResolveType(reflect.TypeOf(&u.ga)); err == nil {
+			return meta.VersionGA, t, nil
+		}
+	}
+	if !isPlaceholderType(u.beta) {
+		if t, err := p.ResolveType(reflect.TypeOf(&u.beta)); err == nil {
+			return meta.VersionBeta, t, nil
+		}
+	}
+	if !isPlaceholderType(u.alpha) {
+		if t, err := p.ResolveType(reflect.TypeOf(&u.alpha)); err == nil {
+			return meta.VersionAlpha, t, nil
+		}
+	}
+	return "", nil, fmt.Errorf("path %s does not exist in any version", p)
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) GetByPath(p Path) (any, error) {
+	ver, _, err := u.versionForPath(p)
+	if err != nil {
+		return nil, fmt.Errorf("GetByPath: %w", err)
+	}
+	var root reflect.Value
+	switch ver {
+	case meta.VersionGA:
+		root = reflect.ValueOf(&u.ga)
+	case meta.VersionAlpha:
+		root = reflect.ValueOf(&u.alpha)
+	case meta.VersionBeta:
+		root = reflect.ValueOf(&u.beta)
+	}
+	v, err := p.resolveValue(root, false)
+	if err != nil {
+		return nil, fmt.Errorf("GetByPath: %w", err)
+	}
+	return v.Interface(), nil
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) SetByPath(p Path, value any) error {
+	if len(p) == 0 {
+		return fmt.Errorf("SetByPath: empty path")
+	}
+	ver, t, err := u.versionForPath(p)
+	if err != nil {
+		return fmt.Errorf("SetByPath: %w", err)
+	}
+	var val reflect.Value
+	if value == nil {
+		val = reflect.Zero(t)
+	} else {
+		val = reflect.ValueOf(value)
+		if !val.Type().AssignableTo(t) {
+			return fmt.Errorf("SetByPath: value of type %s is not assignable to %s (type %s)", val.Type(), p, t)
+		}
+	}
+
+	var setErr error
+	set := func(root reflect.Value) {
+		parentPath, last := p[:len(p)-1], p[len(p)-1]
+		parent, err := parentPath.resolveValue(root, true)
+		if err != nil {
+			setErr = err
+			return
+		}
+		// Map elements are not addressable so they must be set via the map.
+		if last[0] == pathMapIndex {
+			if parent.Kind() != reflect.Map || parent.Type().Key().Kind() != reflect.String {
+				setErr = fmt.Errorf("%s: expected map with string keys, got %s", p, parent.Type())
+				return
+			}
+			if parent.IsNil() {
+				if !parent.CanSet() {
+					setErr = fmt.Errorf("%s is not settable", p)
+					return
+				}
+				parent.Set(reflect.MakeMap(parent.Type()))
+			}
+			parent.SetMapIndex(reflect.ValueOf(last[1:]).Convert(parent.Type().Key()), val)
+			return
+		}
+		target, err := Path{last}.resolveValue(parent, true)
+		if err != nil {
+			setErr = err
+			return
+		}
+		if !target.CanSet() {
+			setErr = fmt.Errorf("%s is not settable", p)
+			return
+		}
+		target.Set(val)
+	}
+
+	switch ver {
+	case meta.VersionGA:
+		err = u.Access(func(x *GA) { set(reflect.ValueOf(x)) })
+	case meta.VersionAlpha:
+		err = u.AccessAlpha(func(x *Alpha) { set(reflect.ValueOf(x)) })
+	case meta.VersionBeta:
+		err = u.AccessBeta(func(x *Beta) { set(reflect.ValueOf(x)) })
+	}
+	if setErr != nil {
+		return fmt.Errorf("SetByPath: %w", setErr)
+	}
+	return err
+}
+
 // ImpliedVersion returns the implied version of the underlying resource.
 // This is determined by the convertibility of the resource.
 //
@@ -365,7 +644,27 @@ func (u *mutableResource[GA, Alpha, Beta]) AccessBeta(f func(x *Beta)) error {
 // Disallowed      | error           | error           | convertible
 // ---------------------------------------------------------------------
 // Error           | error           | error           | error
+//
+// If the TypeTrait implements PlaceholderGATrait, GA may be a PlaceholderType
+// and the implied version is the first of Beta, Alpha that is convertible.
+//
+// If the version was set with PinVersion(), ImpliedVersion returns the pinned
+// version or an error if the resource does not convert to it.
+//
+// The result is cached until the next mutation of the resource.
 func (u *mutableResource[GA, Alpha, Beta]) ImpliedVersion() (meta.Version, error) {
+	if !u.implied.valid {
+		ver, err := u.impliedVersion()
+		u.implied = impliedVersionCache{valid: true, ver: ver, err: err}
+	}
+	return u.implied.ver, u.implied.err
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) impliedVersion() (meta.Version, error) {
+	if u.pinnedVersion != "" {
+		return u.checkPinnedVersion()
+	}
+
 	_, gaErr := u.ToGA()
 	if gaErr == nil {
 		return meta.VersionGA, nil
@@ -383,7 +682,33 @@ func (u *mutableResource[GA, Alpha, Beta]) ImpliedVersion() (meta.Version, error
 	return meta.VersionGA, fmt.Errorf("indeterminant version (ga=%v, alpha=%v, beta=%v)", gaErr, alphaErr, betaErr)
 }
 
+func (u *mutableResource[GA, Alpha, Beta]) PinVersion(ver meta.Version) {
+	u.pinnedVersion = ver
+	u.invalidateCache()
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) checkPinnedVersion() (meta.Version, error) {
+	var err error
+	switch u.pinnedVersion {
+	case meta.VersionGA:
+		_, err = u.ToGA()
+	case meta.VersionAlpha:
+		_, err = u.ToAlpha()
+	case meta.VersionBeta:
+		_, err = u.ToBeta()
+	default:
+		return u.pinnedVersion, fmt.Errorf("%v pinned to invalid version %q", u.resourceID, u.pinnedVersion)
+	}
+	if err != nil {
+		return u.pinnedVersion, fmt.Errorf("%v pinned to version %s but cannot be converted to it: %w", u.resourceID, u.pinnedVersion, err)
+	}
+	return u.pinnedVersion, nil
+}
+
 func (u *mutableResource[GA, Alpha, Beta]) ToGA() (*GA, error) {
+	if isPlaceholderType(u.ga) {
+		return nil, useOfPlaceholderTypeError{msg: u.resourceID.String()}
+	}
 	var errs ConversionError
 	for _, cc := range []ConversionContext{AlphaToGAConversion, BetaToGAConversion} {
 		for _, mf := range u.errors[cc].missingFields {
@@ -444,6 +769,10 @@ func (u *mutableResource[GA, Alpha, Beta]) ToBeta() (*Beta, error) {
 // should skip Access validation. Don't use this for the time being.
 
 func (u *mutableResource[GA, Alpha, Beta]) Set(src *GA) error {
+	if isPlaceholderType(u.ga) {
+		return useOfPlaceholderTypeError{msg: u.resourceID.String()}
+	}
+	u.invalidateCache()
 	c := newCopier(u.copierOptions...)
 	if err := c.do(reflect.ValueOf(&u.ga), reflect.ValueOf(src)); err != nil {
 		return err
@@ -452,6 +781,7 @@ func (u *mutableResource[GA, Alpha, Beta]) Set(src *GA) error {
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) SetAlpha(src *Alpha) error {
+	u.invalidateCache()
 	c := newCopier(u.copierOptions...)
 	if err := c.do(reflect.ValueOf(&u.alpha), reflect.ValueOf(src)); err != nil {
 		return err
@@ -460,6 +790,7 @@ func (u *mutableResource[GA, Alpha, Beta]) SetAlpha(src *Alpha) error {
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) SetBeta(src *Beta) error {
+	u.invalidateCache()
 	c := newCopier(u.copierOptions...)
 	if err := c.do(reflect.ValueOf(&u.beta), reflect.ValueOf(src)); err != nil {
 		return err
@@ -467,11 +798,79 @@ func (u *mutableResource[GA, Alpha, Beta]) SetBeta(src *Beta) error {
 	return u.postAccess(meta.VersionBeta, postAccessSkipValidation)
 }
 
+// accessFrom copies obj into the version of u matching the type of obj. This
+// follows Access(), AccessAlpha() and AccessBeta() except that the
+// checkPostAccess() validation is skipped: obj was read from the server (or
+// exported from a graph) and will have OutputOnly fields set and zero values
+// that were never added to NullFields. Fields in obj that could not be copied
+// are returned as a ConversionError.
+func accessFrom[GA any, Alpha any, Beta any](u *mutableResource[GA, Alpha, Beta], obj any) error {
+	var (
+		dest reflect.Value
+		ver  meta.Version
+	)
+	switch obj.(type) {
+	case *GA:
+		if isPlaceholderType(u.ga) {
+			return useOfPlaceholderTypeError{msg: u.resourceID.String()}
+		}
+		dest, ver = reflect.ValueOf(&u.ga), meta.VersionGA
+	case *Alpha:
+		if isPlaceholderType(u.alpha) {
+			return useOfPlaceholderTypeError{msg: u.resourceID.String()}
+		}
+		dest, ver = reflect.ValueOf(&u.alpha), meta.VersionAlpha
+	case *Beta:
+		if isPlaceholderType(u.beta) {
+			return useOfPlaceholderTypeError{msg: u.resourceID.String()}
+		}
+		dest, ver = reflect.ValueOf(&u.beta), meta.VersionBeta
+	default:
+		return fmt.Errorf("invalid type %T", obj)
+	}
+
+	c := newCopier(u.copierOptions...)
+	if err := c.do(dest, reflect.ValueOf(obj)); err != nil {
+		return err
+	}
+	if len(c.missing) > 0 {
+		var errs ConversionError
+		for _, mf := range c.missing {
+			errs.MissingFields = append(errs.MissingFields, MissingField{Path: mf.Path, Value: mf.Value})
+		}
+		return &errs
+	}
+	return u.postAccess(ver, postAccessSkipValidation)
+}
+
+// validate calls the TypeTrait.Validate() hook with the resource in version
+// ver.
+func (u *mutableResource[GA, Alpha, Beta]) validate(ver meta.Version) error {
+	var obj any
+	switch ver {
+	case meta.VersionGA:
+		obj = &u.ga
+	case meta.VersionAlpha:
+		obj = &u.alpha
+	case meta.VersionBeta:
+		obj = &u.beta
+	default:
+		return fmt.Errorf("%v: invalid version %q", u.resourceID, ver)
+	}
+	if err := u.typeTrait.Validate(ver, obj); err != nil {
+		return fmt.Errorf("%v is invalid: %w", u.resourceID, err)
+	}
+	return nil
+}
+
 func (u *mutableResource[GA, Alpha, Beta]) Freeze() (Resource[GA, Alpha, Beta], error) {
 	ver, err := u.ImpliedVersion()
 	if err != nil {
 		return nil, err
 	}
+	if err := u.validate(ver); err != nil {
+		return nil, err
+	}
 	// For the structures in the other versions, fill in
 	// zero-valued fields in the metafields. This ensures that if
 	// the resource can be diff'd and sync'd correctly in all
@@ -50,6 +50,13 @@ func (p Path) Field(name string) Path {
 	return append(p, string(pathField)+name)
 }
 
+// isServerResponse is true if p is a googleapi.ServerResponse field. The
+// ServerResponse is set by the client library from the HTTP response and is
+// not part of the resource.
+func isServerResponse(p Path) bool {
+	return len(p) > 0 && p[len(p)-1] == string(pathField)+"ServerResponse"
+}
+
 // AnySliceIndex returns a path extended to match any slice index.
 func (p Path) AnySliceIndex() Path {
 	return append(p, anySliceIndex)
@@ -170,6 +177,27 @@ func (p Path) String() string {
 	return strings.Join(p, "")
 }
 
+// DisplayString returns a compact, human-readable form of the path with
+// pointer derefs elided, e.g. "*.Backends!0*.Group" becomes
+// "Backends[0].Group".
+func (p Path) DisplayString() string {
+	var b strings.Builder
+	for _, elem := range p {
+		switch elem[0] {
+		case pathPointer:
+			// Pointer derefs are implicit.
+		case pathField:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(elem[1:])
+		default:
+			fmt.Fprintf(&b, "[%s]", elem[1:])
+		}
+	}
+	return b.String()
+}
+
 // ResolveType will attempt to traverse the type with the Path and return the
 // type of the field.
 func (p Path) ResolveType(t reflect.Type) (reflect.Type, error) {
@@ -206,3 +234,82 @@ func (p Path) ResolveType(t reflect.Type) (reflect.Type, error) {
 	}
 	return t, nil
 }
+
+// resolveAll is the same as resolveValue (without alloc) but expands
+// AnySliceIndex to every element of the slice. Paths that cannot be resolved
+// in v (e.g. are under a nil pointer) are skipped.
+func (p Path) resolveAll(v reflect.Value) []reflect.Value {
+	for i, x := range p {
+		if x == anySliceIndex {
+			if v.Kind() != reflect.Slice {
+				return nil
+			}
+			var ret []reflect.Value
+			for j := 0; j < v.Len(); j++ {
+				ret = append(ret, p[i+1:].resolveAll(v.Index(j))...)
+			}
+			return ret
+		}
+		next, err := Path{x}.resolveValue(v, false)
+		if err != nil {
+			return nil
+		}
+		v = next
+	}
+	return []reflect.Value{v}
+}
+
+// resolveValue traverses the value v with the Path and returns the value of
+// the field. If alloc is true, nil pointers along the path will be allocated
+// (this requires v to be addressable). Wildcard paths are not supported and
+// map keys must be strings.
+func (p Path) resolveValue(v reflect.Value, alloc bool) (reflect.Value, error) {
+	for i, x := range p {
+		switch x[0] {
+		case pathField:
+			if v.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, expected struct, got %s", p, i, v.Type())
+			}
+			fv := v.FieldByName(x[1:])
+			if !fv.IsValid() {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, no field named %q", p, i, x[1:])
+			}
+			v = fv
+		case pathSliceIndex:
+			if v.Kind() != reflect.Slice {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, expected slice, got %s", p, i, v.Type())
+			}
+			idx, err := strconv.Atoi(x[1:])
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, invalid slice index %q", p, i, x[1:])
+			}
+			if idx < 0 || idx >= v.Len() {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, index %d out of range (len=%d)", p, i, idx, v.Len())
+			}
+			v = v.Index(idx)
+		case pathMapIndex:
+			if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, expected map with string keys, got %s", p, i, v.Type())
+			}
+			mv := v.MapIndex(reflect.ValueOf(x[1:]).Convert(v.Type().Key()))
+			if !mv.IsValid() {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, no map key %q", p, i, x[1:])
+			}
+			v = mv
+		case pathPointer:
+			if v.Kind() != reflect.Pointer {
+				return reflect.Value{}, fmt.Errorf("at %s element %d, expected pointer, got %s", p, i, v.Type())
+			}
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("at %s element %d, nil pointer", p, i)
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		default:
+			return reflect.Value{}, fmt.Errorf("at %s element %d, invalid path type %q", p, i, x[0])
+		}
+	}
+	return v, nil
+}
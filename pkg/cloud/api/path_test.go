@@ -45,6 +45,22 @@ func TestPath(t *testing.T) {
 	}
 }
 
+func TestPathDisplayString(t *testing.T) {
+	for _, tc := range []struct {
+		p    Path
+		want string
+	}{
+		{p: Path{}, want: ""},
+		{p: Path{}.Pointer().Field("Port"), want: "Port"},
+		{p: Path{}.Pointer().Field("Backends").Index(0).Pointer().Field("Group"), want: "Backends[0].Group"},
+		{p: Path{}.Pointer().Field("Labels").MapIndex("k"), want: "Labels[k]"},
+	} {
+		if got := tc.p.DisplayString(); got != tc.want {
+			t.Errorf("DisplayString(%v) = %q, want %q", tc.p, got, tc.want)
+		}
+	}
+}
+
 func TestPathEqual(t *testing.T) {
 	t.Parallel()
 
@@ -26,7 +26,10 @@ import (
 // Resource is read-only view into the resource. A Resource
 // has a definitive Version.
 type Resource[GA any, Alpha any, Beta any] interface {
-	// Version of the resource. This cannot be indeterminant.
+	// Version of the resource. This is the version chosen when the
+	// resource was frozen (see MutableResource.ImpliedVersion and
+	// PinVersion). This cannot be indeterminant and does not require a
+	// conversion.
 	Version() meta.Version
 	// ResourceID fully qualitfied name of the resource.
 	ResourceID() *cloud.ResourceID
@@ -40,7 +43,20 @@ type Resource[GA any, Alpha any, Beta any] interface {
 	// other, taking into account the versions of the resources
 	// being compared. Cross Alpha and Beta comparisons are not
 	// currently supported.
-	Diff(other Resource[GA, Alpha, Beta]) (*DiffResult, error)
+	//
+	// Fields marked OutputOnly or System in the TypeTrait are
+	// ignored. Each DiffItem in the result has a Path relative to
+	// the root of the resource, with A being the value in this
+	// resource and B being the value in other. NullFields and
+	// ForceSendFields are ignored unless CompareMetaFieldsAsSets()
+	// is given.
+	Diff(other Resource[GA, Alpha, Beta], opts ...DiffOption) (*DiffResult, error)
+	// DiffExcept is the same as Diff but also ignores differences at (or
+	// under) the given paths. This is for fields that are known to be
+	// managed elsewhere for this comparison only; use the FieldTraits to
+	// ignore a field for all comparisons. Paths may contain wildcards (e.g.
+	// AnySliceIndex).
+	DiffExcept(other Resource[GA, Alpha, Beta], ignore []Path, opts ...DiffOption) (*DiffResult, error)
 
 	// Clone returns an exact structural copy of this resource.
 	// Clone() Resource[GA, Alpha, Beta] XXX
@@ -58,8 +74,13 @@ func (obj *resource[GA, Alpha, Beta]) ToGA() (*GA, error)            { return ob
 func (obj *resource[GA, Alpha, Beta]) ToAlpha() (*Alpha, error)      { return obj.x.ToAlpha() }
 func (obj *resource[GA, Alpha, Beta]) ToBeta() (*Beta, error)        { return obj.x.ToBeta() }
 
+// DiffExcept implements Resource.
+func (obj *resource[GA, Alpha, Beta]) DiffExcept(other Resource[GA, Alpha, Beta], ignore []Path, opts ...DiffOption) (*DiffResult, error) {
+	return obj.Diff(other, append(opts, ignorePaths(ignore))...)
+}
+
 // Diff implements Resource.
-func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta]) (*DiffResult, error) {
+func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta], opts ...DiffOption) (*DiffResult, error) {
 	switch {
 	// Comparisons between the same versions don't need conversions.
 	//
@@ -67,17 +88,17 @@ func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta]) (*Di
 	case obj.Version() == meta.VersionGA && other.Version() == meta.VersionGA:
 		aObj, _ := obj.ToGA()
 		bObj, _ := other.ToGA()
-		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionGA))
+		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionGA), opts...)
 	// cmp(Alpha, Alpha)
 	case obj.Version() == meta.VersionAlpha && other.Version() == meta.VersionAlpha:
 		aObj, _ := obj.ToAlpha()
 		bObj, _ := other.ToAlpha()
-		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionAlpha))
+		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionAlpha), opts...)
 	// cmp(Beta, Beta)
 	case obj.Version() == meta.VersionBeta && other.Version() == meta.VersionBeta:
 		aObj, _ := obj.ToBeta()
 		bObj, _ := other.ToBeta()
-		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionBeta))
+		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionBeta), opts...)
 
 	// GA => Alpha, GA => Beta should be safe and supported with a conversion.
 	//
@@ -93,7 +114,7 @@ func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta]) (*Di
 		if err != nil {
 			return nil, fmt.Errorf("Resource.Diff: %s", err)
 		}
-		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionAlpha))
+		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionAlpha), opts...)
 	// cmp(GA, Beta), cmp(Beta, GA): convert to Beta, then compare.
 	case obj.Version() == meta.VersionGA && other.Version() == meta.VersionBeta:
 		fallthrough
@@ -106,7 +127,7 @@ func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta]) (*Di
 		if err != nil {
 			return nil, fmt.Errorf("Resource.Diff: %s", err)
 		}
-		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionBeta))
+		return diff(aObj, bObj, obj.x.typeTrait.FieldTraits(meta.VersionBeta), opts...)
 
 	// Comparison between Alpha/Beta is not supported right now. This probably
 	// can work with some manual conversion logic.
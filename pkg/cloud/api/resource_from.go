@@ -0,0 +1,104 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ResourceFromOption is an option to ResourceFromGA(), ResourceFromAlpha()
+// and ResourceFromBeta().
+type ResourceFromOption func(*resourceFromConfig)
+
+type resourceFromConfig struct {
+	nameFromID      bool
+	stripOutputOnly bool
+}
+
+// NameFromResourceID sets .Name from the ResourceID, overriding the value in
+// the object.
+func NameFromResourceID() ResourceFromOption {
+	return func(c *resourceFromConfig) { c.nameFromID = true }
+}
+
+// WithoutOutputOnly zeroes the OutputOnly fields (see
+// MutableResource.StripOutputOnly()). Use this when the Resource will be
+// used to insert the object.
+func WithoutOutputOnly() ResourceFromOption {
+	return func(c *resourceFromConfig) { c.stripOutputOnly = true }
+}
+
+// ResourceFromGA returns the frozen Resource for obj, e.g. an object returned
+// from a Get() call. This is equivalent to NewResource(), Set() and Freeze().
+//
+// If typeTrait is nil, then it will be set to BaseTypeTrait.
+func ResourceFromGA[GA any, Alpha any, Beta any](
+	resourceID *cloud.ResourceID,
+	obj *GA,
+	typeTrait TypeTrait[GA, Alpha, Beta],
+	opts ...ResourceFromOption,
+) (Resource[GA, Alpha, Beta], error) {
+	return resourceFrom(resourceID, typeTrait, func(u *mutableResource[GA, Alpha, Beta]) error { return accessFrom(u, obj) }, opts)
+}
+
+// ResourceFromAlpha is the same as ResourceFromGA() for an Alpha obj.
+func ResourceFromAlpha[GA any, Alpha any, Beta any](
+	resourceID *cloud.ResourceID,
+	obj *Alpha,
+	typeTrait TypeTrait[GA, Alpha, Beta],
+	opts ...ResourceFromOption,
+) (Resource[GA, Alpha, Beta], error) {
+	return resourceFrom(resourceID, typeTrait, func(u *mutableResource[GA, Alpha, Beta]) error { return accessFrom(u, obj) }, opts)
+}
+
+// ResourceFromBeta is the same as ResourceFromGA() for a Beta obj.
+func ResourceFromBeta[GA any, Alpha any, Beta any](
+	resourceID *cloud.ResourceID,
+	obj *Beta,
+	typeTrait TypeTrait[GA, Alpha, Beta],
+	opts ...ResourceFromOption,
+) (Resource[GA, Alpha, Beta], error) {
+	return resourceFrom(resourceID, typeTrait, func(u *mutableResource[GA, Alpha, Beta]) error { return accessFrom(u, obj) }, opts)
+}
+
+func resourceFrom[GA any, Alpha any, Beta any](
+	resourceID *cloud.ResourceID,
+	typeTrait TypeTrait[GA, Alpha, Beta],
+	set func(*mutableResource[GA, Alpha, Beta]) error,
+	opts []ResourceFromOption,
+) (Resource[GA, Alpha, Beta], error) {
+	config := resourceFromConfig{}
+	for _, o := range opts {
+		o(&config)
+	}
+
+	u := NewResource(resourceID, typeTrait)
+	if err := set(u); err != nil {
+		return nil, fmt.Errorf("ResourceFrom(%v): %w", resourceID, err)
+	}
+	if config.nameFromID {
+		u.setNameFromID()
+	}
+	if config.stripOutputOnly {
+		if err := u.StripOutputOnly(); err != nil {
+			return nil, fmt.Errorf("ResourceFrom(%v): %w", resourceID, err)
+		}
+	}
+	return u.Freeze()
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// resourceJSON is the serialized form of a Resource.
+type resourceJSON struct {
+	Version meta.Version    `json:"version"`
+	Object  json.RawMessage `json:"object"`
+	// MetaFields are the NullFields and ForceSendFields in Object. These
+	// are not part of the JSON encoding of the API types.
+	MetaFields []metaFieldsJSON `json:"metaFields,omitempty"`
+}
+
+type metaFieldsJSON struct {
+	// Path to the struct containing the metafields.
+	Path            Path     `json:"path"`
+	NullFields      []string `json:"nullFields,omitempty"`
+	ForceSendFields []string `json:"forceSendFields,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The JSON contains the resource in
+// its Version and the metafields (NullFields, ForceSendFields). Use
+// UnmarshalResource to load the Resource.
+func (obj *resource[GA, Alpha, Beta]) MarshalJSON() ([]byte, error) {
+	var (
+		raw any
+		err error
+	)
+	switch obj.ver {
+	case meta.VersionGA:
+		raw, err = obj.ToGA()
+	case meta.VersionAlpha:
+		raw, err = obj.ToAlpha()
+	case meta.VersionBeta:
+		raw, err = obj.ToBeta()
+	default:
+		return nil, fmt.Errorf("MarshalJSON: invalid version %q", obj.ver)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("MarshalJSON: %w", err)
+	}
+
+	ret := resourceJSON{Version: obj.ver}
+	if ret.Object, err = json.Marshal(raw); err != nil {
+		return nil, fmt.Errorf("MarshalJSON: %w", err)
+	}
+	if ret.MetaFields, err = collectMetaFields(reflect.ValueOf(raw)); err != nil {
+		return nil, fmt.Errorf("MarshalJSON: %w", err)
+	}
+	return json.Marshal(ret)
+}
+
+// UnmarshalResource creates a Resource with the given id and TypeTrait from
+// JSON created by Resource.MarshalJSON. The Resource has the same Version as
+// the one that was serialized.
+func UnmarshalResource[GA any, Alpha any, Beta any](
+	id *cloud.ResourceID,
+	tt TypeTrait[GA, Alpha, Beta],
+	data []byte,
+) (Resource[GA, Alpha, Beta], error) {
+	var rj resourceJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return nil, fmt.Errorf("UnmarshalResource %v: %w", id, err)
+	}
+
+	mr := NewResource(id, tt)
+	var err error
+	switch rj.Version {
+	case meta.VersionGA:
+		err = unmarshalObject(rj, new(GA), mr)
+	case meta.VersionAlpha:
+		err = unmarshalObject(rj, new(Alpha), mr)
+	case meta.VersionBeta:
+		err = unmarshalObject(rj, new(Beta), mr)
+	default:
+		err = fmt.Errorf("invalid version %q", rj.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UnmarshalResource %v: %w", id, err)
+	}
+	mr.PinVersion(rj.Version)
+	return mr.Freeze()
+}
+
+func unmarshalObject[GA any, Alpha any, Beta any, T any](rj resourceJSON, obj *T, mr *mutableResource[GA, Alpha, Beta]) error {
+	if err := json.Unmarshal(rj.Object, obj); err != nil {
+		return err
+	}
+	if err := restoreMetaFields(reflect.ValueOf(obj), rj.MetaFields); err != nil {
+		return err
+	}
+	return accessFrom(mr, obj)
+}
+
+// collectMetaFields returns the non-empty metafields of the structs in v.
+func collectMetaFields(v reflect.Value) ([]metaFieldsJSON, error) {
+	var ret []metaFieldsJSON
+	acc := newAcceptorFuncs()
+	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
+		ma, err := newMetafieldAccessor(v)
+		if err != nil {
+			// Not an API struct (e.g. ServerResponse).
+			return true, nil
+		}
+		mf := metaFieldsJSON{
+			Path:            append(Path{}, p...),
+			NullFields:      ma.nullFields.Interface().([]string),
+			ForceSendFields: ma.forceSendFields.Interface().([]string),
+		}
+		if len(mf.NullFields) > 0 || len(mf.ForceSendFields) > 0 {
+			ret = append(ret, mf)
+		}
+		return true, nil
+	}
+	if err := visit(v, acc); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// restoreMetaFields sets the metafields in v.
+func restoreMetaFields(v reflect.Value, mfs []metaFieldsJSON) error {
+	for _, mf := range mfs {
+		sv, err := mf.Path.resolveValue(v, false)
+		if err != nil {
+			return fmt.Errorf("metafields: %w", err)
+		}
+		if sv.Kind() != reflect.Struct || !sv.CanSet() {
+			return fmt.Errorf("metafields: %s is not a settable struct", mf.Path)
+		}
+		ma, err := newMetafieldAccessor(sv)
+		if err != nil {
+			return fmt.Errorf("metafields %s: %w", mf.Path, err)
+		}
+		ma.nullFields.Set(reflect.ValueOf(mf.NullFields))
+		ma.forceSendFields.Set(reflect.ValueOf(mf.ForceSendFields))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResourceJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// The metafields are not part of the JSON encoding, similar to the
+	// compute API types.
+	type inner struct {
+		I               int
+		NullFields      []string `json:"-"`
+		ForceSendFields []string `json:"-"`
+	}
+	type st struct {
+		I               int
+		S               string
+		StP             *inner
+		LSt             []*inner
+		NullFields      []string `json:"-"`
+		ForceSendFields []string `json:"-"`
+	}
+	type stB struct {
+		I               int
+		S               string
+		B               string
+		StP             *inner
+		LSt             []*inner
+		NullFields      []string `json:"-"`
+		ForceSendFields []string `json:"-"`
+	}
+
+	for _, tc := range []struct {
+		name    string
+		res     func() *mutableResource[st, PlaceholderType, stB]
+		wantVer meta.Version
+	}{
+		{
+			name: "ga",
+			res: func() *mutableResource[st, PlaceholderType, stB] {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.Set(&st{I: 1, S: "abc"})
+				return res
+			},
+			wantVer: meta.VersionGA,
+		},
+		{
+			name: "ga with metafields",
+			res: func() *mutableResource[st, PlaceholderType, stB] {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.Set(&st{
+					StP:             &inner{ForceSendFields: []string{"I"}},
+					LSt:             []*inner{{I: 1}, {NullFields: []string{"I"}}},
+					ForceSendFields: []string{"I", "S"},
+				})
+				return res
+			},
+			wantVer: meta.VersionGA,
+		},
+		{
+			name: "beta",
+			res: func() *mutableResource[st, PlaceholderType, stB] {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.SetBeta(&stB{I: 1, B: "b", ForceSendFields: []string{"S"}})
+				return res
+			},
+			wantVer: meta.VersionBeta,
+		},
+		{
+			name: "pinned beta",
+			res: func() *mutableResource[st, PlaceholderType, stB] {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.Set(&st{I: 1})
+				res.PinVersion(meta.VersionBeta)
+				return res
+			},
+			wantVer: meta.VersionBeta,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := tc.res()
+			r, err := mr.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			data, err := json.Marshal(r)
+			if err != nil {
+				t.Fatalf("json.Marshal() = %v, want nil", err)
+			}
+			r2, err := UnmarshalResource[st, PlaceholderType, stB](mr.ResourceID(), nil, data)
+			if err != nil {
+				t.Fatalf("UnmarshalResource() = %v, want nil", err)
+			}
+			if r2.Version() != tc.wantVer {
+				t.Errorf("Version() = %v, want %v", r2.Version(), tc.wantVer)
+			}
+			got, _ := r2.ToBeta()
+			want, _ := r.ToBeta()
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("ToBeta(): diff -got,+want: %s", diff)
+			}
+			res, err := r2.Diff(r)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if res.HasDiff() {
+				t.Errorf("Diff() = %+v, want no diff", res)
+			}
+		})
+	}
+}
+
+func TestUnmarshalResourceError(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	id := newTestResource[st, PlaceholderType, PlaceholderType](nil).ResourceID()
+
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{name: "invalid json", data: `{`},
+		{name: "invalid version", data: `{"version":"v2","object":{}}`},
+		{name: "invalid metafield path", data: `{"version":"ga","object":{},"metaFields":[{"path":["*",".X"]}]}`},
+		{name: "NullFields refers to a missing field", data: `{"version":"ga","object":{},"metaFields":[{"path":["*"],"nullFields":["X"]}]}`},
+		{name: "placeholder version", data: `{"version":"alpha","object":{}}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := UnmarshalResource[st, PlaceholderType, PlaceholderType](id, nil, []byte(tc.data)); err == nil {
+				t.Errorf("UnmarshalResource() = nil, want error")
+			}
+		})
+	}
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	teststruct "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api/converter_test_types"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
 )
 
 func newTestResource[G any, A any, B any](tt TypeTrait[G, A, B]) *mutableResource[G, A, B] {
@@ -837,6 +840,375 @@ func TestResourceCheckSchema(t *testing.T) {
 	}
 }
 
+type misspelledTrait[G any, A any, B any] struct {
+	BaseTypeTrait[G, A, B]
+}
+
+func (misspelledTrait[G, A, B]) FieldTraits(meta.Version) *FieldTraits {
+	ret := &FieldTraits{}
+	ret.AllowZeroValue(Path{}.Pointer().Field("Protcol"))
+	return ret
+}
+
+func TestResourceCheckSchemaFieldTraits(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		Name            string
+		SelfLink        string
+		Protocol        string
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	res := newTestResource[st, PlaceholderType, PlaceholderType](&misspelledTrait[st, PlaceholderType, PlaceholderType]{})
+	err := res.CheckSchema()
+	if err == nil {
+		t.Fatalf("CheckSchema() = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "Protcol") {
+		t.Errorf("CheckSchema() = %v, want error referencing %q", err, "Protcol")
+	}
+}
+
+type diffTrait[G any, A any, B any] struct {
+	BaseTypeTrait[G, A, B]
+}
+
+func (diffTrait[G, A, B]) FieldTraits(meta.Version) *FieldTraits {
+	ret := &FieldTraits{}
+	ret.OutputOnly(Path{}.Pointer().Field("O"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("I"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("A"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("LS"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("M"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("SelfLink"))
+	ret.AllowZeroValue(Path{}.Pointer().Field("EnableCDN"))
+	ret.ServerPopulated(Path{}.Pointer().Field("M"))
+	return ret
+}
+
+func TestResourceDiff(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		Name            string
+		SelfLink        string
+		I               int
+		O               int
+		LS              []string
+		M               map[string]string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stA struct {
+		Name            string
+		SelfLink        string
+		I               int
+		O               int
+		A               int
+		LS              []string
+		M               map[string]string
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	newRes := func(f func(*st), fA func(*stA)) Resource[st, stA, PlaceholderType] {
+		t.Helper()
+		mr := NewResource[st, stA, PlaceholderType](&cloud.ResourceID{
+			ProjectID: "proj-1",
+			Resource:  "st",
+			Key:       meta.GlobalKey("obj-1"),
+		}, &diffTrait[st, stA, PlaceholderType]{})
+		if f != nil {
+			mr.Access(f)
+		}
+		if fA != nil {
+			mr.AccessAlpha(fA)
+		}
+		r, err := mr.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		name string
+		a    Resource[st, stA, PlaceholderType]
+		b    Resource[st, stA, PlaceholderType]
+		opts []DiffOption
+		want []DiffItem
+	}{
+		{
+			name: "no diff",
+			a:    newRes(func(x *st) { x.I = 1 }, nil),
+			b:    newRes(func(x *st) { x.I = 1 }, nil),
+		},
+		{
+			name: "output only fields are ignored",
+			a:    newRes(func(x *st) { x.O = 1 }, nil),
+			b:    newRes(func(x *st) { x.O = 2 }, nil),
+		},
+		{
+			name: "GA field",
+			a:    newRes(func(x *st) { x.I = 1 }, nil),
+			b:    newRes(func(x *st) { x.I = 2 }, nil),
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "slice and map",
+			a:    newRes(func(x *st) { x.LS = []string{"a"}; x.M = map[string]string{"k": "a"} }, nil),
+			b:    newRes(func(x *st) { x.LS = []string{"b"}; x.M = map[string]string{"k": "b"} }, nil),
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("LS").Index(0), A: "a", B: "b"},
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("M").MapIndex("k"), A: "a", B: "b"},
+			},
+		},
+		{
+			name: "GA vs Alpha compares as Alpha",
+			a:    newRes(func(x *st) { x.I = 1 }, nil),
+			b:    newRes(nil, func(x *stA) { x.I = 1; x.A = 5 }),
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("A"), A: 0, B: 5},
+			},
+		},
+		{
+			name: "meta fields are ignored",
+			a:    newRes(func(x *st) { x.ForceSendFields = []string{"I", "LS"} }, nil),
+			b:    newRes(func(x *st) { x.NullFields = []string{"M"} }, nil),
+		},
+		{
+			name: "ForceSendFields order with CompareMetaFieldsAsSets",
+			a:    newRes(func(x *st) { x.ForceSendFields = []string{"I", "LS"} }, nil),
+			b:    newRes(func(x *st) { x.ForceSendFields = []string{"LS", "I"} }, nil),
+			opts: []DiffOption{CompareMetaFieldsAsSets()},
+		},
+		{
+			// The server fills in M and does not return it in NullFields.
+			name: "NullFields of ServerPopulated field with CompareMetaFieldsAsSets",
+			a:    newRes(func(x *st) { x.I = 1; x.NullFields = []string{"M"} }, nil),
+			b:    newRes(func(x *st) { x.I = 1; x.M = map[string]string{"k": "server"} }, nil),
+			opts: []DiffOption{CompareMetaFieldsAsSets()},
+		},
+		{
+			name: "ForceSendFields of ServerPopulated field with CompareMetaFieldsAsSets",
+			a:    newRes(func(x *st) { x.ForceSendFields = []string{"I", "M"} }, nil),
+			b:    newRes(func(x *st) { x.ForceSendFields = []string{"I"}; x.M = map[string]string{"k": "server"} }, nil),
+			opts: []DiffOption{CompareMetaFieldsAsSets()},
+		},
+		{
+			name: "ForceSendFields contents with CompareMetaFieldsAsSets",
+			a:    newRes(func(x *st) { x.ForceSendFields = []string{"I", "LS"} }, nil),
+			b:    newRes(func(x *st) { x.ForceSendFields = []string{"I", "M"} }, nil),
+			opts: []DiffOption{CompareMetaFieldsAsSets()},
+			want: []DiffItem{
+				{
+					State: DiffItemDifferent,
+					Path:  Path{}.Pointer().Field("ForceSendFields"),
+					A:     []string{"I", "LS"},
+					B:     []string{"I", "M"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := tc.a.Diff(tc.b, tc.opts...)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("Diff().Items: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
+func TestResourceDiffExcept(t *testing.T) {
+	t.Parallel()
+
+	type sub struct {
+		Name            string
+		Port            int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type st struct {
+		Name            string
+		SelfLink        string
+		Description     string
+		Port            int
+		Subs            []*sub
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	newRes := func(f func(*st)) Resource[st, PlaceholderType, PlaceholderType] {
+		t.Helper()
+		mr := NewResource[st, PlaceholderType, PlaceholderType](&cloud.ResourceID{
+			ProjectID: "proj-1",
+			Resource:  "st",
+			Key:       meta.GlobalKey("obj-1"),
+		}, nil)
+		mr.Access(func(x *st) {
+			x.Subs = []*sub{{Name: "s"}}
+			x.NullFields = []string{"SelfLink"}
+			f(x)
+		})
+		r, err := mr.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+	base := func(x *st) {
+		x.Description = "abc"
+		x.Port = 80
+		x.Subs[0].Port = 80
+	}
+
+	for _, tc := range []struct {
+		name   string
+		f      func(*st)
+		ignore []Path
+		want   []DiffItem
+	}{
+		{
+			name:   "description is ignored",
+			f:      func(x *st) { base(x); x.Description = "def" },
+			ignore: []Path{Path{}.Pointer().Field("Description")},
+		},
+		{
+			name:   "port is not ignored",
+			f:      func(x *st) { base(x); x.Description = "def"; x.Port = 443 },
+			ignore: []Path{Path{}.Pointer().Field("Description")},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("Port"), A: 80, B: 443},
+			},
+		},
+		{
+			name: "no ignore",
+			f:    func(x *st) { base(x); x.Description = "def" },
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("Description"), A: "abc", B: "def"},
+			},
+		},
+		{
+			name:   "wildcard",
+			f:      func(x *st) { base(x); x.Subs[0].Port = 443 },
+			ignore: []Path{Path{}.Pointer().Field("Subs").AnySliceIndex().Pointer().Field("Port")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newRes(base)
+			b := newRes(tc.f)
+			r, err := a.DiffExcept(b, tc.ignore)
+			if err != nil {
+				t.Fatalf("DiffExcept() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.want); d != "" {
+				t.Errorf("DiffExcept().Items: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
+func TestResourceDiffBoolPointer(t *testing.T) {
+	t.Parallel()
+
+	// EnableCDN is tri-state: nil (unset), false and true are distinct
+	// values.
+	type st struct {
+		Name            string
+		SelfLink        string
+		EnableCDN       *bool
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stA struct {
+		Name            string
+		SelfLink        string
+		EnableCDN       *bool
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	newRes := func(v *bool) Resource[st, stA, PlaceholderType] {
+		t.Helper()
+		mr := NewResource[st, stA, PlaceholderType](&cloud.ResourceID{
+			ProjectID: "proj-1",
+			Resource:  "st",
+			Key:       meta.GlobalKey("obj-1"),
+		}, &diffTrait[st, stA, PlaceholderType]{})
+		mr.Access(func(x *st) { x.EnableCDN = v })
+		r, err := mr.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+	boolP := func(b bool) *bool { return &b }
+	path := Path{}.Pointer().Field("EnableCDN")
+
+	for _, tc := range []struct {
+		name string
+		got  *bool
+		want *bool
+		diff []DiffItem
+	}{
+		{name: "nil and nil"},
+		{name: "false and false", got: boolP(false), want: boolP(false)},
+		{
+			name: "nil and false",
+			want: boolP(false),
+			diff: []DiffItem{{State: DiffItemOnlyInB, Path: path, A: (*bool)(nil), B: boolP(false)}},
+		},
+		{
+			name: "false and nil",
+			got:  boolP(false),
+			diff: []DiffItem{{State: DiffItemOnlyInA, Path: path, A: boolP(false), B: (*bool)(nil)}},
+		},
+		{
+			name: "false and true",
+			got:  boolP(false),
+			want: boolP(true),
+			diff: []DiffItem{{State: DiffItemDifferent, Path: path.Pointer(), A: false, B: true}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newRes(tc.got)
+			want := newRes(tc.want)
+
+			r, err := got.Diff(want)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if d := cmp.Diff(r.Items, tc.diff); d != "" {
+				t.Errorf("Diff().Items: -got,+want: %s", d)
+			}
+			// Conversion between versions must preserve the nil-ness of the
+			// pointer.
+			ga, err := want.ToGA()
+			if err != nil {
+				t.Fatalf("ToGA() = %v, want nil", err)
+			}
+			alpha, err := want.ToAlpha()
+			if err != nil {
+				t.Fatalf("ToAlpha() = %v, want nil", err)
+			}
+			if d := cmp.Diff(ga.EnableCDN, tc.want); d != "" {
+				t.Errorf("ToGA().EnableCDN: -got,+want: %s", d)
+			}
+			if d := cmp.Diff(alpha.EnableCDN, tc.want); d != "" {
+				t.Errorf("ToAlpha().EnableCDN: -got,+want: %s", d)
+			}
+		})
+	}
+}
+
 func TestResourceImpliedVersion(t *testing.T) {
 	t.Parallel()
 
@@ -922,6 +1294,15 @@ func TestResourceImpliedVersion(t *testing.T) {
 			if ver != tc.wantVer {
 				t.Errorf("ImpliedVersion() = %v, want %v", ver, tc.wantVer)
 			}
+			// The frozen Resource reports the implied version without
+			// needing to convert.
+			frozen, err := res.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			if got := frozen.Version(); got != tc.wantVer {
+				t.Errorf("Freeze().Version() = %v, want %v", got, tc.wantVer)
+			}
 		})
 	}
 }
@@ -1102,3 +1483,591 @@ func TestResourceTypeTrait(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceAlphaBetaFieldsWithoutCopyHelper(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type innerAB struct {
+		I               int
+		ABS             string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type st struct {
+		I               int
+		St              inner
+		Name            string
+		SelfLink        string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stA struct {
+		I               int
+		ABS             string
+		St              innerAB
+		Name            string
+		SelfLink        string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stB struct {
+		I               int
+		ABS             string
+		St              innerAB
+		Name            string
+		SelfLink        string
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	// No CopyHelpers are defined; fields common to alpha and beta (ABS) are
+	// copied by the generic copy.
+	tt := &TypeTraitFuncs[st, stA, stB]{
+		FieldTraitsF: func(meta.Version) *FieldTraits {
+			ret := &FieldTraits{}
+			for _, f := range []string{"I", "ABS", "St", "Name", "SelfLink"} {
+				ret.AllowZeroValue(Path{}.Pointer().Field(f))
+			}
+			return ret
+		},
+	}
+	r := newTestResource[st, stA, stB](tt)
+	if err := r.AccessBeta(func(x *stB) {
+		x.ABS = "abc"
+		x.St.ABS = "def"
+	}); err != nil {
+		t.Fatalf("AccessBeta() = %v, want nil", err)
+	}
+	// Changing a GA field must not lose the alpha and beta only fields.
+	if err := r.Access(func(x *st) { x.I = 13 }); err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+
+	wantA := &stA{I: 13, ABS: "abc", St: innerAB{ABS: "def"}, Name: "obj-1"}
+	a, err := r.ToAlpha()
+	if err != nil {
+		t.Fatalf("ToAlpha() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(a, wantA); diff != "" {
+		t.Errorf("ToAlpha() -got,+want: %s", diff)
+	}
+	wantB := &stB{I: 13, ABS: "abc", St: innerAB{ABS: "def"}, Name: "obj-1"}
+	b, err := r.ToBeta()
+	if err != nil {
+		t.Fatalf("ToBeta() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(b, wantB); diff != "" {
+		t.Errorf("ToBeta() -got,+want: %s", diff)
+	}
+	// The fields cannot be represented in GA.
+	if _, err := r.ToGA(); err == nil {
+		t.Errorf("ToGA() = nil, want error")
+	}
+}
+
+// pinAndFreeze pins res to ver and returns the version of the frozen Resource.
+func pinAndFreeze[G any, A any, B any](res *mutableResource[G, A, B], ver meta.Version) (meta.Version, error) {
+	res.PinVersion(ver)
+	fr, err := res.Freeze()
+	if err != nil {
+		return "", err
+	}
+	return fr.Version(), nil
+}
+
+func TestResourcePinVersion(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stB struct {
+		I               int
+		B               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	for _, tc := range []struct {
+		name    string
+		freeze  func() (meta.Version, error)
+		wantVer meta.Version
+		wantErr string
+	}{
+		{
+			name: "pin beta on ga fields",
+			freeze: func() (meta.Version, error) {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.Set(&st{I: 1})
+				return pinAndFreeze(res, meta.VersionBeta)
+			},
+			wantVer: meta.VersionBeta,
+		},
+		{
+			name: "pin ga with beta fields",
+			freeze: func() (meta.Version, error) {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				res.SetBeta(&stB{I: 1, B: 7})
+				return pinAndFreeze(res, meta.VersionGA)
+			},
+			wantErr: "pinned to version ga but cannot be converted to it",
+		},
+		{
+			name: "pin beta on ga only resource",
+			freeze: func() (meta.Version, error) {
+				res := newTestResource[st, PlaceholderType, PlaceholderType](nil)
+				res.Set(&st{I: 1})
+				return pinAndFreeze(res, meta.VersionBeta)
+			},
+			wantErr: "pinned to version beta but cannot be converted to it",
+		},
+		{
+			name: "invalid version",
+			freeze: func() (meta.Version, error) {
+				res := newTestResource[st, PlaceholderType, stB](nil)
+				return pinAndFreeze(res, meta.Version("v2"))
+			},
+			wantErr: `pinned to invalid version "v2"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ver, err := tc.freeze()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Freeze() = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			if ver != tc.wantVer {
+				t.Errorf("Version() = %v, want %v", ver, tc.wantVer)
+			}
+		})
+	}
+}
+
+func TestResourceFreezeValidate(t *testing.T) {
+	t.Parallel()
+
+	// Custom validation for BackendServices with a single health check and
+	// non-zero capacity for a single backend.
+	tt := &TypeTraitFuncs[compute.BackendService, PlaceholderType, PlaceholderType]{
+		ValidateF: func(v meta.Version, obj any) error {
+			if v != meta.VersionGA {
+				return fmt.Errorf("unexpected version %s", v)
+			}
+			bs := obj.(*compute.BackendService)
+			if len(bs.HealthChecks) != 1 {
+				return fmt.Errorf("want exactly one health check, got %d", len(bs.HealthChecks))
+			}
+			if len(bs.Backends) == 1 && bs.Backends[0].CapacityScaler == 0 {
+				return fmt.Errorf("capacityScaler cannot be 0 with a single backend")
+			}
+			return nil
+		},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		bs      *compute.BackendService
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			bs: &compute.BackendService{
+				HealthChecks: []string{"hc"},
+				Backends:     []*compute.Backend{{Group: "ig", CapacityScaler: 1}},
+			},
+		},
+		{
+			name:    "no health checks",
+			bs:      &compute.BackendService{},
+			wantErr: true,
+		},
+		{
+			name: "zero capacity scaler",
+			bs: &compute.BackendService{
+				HealthChecks: []string{"hc"},
+				Backends:     []*compute.Backend{{Group: "ig"}},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := newTestResource[compute.BackendService, PlaceholderType, PlaceholderType](tt)
+			if err := res.Set(tc.bs); err != nil {
+				t.Fatalf("Set() = %v, want nil", err)
+			}
+			_, err := res.Freeze()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Freeze() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceGetSetByPath(t *testing.T) {
+	t.Parallel()
+
+	res := newTestResource[compute.BackendService, PlaceholderType, PlaceholderType](nil)
+	if err := res.Set(&compute.BackendService{
+		Name:     "obj-1",
+		Backends: []*compute.Backend{{Group: "ig1"}, {Group: "ig2"}},
+	}); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+
+	csPath := Path{}.Pointer().Field("Backends").Index(0).Pointer().Field("CapacityScaler")
+	if err := res.SetByPath(csPath, 0.5); err != nil {
+		t.Fatalf("SetByPath(%s, 0.5) = %v, want nil", csPath, err)
+	}
+	got, err := res.GetByPath(csPath)
+	if err != nil {
+		t.Fatalf("GetByPath(%s) = %v, want nil", csPath, err)
+	}
+	if got != 0.5 {
+		t.Errorf("GetByPath(%s) = %v, want 0.5", csPath, got)
+	}
+	ga, _ := res.ToGA()
+	if ga.Backends[0].CapacityScaler != 0.5 || ga.Backends[1].CapacityScaler != 0 {
+		t.Errorf("Backends = %+v, %+v; want CapacityScaler = 0.5, 0", *ga.Backends[0], *ga.Backends[1])
+	}
+
+	// Nil pointers along the path are allocated.
+	cdnPath := Path{}.Pointer().Field("CdnPolicy").Pointer().Field("CacheMode")
+	if err := res.SetByPath(cdnPath, "CACHE_ALL_STATIC"); err != nil {
+		t.Fatalf("SetByPath(%s) = %v, want nil", cdnPath, err)
+	}
+	if ga, _ := res.ToGA(); ga.CdnPolicy == nil || ga.CdnPolicy.CacheMode != "CACHE_ALL_STATIC" {
+		t.Errorf("CdnPolicy = %+v, want CacheMode = CACHE_ALL_STATIC", ga.CdnPolicy)
+	}
+
+	// Maps are created if nil.
+	labelPath := Path{}.Pointer().Field("Metadatas").MapIndex("k")
+	if err := res.SetByPath(labelPath, "v"); err != nil {
+		t.Fatalf("SetByPath(%s) = %v, want nil", labelPath, err)
+	}
+	if got, err := res.GetByPath(labelPath); err != nil || got != "v" {
+		t.Errorf("GetByPath(%s) = %v, %v; want v, nil", labelPath, got, err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		path  Path
+		value any
+	}{
+		{name: "no such field", path: Path{}.Pointer().Field("Protcol"), value: "TCP"},
+		{name: "wrong type", path: csPath, value: "1"},
+		{name: "index out of range", path: Path{}.Pointer().Field("Backends").Index(5).Pointer().Field("Group"), value: "ig"},
+	} {
+		if err := res.SetByPath(tc.path, tc.value); err == nil {
+			t.Errorf("%s: SetByPath(%s, %v) = nil, want error", tc.name, tc.path, tc.value)
+		}
+	}
+	if _, err := res.GetByPath(Path{}.Pointer().Field("Iap").Pointer().Field("Enabled")); err == nil {
+		t.Errorf("GetByPath() through nil pointer = nil, want error")
+	}
+}
+
+func TestResourceSetByPathVersion(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		Name            string
+		SelfLink        string
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stA struct {
+		Name            string
+		SelfLink        string
+		I               int
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	res := newTestResource[st, stA, PlaceholderType](&diffTrait[st, stA, PlaceholderType]{})
+	if err := res.SetByPath(Path{}.Pointer().Field("I"), 3); err != nil {
+		t.Fatalf("SetByPath(.I) = %v, want nil", err)
+	}
+	if ver, err := res.ImpliedVersion(); err != nil || ver != meta.VersionGA {
+		t.Errorf("ImpliedVersion() = %v, %v; want %v, nil", ver, err, meta.VersionGA)
+	}
+	// A is only in Alpha.
+	if err := res.SetByPath(Path{}.Pointer().Field("A"), 5); err != nil {
+		t.Fatalf("SetByPath(.A) = %v, want nil", err)
+	}
+	if ver, err := res.ImpliedVersion(); err != nil || ver != meta.VersionAlpha {
+		t.Errorf("ImpliedVersion() = %v, %v; want %v, nil", ver, err, meta.VersionAlpha)
+	}
+	alpha, err := res.ToAlpha()
+	if err != nil {
+		t.Fatalf("ToAlpha() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(alpha, &stA{Name: "obj-1", SelfLink: alpha.SelfLink, I: 3, A: 5}); diff != "" {
+		t.Errorf("ToAlpha(): -got,+want: %s", diff)
+	}
+}
+
+func TestResourceAccessVersion(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		Name            string
+		SelfLink        string
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stB struct {
+		Name            string
+		SelfLink        string
+		I               int
+		B               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	res := newTestResource[st, PlaceholderType, stB](&diffTrait[st, PlaceholderType, stB]{})
+	if err := res.AccessVersion(meta.VersionGA, func(x any) { x.(*st).I = 3 }); err != nil {
+		t.Fatalf("AccessVersion(GA) = %v, want nil", err)
+	}
+	// B is only in Beta.
+	if err := res.AccessVersion(meta.VersionBeta, func(x any) { x.(*stB).B = 5 }); err != nil {
+		t.Fatalf("AccessVersion(Beta) = %v, want nil", err)
+	}
+	if ver, err := res.ImpliedVersion(); err != nil || ver != meta.VersionBeta {
+		t.Errorf("ImpliedVersion() = %v, %v; want %v, nil", ver, err, meta.VersionBeta)
+	}
+	beta, err := res.ToBeta()
+	if err != nil {
+		t.Fatalf("ToBeta() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(beta, &stB{Name: "obj-1", SelfLink: beta.SelfLink, I: 3, B: 5}); diff != "" {
+		t.Errorf("ToBeta(): -got,+want: %s", diff)
+	}
+
+	for _, v := range []meta.Version{meta.VersionAlpha, "invalid"} {
+		called := false
+		if err := res.AccessVersion(v, func(any) { called = true }); err == nil || called {
+			t.Errorf("AccessVersion(%q) = %v, called = %t; want error, false", v, err, called)
+		}
+	}
+}
+
+func TestResourceImpliedVersionCache(t *testing.T) {
+	t.Parallel()
+
+	type st struct {
+		Name            string
+		SelfLink        string
+		I               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type stA struct {
+		Name            string
+		SelfLink        string
+		I               int
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	res := newTestResource[st, stA, PlaceholderType](&diffTrait[st, stA, PlaceholderType]{})
+	checkVer := func(step string, want meta.Version) {
+		t.Helper()
+		// Call twice to exercise the cached path.
+		for i := 0; i < 2; i++ {
+			if ver, err := res.ImpliedVersion(); err != nil || ver != want {
+				t.Errorf("%s: ImpliedVersion() = %v, %v; want %v, nil", step, ver, err, want)
+			}
+		}
+	}
+
+	checkVer("initial", meta.VersionGA)
+	if err := res.AccessAlpha(func(x *stA) { x.A = 5 }); err != nil {
+		t.Fatalf("AccessAlpha() = %v, want nil", err)
+	}
+	checkVer("after AccessAlpha", meta.VersionAlpha)
+	if err := res.AccessAlpha(func(x *stA) { x.A = 0 }); err != nil {
+		t.Fatalf("AccessAlpha() = %v, want nil", err)
+	}
+	checkVer("after clearing A", meta.VersionGA)
+	res.PinVersion(meta.VersionAlpha)
+	checkVer("after PinVersion", meta.VersionAlpha)
+	if err := res.SetAlpha(&stA{Name: "obj-1", A: 1}); err != nil {
+		t.Fatalf("SetAlpha() = %v, want nil", err)
+	}
+	res.PinVersion("")
+	checkVer("after SetAlpha", meta.VersionAlpha)
+}
+
+func BenchmarkImpliedVersion(b *testing.B) {
+	res := newTestResource[compute.BackendService, PlaceholderType, PlaceholderType](nil)
+	if err := res.Set(&compute.BackendService{
+		Name:     "obj-1",
+		Backends: []*compute.Backend{{Group: "ig1"}, {Group: "ig2"}},
+	}); err != nil {
+		b.Fatalf("Set() = %v, want nil", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := res.ImpliedVersion(); err != nil {
+			b.Fatalf("ImpliedVersion() = %v, want nil", err)
+		}
+	}
+}
+
+func TestResourceAccessWithChanges(t *testing.T) {
+	t.Parallel()
+
+	res := newTestResource[compute.BackendService, PlaceholderType, PlaceholderType](nil)
+	res.Access(func(x *compute.BackendService) {
+		x.Name = "bs"
+		x.Port = 80
+	})
+
+	paths, err := res.AccessWithChanges(func(x *compute.BackendService) {
+		x.Name = "bs" // unchanged
+		x.Port = 8080
+		x.TimeoutSec = 30
+	})
+	if err != nil {
+		t.Fatalf("AccessWithChanges() = %v, want nil", err)
+	}
+	var got []string
+	for _, p := range paths {
+		got = append(got, p.String())
+	}
+	want := []string{
+		Path{}.Pointer().Field("Port").String(),
+		Path{}.Pointer().Field("TimeoutSec").String(),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("AccessWithChanges(): diff -got,+want: %s", diff)
+	}
+	ga, _ := res.ToGA()
+	if ga.Port != 8080 || ga.TimeoutSec != 30 {
+		t.Errorf("ToGA() = {Port: %d, TimeoutSec: %d}, want {Port: 8080, TimeoutSec: 30}", ga.Port, ga.TimeoutSec)
+	}
+}
+
+func TestResourceStripOutputOnlyAnySliceIndex(t *testing.T) {
+	t.Parallel()
+
+	tt := &TypeTraitFuncs[compute.BackendService, PlaceholderType, PlaceholderType]{
+		FieldTraitsF: func(meta.Version) *FieldTraits {
+			ret := NewFieldTraits()
+			ret.OutputOnly(Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Description"))
+			return ret
+		},
+	}
+	res := newTestResource[compute.BackendService, PlaceholderType, PlaceholderType](tt)
+	if err := res.Set(&compute.BackendService{
+		Backends: []*compute.Backend{
+			{Group: "ig-0", Description: "d0"},
+			{Group: "ig-1", Description: "d1"},
+		},
+	}); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	if err := res.StripOutputOnly(); err != nil {
+		t.Fatalf("StripOutputOnly() = %v, want nil", err)
+	}
+	ga, err := res.ToGA()
+	if err != nil {
+		t.Fatalf("ToGA() = %v, want nil", err)
+	}
+	want := []*compute.Backend{{Group: "ig-0"}, {Group: "ig-1"}}
+	if diff := cmp.Diff(ga.Backends, want); diff != "" {
+		t.Errorf("Backends: diff -got,+want: %s", diff)
+	}
+}
+
+type placeholderGATrait[G any, A any, B any] struct {
+	BaseTypeTrait[G, A, B]
+	allow bool
+}
+
+func (tt *placeholderGATrait[G, A, B]) AllowPlaceholderGA() bool { return tt.allow }
+
+func TestResourcePlaceholderGA(t *testing.T) {
+	t.Parallel()
+
+	type stA struct {
+		Name            string
+		SelfLink        string
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	newRes := func(allow bool) *mutableResource[PlaceholderType, stA, PlaceholderType] {
+		return newTestResource[PlaceholderType, stA, PlaceholderType](&placeholderGATrait[PlaceholderType, stA, PlaceholderType]{allow: allow})
+	}
+
+	if err := newRes(false).CheckSchema(); err == nil {
+		t.Errorf("CheckSchema() = nil, want error (PlaceholderType GA is not allowed)")
+	}
+
+	res := newRes(true)
+	if err := res.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+	if err := res.AccessAlpha(func(x *stA) { x.A = 5 }); err != nil {
+		t.Fatalf("AccessAlpha() = %v, want nil", err)
+	}
+	if err := res.Access(func(x *PlaceholderType) {}); err == nil {
+		t.Errorf("Access() = nil, want error")
+	}
+	if err := res.Set(&PlaceholderType{}); err == nil {
+		t.Errorf("Set() = nil, want error")
+	}
+	if v, err := res.GetByPath(Path{}.Pointer().Field("Name")); err != nil || v != "obj-1" {
+		t.Errorf("GetByPath(.Name) = %v, %v; want obj-1, nil", v, err)
+	}
+
+	r, err := res.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	if r.Version() != meta.VersionAlpha {
+		t.Errorf("Version() = %s, want %s", r.Version(), meta.VersionAlpha)
+	}
+	if ga, err := r.ToGA(); err == nil {
+		t.Errorf("ToGA() = %+v, nil; want error", ga)
+	}
+	alpha, err := r.ToAlpha()
+	if err != nil {
+		t.Fatalf("ToAlpha() = %v, want nil", err)
+	}
+	if alpha.Name != "obj-1" || alpha.A != 5 {
+		t.Errorf("ToAlpha() = %+v, want Name=obj-1, A=5", alpha)
+	}
+
+	other := newRes(true)
+	other.AccessAlpha(func(x *stA) { x.A = 6 })
+	otherR, err := other.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	dr, err := r.Diff(otherR)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	want := []DiffItem{{State: DiffItemDifferent, Path: Path{}.Pointer().Field("A"), A: 5, B: 6}}
+	if diff := cmp.Diff(dr.Items, want); diff != "" {
+		t.Errorf("Diff().Items: -got,+want: %s", diff)
+	}
+}
@@ -29,6 +29,10 @@ type TypeTrait[GA any, Alpha any, Beta any] interface {
 	// CopyHelpers are hooks called after the generic copy operation is
 	// complete. The func is given the post-copy src and dest struct and is free
 	// to modify dest to complete the copy operation.
+	//
+	// The generic copy converts directly between each pair of versions, so
+	// fields with the same name and type in Alpha and Beta (but not in GA)
+	// are propagated between Alpha and Beta without a CopyHelper.
 	CopyHelperGAtoAlpha(dest *Alpha, src *GA) error
 	CopyHelperGAtoBeta(dest *Beta, src *GA) error
 	CopyHelperAlphaToGA(dest *GA, src *Alpha) error
@@ -38,6 +42,27 @@ type TypeTrait[GA any, Alpha any, Beta any] interface {
 
 	// FieldTraits returns the field traits for the version given.
 	FieldTraits(meta.Version) *FieldTraits
+
+	// Validate is called by Freeze() after the implied version v has been
+	// determined. obj is the *GA, *Alpha or *Beta resource for version v.
+	// Return an error to reject resources that are invalid.
+	Validate(v meta.Version, obj any) error
+}
+
+// PlaceholderGATrait is an optional interface for a TypeTrait. If
+// AllowPlaceholderGA() returns true, the GA type of the resource may be
+// PlaceholderType. This is used to model resources that only exist in the
+// Alpha (or Beta) API. ToGA() and Access() for these resources return an
+// error.
+type PlaceholderGATrait interface {
+	AllowPlaceholderGA() bool
+}
+
+// allowPlaceholderGA returns true if tt implements PlaceholderGATrait and
+// allows GA to be PlaceholderType.
+func allowPlaceholderGA(tt any) bool {
+	pt, ok := tt.(PlaceholderGATrait)
+	return ok && pt.AllowPlaceholderGA()
 }
 
 // BaseTypeTrait is a TypeTrait that has no effect. This can be embedded to
@@ -56,6 +81,7 @@ func (*BaseTypeTrait[GA, Alpha, Beta]) CopyHelperBetaToAlpha(dest *Alpha, src *B
 	return nil
 }
 func (*BaseTypeTrait[GA, Alpha, Beta]) FieldTraits(meta.Version) *FieldTraits { return &FieldTraits{} }
+func (*BaseTypeTrait[GA, Alpha, Beta]) Validate(meta.Version, any) error      { return nil }
 
 // NewFieldTraits creates a default traits.
 func NewFieldTraits() *FieldTraits {
@@ -78,6 +104,7 @@ type TypeTraitFuncs[GA any, Alpha any, Beta any] struct {
 	CopyHelperBetaToGAF    func(dest *GA, src *Beta) error
 	CopyHelperBetaToAlphaF func(dest *Alpha, src *Beta) error
 	FieldTraitsF           func(meta.Version) *FieldTraits
+	ValidateF              func(meta.Version, any) error
 }
 
 // Implements TypeTrait.
@@ -123,10 +150,34 @@ func (f *TypeTraitFuncs[GA, Alpha, Beta]) FieldTraits(v meta.Version) *FieldTrai
 	}
 	return f.FieldTraitsF(v)
 }
+func (f *TypeTraitFuncs[GA, Alpha, Beta]) Validate(v meta.Version, obj any) error {
+	if f.ValidateF == nil {
+		return nil
+	}
+	return f.ValidateF(v, obj)
+}
 
 // FieldTraits are the features and behavior for fields in the resource.
+//
+// Paths may use AnySliceIndex() to apply a trait to every element of a
+// slice, e.g. Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("CapacityScaler").
 type FieldTraits struct {
-	fields []fieldTrait
+	fields         []fieldTrait
+	immutable      []Path
+	status         []Path
+	allowed        []allowedValues
+	serverDefaults []serverDefault
+	serverFilled   []Path
+}
+
+type serverDefault struct {
+	path  Path
+	value any
+}
+
+type allowedValues struct {
+	path   Path
+	values []string
 }
 
 type fieldTrait struct {
@@ -169,6 +220,42 @@ func (dt *FieldTraits) CheckSchema(t reflect.Type) error {
 			return fmt.Errorf("CheckSchema: %w", err)
 		}
 	}
+	for _, p := range dt.immutable {
+		if _, err := p.ResolveType(t); err != nil {
+			return fmt.Errorf("CheckSchema: Immutable: %w", err)
+		}
+	}
+	for _, p := range dt.status {
+		if _, err := p.ResolveType(t); err != nil {
+			return fmt.Errorf("CheckSchema: Status: %w", err)
+		}
+	}
+	for _, a := range dt.allowed {
+		if a.path[len(a.path)-1][0] != pathField {
+			return fmt.Errorf("CheckSchema: AllowedValues: path %s is not a field reference", a.path)
+		}
+		ft, err := a.path.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: AllowedValues: %w", err)
+		}
+		if ft.Kind() != reflect.String {
+			return fmt.Errorf("CheckSchema: AllowedValues: path %s is %v, not a string", a.path, ft)
+		}
+	}
+	for _, sd := range dt.serverDefaults {
+		ft, err := sd.path.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: ServerDefault: %w", err)
+		}
+		if !isBasicT(ft) || !canConvertDefault(reflect.TypeOf(sd.value), ft) {
+			return fmt.Errorf("CheckSchema: ServerDefault: path %s is %v, default value has type %T", sd.path, ft, sd.value)
+		}
+	}
+	for _, p := range dt.serverFilled {
+		if _, err := p.ResolveType(t); err != nil {
+			return fmt.Errorf("CheckSchema: ServerPopulated: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -188,10 +275,153 @@ func (dt *FieldTraits) AllowZeroValue(p Path) { dt.add(p, FieldTypeAllowZeroValu
 // NonZeroValue specifies the type of the given path.
 func (dt *FieldTraits) NonZeroValue(p Path) { dt.add(p, FieldTypeNonZeroValue) }
 
+// Immutable marks the given path as immutable: the field cannot be changed
+// in place and the resource must be recreated when it differs. This is
+// independent of the FieldType of the path.
+func (dt *FieldTraits) Immutable(p Path) { dt.immutable = append(dt.immutable, p) }
+
+// IsImmutable returns true if p is at or below a path marked Immutable.
+func (dt *FieldTraits) IsImmutable(p Path) bool {
+	for _, ip := range dt.immutable {
+		if p.HasPrefix(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Status marks the given path as status: the field is set by the server and
+// differences are reported in DiffResult.StatusItems for information but do
+// not drive any changes to the resource. Unlike OutputOnly fields, Status
+// fields are compared in a diff.
+func (dt *FieldTraits) Status(p Path) { dt.status = append(dt.status, p) }
+
+// IsStatus returns true if p is at or below a path marked Status.
+func (dt *FieldTraits) IsStatus(p Path) bool {
+	for _, sp := range dt.status {
+		if p.HasPrefix(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedValues restricts the string field at p to one of values. This is
+// used for enum fields. The field may still be empty (i.e. not set). p must
+// refer to a field of type string.
+func (dt *FieldTraits) AllowedValues(p Path, values []string) {
+	dt.allowed = append(dt.allowed, allowedValues{path: p, values: append([]string{}, values...)})
+}
+
+// checkAllowedValue returns an error if the field v at p is not one of the
+// AllowedValues for p.
+func (dt *FieldTraits) checkAllowedValue(p Path, v reflect.Value) error {
+	if v.Kind() != reflect.String || v.String() == "" {
+		return nil
+	}
+	for _, a := range dt.allowed {
+		if !p.Match(a.path) {
+			continue
+		}
+		for _, allowed := range a.values {
+			if v.String() == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s has value %q, must be one of %v", p, v.String(), a.values)
+	}
+	return nil
+}
+
+// ServerDefault sets the value the server uses for the field at p when it is
+// not set (i.e. is the zero value) in the request. A diff treats the zero
+// value and the default value as equal, e.g. a local TimeoutSec of 0 is not a
+// diff from a TimeoutSec of 30 returned by the server. p must refer to a field
+// of basic type (string, int, bool etc.) that value can be converted to.
+func (dt *FieldTraits) ServerDefault(p Path, value any) {
+	dt.serverDefaults = append(dt.serverDefaults, serverDefault{path: p, value: value})
+}
+
+// isServerDefault returns true if one of a and b is the zero value and the
+// other is the ServerDefault for p.
+func (dt *FieldTraits) isServerDefault(p Path, a, b reflect.Value) bool {
+	for _, sd := range dt.serverDefaults {
+		if !p.Match(sd.path) {
+			continue
+		}
+		dv := reflect.ValueOf(sd.value)
+		if !canConvertDefault(dv.Type(), a.Type()) {
+			return false
+		}
+		dv = dv.Convert(a.Type())
+		return (a.IsZero() && b.Equal(dv)) || (b.IsZero() && a.Equal(dv))
+	}
+	return false
+}
+
+// ServerPopulated marks the field at p as filled in by the server when it is
+// not set in the request. Unlike ServerDefault, the value the server picks is
+// not known in advance (e.g. it is derived from other fields) and the field can
+// be of any type. A diff ignores the field when either side is the zero value;
+// two non-zero values are compared as usual.
+func (dt *FieldTraits) ServerPopulated(p Path) { dt.serverFilled = append(dt.serverFilled, p) }
+
+// isServerPopulated returns true if p is a ServerPopulated field and one of a
+// and b is the zero value.
+func (dt *FieldTraits) isServerPopulated(p Path, a, b reflect.Value) bool {
+	if !a.IsZero() && !b.IsZero() {
+		return false
+	}
+	for _, sp := range dt.serverFilled {
+		if p.Match(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerSet returns true if p is a ServerDefault or ServerPopulated field,
+// regardless of its value.
+func (dt *FieldTraits) isServerSet(p Path) bool {
+	for _, sd := range dt.serverDefaults {
+		if p.Match(sd.path) {
+			return true
+		}
+	}
+	for _, sp := range dt.serverFilled {
+		if p.Match(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+// canConvertDefault returns true if a default value of type vt can be
+// converted to the field type ft. Numbers can be converted to other numeric
+// types; strings and bools must match (e.g. an int is not converted to a
+// string).
+func canConvertDefault(vt, ft reflect.Type) bool {
+	if vt == nil || !vt.ConvertibleTo(ft) {
+		return false
+	}
+	switch {
+	case ft.Kind() == reflect.String, vt.Kind() == reflect.String:
+		return ft.Kind() == vt.Kind()
+	case ft.Kind() == reflect.Bool, vt.Kind() == reflect.Bool:
+		return ft.Kind() == vt.Kind()
+	}
+	return true
+}
+
 // Clone create an exact copy of the traits.
 func (dt *FieldTraits) Clone() *FieldTraits {
 	return &FieldTraits{
-		fields: append([]fieldTrait{}, dt.fields...),
+		fields:         append([]fieldTrait{}, dt.fields...),
+		immutable:      append([]Path{}, dt.immutable...),
+		status:         append([]Path{}, dt.status...),
+		allowed:        append([]allowedValues{}, dt.allowed...),
+		serverDefaults: append([]serverDefault{}, dt.serverDefaults...),
+		serverFilled:   append([]Path{}, dt.serverFilled...),
 	}
 }
 
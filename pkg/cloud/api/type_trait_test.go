@@ -124,6 +124,11 @@ func TestFieldTraitsClone(t *testing.T) {
 
 	dt := &FieldTraits{}
 	dt.OutputOnly(Path{}.Pointer().Field("A"))
+	dt.Immutable(Path{}.Pointer().Field("B"))
+	dt.AllowedValues(Path{}.Pointer().Field("C"), []string{"X", "Y"})
+	dt.Status(Path{}.Pointer().Field("E"))
+	dt.ServerDefault(Path{}.Pointer().Field("D"), 30)
+	dt.ServerPopulated(Path{}.Pointer().Field("F"))
 
 	dtc := dt.Clone()
 	if !reflect.DeepEqual(dt, dtc) {
@@ -141,6 +146,7 @@ func TestFieldTraitsCheckSchema(t *testing.T) {
 			L []string
 		}
 		P *string
+		E string
 	}
 
 	for _, tc := range []struct {
@@ -181,6 +187,84 @@ func TestFieldTraitsCheckSchema(t *testing.T) {
 			ty:      reflect.TypeOf(&st{}),
 			wantErr: true,
 		},
+		{
+			name: "immutable path references fields that don't exist",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.Immutable(Path{}.Pointer().Field("X"))
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
+		{
+			name: "allowed values for a string field",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.AllowedValues(Path{}.Pointer().Field("E"), []string{"a"})
+				return &ret
+			}(),
+			ty: reflect.TypeOf(&st{}),
+		},
+		{
+			name: "allowed values for a non-string field",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.AllowedValues(Path{}.Pointer().Field("A"), []string{"a"})
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
+		{
+			name: "server default",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.ServerDefault(Path{}.Pointer().Field("A"), 30)
+				ret.ServerDefault(Path{}.Pointer().Field("E"), "x")
+				return &ret
+			}(),
+			ty: reflect.TypeOf(&st{}),
+		},
+		{
+			name: "server default with the wrong type",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.ServerDefault(Path{}.Pointer().Field("E"), 30)
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
+		{
+			name: "server default for a non-basic field",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.ServerDefault(Path{}.Pointer().Field("S"), 30)
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
+		{
+			name: "server populated",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.ServerPopulated(Path{}.Pointer().Field("S"))
+				return &ret
+			}(),
+			ty: reflect.TypeOf(&st{}),
+		},
+		{
+			name: "server populated invalid path",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.ServerPopulated(Path{}.Pointer().Field("Z"))
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := tc.ft.CheckSchema(tc.ty)
@@ -190,3 +274,62 @@ func TestFieldTraitsCheckSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldTraitsIsImmutable(t *testing.T) {
+	t.Parallel()
+
+	dt := NewFieldTraits()
+	dt.Immutable(Path{}.Pointer().Field("A"))
+	dt.Immutable(Path{}.Pointer().Field("S").Field("L"))
+	// Immutable is independent of the FieldType.
+	dt.NonZeroValue(Path{}.Pointer().Field("A"))
+
+	for _, tc := range []struct {
+		p    Path
+		want bool
+	}{
+		{p: Path{}.Pointer().Field("A"), want: true},
+		{p: Path{}.Pointer().Field("B")},
+		{p: Path{}.Pointer().Field("S")},
+		{p: Path{}.Pointer().Field("S").Field("A")},
+		{p: Path{}.Pointer().Field("S").Field("L"), want: true},
+		{p: Path{}.Pointer().Field("S").Field("L").Index(3), want: true},
+	} {
+		if got := dt.IsImmutable(tc.p); got != tc.want {
+			t.Errorf("IsImmutable(%s) = %t, want %t", tc.p, got, tc.want)
+		}
+	}
+	if got := dt.FieldType(Path{}.Pointer().Field("A")); got != FieldTypeNonZeroValue {
+		t.Errorf("FieldType(.A) = %s, want %s", got, FieldTypeNonZeroValue)
+	}
+}
+
+func TestFieldTraitsAnySliceIndex(t *testing.T) {
+	t.Parallel()
+
+	backends := Path{}.Pointer().Field("Backends")
+
+	dt := NewFieldTraits()
+	dt.AllowZeroValue(backends.AnySliceIndex().Pointer().Field("CapacityScaler"))
+	dt.Immutable(backends.AnySliceIndex().Pointer().Field("Group"))
+
+	for _, tc := range []struct {
+		p             Path
+		wantType      FieldType
+		wantImmutable bool
+	}{
+		{p: backends.Index(0).Pointer().Field("CapacityScaler"), wantType: FieldTypeAllowZeroValue},
+		{p: backends.Index(1).Pointer().Field("CapacityScaler"), wantType: FieldTypeAllowZeroValue},
+		{p: backends.Index(0).Pointer().Field("Group"), wantType: FieldTypeOrdinary, wantImmutable: true},
+		{p: backends.Index(1).Pointer().Field("Group"), wantType: FieldTypeOrdinary, wantImmutable: true},
+		{p: backends.Index(1).Pointer().Field("Description"), wantType: FieldTypeOrdinary},
+		{p: backends, wantType: FieldTypeOrdinary},
+	} {
+		if got := dt.FieldType(tc.p); got != tc.wantType {
+			t.Errorf("FieldType(%s) = %s, want %s", tc.p, got, tc.wantType)
+		}
+		if got := dt.IsImmutable(tc.p); got != tc.wantImmutable {
+			t.Errorf("IsImmutable(%s) = %t, want %t", tc.p, got, tc.wantImmutable)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maxBatchGetSize is the maximum number of calls in a single batch
+	// request to the compute API.
+	maxBatchGetSize = 1000
+)
+
+// BatchGetResult is the result of fetching a single resource with BatchGet.
+type BatchGetResult struct {
+	// Obj is the resource returned by the API (e.g. *compute.BackendService).
+	// Obj is nil if Err is not nil.
+	Obj any
+	// Err is the error from fetching the resource.
+	Err error
+}
+
+// BatchGetter is implemented by Cloud implementations that can fetch multiple
+// resources in a single API call.
+type BatchGetter interface {
+	// BatchGet fetches the resources ids at version ver. The results are
+	// returned in the same order as ids. A non-nil error is returned if the
+	// batch as a whole failed.
+	BatchGet(ctx context.Context, ver meta.Version, ids []*ResourceID) ([]BatchGetResult, error)
+}
+
+// BatchGet fetches the resources ids at version ver from c. This will use a
+// single batch call if c implements BatchGetter, otherwise this falls back to
+// a sequential Get for each resource.
+func BatchGet(ctx context.Context, c Cloud, ver meta.Version, ids []*ResourceID) ([]BatchGetResult, error) {
+	if bg, ok := c.(BatchGetter); ok {
+		return bg.BatchGet(ctx, ver, ids)
+	}
+	return sequentialGet(ctx, c, ver, ids), nil
+}
+
+func sequentialGet(ctx context.Context, c Cloud, ver meta.Version, ids []*ResourceID) []BatchGetResult {
+	ret := make([]BatchGetResult, len(ids))
+	for i, id := range ids {
+		ret[i].Obj, ret[i].Err = getByResourceID(ctx, c, ver, id)
+	}
+	return ret
+}
+
+// GCE implements BatchGetter.
+var _ BatchGetter = (*GCE)(nil)
+
+// BatchGet fetches the resources using the compute JSON batch endpoint of the
+// Service BasePath for ver. Resources that cannot be batched (e.g. non-compute
+// API groups) are fetched sequentially. If the Service does not have an
+// HTTPClient or a compute Service for ver, all resources are fetched
+// sequentially. If a batch request fails as a whole, the resources in
+// that batch are fetched sequentially instead so that a single bad batch does
+// not fail every resource.
+func (gce *GCE) BatchGet(ctx context.Context, ver meta.Version, ids []*ResourceID) ([]BatchGetResult, error) {
+	ret := make([]BatchGetResult, len(ids))
+	basePath := gce.batchBasePath(ver)
+
+	var batched []int
+	for i, id := range ids {
+		if gce.s.HTTPClient == nil || basePath == "" || id.APIGroup != meta.APIGroupCompute || id.Key == nil {
+			ret[i].Obj, ret[i].Err = getByResourceID(ctx, gce, ver, id)
+			continue
+		}
+		batched = append(batched, i)
+	}
+
+	for len(batched) > 0 {
+		n := min(len(batched), maxBatchGetSize)
+		if err := gce.batchGet(ctx, ver, basePath, ids, batched[:n], ret); err != nil {
+			klog.V(2).Infof("GCE.BatchGet(%v, %v): batch failed, falling back to Get: %v", ctx, ver, err)
+			for _, i := range batched[:n] {
+				ret[i].Obj, ret[i].Err = getByResourceID(ctx, gce, ver, ids[i])
+			}
+		}
+		batched = batched[n:]
+	}
+
+	return ret, nil
+}
+
+// batchBasePath returns the BasePath of the compute Service for ver or "" if
+// there is no Service.
+func (gce *GCE) batchBasePath(ver meta.Version) string {
+	switch {
+	case ver == meta.VersionGA && gce.s.GA != nil:
+		return gce.s.GA.BasePath
+	case ver == meta.VersionAlpha && gce.s.Alpha != nil:
+		return gce.s.Alpha.BasePath
+	case ver == meta.VersionBeta && gce.s.Beta != nil:
+		return gce.s.Beta.BasePath
+	}
+	return ""
+}
+
+// batchGet issues a single batch request for ids[idx] and stores the results
+// in ret. The batch endpoint for a BasePath of
+// "https://HOST/compute/v1/" is "https://HOST/batch/compute/v1".
+func (gce *GCE) batchGet(ctx context.Context, ver meta.Version, basePath string, ids []*ResourceID, idx []int, ret []BatchGetResult) error {
+	base, err := url.Parse(basePath)
+	if err != nil {
+		return fmt.Errorf("BatchGet: invalid BasePath %q: %w", basePath, err)
+	}
+	apiPath := strings.TrimSuffix(base.Path, "/")
+	batchURL := url.URL{Scheme: base.Scheme, Host: base.Host, Path: "/batch" + apiPath}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for _, i := range idx {
+		id := ids[i]
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", "application/http")
+		h.Set("Content-ID", fmt.Sprintf("<%d>", i))
+		pw, err := mw.CreatePart(h)
+		if err != nil {
+			return fmt.Errorf("BatchGet: %w", err)
+		}
+		path := apiPath + "/" + RelativeResourceName(id.ProjectID, id.Resource, id.Key)
+		fmt.Fprintf(pw, "GET %s HTTP/1.1\r\n\r\n", path)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("BatchGet: %w", err)
+	}
+
+	ck := &CallContextKey{
+		ProjectID: ids[idx[0]].ProjectID,
+		Operation: "BatchGet",
+		Version:   ver,
+		Service:   "Batch",
+	}
+	callObserverStart(ctx, ck)
+	if err := gce.s.RateLimiter.Accept(ctx, ck); err != nil {
+		klog.V(4).Infof("GCE.BatchGet(%v, %v): RateLimiter error: %v", ctx, ver, err)
+		return err
+	}
+	err = gce.doBatch(ctx, batchURL.String(), "multipart/mixed; boundary="+mw.Boundary(), body, ver, ids, idx, ret)
+	klog.V(4).Infof("GCE.BatchGet(%v, %v, %d calls) = %v", ctx, ver, len(idx), err)
+	callObserverEnd(ctx, ck, err)
+	gce.s.RateLimiter.Observe(ctx, err, ck)
+
+	return err
+}
+
+// doBatch sends the batch request and stores the results for ids[idx] in ret.
+// Requests without a part in the response get an error.
+func (gce *GCE) doBatch(ctx context.Context, batchURL, contentType string, body io.Reader, ver meta.Version, ids []*ResourceID, idx []int, ret []BatchGetResult) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, batchURL, body)
+	if err != nil {
+		return fmt.Errorf("BatchGet: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := gce.s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("BatchGet: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return err
+	}
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("BatchGet: invalid response Content-Type: %w", err)
+	}
+	mr := multipart.NewReader(resp.Body, params["boundary"])
+	pending := map[int]bool{}
+	for _, i := range idx {
+		pending[i] = true
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("BatchGet: %w", err)
+		}
+		i, err := batchPartIndex(part.Header.Get("Content-ID"), len(ids))
+		if err != nil {
+			return err
+		}
+		if !pending[i] {
+			return fmt.Errorf("BatchGet: unexpected response Content-ID %q", part.Header.Get("Content-ID"))
+		}
+		ret[i].Obj, ret[i].Err = parseBatchPart(part, req, ver, ids[i])
+		delete(pending, i)
+	}
+	for _, i := range idx {
+		if pending[i] {
+			ret[i].Err = fmt.Errorf("BatchGet: no response for %v", ids[i])
+		}
+	}
+	return nil
+}
+
+// parseBatchPart parses the HTTP response embedded in a batch response part.
+func parseBatchPart(part io.Reader, req *http.Request, ver meta.Version, id *ResourceID) (any, error) {
+	resp, err := http.ReadResponse(bufio.NewReader(part), req)
+	if err != nil {
+		return nil, fmt.Errorf("BatchGet: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	obj, err := newObjectByResourceID(ver, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("BatchGet: decode %v: %w", id, err)
+	}
+	return obj, nil
+}
+
+// batchPartIndex returns the request index from the Content-ID of a response
+// part. Responses have a Content-ID of the form "<response-N>".
+func batchPartIndex(contentID string, n int) (int, error) {
+	s := strings.TrimSuffix(strings.TrimPrefix(contentID, "<response-"), ">")
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 || i >= n {
+		return 0, fmt.Errorf("BatchGet: invalid response Content-ID %q", contentID)
+	}
+	return i, nil
+}
+
+// MockGCE implements BatchGetter.
+var _ BatchGetter = (*MockGCE)(nil)
+
+// BatchGet returns the resources from the mocks. Each resource is fetched with
+// the Get of the individual mock so the Get hooks are invoked. Use
+// BatchGetHook to intercept the batch as a whole.
+func (mock *MockGCE) BatchGet(ctx context.Context, ver meta.Version, ids []*ResourceID) ([]BatchGetResult, error) {
+	if mock.BatchGetHook != nil {
+		if intercept, ret, err := mock.BatchGetHook(ctx, ver, ids, mock); intercept {
+			klog.V(5).Infof("MockGCE.BatchGet(%v, %v, %v) = %+v, %v", ctx, ver, ids, ret, err)
+			return ret, err
+		}
+	}
+
+	ret := sequentialGet(ctx, mock, ver, ids)
+	klog.V(5).Infof("MockGCE.BatchGet(%v, %v, %v) = %+v, nil", ctx, ver, ids, ret)
+	return ret, nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/cerrors"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// fakeBatchEndpoint responds to a batch request by returning a
+// BackendService for each GET in the batch, NotFound for names starting with
+// "missing" and no response part for names starting with "dropped".
+func fakeBatchEndpoint(t *testing.T, calls *int) roundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		*calls++
+		if got := r.URL.Host + r.URL.Path; got != "compute.example.com/batch/compute/v1" {
+			t.Errorf("batch URL = %q, want compute.example.com/batch/compute/v1", got)
+		}
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+		out := &bytes.Buffer{}
+		mw := multipart.NewWriter(out)
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				return nil, err
+			}
+			if !strings.HasPrefix(req.URL.Path, "/compute/v1/projects/") {
+				t.Errorf("batch part path = %q, want prefix /compute/v1/projects/", req.URL.Path)
+			}
+			name := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			if strings.HasPrefix(name, "dropped") {
+				continue
+			}
+
+			h := textproto.MIMEHeader{}
+			h.Set("Content-Type", "application/http")
+			h.Set("Content-ID", "<response-"+strings.Trim(part.Header.Get("Content-ID"), "<>")+">")
+			pw, err := mw.CreatePart(h)
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(name, "missing") {
+				fmt.Fprint(pw, "HTTP/1.1 404 Not Found\r\nContent-Type: application/json\r\n\r\n{\"error\": {\"code\": 404, \"message\": \"not found\"}}")
+				continue
+			}
+			fmt.Fprintf(pw, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n{\"name\": %q}", name)
+		}
+		mw.Close()
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"multipart/mixed; boundary=" + mw.Boundary()}},
+			Body:       io.NopCloser(out),
+		}, nil
+	}
+}
+
+func TestGCEBatchGet(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := &http.Client{Transport: fakeBatchEndpoint(t, &calls)}
+	svc, err := NewService(context.Background(), client, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v, want nil", err)
+	}
+	// The batch endpoint is derived from the BasePath.
+	svc.GA.BasePath = "https://compute.example.com/compute/v1/"
+	gce := NewGCE(svc)
+	ids := []*ResourceID{
+		NewBackendServicesResourceID("proj", "bs1"),
+		NewBackendServicesResourceID("proj", "missing"),
+		NewBackendServicesResourceID("proj", "bs2"),
+		NewBackendServicesResourceID("proj", "dropped"),
+	}
+
+	results, err := BatchGet(context.Background(), gce, meta.VersionGA, ids)
+	if err != nil {
+		t.Fatalf("BatchGet() = %v, want nil", err)
+	}
+	if calls != 1 {
+		t.Errorf("batch calls = %d, want 1", calls)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(ids))
+	}
+	// A request without a response part gets an error.
+	if results[3].Err == nil || cerrors.IsGoogleAPINotFound(results[3].Err) {
+		t.Errorf("results[3].Err = %v, want missing response error", results[3].Err)
+	}
+	for i, name := range []string{"bs1", "", "bs2"} {
+		if name == "" {
+			if !cerrors.IsGoogleAPINotFound(results[i].Err) {
+				t.Errorf("results[%d].Err = %v, want NotFound", i, results[i].Err)
+			}
+			continue
+		}
+		bs, ok := results[i].Obj.(*ga.BackendService)
+		if !ok || results[i].Err != nil {
+			t.Errorf("results[%d] = %+v, want *ga.BackendService", i, results[i])
+			continue
+		}
+		if bs.Name != name {
+			t.Errorf("results[%d].Name = %q, want %q", i, bs.Name, name)
+		}
+	}
+}
+
+func TestMockBatchGet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	mock.BackendServices().Insert(ctx, meta.GlobalKey("bs1"), &ga.BackendService{Name: "bs1"})
+	mock.RegionBackendServices().Insert(ctx, meta.RegionalKey("bs2", "us-central1"), &ga.BackendService{Name: "bs2"})
+
+	var gets int
+	mock.MockBackendServices.GetHook = func(context.Context, *meta.Key, *MockBackendServices, ...Option) (bool, *ga.BackendService, error) {
+		gets++
+		return false, nil, nil
+	}
+	ids := []*ResourceID{
+		NewBackendServicesResourceID("proj", "bs1"),
+		NewRegionBackendServicesResourceID("proj", "us-central1", "bs2"),
+		NewBackendServicesResourceID("proj", "missing"),
+	}
+
+	results, err := BatchGet(ctx, mock, meta.VersionGA, ids)
+	if err != nil {
+		t.Fatalf("BatchGet() = %v, want nil", err)
+	}
+	// The Get hooks are invoked for each (global) BackendService.
+	if gets != 2 {
+		t.Errorf("Get calls = %d, want 2", gets)
+	}
+	for i, name := range []string{"bs1", "bs2"} {
+		if bs, ok := results[i].Obj.(*ga.BackendService); !ok || bs.Name != name {
+			t.Errorf("results[%d] = %+v, want BackendService %q", i, results[i], name)
+		}
+	}
+	if !cerrors.IsGoogleAPINotFound(results[2].Err) {
+		t.Errorf("results[2].Err = %v, want NotFound", results[2].Err)
+	}
+}
+
+func TestGCEBatchGetBatchError(t *testing.T) {
+	t.Parallel()
+
+	var batches, gets int
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(r.URL.Path, "/batch/") {
+			batches++
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error": {"code": 503, "message": "unavailable"}}`)),
+			}, nil
+		}
+		gets++
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"name": %q}`, name))),
+		}, nil
+	})
+	svc, err := NewService(context.Background(), &http.Client{Transport: transport}, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v, want nil", err)
+	}
+	ids := []*ResourceID{
+		NewBackendServicesResourceID("proj", "bs1"),
+		NewBackendServicesResourceID("proj", "bs2"),
+	}
+
+	// The failed batch falls back to a Get for each resource.
+	results, err := BatchGet(context.Background(), NewGCE(svc), meta.VersionGA, ids)
+	if err != nil {
+		t.Fatalf("BatchGet() = %v, want nil", err)
+	}
+	if batches != 1 || gets != len(ids) {
+		t.Errorf("batch calls, gets = %d, %d; want 1, %d", batches, gets, len(ids))
+	}
+	for i, name := range []string{"bs1", "bs2"} {
+		if bs, ok := results[i].Obj.(*ga.BackendService); !ok || bs.Name != name {
+			t.Errorf("results[%d] = %+v, want BackendService %q", i, results[i], name)
+		}
+	}
+}
+
+// cloudOnly hides the BatchGetter implementation of the wrapped Cloud.
+type cloudOnly struct{ Cloud }
+
+func TestBatchGetFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	mock.BackendServices().Insert(ctx, meta.GlobalKey("bs1"), &ga.BackendService{Name: "bs1"})
+
+	var gets int
+	mock.MockBackendServices.GetHook = func(context.Context, *meta.Key, *MockBackendServices, ...Option) (bool, *ga.BackendService, error) {
+		gets++
+		return false, nil, nil
+	}
+	ids := []*ResourceID{
+		NewBackendServicesResourceID("proj", "bs1"),
+		NewBackendServicesResourceID("proj", "missing"),
+	}
+
+	results, err := BatchGet(ctx, cloudOnly{mock}, meta.VersionGA, ids)
+	if err != nil {
+		t.Fatalf("BatchGet() = %v, want nil", err)
+	}
+	if gets != len(ids) {
+		t.Errorf("Get calls = %d, want %d", gets, len(ids))
+	}
+	if bs, ok := results[0].Obj.(*ga.BackendService); !ok || bs.Name != "bs1" {
+		t.Errorf("results[0] = %+v, want BackendService bs1", results[0])
+	}
+	if !cerrors.IsGoogleAPINotFound(results[1].Err) {
+		t.Errorf("results[1].Err = %v, want NotFound", results[1].Err)
+	}
+}
@@ -33,3 +33,56 @@ func isGoogleAPIErrorCode(err error, code int) bool {
 }
 
 func IsGoogleAPINotFound(err error) bool { return isGoogleAPIErrorCode(err, http.StatusNotFound) }
+
+// IsGoogleAPIPreconditionFailed is true for 412 Precondition Failed errors.
+// This is returned when the fingerprint sent with an update is stale.
+func IsGoogleAPIPreconditionFailed(err error) bool {
+	return isGoogleAPIErrorCode(err, http.StatusPreconditionFailed)
+}
+
+// IsGoogleAPIAlreadyExists is true for 409 Conflict errors and errors with
+// the "alreadyExists" reason. This is returned when the resource (or a
+// sub-resource, such as a rule at the same priority) already exists.
+func IsGoogleAPIAlreadyExists(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	if gerr.Code == http.StatusConflict {
+		return true
+	}
+	for _, item := range gerr.Errors {
+		if item.Reason == "alreadyExists" {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaReasons are the googleapi.ErrorItem reasons returned with a 403 when
+// a quota or rate limit is exceeded.
+var quotaReasons = map[string]bool{
+	"quotaExceeded":         true,
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+}
+
+// IsGoogleAPIQuotaExceeded is true for 429 Too Many Requests errors and 403
+// Forbidden errors with a quota or rate limit reason.
+func IsGoogleAPIQuotaExceeded(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	switch gerr.Code {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		for _, item := range gerr.Errors {
+			if quotaReasons[item.Reason] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -98,3 +98,143 @@ func TestIsGoogleAPINotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestIsGoogleAPIPreconditionFailed(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "Nil error",
+		},
+		{
+			desc: "Not a google API error",
+			err:  fmt.Errorf("some error"),
+		},
+		{
+			desc: "Google API Conflict error",
+			err:  &googleapi.Error{Code: http.StatusConflict, Message: "some message"},
+		},
+		{
+			desc: "Google API PreconditionFailed error",
+			err:  &googleapi.Error{Code: http.StatusPreconditionFailed, Message: "fingerprint mismatch"},
+			want: true,
+		},
+		{
+			desc: "Wrapped Google API PreconditionFailed error",
+			err:  fmt.Errorf("update: %w", &googleapi.Error{Code: http.StatusPreconditionFailed}),
+			want: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := IsGoogleAPIPreconditionFailed(tc.err)
+			if got != tc.want {
+				t.Errorf("IsGoogleAPIPreconditionFailed(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsGoogleAPIAlreadyExists(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "Nil error",
+		},
+		{
+			desc: "Not a google API error",
+			err:  fmt.Errorf("some error"),
+		},
+		{
+			desc: "Google API NotFound error",
+			err:  &googleapi.Error{Code: http.StatusNotFound, Message: "some message"},
+		},
+		{
+			desc: "Google API Conflict error",
+			err:  &googleapi.Error{Code: http.StatusConflict, Message: "some message"},
+			want: true,
+		},
+		{
+			desc: "Google API BadRequest error with alreadyExists reason",
+			err: &googleapi.Error{
+				Code:   http.StatusBadRequest,
+				Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}},
+			},
+			want: true,
+		},
+		{
+			desc: "Wrapped Google API Conflict error",
+			err:  fmt.Errorf("add: %w", &googleapi.Error{Code: http.StatusConflict}),
+			want: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := IsGoogleAPIAlreadyExists(tc.err)
+			if got != tc.want {
+				t.Errorf("IsGoogleAPIAlreadyExists(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsGoogleAPIQuotaExceeded(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "Nil error",
+		},
+		{
+			desc: "Not a google API error",
+			err:  fmt.Errorf("some error"),
+		},
+		{
+			desc: "Google API TooManyRequests error",
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests, Message: "some message"},
+			want: true,
+		},
+		{
+			desc: "Google API Forbidden error with quotaExceeded reason",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}},
+			},
+			want: true,
+		},
+		{
+			desc: "Google API Forbidden error with rateLimitExceeded reason",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "forbidden"}, {Reason: "rateLimitExceeded"}},
+			},
+			want: true,
+		},
+		{
+			desc: "Google API Forbidden error without a quota reason",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "forbidden"}},
+			},
+		},
+		{
+			desc: "Google API BadRequest error with quotaExceeded reason",
+			err: &googleapi.Error{
+				Code:   http.StatusBadRequest,
+				Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := IsGoogleAPIQuotaExceeded(tc.err)
+			if got != tc.want {
+				t.Errorf("IsGoogleAPIQuotaExceeded(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
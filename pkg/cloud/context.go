@@ -46,3 +46,74 @@ type CallContextKey struct {
 	// Resource is the key of the resource being operated on.
 	Resource *meta.Key
 }
+
+type runIDKey struct{}
+
+// WithRunID returns a context that carries id, identifying a run (e.g. an
+// execution of a set of Actions) for correlating logs. The context is passed
+// to the API calls and to the hooks of the mocks, which can retrieve the id
+// with RunID.
+func WithRunID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, id)
+}
+
+// RunID returns the id set by WithRunID. ok is false if there is no run ID
+// in ctx.
+func RunID(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(runIDKey{}).(string)
+	return id, ok
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context that carries id as the requestId for the
+// generated mutating API calls (e.g. Insert, Delete). The API ignores a
+// request with the same requestId as a previous request, making it safe to
+// retry a call that may have succeeded. id must be a valid UUID.
+//
+// Only a single call should be made with the returned context; a different
+// call with the same requestId will be treated as a duplicate.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the id set by WithRequestID. ok is false if there is no
+// request ID in ctx.
+func RequestID(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+type rulePriorityKey struct{}
+
+// WithRulePriority returns a context that carries the priority of the rule
+// for the generated rule methods (e.g. SecurityPolicies GetRule, PatchRule
+// and RemoveRule). The API uses the priority to select the rule in the
+// policy.
+func WithRulePriority(ctx context.Context, priority int64) context.Context {
+	return context.WithValue(ctx, rulePriorityKey{}, priority)
+}
+
+// RulePriority returns the priority set by WithRulePriority. ok is false if
+// there is no rule priority in ctx.
+func RulePriority(ctx context.Context) (priority int64, ok bool) {
+	priority, ok = ctx.Value(rulePriorityKey{}).(int64)
+	return priority, ok
+}
+
+type updateMaskKey struct{}
+
+// WithUpdateMask returns a context that carries the updateMask for the
+// generated Patch methods that take one (e.g. TcpRoutes, SecurityPolicies).
+// mask is a comma-separated list of the JSON names of the fields to update;
+// the other fields of the resource are left unchanged.
+func WithUpdateMask(ctx context.Context, mask string) context.Context {
+	return context.WithValue(ctx, updateMaskKey{}, mask)
+}
+
+// UpdateMask returns the mask set by WithUpdateMask. ok is false if there is
+// no update mask in ctx.
+func UpdateMask(ctx context.Context) (mask string, ok bool) {
+	mask, ok = ctx.Value(updateMaskKey{}).(string)
+	return mask, ok
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// deadlineCall is a call to a service method with the context to use.
+type deadlineCall struct {
+	name string
+	f    func(ctx context.Context, c Cloud) error
+}
+
+// deadlineCalls are the calls checked for context propagation.
+func deadlineCalls() []deadlineCall {
+	bsKey := meta.GlobalKey("bs")
+	hcKey := meta.GlobalKey("hc")
+	negKey := meta.ZonalKey("neg", "us-central1-b")
+
+	return []deadlineCall{
+		{"BackendServices.Insert", func(ctx context.Context, c Cloud) error {
+			return c.BackendServices().Insert(ctx, bsKey, &ga.BackendService{Name: "bs"})
+		}},
+		{"BackendServices.Get", func(ctx context.Context, c Cloud) error {
+			_, err := c.BackendServices().Get(ctx, bsKey)
+			return err
+		}},
+		{"BackendServices.Delete", func(ctx context.Context, c Cloud) error {
+			return c.BackendServices().Delete(ctx, bsKey)
+		}},
+		{"HealthChecks.Insert", func(ctx context.Context, c Cloud) error {
+			return c.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{Name: "hc"})
+		}},
+		{"HealthChecks.Get", func(ctx context.Context, c Cloud) error {
+			_, err := c.HealthChecks().Get(ctx, hcKey)
+			return err
+		}},
+		{"HealthChecks.Delete", func(ctx context.Context, c Cloud) error {
+			return c.HealthChecks().Delete(ctx, hcKey)
+		}},
+		{"NetworkEndpointGroups.Insert", func(ctx context.Context, c Cloud) error {
+			return c.NetworkEndpointGroups().Insert(ctx, negKey, &ga.NetworkEndpointGroup{Name: "neg"})
+		}},
+		{"NetworkEndpointGroups.Get", func(ctx context.Context, c Cloud) error {
+			_, err := c.NetworkEndpointGroups().Get(ctx, negKey)
+			return err
+		}},
+		{"NetworkEndpointGroups.Delete", func(ctx context.Context, c Cloud) error {
+			return c.NetworkEndpointGroups().Delete(ctx, negKey)
+		}},
+	}
+}
+
+// opSelfLink returns the SelfLink of an Operation in the same scope (global
+// or zone) as the resource in the request path.
+func opSelfLink(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		switch {
+		case p == "global":
+			return "https://www.googleapis.com" + strings.Join(parts[:i+1], "/") + "/operations/op"
+		case p == "zones" && i+1 < len(parts):
+			return "https://www.googleapis.com" + strings.Join(parts[:i+2], "/") + "/operations/op"
+		}
+	}
+	return ""
+}
+
+// TestGCEContextDeadline checks that every generated Insert/Get/Delete
+// passes ctx to the API client unchanged, including the calls made while
+// waiting for the Operation.
+func TestGCEContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	for _, call := range deadlineCalls() {
+		call := call
+		t.Run(call.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+			wantDeadline, _ := ctx.Deadline()
+
+			var requests int
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				requests++
+				if d, ok := r.Context().Deadline(); !ok || !d.Equal(wantDeadline) {
+					t.Errorf("%s %s: request deadline = %v, %t; want %v", r.Method, r.URL.Path, d, ok, wantDeadline)
+				}
+				var body string
+				if r.Method == http.MethodGet {
+					body = `{"name": "x"}`
+				} else {
+					// Insert, Delete and Operation wait all return a
+					// completed Operation.
+					body = fmt.Sprintf(`{"name": "op", "status": "DONE", "selfLink": %q}`, opSelfLink(r.URL.Path))
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			})
+			svc, err := NewService(context.Background(), &http.Client{Transport: transport}, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+			if err != nil {
+				t.Fatalf("NewService() = %v, want nil", err)
+			}
+			if err := call.f(ctx, NewGCE(svc)); err != nil {
+				t.Fatalf("%s() = %v, want nil", call.name, err)
+			}
+			if requests == 0 {
+				t.Errorf("%s() made no requests", call.name)
+			}
+		})
+	}
+}
+
+// TestMockContextDeadline checks that the MockGCE passes ctx to the hooks
+// unchanged.
+func TestMockContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	wantDeadline, _ := ctx.Deadline()
+
+	var hooks int
+	check := func(ctx context.Context) {
+		hooks++
+		if d, ok := ctx.Deadline(); !ok || !d.Equal(wantDeadline) {
+			t.Errorf("hook deadline = %v, %t; want %v", d, ok, wantDeadline)
+		}
+	}
+
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	mock.MockBackendServices.InsertHook = func(ctx context.Context, _ *meta.Key, _ *ga.BackendService, _ *MockBackendServices, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+	mock.MockBackendServices.GetHook = func(ctx context.Context, _ *meta.Key, _ *MockBackendServices, _ ...Option) (bool, *ga.BackendService, error) {
+		check(ctx)
+		return false, nil, nil
+	}
+	mock.MockBackendServices.DeleteHook = func(ctx context.Context, _ *meta.Key, _ *MockBackendServices, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+	mock.MockHealthChecks.InsertHook = func(ctx context.Context, _ *meta.Key, _ *ga.HealthCheck, _ *MockHealthChecks, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+	mock.MockHealthChecks.GetHook = func(ctx context.Context, _ *meta.Key, _ *MockHealthChecks, _ ...Option) (bool, *ga.HealthCheck, error) {
+		check(ctx)
+		return false, nil, nil
+	}
+	mock.MockHealthChecks.DeleteHook = func(ctx context.Context, _ *meta.Key, _ *MockHealthChecks, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+	mock.MockNetworkEndpointGroups.InsertHook = func(ctx context.Context, _ *meta.Key, _ *ga.NetworkEndpointGroup, _ *MockNetworkEndpointGroups, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+	mock.MockNetworkEndpointGroups.GetHook = func(ctx context.Context, _ *meta.Key, _ *MockNetworkEndpointGroups, _ ...Option) (bool, *ga.NetworkEndpointGroup, error) {
+		check(ctx)
+		return false, nil, nil
+	}
+	mock.MockNetworkEndpointGroups.DeleteHook = func(ctx context.Context, _ *meta.Key, _ *MockNetworkEndpointGroups, _ ...Option) (bool, error) {
+		check(ctx)
+		return false, nil
+	}
+
+	calls := deadlineCalls()
+	for _, call := range calls {
+		if err := call.f(ctx, mock); err != nil {
+			t.Errorf("%s() = %v, want nil", call.name, err)
+		}
+	}
+	if hooks != len(calls) {
+		t.Errorf("hooks called %d times, want %d", hooks, len(calls))
+	}
+}
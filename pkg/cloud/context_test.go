@@ -0,0 +1,108 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ga "google.golang.org/api/compute/v1"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+func TestGCERulePriority(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getRule"):
+			queries = append(queries, r.URL.Query().Get("priority"))
+			body = `{"priority": 10}`
+		case strings.HasSuffix(r.URL.Path, "/removeRule"):
+			queries = append(queries, r.URL.Query().Get("priority"))
+			body = `{"name": "op", "status": "DONE", "selfLink": "https://www.googleapis.com/compute/v1/projects/proj/global/operations/op"}`
+		default:
+			body = `{"name": "op", "status": "DONE"}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	svc, err := NewService(context.Background(), &http.Client{Transport: transport}, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v, want nil", err)
+	}
+	gce := NewGCE(svc)
+	key := meta.GlobalKey("sp")
+
+	if _, err := gce.SecurityPolicies().GetRule(WithRulePriority(context.Background(), 10), key); err != nil {
+		t.Fatalf("GetRule() = %v, want nil", err)
+	}
+	if err := gce.SecurityPolicies().RemoveRule(WithRulePriority(context.Background(), 20), key); err != nil {
+		t.Fatalf("RemoveRule() = %v, want nil", err)
+	}
+	// Without a priority, the parameter is not sent.
+	if _, err := gce.SecurityPolicies().GetRule(context.Background(), key); err != nil {
+		t.Fatalf("GetRule() = %v, want nil", err)
+	}
+	want := []string{"10", "20", ""}
+	if strings.Join(queries, ",") != strings.Join(want, ",") {
+		t.Errorf("priority query = %q, want %q", queries, want)
+	}
+}
+
+func TestGCEUpdateMask(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPatch {
+			queries = append(queries, r.URL.Query().Get("updateMask"))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"name": "op", "status": "DONE", "selfLink": "https://www.googleapis.com/compute/v1/projects/proj/global/operations/op"}`)),
+		}, nil
+	})
+	svc, err := NewService(context.Background(), &http.Client{Transport: transport}, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v, want nil", err)
+	}
+	gce := NewGCE(svc)
+	key := meta.GlobalKey("sp")
+
+	if err := gce.SecurityPolicies().Patch(WithUpdateMask(context.Background(), "description,labels"), key, &ga.SecurityPolicy{}); err != nil {
+		t.Fatalf("Patch() = %v, want nil", err)
+	}
+	// Without a mask, the parameter is not sent.
+	if err := gce.SecurityPolicies().Patch(context.Background(), key, &ga.SecurityPolicy{}); err != nil {
+		t.Fatalf("Patch() = %v, want nil", err)
+	}
+	want := []string{"description,labels", ""}
+	if strings.Join(queries, ";") != strings.Join(want, ";") {
+		t.Errorf("updateMask query = %q, want %q", queries, want)
+	}
+}
@@ -192,6 +192,7 @@ type Cloud interface {
 // NewGCE returns a GCE.
 func NewGCE(s *Service) *GCE {
 	g := &GCE{
+		s: s,
 	{{- range .All}}
 		{{.Field}}: &{{.GCPWrapType}}{s},
 	{{- end}}
@@ -204,6 +205,7 @@ var _ Cloud = (*GCE)(nil)
 
 // GCE is the golang adapter for the compute APIs.
 type GCE struct {
+	s *Service
 {{- range .All}}
 	{{.Field}} *{{.GCPWrapType}}
 {{- end}}
@@ -226,6 +228,7 @@ func NewMockGCE(projectRouter ProjectRouter) *MockGCE {
 	{{- range .All}}
 		{{.MockField}}: New{{.MockWrapType}}(projectRouter, mock{{.Service}}Objs),
 	{{- end}}
+		MockOperations: NewMockOperations(),
 	}
 	return mock
 }
@@ -238,6 +241,16 @@ type MockGCE struct {
 {{- range .All}}
 	{{.MockField}} *{{.MockWrapType}}
 {{- end}}
+
+	// BatchGetHook allows you to intercept BatchGet. Return (true, _, _) to
+	// prevent the normal execution flow of the mock.
+	BatchGetHook func(ctx context.Context, ver meta.Version, ids []*ResourceID, m *MockGCE) (bool, []BatchGetResult, error)
+
+	// MockOperations are the Operations for WaitForOperation.
+	MockOperations *MockOperations
+	// WaitForOperationHook allows you to intercept WaitForOperation. Return
+	// (true, _) to prevent the normal execution flow of the mock.
+	WaitForOperationHook func(ctx context.Context, selfLink string, m *MockGCE) (bool, error)
 }
 {{range .All}}
 // {{.WrapType}} returns the interface for the {{.Version}} {{.Service}}.
@@ -245,6 +258,69 @@ func (mock *MockGCE) {{.WrapType}}() {{.WrapType}} {
 	return mock.{{.MockField}}
 }
 {{end}}
+// SetGetNotFoundAfterInsert sets GetNotFoundAfterInsert for all of the mocks.
+// Get will return NotFound n times for an object after it is inserted. Note:
+// the count is tracked separately for each API version.
+func (mock *MockGCE) SetGetNotFoundAfterInsert(n int) {
+{{- range .All}}
+{{- if and .GenerateGet .GenerateInsert}}
+	mock.{{.MockField}}.GetNotFoundAfterInsert = n
+{{- end}}
+{{- end}}
+}
+
+// MockGCESnapshot is an opaque token for the objects stored in a MockGCE. See
+// MockGCE.Snapshot().
+type MockGCESnapshot struct {
+{{- range .Groups}}
+	mock{{.Service}}Objs map[meta.Key]*Mock{{.Service}}Obj
+{{- end}}
+	mockOperationsObjs map[string]*MockOperationsObj
+}
+
+// Snapshot returns a token for the objects currently stored in the mock.
+// Restore() rolls the objects back to the state in the token. Only the
+// objects are saved; errors, hooks and other settings of the mocks are not.
+func (mock *MockGCE) Snapshot() *MockGCESnapshot {
+	s := &MockGCESnapshot{}
+{{- range .Groups}}
+{{- with .ServiceInfo}}
+
+	mock.{{.MockField}}.Lock.Lock()
+	s.mock{{.Service}}Objs = map[meta.Key]*Mock{{.Service}}Obj{}
+	for k, obj := range mock.{{.MockField}}.Objects {
+		s.mock{{.Service}}Objs[k] = &Mock{{.Service}}Obj{Obj: deepCopyMockObj(obj.Obj)}
+	}
+	mock.{{.MockField}}.Lock.Unlock()
+{{- end}}
+{{- end}}
+
+	s.mockOperationsObjs = mock.MockOperations.snapshot()
+
+	return s
+}
+
+// Restore the objects in the mock to the state saved by Snapshot(). Objects
+// inserted after the Snapshot are removed. A snapshot can be restored more
+// than once.
+func (mock *MockGCE) Restore(s *MockGCESnapshot) {
+{{- range .Groups}}
+{{- with .ServiceInfo}}
+
+	mock.{{.MockField}}.Lock.Lock()
+	for k := range mock.{{.MockField}}.Objects {
+		delete(mock.{{.MockField}}.Objects, k)
+	}
+	for k, obj := range s.mock{{.Service}}Objs {
+		mock.{{.MockField}}.Objects[k] = &Mock{{.Service}}Obj{Obj: deepCopyMockObj(obj.Obj)}
+	}
+	mock.{{.MockField}}.Lock.Unlock()
+{{- end}}
+{{- end}}
+
+	mock.MockOperations.restore(s.mockOperationsObjs)
+}
+
 
 {{range .Groups}}
 // Mock{{.Service}}Obj is used to store the various object versions in the shared
@@ -410,6 +486,15 @@ type {{.MockWrapType}} struct {
 	{{- if .ListUsable}}
 	ListUsableError *error
 	{{- end}}
+	{{- if and .GenerateGet .GenerateInsert}}
+
+	// GetNotFoundAfterInsert is the number of Get calls that will return
+	// NotFound after an object is inserted. This simulates the eventual
+	// consistency of the GCE API.
+	GetNotFoundAfterInsert int
+	// notFoundGets is the remaining number of NotFound Gets by key.
+	notFoundGets map[meta.Key]int
+	{{- end}}
 
 	// xxxHook allow you to intercept the standard processing of the mock in
 	// order to add your own logic. Return (true, _, _) to prevent the normal
@@ -473,7 +558,14 @@ func (m *{{.MockWrapType}}) Get(ctx context.Context, key *meta.Key, options... O
 		klog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = nil, %v", ctx, key, err)
 		return nil, err
 	}
+	{{- if .GenerateInsert}}
+	if m.notFoundGets[*key] > 0 {
+		// The inserted object is not visible yet.
+		m.notFoundGets[*key]--
+	} else if obj, ok := m.Objects[*key]; ok {
+	{{- else}}
 	if obj, ok := m.Objects[*key]; ok {
+	{{- end}}
 		typedObj := obj.To{{.VersionTitle}}()
 		klog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = %+v, nil", ctx, key, typedObj)
 		return typedObj, nil
@@ -606,6 +698,14 @@ func (m *{{.MockWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.F
 	obj.SelfLink = SelfLinkWithGroup("{{.APIGroup}}", meta.Version{{.VersionTitle}}, projectID, "{{.Resource}}", key)
 
 	m.Objects[*key] = &Mock{{.Service}}Obj{obj}
+	{{- if .GenerateGet}}
+	if m.GetNotFoundAfterInsert > 0 {
+		if m.notFoundGets == nil {
+			m.notFoundGets = map[meta.Key]int{}
+		}
+		m.notFoundGets[*key] = m.GetNotFoundAfterInsert
+	}
+	{{- end}}
 	klog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %+v) = nil", ctx, key, obj)
 	return nil
 }
@@ -641,6 +741,9 @@ func (m *{{.MockWrapType}}) Delete(ctx context.Context, key *meta.Key, options..
 	}
 
 	delete(m.Objects, *key)
+	{{- if and .GenerateGet .GenerateInsert}}
+	delete(m.notFoundGets, *key)
+	{{- end}}
 	klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = nil", ctx, key)
 	return nil
 }
@@ -942,6 +1045,9 @@ func (g *{{.GCPWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQ
 	{{- if .KeyIsZonal}}
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Insert(projectID, key.Zone, obj)
 	{{- end}}
+	if requestID, ok := RequestID(ctx); ok {
+		call.RequestId(requestID)
+	}
 {{- end}}
 	call.Context(ctx)
 
@@ -999,6 +1105,9 @@ func (g *{{.GCPWrapType}}) Delete(ctx context.Context, key *meta.Key, options...
 	{{- if .KeyIsZonal}}
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Delete(projectID, key.Zone, key.Name)
 	{{- end}}
+	if requestID, ok := RequestID(ctx); ok {
+		call.RequestId(requestID)
+	}
 {{- end}}
 
 	call.Context(ctx)
@@ -1182,7 +1291,22 @@ func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.{{.Name}}(projectID, key.Zone, key.Name {{.CallArgs}})
 	{{- end}}
 {{- end}}
+{{- if .HasPriority}}
+	if priority, ok := RulePriority(ctx); ok {
+		call.Priority(priority)
+	}
+{{- end}}
+{{- if .HasUpdateMask}}
+	if mask, ok := UpdateMask(ctx); ok {
+		call.UpdateMask(mask)
+	}
+{{- end}}
 {{- if .IsOperation}}
+{{- if .HasRequestID}}
+	if requestID, ok := RequestID(ctx); ok {
+		call.RequestId(requestID)
+	}
+{{- end}}
 	call.Context(ctx)
 	handleHeaderOptions(&opts, call.Header())
 	op, err := call.Do()
@@ -1286,6 +1410,160 @@ func New{{.Service}}ResourceID(project, zone, name string) *ResourceID {
 	}
 }
 
+// genBatchGet generates the dispatch by ResourceID used by BatchGet.
+func genBatchGet(wr io.Writer) {
+	const text = `
+// getByResourceID calls Get on the service in c for the resource id at version
+// ver.
+func getByResourceID(ctx context.Context, c Cloud, ver meta.Version, id *ResourceID) (any, error) {
+	if id.Key == nil {
+		return nil, fmt.Errorf("getByResourceID: resource %v has no key", id)
+	}
+	switch {
+{{- range .}}
+{{- if and .GenerateGet (not .KeyIsProject)}}
+	case {{template "match" .}}:
+		obj, err := c.{{.WrapType}}().Get(ctx, id.Key, ForceProjectID(id.ProjectID))
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+{{- end}}
+{{- end}}
+	}
+	return nil, fmt.Errorf("getByResourceID: unsupported resource %v (version %q)", id, ver)
+}
+
+// newObjectByResourceID returns an empty API object for the resource id at
+// version ver.
+func newObjectByResourceID(ver meta.Version, id *ResourceID) (any, error) {
+	if id.Key == nil {
+		return nil, fmt.Errorf("newObjectByResourceID: resource %v has no key", id)
+	}
+	switch {
+{{- range .}}
+{{- if and .GenerateGet (not .KeyIsProject)}}
+	case {{template "match" .}}:
+		return &{{.FQObjectType}}{}, nil
+{{- end}}
+{{- end}}
+	}
+	return nil, fmt.Errorf("newObjectByResourceID: unsupported resource %v (version %q)", id, ver)
+}
+`
+	const match = `{{define "match"}}ver == meta.Version("{{.Version}}") && id.APIGroup == meta.APIGroup("{{.APIGroup}}") && id.Resource == "{{.Resource}}" && id.Key.Type() == {{if .KeyIsGlobal}}meta.Global{{else if .KeyIsRegional}}meta.Regional{{else}}meta.Zonal{{end}}{{end}}`
+
+	tmpl := template.Must(template.New("batchGet").Parse(text))
+	template.Must(tmpl.Parse(match))
+	if err := tmpl.Execute(wr, meta.AllServices); err != nil {
+		panic(err)
+	}
+}
+
+// genRecording generates the RecordingCloud wrappers.
+func genRecording(wr io.Writer) {
+	const text = `
+// RecordingCloud implements Cloud.
+var _ Cloud = (*RecordingCloud)(nil)
+{{range .}}
+// {{.WrapType}} returns the interface for the {{.Version}} {{.Service}}.
+func (r *RecordingCloud) {{.WrapType}}() {{.WrapType}} {
+	return &recording{{.WrapType}}{ {{- .WrapType}}: r.c.{{.WrapType}}(), r: r}
+}
+
+// recording{{.WrapType}} records the calls made to {{.WrapType}}. Custom
+// operations are passed through without being recorded.
+type recording{{.WrapType}} struct {
+	{{.WrapType}}
+	r *RecordingCloud
+}
+{{- if .GenerateGet}}
+
+// Get implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) Get(ctx context.Context, key *meta.Key, options ...Option) (*{{.FQObjectType}}, error) {
+	obj, err := x.{{.WrapType}}.Get(ctx, key, options...)
+	x.r.record("{{.Service}}", "Get", meta.Version("{{.Version}}"), key, err)
+	return obj, err
+}
+{{- end}}
+{{- if .GenerateList}}
+
+// List implements {{.WrapType}}.
+{{- if .KeyIsGlobal}}
+func (x *recording{{.WrapType}}) List(ctx context.Context, fl *filter.F, options ...Option) ([]*{{.FQObjectType}}, error) {
+	objs, err := x.{{.WrapType}}.List(ctx, fl, options...)
+{{- end}}
+{{- if .KeyIsRegional}}
+func (x *recording{{.WrapType}}) List(ctx context.Context, region string, fl *filter.F, options ...Option) ([]*{{.FQObjectType}}, error) {
+	objs, err := x.{{.WrapType}}.List(ctx, region, fl, options...)
+{{- end}}
+{{- if .KeyIsZonal}}
+func (x *recording{{.WrapType}}) List(ctx context.Context, zone string, fl *filter.F, options ...Option) ([]*{{.FQObjectType}}, error) {
+	objs, err := x.{{.WrapType}}.List(ctx, zone, fl, options...)
+{{- end}}
+	x.r.record("{{.Service}}", "List", meta.Version("{{.Version}}"), nil, err)
+	return objs, err
+}
+{{- end}}
+{{- if .GenerateInsert}}
+
+// Insert implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQObjectType}}, options ...Option) error {
+	err := x.{{.WrapType}}.Insert(ctx, key, obj, options...)
+	x.r.record("{{.Service}}", "Insert", meta.Version("{{.Version}}"), key, err)
+	return err
+}
+{{- end}}
+{{- if .GenerateDelete}}
+
+// Delete implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) Delete(ctx context.Context, key *meta.Key, options ...Option) error {
+	err := x.{{.WrapType}}.Delete(ctx, key, options...)
+	x.r.record("{{.Service}}", "Delete", meta.Version("{{.Version}}"), key, err)
+	return err
+}
+{{- end}}
+{{- if .AggregatedList}}
+
+// AggregatedList implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) AggregatedList(ctx context.Context, fl *filter.F, options ...Option) (map[string][]*{{.FQObjectType}}, error) {
+	objs, err := x.{{.WrapType}}.AggregatedList(ctx, fl, options...)
+	x.r.record("{{.Service}}", "AggregatedList", meta.Version("{{.Version}}"), nil, err)
+	return objs, err
+}
+{{- end}}
+{{- if .ListUsable}}
+
+// ListUsable implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) ListUsable(ctx context.Context, fl *filter.F, options ...Option) ([]*{{.FQListUsableObjectType}}, error) {
+	objs, err := x.{{.WrapType}}.ListUsable(ctx, fl, options...)
+	x.r.record("{{.Service}}", "ListUsable", meta.Version("{{.Version}}"), nil, err)
+	return objs, err
+}
+{{- end}}
+{{- range .Methods}}
+
+// {{.Name}} implements {{.WrapType}}.
+func (x *recording{{.WrapType}}) {{.FcnArgs}} {
+{{- if .IsOperation}}
+	err := x.{{.WrapType}}.{{.Name}}(ctx, key{{.CallArgs}}, options...)
+	x.r.record("{{.Service}}", "{{.Name}}", meta.Version("{{.Version}}"), key, err)
+	return err
+{{- else}}
+	ret, err := x.{{.WrapType}}.{{.Name}}(ctx, key{{.CallArgs}}{{if .IsPaged}}, fl{{end}}, options...)
+	x.r.record("{{.Service}}", "{{.Name}}", meta.Version("{{.Version}}"), key, err)
+	return ret, err
+{{- end}}
+}
+{{- end}}
+{{end}}
+`
+	tmpl := template.Must(template.New("recording").Parse(text))
+	if err := tmpl.Execute(wr, meta.AllServices); err != nil {
+		panic(err)
+	}
+}
+
 func genUnitTestHeader(wr io.Writer) {
 	const text = `/*
 Copyright {{.Year}} The Kubernetes Authors.
@@ -1627,6 +1905,10 @@ func TestResourceIDConversion(t *testing.T) {
 func main() {
 	flag.Parse()
 
+	if err := meta.CheckServiceTypes(meta.AllServices); err != nil {
+		log.Fatalf("Invalid ServiceInfo: %v", err)
+	}
+
 	out := &bytes.Buffer{}
 
 	switch flags.mode {
@@ -1635,6 +1917,8 @@ func main() {
 		genStubs(out)
 		genTypes(out)
 		genResourceIDs(out)
+		genBatchGet(out)
+		genRecording(out)
 	case "test":
 		genUnitTestHeader(out)
 		genUnitTestServices(out)
@@ -3739,6 +3739,8 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 	var key *meta.Key
 	keyBeta := meta.GlobalKey("key-beta")
 	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
 	// Ignore unused variables.
 	_, _, _ = ctx, mock, key
 
@@ -3746,6 +3748,9 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 	if _, err := mock.BetaSecurityPolicies().Get(ctx, key); err == nil {
 		t.Errorf("BetaSecurityPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
 	}
+	if _, err := mock.SecurityPolicies().Get(ctx, key); err == nil {
+		t.Errorf("SecurityPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
 
 	// Insert.
 	{
@@ -3754,16 +3759,27 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 			t.Errorf("BetaSecurityPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
 		}
 	}
+	{
+		obj := &computega.SecurityPolicy{}
+		if err := mock.SecurityPolicies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("SecurityPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
 
 	// Get across versions.
 	if obj, err := mock.BetaSecurityPolicies().Get(ctx, key); err != nil {
 		t.Errorf("BetaSecurityPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
+	if obj, err := mock.SecurityPolicies().Get(ctx, key); err != nil {
+		t.Errorf("SecurityPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
 
 	// List.
 	mock.MockBetaSecurityPolicies.Objects[*keyBeta] = mock.MockBetaSecurityPolicies.Obj(&computebeta.SecurityPolicy{Name: keyBeta.Name})
+	mock.MockSecurityPolicies.Objects[*keyGA] = mock.MockSecurityPolicies.Obj(&computega.SecurityPolicy{Name: keyGA.Name})
 	want := map[string]bool{
 		"key-beta": true,
+		"key-ga":   true,
 	}
 	_ = want // ignore unused variables.
 	{
@@ -3780,16 +3796,36 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 			}
 		}
 	}
+	{
+		objs, err := mock.SecurityPolicies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("SecurityPolicies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("SecurityPolicies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
 
 	// Delete across versions.
 	if err := mock.BetaSecurityPolicies().Delete(ctx, keyBeta); err != nil {
 		t.Errorf("BetaSecurityPolicies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
 	}
+	if err := mock.SecurityPolicies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("SecurityPolicies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
 
 	// Delete not found.
 	if err := mock.BetaSecurityPolicies().Delete(ctx, keyBeta); err == nil {
 		t.Errorf("BetaSecurityPolicies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
 	}
+	if err := mock.SecurityPolicies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("SecurityPolicies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
 }
 
 func TestServiceAttachmentsGroup(t *testing.T) {
@@ -4246,6 +4282,141 @@ func TestSubnetworksGroup(t *testing.T) {
 	}
 }
 
+func TestTargetGrpcProxiesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyAlpha := meta.GlobalKey("key-alpha")
+	key = keyAlpha
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.AlphaTargetGrpcProxies().Get(ctx, key); err == nil {
+		t.Errorf("AlphaTargetGrpcProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaTargetGrpcProxies().Get(ctx, key); err == nil {
+		t.Errorf("BetaTargetGrpcProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.TargetGrpcProxies().Get(ctx, key); err == nil {
+		t.Errorf("TargetGrpcProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &computealpha.TargetGrpcProxy{}
+		if err := mock.AlphaTargetGrpcProxies().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaTargetGrpcProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
+	{
+		obj := &computebeta.TargetGrpcProxy{}
+		if err := mock.BetaTargetGrpcProxies().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaTargetGrpcProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &computega.TargetGrpcProxy{}
+		if err := mock.TargetGrpcProxies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("TargetGrpcProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaTargetGrpcProxies().Get(ctx, key); err != nil {
+		t.Errorf("AlphaTargetGrpcProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaTargetGrpcProxies().Get(ctx, key); err != nil {
+		t.Errorf("BetaTargetGrpcProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.TargetGrpcProxies().Get(ctx, key); err != nil {
+		t.Errorf("TargetGrpcProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockAlphaTargetGrpcProxies.Objects[*keyAlpha] = mock.MockAlphaTargetGrpcProxies.Obj(&computealpha.TargetGrpcProxy{Name: keyAlpha.Name})
+	mock.MockBetaTargetGrpcProxies.Objects[*keyBeta] = mock.MockBetaTargetGrpcProxies.Obj(&computebeta.TargetGrpcProxy{Name: keyBeta.Name})
+	mock.MockTargetGrpcProxies.Objects[*keyGA] = mock.MockTargetGrpcProxies.Obj(&computega.TargetGrpcProxy{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaTargetGrpcProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaTargetGrpcProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaTargetGrpcProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaTargetGrpcProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaTargetGrpcProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaTargetGrpcProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.TargetGrpcProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("TargetGrpcProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TargetGrpcProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.AlphaTargetGrpcProxies().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaTargetGrpcProxies().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.BetaTargetGrpcProxies().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaTargetGrpcProxies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.TargetGrpcProxies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("TargetGrpcProxies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.AlphaTargetGrpcProxies().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaTargetGrpcProxies().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaTargetGrpcProxies().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaTargetGrpcProxies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.TargetGrpcProxies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("TargetGrpcProxies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestTargetHttpProxiesGroup(t *testing.T) {
 	t.Parallel()
 
@@ -5040,6 +5211,7 @@ func TestResourceIDConversion(t *testing.T) {
 		NewSslCertificatesResourceID("some-project", "my-sslCertificates-resource"),
 		NewSslPoliciesResourceID("some-project", "my-sslPolicies-resource"),
 		NewSubnetworksResourceID("some-project", "us-central1", "my-subnetworks-resource"),
+		NewTargetGrpcProxiesResourceID("some-project", "my-targetGrpcProxies-resource"),
 		NewTargetHttpProxiesResourceID("some-project", "my-targetHttpProxies-resource"),
 		NewTargetHttpsProxiesResourceID("some-project", "my-targetHttpsProxies-resource"),
 		NewTargetPoolsResourceID("some-project", "us-central1", "my-targetPools-resource"),
@@ -0,0 +1,363 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/cerrors"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultListAllConcurrency = 4
+	defaultListAllRetries     = 3
+	defaultListAllBackoff     = time.Second
+)
+
+// ListAllOption is an option to ListAll.
+type ListAllOption func(*listAllConfig)
+
+type listAllConfig struct {
+	concurrency int
+	retries     int
+	backoff     time.Duration
+}
+
+// ListAllConcurrency sets the maximum number of resource types listed in
+// parallel. The default is 4.
+func ListAllConcurrency(n int) ListAllOption {
+	return func(c *listAllConfig) { c.concurrency = n }
+}
+
+// ListAllBackoff sets the number of retries and the initial backoff for a
+// resource type that fails with a rate limit or server error. The backoff is
+// doubled on each retry. The default is 3 retries starting at 1s.
+func ListAllBackoff(retries int, initial time.Duration) ListAllOption {
+	return func(c *listAllConfig) {
+		c.retries = retries
+		c.backoff = initial
+	}
+}
+
+// ListAll lists all of the GA resources in projectID for the resource types
+// supported by the rgraph nodes. Types with an AggregatedList method are
+// listed across all scopes in a single call. Regional and zonal types without
+// AggregatedList are listed in each of the regions and zones returned by
+// Regions().List and Zones().List. The result is keyed by the ResourceID of
+// the object.
+//
+// Each resource type is listed in a separate goroutine (bounded by
+// ListAllConcurrency) and retried with exponential backoff on rate limit and
+// server errors. An error is returned if any resource type could not be
+// listed.
+func ListAll(ctx context.Context, c Cloud, projectID string, opts ...ListAllOption) (map[ResourceMapKey]any, error) {
+	config := listAllConfig{
+		concurrency: defaultListAllConcurrency,
+		retries:     defaultListAllRetries,
+		backoff:     defaultListAllBackoff,
+	}
+	for _, o := range opts {
+		o(&config)
+	}
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+
+	var sc listScopes
+	err := withBackoff(ctx, &config, "scopes", func() error {
+		var err error
+		sc, err = listAllScopes(ctx, c, projectID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListAll: %w", err)
+	}
+
+	var (
+		lock sync.Mutex
+		ret  = map[ResourceMapKey]any{}
+		errs []error
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, config.concurrency)
+	)
+	for _, l := range allListers {
+		l := l
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var objs []listedObject
+			err := withBackoff(ctx, &config, l.resource, func() error {
+				var err error
+				objs, err = l.list(ctx, c, projectID, &sc)
+				return err
+			})
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("ListAll %s: %w", l.resource, err))
+				return
+			}
+			for _, o := range objs {
+				ret[o.id.MapKey()] = o.obj
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return ret, nil
+}
+
+// withBackoff calls f until it succeeds, returns an error that is not
+// retriable or the retries are exhausted.
+func withBackoff(ctx context.Context, config *listAllConfig, name string, f func() error) error {
+	backoff := config.backoff
+	for i := 0; ; i++ {
+		err := f()
+		if err == nil || i >= config.retries || !isListRetriable(err) {
+			return err
+		}
+		klog.V(2).Infof("ListAll(%s): retry %d in %v: %v", name, i+1, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isListRetriable is true for rate limit and server errors.
+func isListRetriable(err error) bool {
+	if cerrors.IsGoogleAPIQuotaExceeded(err) {
+		return true
+	}
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code >= http.StatusInternalServerError
+}
+
+type listedObject struct {
+	id  *ResourceID
+	obj any
+}
+
+// listScopes are the regions and zones in the project.
+type listScopes struct {
+	regions []string
+	zones   []string
+}
+
+// listAllScopes returns the regions and zones in projectID.
+func listAllScopes(ctx context.Context, c Cloud, projectID string) (listScopes, error) {
+	var ret listScopes
+	regions, err := c.Regions().List(ctx, filter.None, ForceProjectID(projectID))
+	if err != nil {
+		return ret, fmt.Errorf("Regions: %w", err)
+	}
+	for _, r := range regions {
+		ret.regions = append(ret.regions, r.Name)
+	}
+	zones, err := c.Zones().List(ctx, filter.None, ForceProjectID(projectID))
+	if err != nil {
+		return ret, fmt.Errorf("Zones: %w", err)
+	}
+	for _, z := range zones {
+		ret.zones = append(ret.zones, z.Name)
+	}
+	return ret, nil
+}
+
+type lister struct {
+	resource string
+	list     func(ctx context.Context, c Cloud, projectID string, sc *listScopes) ([]listedObject, error)
+}
+
+// allListers are the resource types listed by ListAll.
+var allListers = []lister{
+	aggregatedLister(meta.APIGroupCompute, "addresses", Cloud.Addresses, Addresses.AggregatedList),
+	globalLister(meta.APIGroupCompute, "addresses", Cloud.GlobalAddresses, GlobalAddresses.List),
+	aggregatedLister(meta.APIGroupCompute, "backendServices", Cloud.BackendServices, BackendServices.AggregatedList),
+	globalLister(meta.APIGroupCompute, "forwardingRules", Cloud.GlobalForwardingRules, GlobalForwardingRules.List),
+	regionalLister(meta.APIGroupCompute, "forwardingRules", Cloud.ForwardingRules, ForwardingRules.List),
+	globalLister(meta.APIGroupCompute, "healthChecks", Cloud.HealthChecks, HealthChecks.List),
+	regionalLister(meta.APIGroupCompute, "healthChecks", Cloud.RegionHealthChecks, RegionHealthChecks.List),
+	globalLister(meta.APIGroupCompute, "httpHealthChecks", Cloud.HttpHealthChecks, HttpHealthChecks.List),
+	zonalLister(meta.APIGroupCompute, "instanceGroupManagers", Cloud.InstanceGroupManagers, InstanceGroupManagers.List),
+	globalLister(meta.APIGroupCompute, "instanceTemplates", Cloud.InstanceTemplates, InstanceTemplates.List),
+	zonalLister(meta.APIGroupCompute, "instances", Cloud.Instances, Instances.List),
+	aggregatedLister(meta.APIGroupCompute, "networkEndpointGroups", Cloud.NetworkEndpointGroups, NetworkEndpointGroups.AggregatedList),
+	globalLister(meta.APIGroupCompute, "networks", Cloud.Networks, Networks.List),
+	globalLister(meta.APIGroupCompute, "securityPolicies", Cloud.SecurityPolicies, SecurityPolicies.List),
+	globalLister(meta.APIGroupCompute, "sslCertificates", Cloud.SslCertificates, SslCertificates.List),
+	regionalLister(meta.APIGroupCompute, "sslCertificates", Cloud.RegionSslCertificates, RegionSslCertificates.List),
+	globalLister(meta.APIGroupCompute, "targetGrpcProxies", Cloud.TargetGrpcProxies, TargetGrpcProxies.List),
+	globalLister(meta.APIGroupCompute, "targetHttpProxies", Cloud.TargetHttpProxies, TargetHttpProxies.List),
+	regionalLister(meta.APIGroupCompute, "targetHttpProxies", Cloud.RegionTargetHttpProxies, RegionTargetHttpProxies.List),
+	globalLister(meta.APIGroupCompute, "targetHttpsProxies", Cloud.TargetHttpsProxies, TargetHttpsProxies.List),
+	regionalLister(meta.APIGroupCompute, "targetHttpsProxies", Cloud.RegionTargetHttpsProxies, RegionTargetHttpsProxies.List),
+	regionalLister(meta.APIGroupCompute, "targetPools", Cloud.TargetPools, TargetPools.List),
+	globalLister(meta.APIGroupCompute, "urlMaps", Cloud.UrlMaps, UrlMaps.List),
+	regionalLister(meta.APIGroupCompute, "urlMaps", Cloud.RegionUrlMaps, RegionUrlMaps.List),
+	globalLister(meta.APIGroupNetworkServices, "tcpRoutes", Cloud.TcpRoutes, TcpRoutes.List),
+}
+
+// aggregatedLister lists the resources across all scopes with svc.AggregatedList.
+func aggregatedLister[S any, T any](
+	apiGroup meta.APIGroup,
+	resource string,
+	svc func(Cloud) S,
+	list func(S, context.Context, *filter.F, ...Option) (map[string][]*T, error),
+) lister {
+	return lister{
+		resource: resource,
+		list: func(ctx context.Context, c Cloud, projectID string, _ *listScopes) ([]listedObject, error) {
+			agg, err := list(svc(c), ctx, filter.None, ForceProjectID(projectID))
+			if err != nil {
+				return nil, err
+			}
+			var ret []listedObject
+			for scope, objs := range agg {
+				for _, obj := range objs {
+					key, err := keyForAggregatedScope(scope, objectName(obj))
+					if err != nil {
+						return nil, err
+					}
+					ret = append(ret, listedObject{
+						id:  &ResourceID{ProjectID: projectID, APIGroup: apiGroup, Resource: resource, Key: key},
+						obj: obj,
+					})
+				}
+			}
+			return ret, nil
+		},
+	}
+}
+
+// globalLister lists the resources in the global scope with svc.List.
+func globalLister[S any, T any](
+	apiGroup meta.APIGroup,
+	resource string,
+	svc func(Cloud) S,
+	list func(S, context.Context, *filter.F, ...Option) ([]*T, error),
+) lister {
+	return lister{
+		resource: resource,
+		list: func(ctx context.Context, c Cloud, projectID string, _ *listScopes) ([]listedObject, error) {
+			objs, err := list(svc(c), ctx, filter.None, ForceProjectID(projectID))
+			if err != nil {
+				return nil, err
+			}
+			var ret []listedObject
+			for _, obj := range objs {
+				ret = append(ret, listedObject{
+					id:  &ResourceID{ProjectID: projectID, APIGroup: apiGroup, Resource: resource, Key: meta.GlobalKey(objectName(obj))},
+					obj: obj,
+				})
+			}
+			return ret, nil
+		},
+	}
+}
+
+// regionalLister lists the resources in each region with svc.List.
+func regionalLister[S any, T any](
+	apiGroup meta.APIGroup,
+	resource string,
+	svc func(Cloud) S,
+	list func(S, context.Context, string, *filter.F, ...Option) ([]*T, error),
+) lister {
+	return scopedLister(apiGroup, resource, svc, list, func(sc *listScopes) []string { return sc.regions }, meta.RegionalKey)
+}
+
+// zonalLister lists the resources in each zone with svc.List.
+func zonalLister[S any, T any](
+	apiGroup meta.APIGroup,
+	resource string,
+	svc func(Cloud) S,
+	list func(S, context.Context, string, *filter.F, ...Option) ([]*T, error),
+) lister {
+	return scopedLister(apiGroup, resource, svc, list, func(sc *listScopes) []string { return sc.zones }, meta.ZonalKey)
+}
+
+// scopedLister lists the resources with svc.List in each of the scopes
+// (regions or zones) returned by scopes. keyFunc returns the key for the
+// resource name in the scope.
+func scopedLister[S any, T any](
+	apiGroup meta.APIGroup,
+	resource string,
+	svc func(Cloud) S,
+	list func(S, context.Context, string, *filter.F, ...Option) ([]*T, error),
+	scopes func(*listScopes) []string,
+	keyFunc func(name, scope string) *meta.Key,
+) lister {
+	return lister{
+		resource: resource,
+		list: func(ctx context.Context, c Cloud, projectID string, sc *listScopes) ([]listedObject, error) {
+			var ret []listedObject
+			for _, scope := range scopes(sc) {
+				objs, err := list(svc(c), ctx, scope, filter.None, ForceProjectID(projectID))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", scope, err)
+				}
+				for _, obj := range objs {
+					ret = append(ret, listedObject{
+						id:  &ResourceID{ProjectID: projectID, APIGroup: apiGroup, Resource: resource, Key: keyFunc(objectName(obj), scope)},
+						obj: obj,
+					})
+				}
+			}
+			return ret, nil
+		},
+	}
+}
+
+// keyForAggregatedScope returns the key for the named resource in the
+// AggregatedList scope (e.g. "regions/us-central1"). This is the inverse of
+// aggregatedListKey().
+func keyForAggregatedScope(scope, name string) (*meta.Key, error) {
+	switch {
+	case scope == "global":
+		return meta.GlobalKey(name), nil
+	case strings.HasPrefix(scope, "regions/"):
+		return meta.RegionalKey(name, strings.TrimPrefix(scope, "regions/")), nil
+	case strings.HasPrefix(scope, "zones/"):
+		return meta.ZonalKey(name, strings.TrimPrefix(scope, "zones/")), nil
+	}
+	return nil, fmt.Errorf("invalid AggregatedList scope %q", scope)
+}
+
+// objectName returns the Name field of the API object.
+func objectName(obj any) string {
+	return reflect.ValueOf(obj).Elem().FieldByName("Name").String()
+}
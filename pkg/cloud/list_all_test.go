@@ -0,0 +1,137 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	const projectID = "proj-1"
+
+	for _, tc := range []struct {
+		name string
+		// listErrs are returned by HealthChecks.List in order before
+		// listing normally.
+		listErrs []error
+		wantErr  bool
+	}{
+		{name: "no errors"},
+		{
+			name:     "retry on rate limit",
+			listErrs: []error{&googleapi.Error{Code: http.StatusTooManyRequests}},
+		},
+		{
+			name: "retries exhausted",
+			listErrs: []error{
+				&googleapi.Error{Code: http.StatusServiceUnavailable},
+				&googleapi.Error{Code: http.StatusServiceUnavailable},
+				&googleapi.Error{Code: http.StatusServiceUnavailable},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "non-retriable error",
+			listErrs: []error{&googleapi.Error{Code: http.StatusForbidden}},
+			wantErr:  true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			mock := NewMockGCE(&SingleProjectRouter{ID: projectID})
+
+			bsKeys := []*meta.Key{meta.GlobalKey("bs-1"), meta.RegionalKey("bs-2", "us-central1")}
+			for _, key := range bsKeys {
+				if err := mock.BackendServices().Insert(ctx, key, &ga.BackendService{Name: key.Name}); err != nil {
+					t.Fatalf("BackendServices().Insert(%v) = %v, want nil", key, err)
+				}
+			}
+			mock.MockRegions.Objects[*meta.GlobalKey("us-central1")] = &MockRegionsObj{Obj: &ga.Region{Name: "us-central1"}}
+			mock.MockZones.Objects[*meta.GlobalKey("us-central1-b")] = &MockZonesObj{Obj: &ga.Zone{Name: "us-central1-b"}}
+			frKey := meta.RegionalKey("fr-1", "us-central1")
+			if err := mock.ForwardingRules().Insert(ctx, frKey, &ga.ForwardingRule{Name: frKey.Name}); err != nil {
+				t.Fatalf("ForwardingRules().Insert(%v) = %v, want nil", frKey, err)
+			}
+			igmKey := meta.ZonalKey("igm-1", "us-central1-b")
+			if err := mock.InstanceGroupManagers().Insert(ctx, igmKey, &ga.InstanceGroupManager{Name: igmKey.Name}); err != nil {
+				t.Fatalf("InstanceGroupManagers().Insert(%v) = %v, want nil", igmKey, err)
+			}
+			hcKey := meta.GlobalKey("hc-1")
+			if err := mock.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{Name: hcKey.Name}); err != nil {
+				t.Fatalf("HealthChecks().Insert(%v) = %v, want nil", hcKey, err)
+			}
+
+			listErrs := tc.listErrs
+			mock.MockHealthChecks.ListHook = func(context.Context, *filter.F, *MockHealthChecks, ...Option) (bool, []*ga.HealthCheck, error) {
+				if len(listErrs) == 0 {
+					return false, nil, nil
+				}
+				err := listErrs[0]
+				listErrs = listErrs[1:]
+				return true, nil, err
+			}
+
+			got, err := ListAll(ctx, mock, projectID, ListAllConcurrency(2), ListAllBackoff(2, time.Millisecond))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ListAll() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			var gotIDs []string
+			for k, obj := range got {
+				if obj == nil {
+					t.Errorf("got[%v] = nil, want object", k)
+				}
+				gotIDs = append(gotIDs, k.ToID().String())
+			}
+			sort.Strings(gotIDs)
+			wantIDs := []string{
+				(&ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "backendServices", Key: bsKeys[0]}).String(),
+				(&ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "backendServices", Key: bsKeys[1]}).String(),
+				(&ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "forwardingRules", Key: frKey}).String(),
+				(&ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "healthChecks", Key: hcKey}).String(),
+				(&ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "instanceGroupManagers", Key: igmKey}).String(),
+			}
+			sort.Strings(wantIDs)
+			if diff := cmp.Diff(gotIDs, wantIDs); diff != "" {
+				t.Errorf("ListAll() IDs: diff -got,+want: %s", diff)
+			}
+
+			bsID := &ResourceID{ProjectID: projectID, APIGroup: meta.APIGroupCompute, Resource: "backendServices", Key: bsKeys[1]}
+			if bs, ok := got[bsID.MapKey()].(*ga.BackendService); !ok || bs.Name != "bs-2" {
+				t.Errorf("got[%v] = %+v, want BackendService bs-2", bsID, got[bsID.MapKey()])
+			}
+		})
+	}
+}
@@ -39,6 +39,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -48,6 +51,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -57,6 +63,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&beta.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -65,6 +74,9 @@ var ComputeServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&alpha.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -73,6 +85,9 @@ var ComputeServices = []*ServiceInfo{
 		version:     VersionBeta,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&beta.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -80,6 +95,9 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "addresses",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "BackendService",
@@ -95,7 +113,8 @@ var ComputeServices = []*ServiceInfo{
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "BackendService",
@@ -111,7 +130,8 @@ var ComputeServices = []*ServiceInfo{
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "BackendService",
@@ -127,7 +147,8 @@ var ComputeServices = []*ServiceInfo{
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "BackendService",
@@ -142,6 +163,7 @@ var ComputeServices = []*ServiceInfo{
 			"Update",
 			"SetSecurityPolicy",
 		},
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "BackendService",
@@ -156,6 +178,7 @@ var ComputeServices = []*ServiceInfo{
 			"Update",
 			"SetSecurityPolicy",
 		},
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "BackendService",
@@ -170,6 +193,7 @@ var ComputeServices = []*ServiceInfo{
 			"Update",
 			"SetSecurityPolicy",
 		},
+		rateLimitClass: backendServiceRateLimitClass,
 	},
 	{
 		Object:      "Disk",
@@ -563,7 +587,7 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "networks",
 		version:     VersionBeta,
 		keyType:     Global,
-		serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+		serviceType: reflect.TypeOf(&beta.NetworksService{}),
 	},
 	{
 		Object:      "Network",
@@ -571,7 +595,7 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "networks",
 		version:     VersionGA,
 		keyType:     Global,
-		serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+		serviceType: reflect.TypeOf(&ga.NetworksService{}),
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -585,7 +609,8 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -599,7 +624,8 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -613,7 +639,8 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
-		options: AggregatedList,
+		options:        AggregatedList,
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -627,6 +654,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -640,6 +668,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -653,6 +682,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -666,6 +696,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -679,6 +710,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -692,6 +724,7 @@ var ComputeServices = []*ServiceInfo{
 			"DetachNetworkEndpoints",
 			"ListNetworkEndpoints",
 		},
+		rateLimitClass: negRateLimitClass,
 	},
 	{
 		Object:   "Project",
@@ -755,11 +788,27 @@ var ComputeServices = []*ServiceInfo{
 		},
 	},
 	{
-		Object:      "Route",
-		Service:     "Routes",
-		Resource:    "routes",
+		Object:         "Route",
+		Service:        "Routes",
+		Resource:       "routes",
+		keyType:        Global,
+		serviceType:    reflect.TypeOf(&ga.RoutesService{}),
+		rateLimitClass: routeRateLimitClass,
+	},
+	{
+		Object:      "SecurityPolicy",
+		Service:     "SecurityPolicies",
+		Resource:    "securityPolicies",
+		version:     VersionGA,
 		keyType:     Global,
-		serviceType: reflect.TypeOf(&ga.RoutesService{}),
+		serviceType: reflect.TypeOf(&ga.SecurityPoliciesService{}),
+		additionalMethods: []string{
+			"AddRule",
+			"GetRule",
+			"Patch",
+			"PatchRule",
+			"RemoveRule",
+		},
 	},
 	{
 		Object:      "SecurityPolicy",
@@ -863,6 +912,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.SslPoliciesService{}),
 		options:     NoList, // List() naming convention is different in GCE API for this resource
+		additionalMethods: []string{
+			"Patch",
+		},
 	},
 	{
 		Object:      "SslPolicy",
@@ -871,6 +923,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.RegionSslPoliciesService{}),
 		options:     NoList, // List() naming convention is different in GCE API for this resource
+		additionalMethods: []string{
+			"Patch",
+		},
 	},
 	{
 		Object:      "Subnetwork",
@@ -908,6 +963,38 @@ var ComputeServices = []*ServiceInfo{
 			"Patch",
 		},
 	},
+	{
+		Object:      "TargetGrpcProxy",
+		Service:     "TargetGrpcProxies",
+		Resource:    "targetGrpcProxies",
+		version:     VersionAlpha,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&alpha.TargetGrpcProxiesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "TargetGrpcProxy",
+		Service:     "TargetGrpcProxies",
+		Resource:    "targetGrpcProxies",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.TargetGrpcProxiesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "TargetGrpcProxy",
+		Service:     "TargetGrpcProxies",
+		Resource:    "targetGrpcProxies",
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.TargetGrpcProxiesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
 	{
 		Object:      "TargetHttpProxy",
 		Service:     "TargetHttpProxies",
@@ -1060,7 +1147,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.TargetPoolsService{}),
 		additionalMethods: []string{
+			"AddHealthCheck",
 			"AddInstance",
+			"RemoveHealthCheck",
 			"RemoveInstance",
 		},
 	},
@@ -1132,6 +1221,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&alpha.UrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
@@ -1143,6 +1233,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&beta.UrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
@@ -1153,6 +1244,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.UrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
@@ -1164,6 +1256,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.RegionUrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
@@ -1175,6 +1268,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&beta.RegionUrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
@@ -1186,6 +1280,7 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.RegionUrlMapsService{}),
 		additionalMethods: []string{
+			"Patch",
 			"Update",
 		},
 	},
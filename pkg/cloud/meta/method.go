@@ -121,6 +121,12 @@ type Method struct {
 	// ItemType is the type of the individual elements returns from a
 	// Pages() call. This is only applicable for MethodPaged kind.
 	ItemType string
+	// hasRequestID is true if the xxxCall has a RequestId() method.
+	hasRequestID bool
+	// hasPriority is true if the xxxCall has a Priority() method.
+	hasPriority bool
+	// hasUpdateMask is true if the xxxCall has an UpdateMask() method.
+	hasUpdateMask bool
 }
 
 // IsOperation is true if the method is an Operation.
@@ -128,6 +134,24 @@ func (m *Method) IsOperation() bool {
 	return m.kind == MethodOperation
 }
 
+// HasRequestID is true if the call for the method takes a requestId (see
+// cloud.WithRequestID).
+func (m *Method) HasRequestID() bool {
+	return m.hasRequestID
+}
+
+// HasPriority is true if the call for the method takes the priority of a
+// rule (see cloud.WithRulePriority).
+func (m *Method) HasPriority() bool {
+	return m.hasPriority
+}
+
+// HasUpdateMask is true if the call for the method takes an updateMask (see
+// cloud.WithUpdateMask).
+func (m *Method) HasUpdateMask() bool {
+	return m.hasUpdateMask
+}
+
 // IsPaged is true if the method paged.
 func (m *Method) IsPaged() bool {
 	return m.kind == MethodPaged
@@ -207,6 +231,9 @@ func (m *Method) init() {
 			m.Service, m.Name(), returnTypeName))
 	}
 	_, hasPages := returnType.MethodByName("Pages")
+	_, m.hasRequestID = returnType.MethodByName("RequestId")
+	_, m.hasPriority = returnType.MethodByName("Priority")
+	_, m.hasUpdateMask = returnType.MethodByName("UpdateMask")
 	// Do() method must return (*T, error).
 	switch doMethod.Func.Type().NumOut() {
 	case 2:
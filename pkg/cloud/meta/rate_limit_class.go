@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import "strings"
+
+// RateLimitClass is the cost class of an API method. Rate limiters can use
+// the class to bucket calls instead of treating every method the same.
+type RateLimitClass string
+
+const (
+	// RateLimitClassRead are inexpensive read-only methods (e.g. Get, List).
+	RateLimitClassRead RateLimitClass = "Read"
+	// RateLimitClassHeavyRead are read-only methods that are expensive for
+	// the server (e.g. BackendServices.GetHealth).
+	RateLimitClassHeavyRead RateLimitClass = "HeavyRead"
+	// RateLimitClassMutate are methods that change the resource.
+	RateLimitClassMutate RateLimitClass = "Mutate"
+	// RateLimitClassHeavyMutate are methods that change the resource and
+	// are expensive for the server (e.g. NEG AttachNetworkEndpoints).
+	RateLimitClassHeavyMutate RateLimitClass = "HeavyMutate"
+)
+
+// defaultRateLimitClass returns the class for the method based on its name.
+// Methods that start with "Get" or "List" or are "AggregatedList" are reads;
+// all other methods are mutations.
+func defaultRateLimitClass(method string) RateLimitClass {
+	for _, prefix := range []string{"Get", "List", "AggregatedList"} {
+		if strings.HasPrefix(method, prefix) {
+			return RateLimitClassRead
+		}
+	}
+	return RateLimitClassMutate
+}
+
+var (
+	// backendServiceRateLimitClass: GetHealth probes all of the backends
+	// and changes to the BackendService are programmed into the load
+	// balancer.
+	backendServiceRateLimitClass = map[string]RateLimitClass{
+		"GetHealth": RateLimitClassHeavyRead,
+		"Patch":     RateLimitClassHeavyMutate,
+		"Update":    RateLimitClassHeavyMutate,
+	}
+	// negRateLimitClass: endpoint changes are programmed into every load
+	// balancer that uses the NEG. A NEG may contain a large number of
+	// endpoints.
+	negRateLimitClass = map[string]RateLimitClass{
+		"AttachNetworkEndpoints": RateLimitClassHeavyMutate,
+		"DetachNetworkEndpoints": RateLimitClassHeavyMutate,
+		"ListNetworkEndpoints":   RateLimitClassHeavyRead,
+	}
+	// routeRateLimitClass: route changes are programmed into the entire
+	// network.
+	routeRateLimitClass = map[string]RateLimitClass{
+		"Insert": RateLimitClassHeavyMutate,
+		"Delete": RateLimitClassHeavyMutate,
+	}
+)
@@ -45,6 +45,8 @@ type ServiceInfo struct {
 	additionalMethods   []string
 	options             int
 	aggregatedListField string
+	// rateLimitClass overrides the RateLimitClass for the named methods.
+	rateLimitClass map[string]RateLimitClass
 }
 
 // Version returns the version of the Service, defaulting to GA if APIVersion
@@ -283,6 +285,15 @@ func (i *ServiceInfo) ListUsable() bool {
 	return i.options&ListUsable != 0
 }
 
+// RateLimitClass returns the RateLimitClass of the method (e.g. "Get",
+// "AttachNetworkEndpoints").
+func (i *ServiceInfo) RateLimitClass(method string) RateLimitClass {
+	if c, ok := i.rateLimitClass[method]; ok {
+		return c
+	}
+	return defaultRateLimitClass(method)
+}
+
 // ServiceGroup is a grouping of the same service but at different API versions.
 type ServiceGroup struct {
 	Alpha *ServiceInfo
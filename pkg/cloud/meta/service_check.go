@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// apiPackages maps the Go package of an API client to the APIGroup and Version
+// it implements.
+var apiPackages = map[string]struct {
+	group   APIGroup
+	version Version
+}{
+	"google.golang.org/api/compute/v1":              {APIGroupCompute, VersionGA},
+	"google.golang.org/api/compute/v0.alpha":        {APIGroupCompute, VersionAlpha},
+	"google.golang.org/api/compute/v0.beta":         {APIGroupCompute, VersionBeta},
+	"google.golang.org/api/networkservices/v1":      {APIGroupNetworkServices, VersionGA},
+	"google.golang.org/api/networkservices/v1beta1": {APIGroupNetworkServices, VersionBeta},
+}
+
+// checkServiceType returns an error if the serviceType of the ServiceInfo is
+// not from the API package for its APIGroup and Version.
+func (i *ServiceInfo) checkServiceType() error {
+	if i.serviceType == nil {
+		return fmt.Errorf("%s (%s): serviceType is nil", i.Service, i.Object)
+	}
+	t := i.serviceType
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	pkg, ok := apiPackages[t.PkgPath()]
+	if !ok {
+		return fmt.Errorf("%s (%s): serviceType %v is from unknown package %q", i.Service, i.Object, t, t.PkgPath())
+	}
+	if pkg.group != i.APIGroup || pkg.version != i.Version() {
+		return fmt.Errorf("%s (%s): serviceType %v is %s/%s, but ServiceInfo is %s/%s",
+			i.Service, i.Object, t, pkg.group, pkg.version, i.APIGroup, i.Version())
+	}
+	return nil
+}
+
+// CheckServiceTypes verifies that the serviceType of each ServiceInfo
+// matches its declared APIGroup and Version. This catches copy-paste errors
+// such as a GA ServiceInfo bound to the alpha service.
+func CheckServiceTypes(services []*ServiceInfo) error {
+	var errs []error
+	for _, s := range services {
+		if err := s.checkServiceType(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
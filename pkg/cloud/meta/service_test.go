@@ -0,0 +1,140 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+	nsga "google.golang.org/api/networkservices/v1"
+)
+
+func TestCheckServiceTypes(t *testing.T) {
+	if err := CheckServiceTypes(AllServices); err != nil {
+		t.Errorf("CheckServiceTypes(AllServices) = %v, want nil", err)
+	}
+}
+
+func TestCheckServiceType(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		s       *ServiceInfo
+		wantErr bool
+	}{
+		{
+			name: "ga",
+			s: &ServiceInfo{
+				Object:      "Network",
+				Service:     "Networks",
+				APIGroup:    APIGroupCompute,
+				serviceType: reflect.TypeOf(&ga.NetworksService{}),
+			},
+		},
+		{
+			name: "alpha",
+			s: &ServiceInfo{
+				Object:      "Network",
+				Service:     "Networks",
+				APIGroup:    APIGroupCompute,
+				version:     VersionAlpha,
+				serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+			},
+		},
+		{
+			name: "ga version with alpha type",
+			s: &ServiceInfo{
+				Object:      "Network",
+				Service:     "Networks",
+				APIGroup:    APIGroupCompute,
+				serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong api group",
+			s: &ServiceInfo{
+				Object:      "TcpRoute",
+				Service:     "TcpRoutes",
+				APIGroup:    APIGroupCompute,
+				serviceType: reflect.TypeOf(&nsga.ProjectsLocationsTcpRoutesService{}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown package",
+			s: &ServiceInfo{
+				Object:      "Fake",
+				Service:     "Fakes",
+				APIGroup:    APIGroupCompute,
+				serviceType: reflect.TypeOf(&fakeService{}),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "nil serviceType",
+			s:       &ServiceInfo{Object: "Fake", Service: "Fakes"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.s.checkServiceType()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("checkServiceType() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateLimitClass(t *testing.T) {
+	var neg *ServiceInfo
+	for _, s := range AllServices {
+		if s.Service == "NetworkEndpointGroups" && s.Version() == VersionGA {
+			neg = s
+		}
+	}
+	if neg == nil {
+		t.Fatal("NetworkEndpointGroups GA not in AllServices")
+	}
+	for _, tc := range []struct {
+		method string
+		want   RateLimitClass
+	}{
+		{method: "Get", want: RateLimitClassRead},
+		{method: "AggregatedList", want: RateLimitClassRead},
+		{method: "Insert", want: RateLimitClassMutate},
+		{method: "ListNetworkEndpoints", want: RateLimitClassHeavyRead},
+		{method: "AttachNetworkEndpoints", want: RateLimitClassHeavyMutate},
+		{method: "DetachNetworkEndpoints", want: RateLimitClassHeavyMutate},
+	} {
+		if got := neg.RateLimitClass(tc.method); got != tc.want {
+			t.Errorf("RateLimitClass(%q) = %q, want %q", tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimitClassMethods(t *testing.T) {
+	// Overrides must refer to methods of the API service.
+	for _, s := range AllServices {
+		for m := range s.rateLimitClass {
+			if _, ok := s.serviceType.MethodByName(m); !ok {
+				t.Errorf("%s %s: rateLimitClass has unknown method %q", s.Version(), s.Service, m)
+			}
+		}
+	}
+}
@@ -143,6 +143,87 @@ var _ = cloud.MockTargetPools{
 	RemoveInstanceHook: RemoveInstanceHook,
 }
 
+// securityPolicyRule returns the index of the rule with the priority in the
+// SecurityPolicy or -1 if there is no such rule.
+func securityPolicyRule(sp *ga.SecurityPolicy, priority int64) int {
+	for i, r := range sp.Rules {
+		if r.Priority == priority {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddSecurityPolicyRuleHook mocks adding a rule to a SecurityPolicy. A rule
+// with the same priority returns a Conflict error.
+func AddSecurityPolicyRuleHook(ctx context.Context, key *meta.Key, rule *ga.SecurityPolicyRule, m *cloud.MockSecurityPolicies, options ...cloud.Option) error {
+	sp, err := m.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if securityPolicyRule(sp, rule.Priority) >= 0 {
+		return &googleapi.Error{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("Rule with priority %d already exists in SecurityPolicy %s", rule.Priority, key),
+		}
+	}
+	sp.Rules = append(sp.Rules, rule)
+	return nil
+}
+
+// Verify AddSecurityPolicyRuleHook implements cloud.MockSecurityPolicies.AddRuleHook.
+var _ = cloud.MockSecurityPolicies{
+	AddRuleHook: AddSecurityPolicyRuleHook,
+}
+
+// PatchSecurityPolicyRuleHook mocks patching the rule of a SecurityPolicy
+// with the priority in ctx (see cloud.WithRulePriority).
+func PatchSecurityPolicyRuleHook(ctx context.Context, key *meta.Key, rule *ga.SecurityPolicyRule, m *cloud.MockSecurityPolicies, options ...cloud.Option) error {
+	sp, err := m.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	priority, _ := cloud.RulePriority(ctx)
+	i := securityPolicyRule(sp, priority)
+	if i < 0 {
+		return &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Rule with priority %d was not found in SecurityPolicy %s", priority, key),
+		}
+	}
+	sp.Rules[i] = rule
+	return nil
+}
+
+// Verify PatchSecurityPolicyRuleHook implements cloud.MockSecurityPolicies.PatchRuleHook.
+var _ = cloud.MockSecurityPolicies{
+	PatchRuleHook: PatchSecurityPolicyRuleHook,
+}
+
+// RemoveSecurityPolicyRuleHook mocks removing the rule of a SecurityPolicy
+// with the priority in ctx (see cloud.WithRulePriority).
+func RemoveSecurityPolicyRuleHook(ctx context.Context, key *meta.Key, m *cloud.MockSecurityPolicies, options ...cloud.Option) error {
+	sp, err := m.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	priority, _ := cloud.RulePriority(ctx)
+	i := securityPolicyRule(sp, priority)
+	if i < 0 {
+		return &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Rule with priority %d was not found in SecurityPolicy %s", priority, key),
+		}
+	}
+	sp.Rules = append(sp.Rules[:i], sp.Rules[i+1:]...)
+	return nil
+}
+
+// Verify RemoveSecurityPolicyRuleHook implements cloud.MockSecurityPolicies.RemoveRuleHook.
+var _ = cloud.MockSecurityPolicies{
+	RemoveRuleHook: RemoveSecurityPolicyRuleHook,
+}
+
 func convertAndInsertAlphaForwardingRule(key *meta.Key, obj gceObject, mRules map[meta.Key]*cloud.MockForwardingRulesObj, version meta.Version, projectID string) (bool, error) {
 	if !key.Valid() {
 		return true, fmt.Errorf("invalid GCE key (%+v)", key)
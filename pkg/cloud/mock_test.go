@@ -25,6 +25,7 @@ import (
 	beta "google.golang.org/api/compute/v0.beta"
 	ga "google.golang.org/api/compute/v1"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/cerrors"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 )
@@ -149,3 +150,91 @@ func TestMocks(t *testing.T) {
 		t.Errorf("Addresses().Delete(%v, %v) = nil; want error", ctx, key)
 	}
 }
+
+func TestMockGetNotFoundAfterInsert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	mock.SetGetNotFoundAfterInsert(2)
+
+	key := meta.GlobalKey("bs")
+	if err := mock.BackendServices().Insert(ctx, key, &ga.BackendService{}); err != nil {
+		t.Fatalf("BackendServices().Insert(%v, %v, _) = %v; want nil", ctx, key, err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := mock.BackendServices().Get(ctx, key); !cerrors.IsGoogleAPINotFound(err) {
+			t.Errorf("BackendServices().Get(%v, %v) #%d = _, %v; want NotFound", ctx, key, i, err)
+		}
+	}
+	obj, err := mock.BackendServices().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("BackendServices().Get(%v, %v) = _, %v; want nil", ctx, key, err)
+	}
+	if obj.Name != "bs" {
+		t.Errorf("BackendServices().Get(%v, %v) = %+v; want Name = %q", ctx, key, obj, "bs")
+	}
+}
+
+func TestMockSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+
+	key1 := meta.GlobalKey("bs1")
+	key2 := meta.GlobalKey("bs2")
+	if err := mock.BackendServices().Insert(ctx, key1, &ga.BackendService{
+		Port:     80,
+		Backends: []*ga.Backend{{Group: "ig1"}},
+	}); err != nil {
+		t.Fatalf("BackendServices().Insert(%v, %v, _) = %v; want nil", ctx, key1, err)
+	}
+	snapshot := mock.Snapshot()
+
+	if err := mock.BackendServices().Insert(ctx, key2, &ga.BackendService{}); err != nil {
+		t.Fatalf("BackendServices().Insert(%v, %v, _) = %v; want nil", ctx, key2, err)
+	}
+	// Changes to the objects in the snapshot are also rolled back,
+	// including nested fields.
+	mock.MockBackendServices.Objects[*key1].Obj.(*ga.BackendService).Port = 443
+	mock.MockBackendServices.Objects[*key1].Obj.(*ga.BackendService).Backends[0].Group = "ig2"
+
+	// Restoring twice gives the same state.
+	for i := 0; i < 2; i++ {
+		mock.Restore(snapshot)
+
+		objs, err := mock.AlphaBackendServices().List(ctx, filter.None)
+		if err != nil {
+			t.Fatalf("AlphaBackendServices().List(%v, _) = _, %v; want nil", ctx, err)
+		}
+		if len(objs) != 1 || objs[0].Name != "bs1" || objs[0].Port != 80 {
+			t.Fatalf("#%d: AlphaBackendServices().List(%v, _) = %+v; want [bs1 with Port 80]", i, ctx, objs)
+		}
+		if len(objs[0].Backends) != 1 || objs[0].Backends[0].Group != "ig1" {
+			t.Errorf("#%d: Backends = %+v; want [ig1]", i, objs[0].Backends)
+		}
+		// Mutate the restored object; this must not change the snapshot.
+		mock.MockBackendServices.Objects[*key1].Obj.(*ga.BackendService).Port = 443
+		mock.MockBackendServices.Objects[*key1].Obj.(*ga.BackendService).Backends[0].Group = "ig2"
+	}
+}
+
+func TestMockSnapshotRestoreOperations(t *testing.T) {
+	t.Parallel()
+
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	mock.MockOperations.Insert(&ga.Operation{Name: "op1", SelfLink: "op1"}, 1)
+	snapshot := mock.Snapshot()
+
+	mock.MockOperations.Insert(&ga.Operation{Name: "op2", SelfLink: "op2"}, 1)
+	mock.MockOperations.Objects["op1"].Obj.Status = "DONE"
+	mock.Restore(snapshot)
+
+	if len(mock.MockOperations.Objects) != 1 || mock.MockOperations.Objects["op1"] == nil {
+		t.Fatalf("MockOperations.Objects = %+v; want [op1]", mock.MockOperations.Objects)
+	}
+	if got := mock.MockOperations.Objects["op1"].Obj.Status; got != "" {
+		t.Errorf("op1 Status = %q; want %q", got, "")
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+// OperationWaiter is implemented by Cloud implementations that can wait for a
+// compute Operation given its SelfLink. This is used by callers that start an
+// Operation outside of the generated methods, which wait for their own
+// Operations.
+type OperationWaiter interface {
+	// WaitForOperation polls the Operation until it is DONE or ctx is done.
+	// Returns the error of the Operation, if any.
+	WaitForOperation(ctx context.Context, selfLink string) error
+}
+
+// WaitForOperation waits for the compute Operation selfLink to be DONE using
+// c. Returns an error if c does not implement OperationWaiter.
+func WaitForOperation(ctx context.Context, c Cloud, selfLink string) error {
+	ow, ok := c.(OperationWaiter)
+	if !ok {
+		return fmt.Errorf("WaitForOperation: %T does not implement OperationWaiter", c)
+	}
+	return ow.WaitForOperation(ctx, selfLink)
+}
+
+// GCE implements OperationWaiter.
+var _ OperationWaiter = (*GCE)(nil)
+
+// WaitForOperation polls the Operation using the global, regional or zonal
+// Operations API depending on the scope of the selfLink. The rate of polling
+// is governed by the RateLimiter of the Service.
+func (gce *GCE) WaitForOperation(ctx context.Context, selfLink string) error {
+	op, err := gce.s.operationFromSelfLink(selfLink)
+	if err != nil {
+		return err
+	}
+	return gce.s.pollOperation(ctx, op)
+}
+
+// operationFromSelfLink returns the operation for the version of the API in
+// the selfLink.
+func (s *Service) operationFromSelfLink(selfLink string) (operation, error) {
+	id, err := ParseResourceURL(selfLink)
+	if err != nil {
+		return nil, fmt.Errorf("WaitForOperation: %w", err)
+	}
+	if id.Key == nil || id.Resource != "operations" {
+		return nil, fmt.Errorf("WaitForOperation: %q is not an Operation", selfLink)
+	}
+	switch {
+	case strings.Contains(selfLink, "/compute/alpha/"):
+		return &alphaOperation{s: s, projectID: id.ProjectID, key: id.Key}, nil
+	case strings.Contains(selfLink, "/compute/beta/"):
+		return &betaOperation{s: s, projectID: id.ProjectID, key: id.Key}, nil
+	}
+	return &gaOperation{s: s, projectID: id.ProjectID, key: id.Key}, nil
+}
+
+// mockOperationPollInterval is the time between polls in
+// MockGCE.WaitForOperation.
+const mockOperationPollInterval = time.Millisecond
+
+// MockOperations holds the compute Operations for MockGCE.WaitForOperation.
+type MockOperations struct {
+	Lock sync.Mutex
+
+	// Objects are the Operations by SelfLink.
+	Objects map[string]*MockOperationsObj
+}
+
+// MockOperationsObj is an Operation in the mock.
+type MockOperationsObj struct {
+	Obj *ga.Operation
+	// PollsUntilDone is the number of polls before the Status of the
+	// Operation is set to DONE.
+	PollsUntilDone int
+	// Polls is the number of times the Operation has been polled.
+	Polls int
+}
+
+// NewMockOperations returns a new mock for Operations.
+func NewMockOperations() *MockOperations {
+	return &MockOperations{Objects: map[string]*MockOperationsObj{}}
+}
+
+// Insert adds op to the mock. op will be DONE after it has been polled
+// pollsUntilDone times.
+func (m *MockOperations) Insert(op *ga.Operation, pollsUntilDone int) {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	m.Objects[op.SelfLink] = &MockOperationsObj{Obj: op, PollsUntilDone: pollsUntilDone}
+}
+
+// snapshot returns a copy of the Operations for MockGCE.Snapshot().
+func (m *MockOperations) snapshot() map[string]*MockOperationsObj {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	ret := map[string]*MockOperationsObj{}
+	for k, obj := range m.Objects {
+		ret[k] = obj.deepCopy()
+	}
+	return ret
+}
+
+// restore the Operations saved by snapshot().
+func (m *MockOperations) restore(objs map[string]*MockOperationsObj) {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	for k := range m.Objects {
+		delete(m.Objects, k)
+	}
+	for k, obj := range objs {
+		m.Objects[k] = obj.deepCopy()
+	}
+}
+
+func (o *MockOperationsObj) deepCopy() *MockOperationsObj {
+	ret := *o
+	ret.Obj = deepCopyMockObj(o.Obj).(*ga.Operation)
+	return &ret
+}
+
+// poll the Operation once, returning true if it is DONE.
+func (m *MockOperations) poll(selfLink string) (bool, error) {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	obj, ok := m.Objects[selfLink]
+	if !ok {
+		return false, &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("MockOperations %q not found", selfLink),
+		}
+	}
+	obj.Polls++
+	if obj.Obj.Status != operationStatusDone && obj.Polls > obj.PollsUntilDone {
+		obj.Obj.Status = operationStatusDone
+	}
+	if obj.Obj.Status != operationStatusDone {
+		return false, nil
+	}
+	if e := obj.Obj.Error; e != nil && len(e.Errors) > 0 && e.Errors[0] != nil {
+		return true, &googleapi.Error{Code: int(obj.Obj.HttpErrorStatusCode), Message: fmt.Sprintf("%v - %v", e.Errors[0].Code, e.Errors[0].Message)}
+	}
+	return true, nil
+}
+
+// MockGCE implements OperationWaiter.
+var _ OperationWaiter = (*MockGCE)(nil)
+
+// WaitForOperation polls the Operation in MockOperations until it is DONE.
+func (mock *MockGCE) WaitForOperation(ctx context.Context, selfLink string) error {
+	if mock.WaitForOperationHook != nil {
+		if intercept, err := mock.WaitForOperationHook(ctx, selfLink, mock); intercept {
+			klog.V(5).Infof("MockGCE.WaitForOperation(%v, %q) = %v", ctx, selfLink, err)
+			return err
+		}
+	}
+
+	for {
+		done, err := mock.MockOperations.poll(selfLink)
+		if done || err != nil {
+			klog.V(5).Infof("MockGCE.WaitForOperation(%v, %q) = %v", ctx, selfLink, err)
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(mockOperationPollInterval):
+		}
+	}
+}
+
+// RecordingCloud implements OperationWaiter.
+var _ OperationWaiter = (*RecordingCloud)(nil)
+
+// WaitForOperation waits using the wrapped Cloud. Waiting is not recorded.
+// Returns an error if the wrapped Cloud does not implement OperationWaiter.
+func (r *RecordingCloud) WaitForOperation(ctx context.Context, selfLink string) error {
+	return WaitForOperation(ctx, r.c, selfLink)
+}
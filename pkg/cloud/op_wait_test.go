@@ -0,0 +1,234 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+func TestGCEWaitForOperation(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		selfLink string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:     "global",
+			selfLink: SelfLink(meta.VersionGA, "proj", "operations", meta.GlobalKey("op")),
+			wantPath: "/compute/v1/projects/proj/global/operations/op/wait",
+		},
+		{
+			name:     "regional",
+			selfLink: SelfLink(meta.VersionGA, "proj", "operations", meta.RegionalKey("op", "us-central1")),
+			wantPath: "/compute/v1/projects/proj/regions/us-central1/operations/op/wait",
+		},
+		{
+			name:     "zonal alpha",
+			selfLink: SelfLink(meta.VersionAlpha, "proj", "operations", meta.ZonalKey("op", "us-central1-b")),
+			wantPath: "/compute/alpha/projects/proj/zones/us-central1-b/operations/op/wait",
+		},
+		{
+			name:     "not an operation",
+			selfLink: SelfLink(meta.VersionGA, "proj", "backendServices", meta.GlobalKey("bs")),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid URL",
+			selfLink: "invalid",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var polls int
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				polls++
+				if r.URL.Path != tc.wantPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, tc.wantPath)
+				}
+				status := "RUNNING"
+				if polls == 3 {
+					status = "DONE"
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"name": "op", "status": %q}`, status))),
+				}, nil
+			})
+			svc, err := NewService(context.Background(), &http.Client{Transport: transport}, &SingleProjectRouter{ID: "proj"}, &NopRateLimiter{})
+			if err != nil {
+				t.Fatalf("NewService() = %v, want nil", err)
+			}
+			err = WaitForOperation(context.Background(), NewGCE(svc), tc.selfLink)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("WaitForOperation(_, _, %q) = %v; gotErr = %t, want %t", tc.selfLink, err, gotErr, tc.wantErr)
+			}
+			if !tc.wantErr && polls != 3 {
+				t.Errorf("polls = %d, want 3", polls)
+			}
+		})
+	}
+}
+
+func TestMockWaitForOperation(t *testing.T) {
+	selfLink := SelfLink(meta.VersionGA, "proj", "operations", meta.GlobalKey("op"))
+
+	for _, tc := range []struct {
+		name           string
+		op             *ga.Operation
+		pollsUntilDone int
+		cancel         bool
+		timeout        time.Duration
+
+		wantPolls int
+		// wantMaxPolls is checked instead of wantPolls if set.
+		wantMaxPolls int
+		wantCode     int
+		wantErr      error
+	}{
+		{
+			name:           "done after polls",
+			op:             &ga.Operation{Name: "op", SelfLink: selfLink, Status: "RUNNING"},
+			pollsUntilDone: 3,
+			wantPolls:      4,
+		},
+		{
+			name:      "already done",
+			op:        &ga.Operation{Name: "op", SelfLink: selfLink, Status: "DONE"},
+			wantPolls: 1,
+		},
+		{
+			name: "operation error",
+			op: &ga.Operation{
+				Name:                "op",
+				SelfLink:            selfLink,
+				Status:              "RUNNING",
+				HttpErrorStatusCode: http.StatusConflict,
+				Error: &ga.OperationError{
+					Errors: []*ga.OperationErrorErrors{{Code: "RESOURCE_IN_USE", Message: "in use"}},
+				},
+			},
+			pollsUntilDone: 1,
+			wantPolls:      2,
+			wantCode:       http.StatusConflict,
+		},
+		{
+			name:     "not found",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:           "context cancelled",
+			op:             &ga.Operation{Name: "op", SelfLink: selfLink, Status: "RUNNING"},
+			pollsUntilDone: 1000,
+			cancel:         true,
+			wantErr:        context.Canceled,
+		},
+		{
+			// The mock waits between polls instead of spinning.
+			name:           "context deadline",
+			op:             &ga.Operation{Name: "op", SelfLink: selfLink, Status: "RUNNING"},
+			pollsUntilDone: 1000000,
+			timeout:        20 * time.Millisecond,
+			wantErr:        context.DeadlineExceeded,
+			wantMaxPolls:   100,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+			if tc.op != nil {
+				mock.MockOperations.Insert(tc.op, tc.pollsUntilDone)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+			if tc.timeout != 0 {
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			err := WaitForOperation(ctx, mock, selfLink)
+			var gerr *googleapi.Error
+			switch {
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("WaitForOperation() = %v, want %v", err, tc.wantErr)
+				}
+			case tc.wantCode != 0:
+				if !errors.As(err, &gerr) || gerr.Code != tc.wantCode {
+					t.Fatalf("WaitForOperation() = %v, want googleapi.Error with code %d", err, tc.wantCode)
+				}
+			case err != nil:
+				t.Fatalf("WaitForOperation() = %v, want nil", err)
+			}
+
+			if tc.op == nil || tc.cancel {
+				return
+			}
+			obj := mock.MockOperations.Objects[selfLink]
+			if tc.wantMaxPolls != 0 {
+				if obj.Polls > tc.wantMaxPolls {
+					t.Errorf("Polls = %d, want <= %d", obj.Polls, tc.wantMaxPolls)
+				}
+				return
+			}
+			if obj.Polls != tc.wantPolls {
+				t.Errorf("Polls = %d, want %d", obj.Polls, tc.wantPolls)
+			}
+			if obj.Obj.Status != "DONE" {
+				t.Errorf("Status = %q, want DONE", obj.Obj.Status)
+			}
+		})
+	}
+}
+
+func TestMockWaitForOperationHook(t *testing.T) {
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	injected := errors.New("injected")
+	mock.WaitForOperationHook = func(context.Context, string, *MockGCE) (bool, error) { return true, injected }
+	if err := mock.WaitForOperation(context.Background(), "x"); !errors.Is(err, injected) {
+		t.Errorf("WaitForOperation() = %v, want %v", err, injected)
+	}
+}
+
+func TestRecordingCloudWaitForOperation(t *testing.T) {
+	selfLink := SelfLink(meta.VersionGA, "proj", "operations", meta.GlobalKey("op"))
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	mock.MockOperations.Insert(&ga.Operation{Name: "op", SelfLink: selfLink, Status: "RUNNING"}, 1)
+
+	rc := NewRecordingCloud(mock)
+	if err := WaitForOperation(context.Background(), rc, selfLink); err != nil {
+		t.Fatalf("WaitForOperation() = %v, want nil", err)
+	}
+	if calls := rc.Calls(); len(calls) != 0 {
+		t.Errorf("rc.Calls() = %v, want none", calls)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// RecordedCall is a single API call made through a RecordingCloud.
+type RecordedCall struct {
+	// Service called (e.g. "BackendServices").
+	Service string
+	// Method called (e.g. "Insert").
+	Method string
+	// Version of the API.
+	Version meta.Version
+	// Key of the resource. This will be nil for calls that do not
+	// reference a single resource (e.g. List).
+	Key *meta.Key
+	// Err returned by the call.
+	Err error
+}
+
+// String returns a compact representation of the call, e.g.
+// "ga.BackendServices.Insert(Key{"bs1"})".
+func (c RecordedCall) String() string {
+	var key string
+	if c.Key != nil {
+		key = c.Key.String()
+	}
+	return fmt.Sprintf("%s.%s.%s(%s)", c.Version, c.Service, c.Method, key)
+}
+
+// NewRecordingCloud returns a Cloud that records every call made to c.
+//
+//	mock := NewMockGCE(&SingleProjectRouter{"proj"})
+//	rc := NewRecordingCloud(mock)
+//	// ... run code using rc ...
+//	for _, call := range rc.Calls() { ... }
+//
+// Custom operations that are implemented by hand (e.g. the *Ops interfaces)
+// are passed through to c without being recorded, as is WaitForOperation.
+// RecordingCloud does not implement BatchGetter, so BatchGet() will be
+// recorded as a sequence of individual Gets.
+func NewRecordingCloud(c Cloud) *RecordingCloud {
+	return &RecordingCloud{c: c}
+}
+
+// RecordingCloud is a Cloud that records the calls made through it. It is
+// intended to be used in tests to assert the sequence of operations.
+type RecordingCloud struct {
+	c Cloud
+
+	lock  sync.Mutex
+	calls []RecordedCall
+}
+
+// Calls returns the calls made so far, in order.
+func (r *RecordingCloud) Calls() []RecordedCall {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// Filter returns the calls for which f returns true, in order.
+func (r *RecordingCloud) Filter(f func(RecordedCall) bool) []RecordedCall {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var ret []RecordedCall
+	for _, c := range r.calls {
+		if f(c) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Reset clears the recorded calls.
+func (r *RecordingCloud) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.calls = nil
+}
+
+func (r *RecordingCloud) record(service, method string, ver meta.Version, key *meta.Key, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if key != nil {
+		k := *key
+		key = &k
+	}
+	r.calls = append(r.calls, RecordedCall{
+		Service: service,
+		Method:  method,
+		Version: ver,
+		Key:     key,
+		Err:     err,
+	})
+}
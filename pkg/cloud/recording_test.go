@@ -0,0 +1,90 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestRecordingCloud(t *testing.T) {
+	ctx := context.Background()
+	errInjected := errors.New("injected")
+
+	mock := NewMockGCE(&SingleProjectRouter{ID: "proj"})
+	mock.MockAddresses.GetError[*meta.RegionalKey("err", "us-central1")] = errInjected
+	rc := NewRecordingCloud(mock)
+
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	rc.Addresses().Insert(ctx, addrKey, &ga.Address{Name: "addr"})
+	rc.AlphaAddresses().Get(ctx, addrKey)
+	rc.Addresses().Get(ctx, meta.RegionalKey("err", "us-central1"))
+	rc.Addresses().List(ctx, "us-central1", nil)
+	rc.Addresses().Delete(ctx, addrKey)
+
+	want := []RecordedCall{
+		{Service: "Addresses", Method: "Insert", Version: meta.VersionGA, Key: addrKey},
+		{Service: "Addresses", Method: "Get", Version: meta.VersionAlpha, Key: addrKey},
+		{Service: "Addresses", Method: "Get", Version: meta.VersionGA, Key: meta.RegionalKey("err", "us-central1"), Err: errInjected},
+		{Service: "Addresses", Method: "List", Version: meta.VersionGA},
+		{Service: "Addresses", Method: "Delete", Version: meta.VersionGA, Key: addrKey},
+	}
+	if diff := cmp.Diff(rc.Calls(), want, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("Calls(): -got,+want: %s", diff)
+	}
+
+	gets := rc.Filter(func(c RecordedCall) bool { return c.Method == "Get" })
+	if len(gets) != 2 {
+		t.Errorf("len(Filter(Get)) = %d, want 2", len(gets))
+	}
+
+	// The underlying Cloud is called.
+	if _, err := mock.AlphaAddresses().Get(ctx, addrKey); err == nil {
+		t.Errorf("Get(%v) = nil, want NotFound after Delete", addrKey)
+	}
+
+	rc.Reset()
+	if got := rc.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after Reset() = %v, want []", got)
+	}
+}
+
+func TestRecordedCallString(t *testing.T) {
+	for _, tc := range []struct {
+		call RecordedCall
+		want string
+	}{
+		{
+			call: RecordedCall{Service: "BackendServices", Method: "Insert", Version: meta.VersionGA, Key: meta.GlobalKey("bs")},
+			want: `ga.BackendServices.Insert(Key{"bs"})`,
+		},
+		{
+			call: RecordedCall{Service: "Addresses", Method: "List", Version: meta.VersionBeta},
+			want: `beta.Addresses.List()`,
+		},
+	} {
+		if got := tc.call.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphviz
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// DiffStatus of a node or edge between the got and want graphs.
+type DiffStatus string
+
+const (
+	// DiffAdded is only in want.
+	DiffAdded DiffStatus = "added"
+	// DiffRemoved is only in got.
+	DiffRemoved DiffStatus = "removed"
+	// DiffChanged is in both but the resource differs.
+	DiffChanged DiffStatus = "changed"
+	// DiffUnchanged is the same in both.
+	DiffUnchanged DiffStatus = "unchanged"
+)
+
+// DoDiff returns a .dot representation that overlays the got and want graphs.
+// Nodes are colored by whether they are added, removed, changed or
+// unchanged from got to want. Edges that are only in want are colored green
+// and edges that are only in got are drawn dashed.
+//
+// Only LegendOption is used from opts.
+func DoDiff(got, want *rgraph.Graph, opts ...Option) string {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+
+	pairs := map[cloud.ResourceMapKey]*nodePair{}
+	pair := func(id *cloud.ResourceID) *nodePair {
+		p, ok := pairs[id.MapKey()]
+		if !ok {
+			p = &nodePair{}
+			pairs[id.MapKey()] = p
+		}
+		return p
+	}
+	for _, n := range got.All() {
+		if n.State() == rnode.NodeExists {
+			pair(n.ID()).got = n
+		}
+	}
+	for _, n := range want.All() {
+		if n.State() == rnode.NodeExists {
+			pair(n.ID()).want = n
+		}
+	}
+
+	type edgeKey struct{ from, to, field string }
+	edges := map[edgeKey]DiffStatus{}
+	var keys []cloud.ResourceMapKey
+	for k, p := range pairs {
+		keys = append(keys, k)
+		if p.got != nil {
+			for _, ref := range p.got.OutRefs() {
+				edges[edgeKey{ref.From.String(), ref.To.String(), ref.Path.DisplayString()}] = DiffRemoved
+			}
+		}
+		if p.want != nil {
+			for _, ref := range p.want.OutRefs() {
+				ek := edgeKey{ref.From.String(), ref.To.String(), ref.Path.DisplayString()}
+				if _, ok := edges[ek]; ok {
+					edges[ek] = DiffUnchanged
+				} else {
+					edges[ek] = DiffAdded
+				}
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return pairs[keys[i]].id().String() < pairs[keys[j]].id().String() })
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph G {\n")
+	buf.WriteString("  rankdir=TB\n") // layout top to bottom.
+
+	for _, k := range keys {
+		p := pairs[k]
+		status := nodeDiffStatus(p.got, p.want)
+		gn := &viznode{
+			name:  p.id().String(),
+			shape: "box",
+			style: "filled",
+			kv:    map[string]any{"diff": status},
+		}
+		gn.fillcolor, gn.color = diffColors(status)
+		if status == DiffRemoved {
+			gn.style = `"filled,dashed"`
+		}
+		buf.WriteString(gn.String())
+	}
+
+	var edgeKeys []edgeKey
+	for k := range edges {
+		edgeKeys = append(edgeKeys, k)
+	}
+	sort.Slice(edgeKeys, func(i, j int) bool {
+		a, b := edgeKeys[i], edgeKeys[j]
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		if a.to != b.to {
+			return a.to < b.to
+		}
+		return a.field < b.field
+	})
+	for _, k := range edgeKeys {
+		var attribs string
+		switch edges[k] {
+		case DiffAdded:
+			attribs = ",color=green"
+		case DiffRemoved:
+			attribs = ",style=dashed,color=red"
+		}
+		fmt.Fprintf(&buf, "  %q -> %q [label=<%s>%s]\n", k.from, k.to, html.EscapeString(k.field), attribs)
+	}
+
+	if c.legend {
+		buf.WriteString(diffLegend())
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// nodePair is the got and want node for a resource. A nil node does not
+// exist in that graph.
+type nodePair struct{ got, want rnode.Node }
+
+func (p *nodePair) id() *cloud.ResourceID {
+	if p.want != nil {
+		return p.want.ID()
+	}
+	return p.got.ID()
+}
+
+// nodeDiffStatus compares the nodes from got and want. Either may be nil but
+// not both.
+func nodeDiffStatus(got, want rnode.Node) DiffStatus {
+	switch {
+	case got == nil:
+		return DiffAdded
+	case want == nil:
+		return DiffRemoved
+	}
+	details, err := want.Diff(got)
+	if err != nil || details.Operation != rnode.OpNothing {
+		return DiffChanged
+	}
+	return DiffUnchanged
+}
+
+// diffColors returns the fill and outline colors for the status. These are
+// the same as the colors for the corresponding planned operation in Do().
+func diffColors(status DiffStatus) (string, string) {
+	var n viznode
+	op := map[DiffStatus]rnode.Operation{
+		DiffAdded:     rnode.OpCreate,
+		DiffRemoved:   rnode.OpDelete,
+		DiffChanged:   rnode.OpUpdate,
+		DiffUnchanged: rnode.OpNothing,
+	}[status]
+	return n.opColor(op), n.opOutlineColor(op)
+}
+
+// diffLegend returns a subgraph with a node for each DiffStatus and examples
+// of added and removed edges.
+func diffLegend() string {
+	var buf bytes.Buffer
+	buf.WriteString("  subgraph cluster_legend {\n")
+	buf.WriteString("    label=\"Legend\"\n")
+	buf.WriteString("    style=dashed\n")
+
+	var prev string
+	for _, status := range []DiffStatus{DiffUnchanged, DiffAdded, DiffChanged, DiffRemoved} {
+		name := "legend_" + string(status)
+		fill, color := diffColors(status)
+		attribs := fmt.Sprintf("label=%q,shape=box,style=filled,fillcolor=%s", string(status), fill)
+		if color != "" {
+			attribs += ",color=" + color
+		}
+		fmt.Fprintf(&buf, "    %q [%s]\n", name, attribs)
+		// Invisible edges keep the legend in a single column.
+		if prev != "" {
+			fmt.Fprintf(&buf, "    %q -> %q [style=invis]\n", prev, name)
+		}
+		prev = name
+	}
+	buf.WriteString("    \"legend_from\" [label=\"resource\",shape=box]\n")
+	buf.WriteString("    \"legend_to\" [label=\"referenced resource\",shape=box]\n")
+	buf.WriteString("    \"legend_from\" -> \"legend_to\" [label=<added reference>,color=green]\n")
+	buf.WriteString("    \"legend_from\" -> \"legend_to\" [label=<removed reference>,style=dashed,color=red]\n")
+	buf.WriteString("  }\n")
+
+	return buf.String()
+}
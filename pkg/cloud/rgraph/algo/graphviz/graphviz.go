@@ -19,6 +19,7 @@ package graphviz
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"sort"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -26,9 +27,35 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 )
 
+// Option for Do().
+type Option func(*config)
+
+type config struct {
+	colorByOp bool
+	legend    bool
+}
+
+// ColorByOpOption outlines each node with a darker shade of its fill color
+// for the planned operation (green=create, khaki=update, orange=recreate,
+// red=delete) and adds the PlanDetails.Why as a tooltip.
+func ColorByOpOption() Option {
+	return func(c *config) { c.colorByOp = true }
+}
+
+// LegendOption adds a legend subgraph explaining the node colors and edge
+// labels.
+func LegendOption() Option {
+	return func(c *config) { c.legend = true }
+}
+
 // Do returns a .dot (http://graphviz.org) representation of the resource graph
 // for visualization.
-func Do(g *rgraph.Graph) string {
+func Do(g *rgraph.Graph, opts ...Option) string {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+
 	var buf bytes.Buffer
 	buf.WriteString("digraph G {\n")
 	buf.WriteString("  rankdir=TB\n") // layout top to bottom.
@@ -45,24 +72,72 @@ func Do(g *rgraph.Graph) string {
 		}
 		deps := node.OutRefs()
 		for _, dep := range deps {
-			e := vizedge{from: node.ID(), to: dep.To, field: dep.Path.String()}
+			e := vizedge{from: node.ID(), to: dep.To, field: dep.Path.DisplayString()}
 			buf.WriteString(e.String())
 		}
 
 		gn.fillcolor = gn.opColor(node.Plan().Op())
+		if c.colorByOp {
+			gn.color = gn.opOutlineColor(node.Plan().Op())
+			if details := node.Plan().Details(); details != nil && details.Why != "" {
+				gn.tooltip = fmt.Sprintf("%q", details.Why)
+			}
+		}
 		buf.WriteString(gn.String())
 	}
+	if c.legend {
+		buf.WriteString(legend(&c))
+	}
 	buf.WriteString("}\n")
 
 	return buf.String()
 }
 
+// legend returns a subgraph with a node for each of the planned operations,
+// drawn the same way as the resource nodes and an example of a labelled edge.
+func legend(c *config) string {
+	var buf bytes.Buffer
+	buf.WriteString("  subgraph cluster_legend {\n")
+	buf.WriteString("    label=\"Legend\"\n")
+	buf.WriteString("    style=dashed\n")
+
+	var prev string
+	for _, op := range []rnode.Operation{
+		rnode.OpNothing,
+		rnode.OpCreate,
+		rnode.OpUpdate,
+		rnode.OpRecreate,
+		rnode.OpDelete,
+	} {
+		var n viznode
+		name := "legend_" + string(op)
+		attribs := fmt.Sprintf("label=%q,shape=box,style=filled,fillcolor=%s", string(op), n.opColor(op))
+		if color := n.opOutlineColor(op); c.colorByOp && color != "" {
+			attribs += ",color=" + color
+		}
+		fmt.Fprintf(&buf, "    %q [%s]\n", name, attribs)
+		// Invisible edges keep the legend in a single column.
+		if prev != "" {
+			fmt.Fprintf(&buf, "    %q -> %q [style=invis]\n", prev, name)
+		}
+		prev = name
+	}
+	buf.WriteString("    \"legend_from\" [label=\"resource\",shape=box]\n")
+	buf.WriteString("    \"legend_to\" [label=\"referenced resource\",shape=box]\n")
+	buf.WriteString("    \"legend_from\" -> \"legend_to\" [label=<field with the reference>]\n")
+	buf.WriteString("  }\n")
+
+	return buf.String()
+}
+
 type viznode struct {
 	name string
 
+	color     string
 	fillcolor string
 	shape     string
 	style     string
+	tooltip   string
 
 	kv map[string]any
 }
@@ -75,22 +150,29 @@ func (*viznode) indent(n int) string {
 	return ret
 }
 
+// opPalette is the fill and outline color for each planned operation. The
+// outline is a darker shade of the fill so that the two are read as the same
+// operation. Operations not in the table are filled with defaultOpColor.
+var opPalette = map[rnode.Operation]struct{ fill, outline string }{
+	rnode.OpNothing:  {fill: "gray90"},
+	rnode.OpUnknown:  {fill: "gray90"},
+	rnode.OpCreate:   {fill: "palegreen", outline: "green"},
+	rnode.OpUpdate:   {fill: "khaki1", outline: "khaki4"},
+	rnode.OpRecreate: {fill: "yellow", outline: "orange"},
+	rnode.OpDelete:   {fill: "pink", outline: "red"},
+}
+
+const defaultOpColor = "mediumpurple1"
+
 func (*viznode) opColor(op rnode.Operation) string {
-	switch op {
-	case rnode.OpCreate:
-		return "palegreen"
-	case rnode.OpDelete:
-		return "pink"
-	case rnode.OpRecreate:
-		return "yellow"
-	case rnode.OpUpdate:
-		return "khaki1"
-	case rnode.OpNothing:
-		return "gray90"
-	case rnode.OpUnknown:
-		return "gray90"
+	if c, ok := opPalette[op]; ok {
+		return c.fill
 	}
-	return "mediumpurple1"
+	return defaultOpColor
+}
+
+func (*viznode) opOutlineColor(op rnode.Operation) string {
+	return opPalette[op].outline
 }
 
 func (n *viznode) String() string {
@@ -121,9 +203,11 @@ func (n *viznode) String() string {
 		key string
 		val *string
 	}{
+		{"color", &n.color},
 		{"fillcolor", &n.fillcolor},
 		{"shape", &n.shape},
 		{"style", &n.style},
+		{"tooltip", &n.tooltip},
 	} {
 		if *at.val != "" {
 			attribsStr += fmt.Sprintf(`,%s=%s`, at.key, *at.val)
@@ -144,5 +228,5 @@ type vizedge struct {
 }
 
 func (e *vizedge) String() string {
-	return fmt.Sprintf("  \"%s\" -> \"%s\" [label=<%s>]\n", e.from, e.to, e.field)
+	return fmt.Sprintf("  \"%s\" -> \"%s\" [label=<%s>]\n", e.from, e.to, html.EscapeString(e.field))
 }
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphviz
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDoColorByOp(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+	gb := rgraph.NewBuilder()
+	gb.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	g, err := gb.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	g.Get(b.N("hc").HealthCheck().ID()).Plan().Set(rnode.PlanDetails{
+		Operation: rnode.OpDelete,
+		Why:       "not in want",
+	})
+
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "default",
+			notWant: []string{"color=red", "tooltip="},
+		},
+		{
+			name: "color by op",
+			opts: []Option{ColorByOpOption()},
+			want: []string{"color=red", `tooltip="not in want"`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Do(g, tc.opts...)
+			for _, s := range tc.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("Do() = %q, want to contain %q", got, s)
+				}
+			}
+			for _, s := range tc.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("Do() = %q, want to not contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestDoEdgeLabels(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+	gb := rgraph.NewBuilder()
+	gb.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	gb.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	g, err := gb.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	got := Do(g)
+	want := fmt.Sprintf("%q -> %q [label=<HealthChecks[0]>]",
+		b.N("bs").BackendService().ID().String(),
+		b.N("hc").HealthCheck().ID().String())
+	if !strings.Contains(got, want) {
+		t.Errorf("Do() = %q, want to contain %q", got, want)
+	}
+}
+
+func TestDoLegend(t *testing.T) {
+	g, err := rgraph.NewBuilder().Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	if got := Do(g); strings.Contains(got, "cluster_legend") {
+		t.Errorf("Do() = %q, want no legend", got)
+	}
+	got := Do(g, LegendOption(), ColorByOpOption())
+	for _, s := range []string{
+		"subgraph cluster_legend",
+		`"legend_Create" [label="Create",shape=box,style=filled,fillcolor=palegreen,color=green]`,
+		`"legend_Update" [label="Update",shape=box,style=filled,fillcolor=khaki1,color=khaki4]`,
+		`"legend_Recreate" [label="Recreate",shape=box,style=filled,fillcolor=yellow,color=orange]`,
+		`"legend_Delete" [label="Delete",shape=box,style=filled,fillcolor=pink,color=red]`,
+		`"legend_from" -> "legend_to" [label=<field with the reference>]`,
+	} {
+		if !strings.Contains(got, s) {
+			t.Errorf("Do() = %q, want to contain %q", got, s)
+		}
+	}
+}
+
+func TestDoDiff(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+
+	gotBuilder := rgraph.NewBuilder()
+	gotBuilder.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	gotBuilder.Add(b.N("hc-old").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	gotBuilder.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc-old").HealthCheck().SelfLink()}
+	}))
+	got, err := gotBuilder.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	wantBuilder := rgraph.NewBuilder()
+	wantBuilder.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	wantBuilder.Add(b.N("hc-new").HealthCheck().Build(func(x *compute.HealthCheck) {}))
+	wantBuilder.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc-new").HealthCheck().SelfLink()}
+	}))
+	want, err := wantBuilder.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	// nodeAttribs returns the attributes that end the node definition for the
+	// resource.
+	nodeAttribs := func(dot string, id string) string {
+		start := strings.Index(dot, fmt.Sprintf("%q [label=<", id))
+		if start == -1 {
+			t.Fatalf("DoDiff() = %q, no node for %q", dot, id)
+		}
+		end := strings.Index(dot[start:], "\n  >")
+		attribsEnd := strings.Index(dot[start+end:], "]\n")
+		return dot[start+end : start+end+attribsEnd]
+	}
+
+	dot := DoDiff(got, want)
+	for _, tc := range []struct {
+		id   string
+		want string
+	}{
+		{id: b.N("hc").HealthCheck().ID().String(), want: "fillcolor=gray90"},
+		{id: b.N("hc-new").HealthCheck().ID().String(), want: "fillcolor=palegreen"},
+		{id: b.N("hc-old").HealthCheck().ID().String(), want: "fillcolor=pink"},
+		{id: b.N("bs").BackendService().ID().String(), want: "fillcolor=khaki1"},
+	} {
+		if attribs := nodeAttribs(dot, tc.id); !strings.Contains(attribs, tc.want) {
+			t.Errorf("DoDiff(): node %q has attributes %q, want to contain %q", tc.id, attribs, tc.want)
+		}
+	}
+	if nodeAttribs(dot, b.N("hc").HealthCheck().ID().String()) == nodeAttribs(dot, b.N("hc-new").HealthCheck().ID().String()) {
+		t.Errorf("DoDiff(): added and unchanged nodes have the same attributes")
+	}
+
+	for _, s := range []string{
+		fmt.Sprintf("%q -> %q [label=<HealthChecks[0]>,style=dashed,color=red]",
+			b.N("bs").BackendService().ID().String(), b.N("hc-old").HealthCheck().ID().String()),
+		fmt.Sprintf("%q -> %q [label=<HealthChecks[0]>,color=green]",
+			b.N("bs").BackendService().ID().String(), b.N("hc-new").HealthCheck().ID().String()),
+	} {
+		if !strings.Contains(dot, s) {
+			t.Errorf("DoDiff() = %q, want to contain %q", dot, s)
+		}
+	}
+	if strings.Contains(dot, "cluster_legend") {
+		t.Errorf("DoDiff() = %q, want no legend", dot)
+	}
+	if legend := DoDiff(got, want, LegendOption()); !strings.Contains(legend, `"legend_added" [label="added",shape=box,style=filled,fillcolor=palegreen,color=green]`) {
+		t.Errorf("DoDiff(LegendOption) = %q, want legend", legend)
+	}
+}
@@ -18,6 +18,7 @@ package localplan
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
@@ -27,20 +28,39 @@ import (
 // resulting plan in the "want" Graph. It is required that got and want have the
 // same set of Nodes; Nodes that don't exist need to be marked as with
 // NodeStateDoesNotExist.
-func PlanWantGraph(got, want *rgraph.Graph) error {
-	p := planner{got: got, want: want}
+func PlanWantGraph(got, want *rgraph.Graph, opts ...Option) error {
+	p := planner{got: got, want: want, workers: 1}
+	for _, o := range opts {
+		o(&p)
+	}
 	return p.do()
 }
 
+// Option for PlanWantGraph.
+type Option func(*planner)
+
+// ParallelOption plans the Nodes using the given number of concurrent
+// workers. The plan for each Node only depends on the Node's own got and want
+// values, so the result is the same as planning serially. workers < 1 is
+// treated as 1.
+func ParallelOption(workers int) Option {
+	return func(p *planner) { p.workers = workers }
+}
+
 type planner struct {
 	got  *rgraph.Graph
 	want *rgraph.Graph
+	// workers is the number of Nodes to plan concurrently.
+	workers int
 }
 
 func (p *planner) do() error {
 	if err := p.preconditions(); err != nil {
 		return err
 	}
+	if p.workers > 1 {
+		return p.doParallel()
+	}
 	for _, gotNode := range p.got.All() {
 		wantNode := p.want.Get(gotNode.ID())
 		// Preconditions check that wantNode is not nil.
@@ -52,6 +72,37 @@ func (p *planner) do() error {
 	return nil
 }
 
+// doParallel is the same as do() but plans up to p.workers Nodes at once.
+func (p *planner) doParallel() error {
+	gotNodes := p.got.All()
+	errs := make([]error, len(gotNodes))
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < p.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				gotNode := gotNodes[i]
+				errs[i] = p.planWantGraph(gotNode, p.want.Get(gotNode.ID()))
+			}
+		}()
+	}
+	for i := range gotNodes {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *planner) preconditions() error {
 	for _, node := range p.got.All() {
 		if p.want.Get(node.ID()) == nil {
@@ -81,10 +132,17 @@ func (p *planner) planWantGraph(gotNode, wantNode rnode.Node) error {
 	statePair := s{gotNode.State(), wantNode.State()}
 	switch statePair {
 	case s{rnode.NodeExists, rnode.NodeExists}:
+		if r, ok := wantNode.(rnode.RefResolver); ok {
+			r.ResolveRefs(p.got.Get)
+		}
 		action, err := wantNode.Diff(gotNode)
 		if err != nil {
 			return fmt.Errorf("localPlanner: %w", err)
 		}
+		if wantNode.ForceRecreate() && action.Operation != rnode.OpRecreate {
+			action.Operation = rnode.OpRecreate
+			action.Why = fmt.Sprintf("ForceRecreate is set; %s", action.Why)
+		}
 		wantNode.Plan().Set(*action)
 
 	case s{rnode.NodeExists, rnode.NodeDoesNotExist}:
@@ -26,6 +26,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/graphviz"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestLocalPlan(t *testing.T) {
@@ -292,3 +293,80 @@ func TestLocalPlan(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanWantGraphParallel(t *testing.T) {
+	const (
+		project  = "project-1"
+		numNodes = 500
+	)
+	makeID := func(i int) *cloud.ResourceID {
+		return fake.ID(project, meta.GlobalKey(fmt.Sprintf("fake-%d", i)))
+	}
+	newNode := func(i int, v string, state rnode.NodeState) rnode.Builder {
+		id := makeID(i)
+		nb := fake.NewBuilder(id)
+		mr := fake.NewMutableFake(project, id.Key)
+		mr.Access(func(x *fake.FakeResource) { x.Value = v })
+		r, _ := mr.Freeze()
+		nb.SetResource(r)
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(state)
+		return nb
+	}
+	// buildGraphs returns a got and want graph with a mix of nop, delete,
+	// create and update Nodes.
+	buildGraphs := func() (*rgraph.Graph, *rgraph.Graph) {
+		gotb := rgraph.NewBuilder()
+		wantb := rgraph.NewBuilder()
+		for i := 0; i < numNodes; i++ {
+			switch i % 4 {
+			case 0: // nop
+				gotb.Add(newNode(i, "", rnode.NodeExists))
+				wantb.Add(newNode(i, "", rnode.NodeExists))
+			case 1: // delete
+				gotb.Add(newNode(i, "", rnode.NodeExists))
+				wantb.Add(newNode(i, "", rnode.NodeDoesNotExist))
+			case 2: // create
+				gotb.Add(newNode(i, "", rnode.NodeDoesNotExist))
+				wantb.Add(newNode(i, "", rnode.NodeExists))
+			case 3: // update
+				gotb.Add(newNode(i, "abc", rnode.NodeExists))
+				wantb.Add(newNode(i, "def", rnode.NodeExists))
+			}
+		}
+		got, err := gotb.Build()
+		if err != nil {
+			t.Fatalf("gotb.Build() = %v, want nil", err)
+		}
+		want, err := wantb.Build()
+		if err != nil {
+			t.Fatalf("wantb.Build() = %v, want nil", err)
+		}
+		return got, want
+	}
+	planOps := func(g *rgraph.Graph) map[string]rnode.Operation {
+		ret := map[string]rnode.Operation{}
+		for _, n := range g.All() {
+			ret[n.ID().String()] = n.Plan().Op()
+		}
+		return ret
+	}
+
+	got, want := buildGraphs()
+	if err := PlanWantGraph(got, want); err != nil {
+		t.Fatalf("PlanWantGraph() = %v, want nil", err)
+	}
+	serialPlan := planOps(want)
+
+	// Run multiple times to give the race detector a chance to catch
+	// unsynchronized access and to check the result is deterministic.
+	for run := 0; run < 5; run++ {
+		got, want := buildGraphs()
+		if err := PlanWantGraph(got, want, ParallelOption(8)); err != nil {
+			t.Fatalf("PlanWantGraph(_, _, ParallelOption(8)) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(planOps(want), serialPlan); diff != "" {
+			t.Errorf("run %d: parallel plan: diff -got,+want: %s", run, diff)
+		}
+	}
+}
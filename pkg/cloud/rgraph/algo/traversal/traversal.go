@@ -110,3 +110,41 @@ func TransitiveInRefs(g *rgraph.Graph, n rnode.Node) ([]rnode.Node, error) {
 
 	return ret, nil
 }
+
+// TransitiveOutRefs returns the set of Nodes (inclusive of the starting node)
+// that the node points to. For example, for graph A => B => C; D => B, this
+// will return [B, C] for B.
+func TransitiveOutRefs(g *rgraph.Graph, n rnode.Node) ([]rnode.Node, error) {
+	if g.Get(n.ID()) == nil {
+		return nil, fmt.Errorf("starting node %s not in graph", n.ID())
+	}
+
+	var work algo.Queue[rnode.Node]
+	work.Add(n)
+
+	done := map[cloud.ResourceMapKey]rnode.Node{}
+
+	for !work.Empty() {
+		cur := work.Pop()
+		done[cur.ID().MapKey()] = cur
+
+		refs := cur.OutRefs()
+		for _, ref := range refs {
+			if _, ok := done[ref.To.MapKey()]; ok {
+				continue
+			}
+			to := g.Get(ref.To)
+			if to == nil {
+				return nil, fmt.Errorf("invalid graph: to node %v not in graph", ref.To)
+			}
+			work.Add(to)
+		}
+	}
+
+	var ret []rnode.Node
+	for _, node := range done {
+		ret = append(ret, node)
+	}
+
+	return ret, nil
+}
@@ -373,3 +373,84 @@ func TestTransitiveRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestTransitiveOutRefs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		start   string
+		graph   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty graph",
+			wantErr: true,
+		},
+		{
+			name:  "one node",
+			graph: "a",
+			start: "a",
+			want:  []string{"a"},
+		},
+		{
+			name:  "no outrefs",
+			graph: "a->b",
+			start: "b",
+			want:  []string{"b"},
+		},
+		{
+			name:  "many hops",
+			graph: "a->b->c->d",
+			start: "b",
+			want:  []string{"b", "c", "d"},
+		},
+		{
+			name:  "fan out",
+			graph: "a->b->c; b->d; e->b",
+			start: "b",
+			want:  []string{"b", "c", "d"},
+		},
+		{
+			name:  "cycle 3",
+			graph: "a->b; b->c; c->a",
+			start: "b",
+			want:  []string{"a", "b", "c"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := parseGraph(t, tc.graph)
+
+			var startNode rnode.Node
+			if tc.start == "" {
+				// Create sentinel node.
+				startID := fake.ID(project, meta.GlobalKey("sentinel"))
+				nb := fake.NewBuilder(startID)
+				var err error
+				startNode, err = nb.Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				startID := fake.ID(project, meta.GlobalKey(tc.start))
+				startNode = g.Get(startID)
+			}
+			nodes, err := TransitiveOutRefs(g, startNode)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("TransitiveOutRefs() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+
+			got := map[string]struct{}{}
+			for _, n := range nodes {
+				got[n.ID().String()] = struct{}{}
+			}
+			want := map[string]struct{}{}
+			for _, w := range tc.want {
+				want[fake.ID(project, meta.GlobalKey(w)).String()] = struct{}{}
+			}
+
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Fatalf("Diff() -got+want: %s", diff)
+			}
+		})
+	}
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package rgraph
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 )
 
@@ -51,17 +55,49 @@ func (g *Builder) All() []rnode.Builder {
 func (g *Builder) Add(node rnode.Builder) { g.nodes[node.ID().MapKey()] = node }
 
 // Get the node named by id from the graph. Returns nil if the node does not
-// exist.
+// exist. Nodes are matched on the full ResourceID (project, API group,
+// resource and key, including the scope), so resources with the same name in
+// different scopes are distinct.
 func (g *Builder) Get(id *cloud.ResourceID) rnode.Builder { return g.nodes[id.MapKey()] }
 
+// BuildOption for Builder.Build.
+type BuildOption func(*buildConfig)
+
+type buildConfig struct {
+	// locationsCtx and locationsCloud are set by ValidateLocationsOption.
+	locationsCtx   context.Context
+	locationsCloud cloud.Cloud
+}
+
+// ValidateLocationsOption checks that the region or zone in the key of
+// each node is one of the locations available to the project, as returned
+// by the Regions and Zones List APIs. This results in additional calls to
+// the Cloud (one per project and location type) so is not done by default.
+func ValidateLocationsOption(ctx context.Context, cl cloud.Cloud) BuildOption {
+	return func(c *buildConfig) {
+		c.locationsCtx = ctx
+		c.locationsCloud = cl
+	}
+}
+
 // Build a Graph for planning from the nodes.
-func (g *Builder) Build() (*Graph, error) {
+func (g *Builder) Build(opts ...BuildOption) (*Graph, error) {
+	var config buildConfig
+	for _, o := range opts {
+		o(&config)
+	}
+
 	if err := g.computeInRefs(); err != nil {
 		return nil, err
 	}
 	if err := g.validate(); err != nil {
 		return nil, err
 	}
+	if config.locationsCloud != nil {
+		if err := g.validateLocations(config.locationsCtx, config.locationsCloud); err != nil {
+			return nil, err
+		}
+	}
 
 	newGraph := newGraph()
 	for _, nb := range g.nodes {
@@ -86,8 +122,11 @@ func (g *Builder) MustBuild() *Graph {
 }
 
 // computeInRefs calculates the inbound references to a resource from all of the
-// nodes in the graph.
+// nodes in the graph. All references must be to nodes in the graph. Resources
+// that are not managed should be added as nodes with OwnershipExternal; these
+// will be fetched from the Cloud during planning.
 func (g *Builder) computeInRefs() error {
+	var unresolved []string
 	for _, fromNode := range g.nodes {
 		refs, err := fromNode.OutRefs()
 		if err != nil {
@@ -96,11 +135,17 @@ func (g *Builder) computeInRefs() error {
 		for _, ref := range refs {
 			toNode, ok := g.nodes[ref.To.MapKey()]
 			if !ok {
-				return fmt.Errorf("%s: missing outRef: %s points to %s which isn't in the graph", builderErrPrefix, fromNode.ID(), ref.To)
+				unresolved = append(unresolved, fmt.Sprintf("%s -> %s", fromNode.ID(), ref.To))
+				continue
 			}
 			toNode.AddInRef(ref)
 		}
 	}
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return fmt.Errorf("%s: missing outRefs to resources that aren't in the graph (add them with OwnershipExternal if they are not managed): %s",
+			builderErrPrefix, strings.Join(unresolved, ", "))
+	}
 	return nil
 }
 
@@ -117,21 +162,55 @@ func (g *Builder) validate() error {
 			return fmt.Errorf("%s: node and resource id mismatch (node=%v, id=%v)", builderErrPrefix, n.ID(), resource.ResourceID())
 		}
 	}
-	// All resources have their dependencies in the graph if they are OwnershipManaged.
+	// OutRefs are checked by computeInRefs().
+
+	return nil
+}
+
+// validateLocations checks the region and zone of the node keys against the
+// locations listed for each project.
+func (g *Builder) validateLocations(ctx context.Context, cl cloud.Cloud) error {
+	regions := map[string]map[string]bool{}
+	zones := map[string]map[string]bool{}
+
+	var invalid []string
 	for _, n := range g.nodes {
-		if n.Ownership() != rnode.OwnershipManaged {
-			continue
-		}
-		deps, err := n.OutRefs()
-		if err != nil {
-			return err
-		}
-		for _, d := range deps {
-			if _, ok := g.nodes[d.To.MapKey()]; !ok {
-				return fmt.Errorf("%s: missing outRef: %v points to %v which isn't in the graph", builderErrPrefix, n.ID(), d.To)
+		id := n.ID()
+		switch {
+		case id.Key.Region != "":
+			if _, ok := regions[id.ProjectID]; !ok {
+				objs, err := cl.Regions().List(ctx, filter.None, cloud.ForceProjectID(id.ProjectID))
+				if err != nil {
+					return fmt.Errorf("%s: validateLocations: %w", builderErrPrefix, err)
+				}
+				regions[id.ProjectID] = map[string]bool{}
+				for _, r := range objs {
+					regions[id.ProjectID][r.Name] = true
+				}
+			}
+			if !regions[id.ProjectID][id.Key.Region] {
+				invalid = append(invalid, fmt.Sprintf("%s (region %q)", id, id.Key.Region))
+			}
+		case id.Key.Zone != "":
+			if _, ok := zones[id.ProjectID]; !ok {
+				objs, err := cl.Zones().List(ctx, filter.None, cloud.ForceProjectID(id.ProjectID))
+				if err != nil {
+					return fmt.Errorf("%s: validateLocations: %w", builderErrPrefix, err)
+				}
+				zones[id.ProjectID] = map[string]bool{}
+				for _, z := range objs {
+					zones[id.ProjectID][z.Name] = true
+				}
+			}
+			if !zones[id.ProjectID][id.Key.Zone] {
+				invalid = append(invalid, fmt.Sprintf("%s (zone %q)", id, id.Key.Zone))
 			}
 		}
 	}
-
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("%s: nodes with locations that are not available in the project: %s",
+			builderErrPrefix, strings.Join(invalid, ", "))
+	}
 	return nil
 }
@@ -19,6 +19,7 @@ package exec
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 )
@@ -47,12 +48,62 @@ type Action interface {
 	Metadata() *ActionMetadata
 }
 
+// SyncRecorder is optionally implemented by Actions. The Executor calls
+// RecordSync() with the completion time after the Action has been Run
+// successfully. This is not called for DryRun.
+type SyncRecorder interface {
+	RecordSync(t time.Time)
+}
+
+// recordSync calls RecordSync() if the Action implements SyncRecorder.
+func recordSync(a Action, t time.Time) {
+	if sr, ok := a.(SyncRecorder); ok {
+		sr.RecordSync(t)
+	}
+}
+
+// Refresher is optionally implemented by Actions. If RefreshAfterApplyOption
+// is set, the Executor calls Refresh() after the Action has been Run
+// successfully and before its Events are signalled. Refresh() reads the
+// resource back from the Cloud so that Actions that depend on this one see
+// the current state (e.g. an updated fingerprint). This is not called for
+// DryRun.
+type Refresher interface {
+	Refresh(ctx context.Context, gcp cloud.Cloud) error
+}
+
+// Signaler is optionally implemented by Actions whose DryRun() has side
+// effects (e.g. recording the time for tracing). Signals() returns the Events
+// that the Action signals when it is run, without any side effects.
+type Signaler interface {
+	Signals() EventList
+}
+
+// signals returns the Events the Action signals when it is run, using
+// Signals() if the Action implements Signaler and DryRun() otherwise.
+func signals(a Action) EventList {
+	if s, ok := a.(Signaler); ok {
+		return s.Signals()
+	}
+	return a.DryRun()
+}
+
+// actionName returns the Name in the Metadata of the Action or a.String() if
+// the Action has no Metadata.
+func actionName(a Action) string {
+	if m := a.Metadata(); m != nil {
+		return m.Name
+	}
+	return a.String()
+}
+
 type ActionType string
 
 var (
 	ActionTypeCreate ActionType = "Create"
 	ActionTypeDelete ActionType = "Delete"
 	ActionTypeUpdate ActionType = "Update"
+	ActionTypePatch  ActionType = "Patch"
 	ActionTypeMeta   ActionType = "Meta"
 	ActionTypeCustom ActionType = "Custom"
 )
@@ -65,6 +116,10 @@ type ActionMetadata struct {
 	Type ActionType
 	// Summary is a human readable description of this action.
 	Summary string
+	// ResourceID of the resource this action operates on. This is nil for
+	// actions that are not associated with a single resource (e.g.
+	// EventAction).
+	ResourceID *cloud.ResourceID
 }
 
 // ActionBase is a helper that implements some standard behaviors of common
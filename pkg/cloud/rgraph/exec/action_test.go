@@ -33,6 +33,8 @@ type testAction struct {
 	events  EventList
 	err     error
 	runHook func(context.Context) error
+	// id is returned as the ResourceID in the Metadata.
+	id *cloud.ResourceID
 }
 
 func (a *testAction) String() string {
@@ -54,9 +56,10 @@ func (a *testAction) Run(ctx context.Context, _ cloud.Cloud) (EventList, error)
 
 func (a *testAction) Metadata() *ActionMetadata {
 	return &ActionMetadata{
-		Name:    fmt.Sprintf("%s(%v)", a.name, a.events),
-		Type:    ActionTypeCustom,
-		Summary: "Action used for testing",
+		Name:       fmt.Sprintf("%s(%v)", a.name, a.events),
+		Type:       ActionTypeCustom,
+		Summary:    "Action used for testing",
+		ResourceID: a.id,
 	}
 }
 
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ErrActionTimeout is returned (wrapped) when an Action does not finish within
+// the timeout configured for its ActionType with ActionTimeoutOption.
+var ErrActionTimeout = errors.New("action timed out")
+
+// runWithTimeout runs the Action with a context derived from ctx with the
+// timeout for the ActionType in c. The Action is run with ctx if there is no
+// timeout for the type or the Action has no Metadata.
+func (c *ExecutorConfig) runWithTimeout(ctx context.Context, gcp cloud.Cloud, a Action) (EventList, error) {
+	md := a.Metadata()
+	if md == nil {
+		return a.Run(ctx, gcp)
+	}
+	actionType := md.Type
+	timeout, ok := c.ActionTimeouts[actionType]
+	if !ok || timeout == 0 {
+		return a.Run(ctx, gcp)
+	}
+
+	actionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	events, err := a.Run(actionCtx, gcp)
+	// Only report a timeout if it was due to the timeout for the Action and
+	// not from the parent context.
+	if err != nil && errors.Is(actionCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return events, fmt.Errorf("%w (%s Action after %v): %w", ErrActionTimeout, actionType, timeout, err)
+	}
+	return events, err
+}
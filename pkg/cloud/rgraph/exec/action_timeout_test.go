@@ -0,0 +1,159 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestActionTimeoutOption(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		newExecutor func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newExecutor: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			newExecutor: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj1"})
+			// The Insert is slow and only returns when the context is done.
+			mock.MockGlobalAddresses.InsertHook = func(ctx context.Context, _ *meta.Key, _ *compute.Address, _ *cloud.MockGlobalAddresses, _ ...cloud.Option) (bool, error) {
+				select {
+				case <-ctx.Done():
+					return true, ctx.Err()
+				case <-time.After(time.Minute):
+					return true, nil
+				}
+			}
+
+			slow := &testAction{
+				name:   "slow",
+				events: EventList{StringEvent("slow")},
+				runHook: func(ctx context.Context) error {
+					return mock.GlobalAddresses().Insert(ctx, meta.GlobalKey("addr"), &compute.Address{Name: "addr"})
+				},
+			}
+			ex, err := tc.newExecutor(mock, []Action{slow},
+				ActionTimeoutOption(map[ActionType]time.Duration{ActionTypeCustom: 10 * time.Millisecond}),
+				ErrorStrategyOption(ContinueOnError))
+			if err != nil {
+				t.Fatalf("newExecutor() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("len(result.Errors) = %d, want 1 (%v)", len(result.Errors), result.Errors)
+			}
+			if !result.Errors[0].TimedOut() {
+				t.Errorf("result.Errors[0].TimedOut() = false, want true (err = %v)", result.Errors[0].Err)
+			}
+			if !errors.Is(result.Errors[0].Err, context.DeadlineExceeded) {
+				t.Errorf("result.Errors[0].Err = %v, want wrapped %v", result.Errors[0].Err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
+
+func TestActionTimeoutOptionOtherTypes(t *testing.T) {
+	// Errors from Actions without a timeout for their type are not
+	// reported as timeouts.
+	a := &testAction{name: "a", err: errors.New("injected")}
+	ex, err := NewSerialExecutor(nil, []Action{a},
+		ActionTimeoutOption(map[ActionType]time.Duration{ActionTypeCreate: time.Nanosecond}))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, _ := ex.Run(context.Background())
+	if len(result.Errors) != 1 || result.Errors[0].TimedOut() {
+		t.Errorf("result.Errors = %v, want 1 error that is not a timeout", result.Errors)
+	}
+}
+
+func TestActionTimeoutOptionInvalid(t *testing.T) {
+	_, err := NewSerialExecutor(nil, nil, ActionTimeoutOption(map[ActionType]time.Duration{ActionTypeCreate: -time.Second}))
+	if err == nil {
+		t.Errorf("NewSerialExecutor() = nil, want error for negative timeout")
+	}
+}
+
+// noMetadataAction is an Action without Metadata.
+type noMetadataAction struct{ testAction }
+
+func (*noMetadataAction) Metadata() *ActionMetadata { return nil }
+
+func TestActionTimeoutNoMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		newExecutor func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newExecutor: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			newExecutor: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Actions without Metadata are run without a timeout.
+			a := &noMetadataAction{testAction{
+				name:   "a",
+				events: EventList{StringEvent("a")},
+				runHook: func(ctx context.Context) error {
+					time.Sleep(10 * time.Millisecond)
+					return ctx.Err()
+				},
+			}}
+			ex, err := tc.newExecutor(nil, []Action{a},
+				ActionTimeoutOption(map[ActionType]time.Duration{ActionTypeCustom: time.Nanosecond}))
+			if err != nil {
+				t.Fatalf("newExecutor() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if len(result.Completed) != 1 {
+				t.Errorf("result.Completed = %v, want 1 Action", result.Completed)
+			}
+		})
+	}
+}
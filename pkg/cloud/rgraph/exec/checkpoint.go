@@ -0,0 +1,91 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import "sync"
+
+// CheckpointFunc is called by the Executor after each Action completes
+// successfully. completed is the set of names (ActionMetadata.Name) of all of
+// the Actions completed so far, including the Actions that were skipped
+// because they were given to ResumeFromOption. completed is a copy and may be
+// retained by the callee.
+type CheckpointFunc func(completed map[string]bool)
+
+// CheckpointOption sets a callback that is given a checkpoint of the
+// execution. The checkpoint can be saved and given to ResumeFromOption to
+// continue the execution of the same Actions after a crash. The callback is
+// called serially, even for the parallel executor, so it should not block.
+func CheckpointOption(f CheckpointFunc) Option {
+	return func(c *ExecutorConfig) { c.Checkpoint = f }
+}
+
+// ResumeFromOption skips the Actions named in completed, e.g. the last
+// checkpoint from a previous execution (see CheckpointOption). Skipped Actions
+// are not run but their events (Action.DryRun) are signalled so that the
+// Actions that depend on them can run. Skipped Actions are returned in
+// Result.Skipped.
+func ResumeFromOption(completed map[string]bool) Option {
+	return func(c *ExecutorConfig) { c.ResumeFrom = completed }
+}
+
+// checkpoint tracks the set of completed Actions and invokes the
+// CheckpointFunc.
+type checkpoint struct {
+	// resumeFrom is not modified after construction.
+	resumeFrom map[string]bool
+
+	// lock serializes calls to f and guards completed.
+	lock      sync.Mutex
+	f         CheckpointFunc
+	completed map[string]bool
+}
+
+func newCheckpoint(f CheckpointFunc, resumeFrom map[string]bool) *checkpoint {
+	ret := &checkpoint{f: f, resumeFrom: map[string]bool{}, completed: map[string]bool{}}
+	for name, done := range resumeFrom {
+		if done {
+			ret.resumeFrom[name] = true
+			ret.completed[name] = true
+		}
+	}
+	return ret
+}
+
+// skip returns true if the Action was completed in a previous execution.
+// Actions without Metadata are never skipped.
+func (c *checkpoint) skip(a Action) bool {
+	if len(c.resumeFrom) == 0 || a.Metadata() == nil {
+		return false
+	}
+	return c.resumeFrom[a.Metadata().Name]
+}
+
+// finish is called after the Action has completed successfully. Actions
+// without Metadata are not recorded in the checkpoint.
+func (c *checkpoint) finish(a Action) {
+	if c.f == nil || a.Metadata() == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.completed[a.Metadata().Name] = true
+	completed := make(map[string]bool, len(c.completed))
+	for name := range c.completed {
+		completed[name] = true
+	}
+	c.f(completed)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckpointResumeFrom(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		newEx func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			const graphStr = "A -> B -> C -> D"
+
+			var (
+				lock sync.Mutex
+				ran  []string
+			)
+			// newActions returns the Actions in graphStr, recording the Actions
+			// that are run. Actions named in fail return an error.
+			newActions := func(fail string) []Action {
+				actions := actionsFromGraphStr(graphStr)
+				for _, a := range actions {
+					ta := a.(*testAction)
+					name := ta.name
+					ta.runHook = func(context.Context) error {
+						lock.Lock()
+						defer lock.Unlock()
+						ran = append(ran, name)
+						if name == fail {
+							return errors.New("crash")
+						}
+						return nil
+					}
+				}
+				return actions
+			}
+			names := func(actions []Action) []string {
+				var ret []string
+				for _, a := range actions {
+					ret = append(ret, a.(*testAction).name)
+				}
+				sort.Strings(ret)
+				return ret
+			}
+
+			// The first execution stops at C.
+			var last map[string]bool
+			checkpoints := 0
+			ex, err := tc.newEx(nil, newActions("C"), CheckpointOption(func(completed map[string]bool) {
+				checkpoints++
+				last = completed
+			}))
+			if err != nil {
+				t.Fatalf("newEx() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background()); err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+			if checkpoints != 2 || len(last) != 2 {
+				t.Fatalf("got %d checkpoints, last = %v; want 2 checkpoints with 2 Actions", checkpoints, last)
+			}
+
+			// Resume from the checkpoint; only C and D are run.
+			ran = nil
+			ex, err = tc.newEx(nil, newActions(""), ResumeFromOption(last), CheckpointOption(func(completed map[string]bool) {
+				last = completed
+			}))
+			if err != nil {
+				t.Fatalf("newEx() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(ran, []string{"C", "D"}); diff != "" {
+				t.Errorf("Actions run: -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(names(result.Skipped), []string{"A", "B"}); diff != "" {
+				t.Errorf("result.Skipped: -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(names(result.Completed), []string{"C", "D"}); diff != "" {
+				t.Errorf("result.Completed: -got,+want: %s", diff)
+			}
+			if len(result.Pending) != 0 {
+				t.Errorf("result.Pending = %v, want none", result.Pending)
+			}
+			if len(last) != 4 {
+				t.Errorf("last checkpoint = %v, want all 4 Actions", last)
+			}
+		})
+	}
+}
+
+func TestCheckpointNoMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		newEx func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		for _, checkpointing := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/checkpointing=%t", tc.name, checkpointing), func(t *testing.T) {
+				// Actions without Metadata are run and never checkpointed.
+				var (
+					checkpoints int
+					opts        []Option
+				)
+				if checkpointing {
+					opts = append(opts,
+						CheckpointOption(func(map[string]bool) { checkpoints++ }),
+						ResumeFromOption(map[string]bool{"A": true}))
+				}
+				a := &noMetadataAction{testAction{name: "A", events: EventList{StringEvent("A")}}}
+				b := &noMetadataAction{testAction{name: "B", events: EventList{StringEvent("B")}}}
+				b.Want = EventList{StringEvent("A")}
+
+				ex, err := tc.newEx(nil, []Action{a, b}, opts...)
+				if err != nil {
+					t.Fatalf("newEx() = %v, want nil", err)
+				}
+				result, err := ex.Run(context.Background())
+				if err != nil {
+					t.Fatalf("Run() = %v, want nil", err)
+				}
+				if len(result.Completed) != 2 || len(result.Skipped) != 0 {
+					t.Errorf("result = %+v, want 2 Completed and no Skipped", result)
+				}
+				if checkpoints != 0 {
+					t.Errorf("got %d checkpoints, want 0", checkpoints)
+				}
+			})
+		}
+	}
+}
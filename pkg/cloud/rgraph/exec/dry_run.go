@@ -0,0 +1,77 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// DryRunCall is an API call that an Action would have made if it was not run
+// in dry run mode.
+type DryRunCall struct {
+	// Method called, e.g. "Insert".
+	Method string
+	// ResourceID the method operates on.
+	ResourceID *cloud.ResourceID
+	// Body is the JSON serialized request body. Body is empty for methods
+	// that do not send one (e.g. "Delete").
+	Body string
+}
+
+func (c DryRunCall) String() string {
+	return fmt.Sprintf("%s(%v)", c.Method, c.ResourceID)
+}
+
+// DryRunCaller is optionally implemented by Actions. The Executor will record
+// the DryRunCalls() of the Action in Result.DryRunCalls when running with
+// DryRunOption(true).
+type DryRunCaller interface {
+	// DryRunCalls returns the API calls the Action would make when Run.
+	DryRunCalls() ([]DryRunCall, error)
+}
+
+// dryRunCalls returns the calls for the Action or nil if the Action does not
+// implement DryRunCaller.
+func dryRunCalls(a Action) ([]DryRunCall, error) {
+	dc, ok := a.(DryRunCaller)
+	if !ok {
+		return nil, nil
+	}
+	return dc.DryRunCalls()
+}
+
+// Requester is optionally implemented by Actions that send a single API
+// request. Request returns the call that Run will make so that it can be
+// recorded (e.g. in an audit log) before the Action is run.
+type Requester interface {
+	// Request returns the method and the JSON serialized request body that
+	// Run will send.
+	Request() (DryRunCall, error)
+}
+
+// ActionRequest returns the request that the Action will send when Run. ok is
+// false if the Action does not implement Requester.
+func ActionRequest(a Action) (call DryRunCall, ok bool, err error) {
+	r, ok := a.(Requester)
+	if !ok {
+		return DryRunCall{}, false, nil
+	}
+	call, err = r.Request()
+	return call, true, err
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+// dryRunCallAction is a testAction that implements DryRunCaller.
+type dryRunCallAction struct {
+	testAction
+}
+
+func (a *dryRunCallAction) DryRunCalls() ([]DryRunCall, error) {
+	return []DryRunCall{{Method: "Insert", ResourceID: a.id}}, nil
+}
+
+func TestDryRunCalls(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			new: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			new: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var runs atomic.Int32
+			runHook := func(context.Context) error {
+				runs.Add(1)
+				return errors.New("action must not be run in dry run")
+			}
+			newAction := func(name string, want ...Event) *dryRunCallAction {
+				a := &dryRunCallAction{testAction{
+					name:    name,
+					events:  EventList{StringEvent(name)},
+					runHook: runHook,
+					id:      &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey(name)},
+				}}
+				a.Want = want
+				return a
+			}
+			actions := []Action{
+				newAction("A"),
+				newAction("B", StringEvent("A")),
+				newAction("C", StringEvent("A")),
+			}
+
+			ex, err := tc.new(nil, actions, DryRunOption(true))
+			if err != nil {
+				t.Fatalf("new executor = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if n := runs.Load(); n != 0 {
+				t.Errorf("Actions run %d times, want 0", n)
+			}
+
+			var got []string
+			for _, c := range result.DryRunCalls {
+				got = append(got, c.String())
+			}
+			sort.Strings(got)
+			want := []string{
+				"Insert(addresses:proj/A)",
+				"Insert(addresses:proj/B)",
+				"Insert(addresses:proj/C)",
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("DryRunCalls: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
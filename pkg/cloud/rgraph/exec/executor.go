@@ -18,8 +18,11 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 )
 
 type Result struct {
@@ -30,6 +33,15 @@ type Result struct {
 	// Pending are Actions that could not be executed due to missing
 	// preconditions.
 	Pending []Action
+	// DryRunCalls are the API calls that would have been made by the
+	// Completed Actions. This is only populated in dry run mode.
+	DryRunCalls []DryRunCall
+	// RetryBudget is the budget shared by the retriable Actions in the
+	// execution. This is nil if RetryBudgetOption was not set.
+	RetryBudget *RetryBudget
+	// Skipped are Actions that were not run because they were completed in
+	// a previous execution (see ResumeFromOption).
+	Skipped []Action
 }
 
 func (r *Result) DeepCopy() *Result {
@@ -41,14 +53,56 @@ func (r *Result) DeepCopy() *Result {
 	copy(resultCopy.Completed, r.Completed)
 	copy(resultCopy.Errors, r.Errors)
 	copy(resultCopy.Pending, r.Pending)
+	if r.DryRunCalls != nil {
+		resultCopy.DryRunCalls = make([]DryRunCall, len(r.DryRunCalls))
+		copy(resultCopy.DryRunCalls, r.DryRunCalls)
+	}
+	resultCopy.RetryBudget = r.RetryBudget
+	if r.Skipped != nil {
+		resultCopy.Skipped = make([]Action, len(r.Skipped))
+		copy(resultCopy.Skipped, r.Skipped)
+	}
 	return &resultCopy
 }
 
+// ErrorsByResource returns the Errors grouped by the resource of the failed
+// Action (see ActionWithErr.ResourceID). Errors for Actions that are not
+// associated with a resource are not included.
+func (r *Result) ErrorsByResource() map[cloud.ResourceMapKey][]ActionWithErr {
+	ret := map[cloud.ResourceMapKey][]ActionWithErr{}
+	for _, ae := range r.Errors {
+		if ae.ResourceID == nil {
+			continue
+		}
+		key := ae.ResourceID.MapKey()
+		ret[key] = append(ret[key], ae)
+	}
+	return ret
+}
+
+// ActionWithErr is an Action that failed with an error.
 type ActionWithErr struct {
 	Action Action
 	Err    error
+	// Metadata of the Action at the time of the error.
+	Metadata *ActionMetadata
+	// ResourceID the Action was operating on. This is nil if the Action is
+	// not associated with a resource.
+	ResourceID *cloud.ResourceID
 }
 
+func newActionWithErr(a Action, err error) ActionWithErr {
+	ret := ActionWithErr{Action: a, Err: err, Metadata: a.Metadata()}
+	if ret.Metadata != nil {
+		ret.ResourceID = ret.Metadata.ResourceID
+	}
+	return ret
+}
+
+// TimedOut is true if the Action failed because it exceeded the timeout for
+// its ActionType (see ActionTimeoutOption).
+func (a ActionWithErr) TimedOut() bool { return errors.Is(a.Err, ErrActionTimeout) }
+
 // Executor performs the operations given by a list of Actions.
 type Executor interface {
 	// Run the actions. Returns non-nil if there was an error in execution of
@@ -81,6 +135,36 @@ func WaitForOrphansTimeoutOption(t time.Duration) Option {
 	return func(c *ExecutorConfig) { c.WaitForOrphansTimeout = t }
 }
 
+// RetryBudgetOption limits the total number of retries made by retriable
+// Actions (see NewRetriableAction) across the entire execution to n. Once the
+// budget is exhausted, Actions fail with ErrRetryBudgetExhausted instead of
+// retrying; n = 0 disables retries. The remaining budget is available in
+// Result.RetryBudget. Retries are not limited if this option is not set.
+func RetryBudgetOption(n int) Option {
+	return func(c *ExecutorConfig) { c.RetryBudget = &n }
+}
+
+// ActionTimeoutOption sets a timeout for running each Action by ActionType.
+// Actions that exceed the timeout fail with ErrActionTimeout (see
+// ActionWithErr.TimedOut). ActionTypes without an entry are not limited
+// beyond the TimeoutOption for the entire execution.
+func ActionTimeoutOption(timeouts map[ActionType]time.Duration) Option {
+	return func(c *ExecutorConfig) { c.ActionTimeouts = timeouts }
+}
+
+// RefreshAfterApplyOption will re-read the resource from the Cloud after each
+// successful Action that implements Refresher.
+func RefreshAfterApplyOption(refresh bool) Option {
+	return func(c *ExecutorConfig) { c.RefreshAfterApply = refresh }
+}
+
+// RunIDOption sets an id for the execution. The id is added to the context
+// passed to Action.Run (see cloud.WithRunID) so that logs and mock hooks can
+// be correlated with the execution.
+func RunIDOption(id string) Option {
+	return func(c *ExecutorConfig) { c.RunID = id }
+}
+
 // ErrorStrategy to use when an Action returns an error.
 type ErrorStrategy string
 
@@ -110,10 +194,29 @@ func defaultExecutorConfig() *ExecutorConfig {
 // ExecutorConfig for the executor implementation.
 type ExecutorConfig struct {
 	Tracer                Tracer
+	Progress              ProgressFunc
 	DryRun                bool
 	ErrorStrategy         ErrorStrategy
 	Timeout               time.Duration
 	WaitForOrphansTimeout time.Duration
+	// RetryBudget is the total number of retries allowed. Nil means no
+	// limit.
+	RetryBudget *int
+	// ActionTimeouts is the timeout for running a single Action of the
+	// given type.
+	ActionTimeouts map[ActionType]time.Duration
+	// RefreshAfterApply calls Refresher.Refresh() after each successful
+	// Action.
+	RefreshAfterApply bool
+	// RunID is added to the context of the Actions. Empty means no run ID.
+	RunID string
+	// Checkpoint is called with the set of completed Actions.
+	Checkpoint CheckpointFunc
+	// ResumeFrom is the set of Actions completed by a previous execution.
+	ResumeFrom map[string]bool
+	// Metrics receives observations of the execution. Nil means no
+	// metrics.
+	Metrics MetricsCollector
 }
 
 func (c *ExecutorConfig) validate() error {
@@ -122,5 +225,13 @@ func (c *ExecutorConfig) validate() error {
 	default:
 		return fmt.Errorf("invalid ErrorStrategy: %q", c.ErrorStrategy)
 	}
+	if c.RetryBudget != nil && *c.RetryBudget < 0 {
+		return fmt.Errorf("invalid RetryBudget: %d", *c.RetryBudget)
+	}
+	for t, d := range c.ActionTimeouts {
+		if d < 0 {
+			return fmt.Errorf("invalid ActionTimeout for %s: %v", t, d)
+		}
+	}
 	return nil
 }
@@ -54,6 +54,11 @@ func NewParallelExecutor(c cloud.Cloud, pending []Action, opts ...Option) (*para
 	if err := ret.config.validate(); err != nil {
 		return nil, err
 	}
+	ret.progress = newProgress(ret.config.Progress, len(pending))
+	ret.checkpoint = newCheckpoint(ret.config.Checkpoint, ret.config.ResumeFrom)
+	if ret.config.RetryBudget != nil {
+		ret.result.RetryBudget = NewRetryBudget(*ret.config.RetryBudget)
+	}
 	return ret, nil
 }
 
@@ -65,8 +70,10 @@ type parallelExecutor struct {
 	lock   sync.Mutex
 	result *Result
 
-	pq   *algo.ParallelQueue[Action]
-	done chan *TraceEntry
+	pq         *algo.ParallelQueue[Action]
+	done       chan *TraceEntry
+	progress   *progress
+	checkpoint *checkpoint
 }
 
 // parallelExecutor implements Executor.
@@ -81,6 +88,15 @@ var _ Executor = (*parallelExecutor)(nil)
 // To handle timeout properly use TimeoutOption for canceling running actions
 // and WaitForOrphansTimeoutOption for canceling post error cleanup.
 func (ex *parallelExecutor) Run(ctx context.Context) (*Result, error) {
+	if ex.result.RetryBudget != nil {
+		ctx = withRetryBudget(ctx, ex.result.RetryBudget)
+	}
+	if ex.config.RunID != "" {
+		ctx = cloud.WithRunID(ctx, ex.config.RunID)
+	}
+	if ex.config.Metrics != nil {
+		ctx = withMetrics(ctx, ex.config.Metrics)
+	}
 	ex.queueRunnableActions()
 
 	queueErr := ex.runActionQueue(ctx)
@@ -132,11 +148,29 @@ func (ex *parallelExecutor) runAction(ctx context.Context, a Action) error {
 		Start:  time.Now(),
 	}
 	klog.V(4).Infof("Run action %s", a)
-	events, runErr := a.Run(ctx, ex.cloud)
+	ex.progress.start(a)
+	skipped := ex.checkpoint.skip(a)
+	var (
+		events EventList
+		runErr error
+	)
+	switch {
+	case skipped:
+		klog.V(4).Infof("Skip action %s, completed in a previous execution", a)
+		events = a.DryRun()
+	case ex.config.DryRun:
+		events, runErr = ex.dryRun(a)
+	default:
+		events, runErr = ex.config.runAndRefresh(ctx, ex.cloud, a)
+	}
 	te.End = time.Now()
+	if !skipped && !ex.config.DryRun {
+		ex.config.observeAction(a, te.End.Sub(te.Start), runErr)
+	}
+	ex.progress.finish(a)
 	klog.V(4).Infof("Finish action %s, err: %v", a, runErr)
 
-	ex.addActionResult(a, runErr)
+	ex.addActionResult(a, skipped, runErr)
 
 	if runErr != nil {
 		klog.V(2).Infof("Got error  %v, from action %s error_strategy: %s", runErr, a, ex.config.ErrorStrategy)
@@ -148,6 +182,10 @@ func (ex *parallelExecutor) runAction(ctx context.Context, a Action) error {
 			return fmt.Errorf("parallelExecutor: StopOnError due to Action %s: %w", a, runErr)
 		}
 	} else {
+		if !skipped && !ex.config.DryRun {
+			recordSync(a, te.End)
+			ex.checkpoint.finish(a)
+		}
 		// notify parents only when action finished with success
 		te.Signaled = ex.signal(events)
 	}
@@ -161,6 +199,19 @@ func (ex *parallelExecutor) runAction(ctx context.Context, a Action) error {
 	return nil
 }
 
+// dryRun records the DryRunCalls for the Action in the result and returns
+// the Events it would signal.
+func (ex *parallelExecutor) dryRun(a Action) (EventList, error) {
+	calls, err := dryRunCalls(a)
+	if err != nil {
+		return nil, err
+	}
+	ex.lock.Lock()
+	ex.result.DryRunCalls = append(ex.result.DryRunCalls, calls...)
+	ex.lock.Unlock()
+	return a.DryRun(), nil
+}
+
 func (ex *parallelExecutor) queueRunnableActions() {
 	ex.lock.Lock()
 	defer ex.lock.Unlock()
@@ -203,12 +254,15 @@ func (ex *parallelExecutor) signal(evs []Event) []TraceSignal {
 	return ret
 }
 
-func (ex *parallelExecutor) addActionResult(a Action, runErr error) {
+func (ex *parallelExecutor) addActionResult(a Action, skipped bool, runErr error) {
 	ex.lock.Lock()
 	defer ex.lock.Unlock()
-	if runErr == nil {
+	switch {
+	case runErr == nil && skipped:
+		ex.result.Skipped = append(ex.result.Skipped, a)
+	case runErr == nil:
 		ex.result.Completed = append(ex.result.Completed, a)
-	} else {
-		ex.result.Errors = append(ex.result.Errors, ActionWithErr{Action: a, Err: runErr})
+	default:
+		ex.result.Errors = append(ex.result.Errors, newActionWithErr(a, runErr))
 	}
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResultErrorsByResource(t *testing.T) {
+	idA := &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey("a")}
+	idB := &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey("b")}
+	idC := &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey("c")}
+
+	for _, tc := range []struct {
+		name  string
+		newEx func(cloud.Cloud, []Action) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []Action) (Executor, error) {
+				return NewSerialExecutor(c, a, ErrorStrategyOption(ContinueOnError))
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []Action) (Executor, error) {
+				return NewParallelExecutor(c, a, ErrorStrategyOption(ContinueOnError))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actions := []Action{
+				&testAction{name: "A", id: idA, err: errors.New("errA")},
+				&testAction{name: "B", id: idB, err: errors.New("errB")},
+				&testAction{name: "C", id: idC},
+				// Errors without a ResourceID are not in the map.
+				&testAction{name: "D", err: errors.New("errD")},
+			}
+			mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			ex, err := tc.newEx(mockCloud, actions)
+			if err != nil {
+				t.Fatalf("newEx() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+			if len(result.Errors) != 3 {
+				t.Fatalf("len(result.Errors) = %d, want 3", len(result.Errors))
+			}
+
+			got := map[cloud.ResourceMapKey][]string{}
+			for key, errs := range result.ErrorsByResource() {
+				for _, ae := range errs {
+					if ae.Metadata == nil || ae.Metadata.ResourceID != ae.ResourceID {
+						t.Errorf("ae.Metadata = %+v, want ResourceID %v", ae.Metadata, ae.ResourceID)
+					}
+					got[key] = append(got[key], ae.Err.Error())
+				}
+			}
+			want := map[cloud.ResourceMapKey][]string{
+				idA.MapKey(): {"errA"},
+				idB.MapKey(): {"errB"},
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("ErrorsByResource() -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestRunIDOption(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		newEx func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		for _, runID := range []string{"", "run-123"} {
+			t.Run(tc.name+"/"+runID, func(t *testing.T) {
+				mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+
+				var (
+					lock sync.Mutex
+					got  []string
+				)
+				mockCloud.MockAddresses.InsertHook = func(ctx context.Context, key *meta.Key, _ *compute.Address, _ *cloud.MockAddresses, _ ...cloud.Option) (bool, error) {
+					lock.Lock()
+					defer lock.Unlock()
+					id, ok := cloud.RunID(ctx)
+					if ok != (runID != "") {
+						t.Errorf("cloud.RunID() = _, %t, want %t", ok, runID != "")
+					}
+					got = append(got, key.Name+":"+id)
+					return false, nil
+				}
+
+				var actions []Action
+				for _, name := range []string{"a", "b"} {
+					name := name
+					actions = append(actions, &testAction{
+						name: name,
+						runHook: func(ctx context.Context) error {
+							return mockCloud.Addresses().Insert(ctx, meta.RegionalKey(name, "us-central1"), &compute.Address{})
+						},
+					})
+				}
+
+				var opts []Option
+				if runID != "" {
+					opts = append(opts, RunIDOption(runID))
+				}
+				ex, err := tc.newEx(mockCloud, actions, opts...)
+				if err != nil {
+					t.Fatalf("newEx() = %v, want nil", err)
+				}
+				if _, err := ex.Run(context.Background()); err != nil {
+					t.Fatalf("Run() = %v, want nil", err)
+				}
+
+				want := []string{"a:" + runID, "b:" + runID}
+				lock.Lock()
+				defer lock.Unlock()
+				if diff := cmp.Diff(got, want, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+					t.Errorf("hook run IDs: diff -got,+want: %s", diff)
+				}
+			})
+		}
+	}
+}
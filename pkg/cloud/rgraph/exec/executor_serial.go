@@ -39,15 +39,23 @@ func NewSerialExecutor(c cloud.Cloud, pending []Action, opts ...Option) (*serial
 	if err := ret.config.validate(); err != nil {
 		return nil, err
 	}
+	ret.progress = newProgress(ret.config.Progress, len(pending))
+	ret.checkpoint = newCheckpoint(ret.config.Checkpoint, ret.config.ResumeFrom)
+	if ret.config.RetryBudget != nil {
+		ret.result.RetryBudget = NewRetryBudget(*ret.config.RetryBudget)
+	}
 
 	if ret.config.DryRun {
 		ret.runFunc = func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+			calls, err := dryRunCalls(a)
+			if err != nil {
+				return nil, err
+			}
+			ret.result.DryRunCalls = append(ret.result.DryRunCalls, calls...)
 			return a.DryRun(), nil
 		}
 	} else {
-		ret.runFunc = func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
-			return a.Run(ctx, c)
-		}
+		ret.runFunc = ret.config.runAndRefresh
 	}
 
 	return ret, nil
@@ -56,9 +64,11 @@ func NewSerialExecutor(c cloud.Cloud, pending []Action, opts ...Option) (*serial
 type serialExecutor struct {
 	config *ExecutorConfig
 
-	cloud   cloud.Cloud
-	runFunc func(context.Context, cloud.Cloud, Action) (EventList, error)
-	result  *Result
+	cloud      cloud.Cloud
+	runFunc    func(context.Context, cloud.Cloud, Action) (EventList, error)
+	result     *Result
+	progress   *progress
+	checkpoint *checkpoint
 }
 
 var _ Executor = (*serialExecutor)(nil)
@@ -75,6 +85,15 @@ func (ex *serialExecutor) Run(ctx context.Context) (*Result, error) {
 		ctx, cancel = context.WithTimeout(ctx, ex.config.Timeout)
 		defer cancel()
 	}
+	if ex.result.RetryBudget != nil {
+		ctx = withRetryBudget(ctx, ex.result.RetryBudget)
+	}
+	if ex.config.RunID != "" {
+		ctx = cloud.WithRunID(ctx, ex.config.RunID)
+	}
+	if ex.config.Metrics != nil {
+		ctx = withMetrics(ctx, ex.config.Metrics)
+	}
 	return ex.runInternal(ctx)
 }
 
@@ -96,19 +115,42 @@ func (ex *serialExecutor) runInternal(ctx context.Context) (*Result, error) {
 }
 
 func (ex *serialExecutor) runAction(ctx context.Context, a Action) error {
-	klog.V(4).Infof("runAction %s", a)
+	logActionStart(a)
 
 	te := &TraceEntry{
 		Action: a,
 		Start:  time.Now(),
 	}
-	events, runErr := ex.runFunc(ctx, ex.cloud, a)
+	ex.progress.start(a)
+	skipped := ex.checkpoint.skip(a)
+	var (
+		events EventList
+		runErr error
+	)
+	if skipped {
+		klog.V(4).Infof("runAction %s: skipped, completed in a previous execution", a)
+		events = a.DryRun()
+	} else {
+		events, runErr = ex.runFunc(ctx, ex.cloud, a)
+	}
 	te.End = time.Now()
+	ex.progress.finish(a)
+	logActionFinish(a, te.End.Sub(te.Start), skipped, runErr)
+	if !skipped && !ex.config.DryRun {
+		ex.config.observeAction(a, te.End.Sub(te.Start), runErr)
+	}
 
-	if runErr == nil {
+	switch {
+	case runErr == nil && skipped:
+		ex.result.Skipped = append(ex.result.Skipped, a)
+	case runErr == nil:
 		ex.result.Completed = append(ex.result.Completed, a)
-	} else {
-		ex.result.Errors = append(ex.result.Errors, ActionWithErr{Action: a, Err: runErr})
+		if !ex.config.DryRun {
+			recordSync(a, te.End)
+			ex.checkpoint.finish(a)
+		}
+	default:
+		ex.result.Errors = append(ex.result.Errors, newActionWithErr(a, runErr))
 		switch ex.config.ErrorStrategy {
 		case ContinueOnError:
 		case StopOnError:
@@ -128,6 +170,34 @@ func (ex *serialExecutor) runAction(ctx context.Context, a Action) error {
 	return ctx.Err()
 }
 
+// actionLogLevel is the verbosity for logging the start and finish of each
+// Action.
+const actionLogLevel klog.Level = 3
+
+// logActionStart logs at actionLogLevel. The Action Metadata is only computed
+// if the log level is enabled.
+func logActionStart(a Action) {
+	if v := klog.V(actionLogLevel); v.Enabled() {
+		v.InfoS("Action start", actionLogKeys(a)...)
+	}
+}
+
+// logActionFinish logs at actionLogLevel. The Action Metadata is only
+// computed if the log level is enabled.
+func logActionFinish(a Action, d time.Duration, skipped bool, err error) {
+	if v := klog.V(actionLogLevel); v.Enabled() {
+		v.InfoS("Action finish", append(actionLogKeys(a), "duration", d, "skipped", skipped, "err", err)...)
+	}
+}
+
+func actionLogKeys(a Action) []any {
+	m := a.Metadata()
+	if m == nil {
+		return []any{"action", a.String()}
+	}
+	return []any{"action", m.Name, "type", m.Type, "resourceID", m.ResourceID}
+}
+
 func (ex *serialExecutor) next() Action {
 	for i, a := range ex.result.Pending {
 		if a.CanRun() {
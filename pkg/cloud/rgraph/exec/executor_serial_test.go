@@ -17,14 +17,20 @@ limitations under the License.
 package exec
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/klog/v2"
 )
 
 func sortedStrings[T any](l []T, f func(T) string) []string {
@@ -274,3 +280,66 @@ func TestSerialExecutorTimeoutOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSerialExecutorLogging(t *testing.T) {
+	id := &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey("a")}
+
+	for _, tc := range []struct {
+		name      string
+		verbosity int
+		wantLines []string
+	}{
+		{
+			name:      "below threshold",
+			verbosity: int(actionLogLevel) - 1,
+		},
+		{
+			name:      "at threshold",
+			verbosity: int(actionLogLevel),
+			wantLines: []string{
+				`"Action start" action="A([A])" type="Custom" resourceID="addresses:proj/a"`,
+				`"Action finish" action="A([A])" type="Custom" resourceID="addresses:proj/a"`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := klog.CaptureState()
+			defer state.Restore()
+
+			fs := flag.NewFlagSet("klog", flag.ContinueOnError)
+			klog.InitFlags(fs)
+			for k, v := range map[string]string{
+				"logtostderr":     "false",
+				"alsologtostderr": "false",
+				"stderrthreshold": "FATAL",
+				"v":               fmt.Sprint(tc.verbosity),
+			} {
+				if err := fs.Set(k, v); err != nil {
+					t.Fatalf("fs.Set(%q, %q) = %v, want nil", k, v, err)
+				}
+			}
+			var buf bytes.Buffer
+			klog.SetOutput(&buf)
+
+			actions := []Action{&testAction{name: "A", events: EventList{StringEvent("A")}, id: id}}
+			ex, err := NewSerialExecutor(nil, actions)
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background()); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			klog.Flush()
+
+			out := buf.String()
+			for _, want := range tc.wantLines {
+				if !strings.Contains(out, want) {
+					t.Errorf("log output does not contain %q; output:\n%s", want, out)
+				}
+			}
+			if len(tc.wantLines) == 0 && strings.Contains(out, "Action start") {
+				t.Errorf("log output contains Action lines at verbosity %d; output:\n%s", tc.verbosity, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsCollector receives observations of the execution, e.g. to export
+// them as Prometheus counters and histograms. resource is the type of the
+// resource (ResourceID.Resource, e.g. "backendServices") and is empty if the
+// Action is not associated with a resource. Implementations must be
+// thread-safe as the parallel executor calls them concurrently.
+type MetricsCollector interface {
+	// ObserveAction is called after each Action has run. err is the error
+	// returned by the Action. Actions that are skipped (see
+	// ResumeFromOption) or run in dry run mode are not observed.
+	ObserveAction(actionType ActionType, resource string, d time.Duration, err error)
+	// ObserveRetry is called each time a retriable Action (see
+	// NewRetriableAction) is retried.
+	ObserveRetry(actionType ActionType, resource string)
+}
+
+// MetricsOption sets the collector for metrics of the execution.
+func MetricsOption(m MetricsCollector) Option {
+	return func(c *ExecutorConfig) { c.Metrics = m }
+}
+
+// observeAction reports the Action to the MetricsCollector, if set.
+func (c *ExecutorConfig) observeAction(a Action, d time.Duration, err error) {
+	if c.Metrics == nil {
+		return
+	}
+	actionType, resource := metricsLabels(a)
+	c.Metrics.ObserveAction(actionType, resource, d, err)
+}
+
+func metricsLabels(a Action) (ActionType, string) {
+	m := a.Metadata()
+	if m == nil {
+		return "", ""
+	}
+	if m.ResourceID == nil {
+		return m.Type, ""
+	}
+	return m.Type, m.ResourceID.Resource
+}
+
+type metricsKey struct{}
+
+// withMetrics returns a context that carries the collector to the Actions.
+func withMetrics(ctx context.Context, m MetricsCollector) context.Context {
+	return context.WithValue(ctx, metricsKey{}, m)
+}
+
+// metricsFrom returns the collector in the context or nil if there is none.
+func metricsFrom(ctx context.Context) MetricsCollector {
+	m, _ := ctx.Value(metricsKey{}).(MetricsCollector)
+	return m
+}
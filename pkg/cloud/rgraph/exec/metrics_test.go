@@ -0,0 +1,115 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeMetrics struct {
+	lock    sync.Mutex
+	actions []string
+	retries []string
+}
+
+func (m *fakeMetrics) ObserveAction(actionType ActionType, resource string, d time.Duration, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.actions = append(m.actions, fmt.Sprintf("%s/%s err=%t", actionType, resource, err != nil))
+}
+
+func (m *fakeMetrics) ObserveRetry(actionType ActionType, resource string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.retries = append(m.retries, fmt.Sprintf("%s/%s", actionType, resource))
+}
+
+func TestMetricsOption(t *testing.T) {
+	addrID := &cloud.ResourceID{ProjectID: "proj", Resource: "addresses", Key: meta.GlobalKey("a")}
+	bsID := &cloud.ResourceID{ProjectID: "proj", Resource: "backendServices", Key: meta.GlobalKey("b")}
+
+	newActions := func() []Action {
+		// D fails on the first attempt and is retried.
+		var attempts int
+		d := &testAction{name: "D", events: EventList{StringEvent("D")}, id: bsID}
+		d.runHook = func(context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("injected")
+			}
+			d.err = nil
+			return nil
+		}
+		return []Action{
+			&testAction{name: "A", events: EventList{StringEvent("A")}, id: addrID},
+			&testAction{name: "B", events: EventList{StringEvent("B")}, id: bsID, ActionBase: ActionBase{Want: EventList{StringEvent("A")}}},
+			&testAction{name: "C", events: EventList{StringEvent("C")}, err: errors.New("injected")},
+			NewRetriableAction(d, func(error) (bool, time.Duration) { return true, 0 }),
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		new  func([]Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			new: func(a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(nil, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			new: func(a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(nil, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &fakeMetrics{}
+			ex, err := tc.new(newActions(), MetricsOption(m), ErrorStrategyOption(ContinueOnError))
+			if err != nil {
+				t.Fatalf("new executor = %v, want nil", err)
+			}
+			ex.Run(context.Background())
+
+			sort.Strings(m.actions)
+			wantActions := []string{
+				"Custom/ err=true",
+				"Custom/addresses err=false",
+				"Custom/backendServices err=false",
+				"Custom/backendServices err=false",
+			}
+			if diff := cmp.Diff(m.actions, wantActions); diff != "" {
+				t.Errorf("ObserveAction: diff -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(m.retries, []string{"Custom/backendServices"}); diff != "" {
+				t.Errorf("ObserveRetry: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
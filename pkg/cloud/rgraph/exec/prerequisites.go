@@ -0,0 +1,170 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Prerequisites returns the names (see ActionMetadata.Name) of the Actions in
+// actions that signal an Event that a is still waiting on. This is used to
+// explain why an Action is blocked.
+//
+// The Events signalled by each Action are determined by calling Signals() (see
+// Signaler) or DryRun(). Actions without Metadata are named by a.String(). The
+// returned names are sorted and do not include a itself.
+func Prerequisites(a Action, actions []Action) []string {
+	var ret []string
+	for _, other := range prerequisiteActions(a, actions) {
+		ret = append(ret, actionName(other))
+	}
+	return ret
+}
+
+// prerequisiteActions is the same as Prerequisites but returns the Actions,
+// sorted by name.
+func prerequisiteActions(a Action, actions []Action) []Action {
+	pending := a.PendingEvents()
+	if len(pending) == 0 {
+		return nil
+	}
+	var ret []Action
+	for _, other := range actions {
+		if other == a {
+			continue
+		}
+		if signalsAny(signals(other), pending) {
+			ret = append(ret, other)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return actionName(ret[i]) < actionName(ret[j])
+	})
+	return ret
+}
+
+// BlockedReason explains why an Action in Result.Pending was not run.
+type BlockedReason struct {
+	// Action that is pending.
+	Action Action
+	// Failed are the names of the prerequisite Actions that failed with an
+	// error.
+	Failed []string
+	// Pending are the names of the prerequisite Actions that are themselves
+	// pending.
+	Pending []string
+	// RootCauses are the names of the failed Actions that transitively block
+	// Action.
+	RootCauses []string
+	// Chain is the shortest sequence of Action names starting with Action
+	// where each Action is blocked by the next one. The last element is a
+	// root cause. Chain is empty if there is no root cause, e.g. if the
+	// Action is waiting for an Event that no Action will signal.
+	Chain []string
+}
+
+func (br BlockedReason) String() string {
+	name := actionName(br.Action)
+	if len(br.Chain) == 0 {
+		return fmt.Sprintf("%s: blocked on events %v with no failed prerequisite", name, br.Action.PendingEvents())
+	}
+	return fmt.Sprintf("%s: blocked by %s", name, strings.Join(br.Chain[1:], " <- "))
+}
+
+// WhyBlocked returns a BlockedReason for each Action in Pending, in the same
+// order. Prerequisites are found among the Errors and Pending Actions (see
+// Prerequisites). Actions without Metadata are named by a.String().
+func (r *Result) WhyBlocked() []BlockedReason {
+	failed := map[Action]bool{}
+	var candidates []Action
+	for _, ae := range r.Errors {
+		failed[ae.Action] = true
+		candidates = append(candidates, ae.Action)
+	}
+	candidates = append(candidates, r.Pending...)
+
+	prereqs := map[Action][]Action{}
+	for _, a := range r.Pending {
+		prereqs[a] = prerequisiteActions(a, candidates)
+	}
+
+	var ret []BlockedReason
+	for _, a := range r.Pending {
+		br := BlockedReason{Action: a}
+		for _, p := range prereqs[a] {
+			if failed[p] {
+				br.Failed = append(br.Failed, actionName(p))
+			} else {
+				br.Pending = append(br.Pending, actionName(p))
+			}
+		}
+		br.RootCauses, br.Chain = rootCauses(a, prereqs, failed)
+		ret = append(ret, br)
+	}
+	return ret
+}
+
+// rootCauses does a breadth-first traversal of the prereqs from a and
+// returns the names of the failed Actions that were reached along with the
+// chain of names to the first (closest) one.
+func rootCauses(a Action, prereqs map[Action][]Action, failed map[Action]bool) ([]string, []string) {
+	type item struct {
+		a     Action
+		chain []string
+	}
+	var (
+		causes []string
+		chain  []string
+	)
+	visited := map[Action]bool{a: true}
+	queue := []item{{a: a, chain: []string{actionName(a)}}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, p := range prereqs[cur.a] {
+			if visited[p] {
+				continue
+			}
+			visited[p] = true
+			pChain := append(append([]string{}, cur.chain...), actionName(p))
+			if failed[p] {
+				causes = append(causes, actionName(p))
+				if chain == nil {
+					chain = pChain
+				}
+				continue
+			}
+			queue = append(queue, item{a: p, chain: pChain})
+		}
+	}
+	sort.Strings(causes)
+	return causes, chain
+}
+
+// signalsAny returns true if any of the events are in want.
+func signalsAny(events, want EventList) bool {
+	for _, ev := range events {
+		for _, w := range want {
+			if ev.Equal(w) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrerequisites(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		graph  string
+		action string
+		want   []string
+	}{
+		{
+			name:   "no prerequisites",
+			graph:  "A -> B",
+			action: "A",
+		},
+		{
+			name:   "single",
+			graph:  "A -> B",
+			action: "B",
+			want:   []string{"A([A])"},
+		},
+		{
+			name:   "multiple",
+			graph:  "A -> C; B -> C; C -> D",
+			action: "C",
+			want:   []string{"A([A])", "B([B])"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actions := actionsFromGraphStr(tc.graph)
+			var a Action
+			for _, x := range actions {
+				if x.(*testAction).name == tc.action {
+					a = x
+				}
+			}
+			if a == nil {
+				t.Fatalf("action %q not in graph %q", tc.action, tc.graph)
+			}
+			got := Prerequisites(a, actions)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Prerequisites(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+// signalerAction is a testAction that implements Signaler and counts the
+// calls to DryRun().
+type signalerAction struct {
+	testAction
+	dryRuns int
+}
+
+func (a *signalerAction) DryRun() EventList {
+	a.dryRuns++
+	return a.events
+}
+
+func (a *signalerAction) Signals() EventList { return a.events }
+
+func TestPrerequisitesSignaler(t *testing.T) {
+	// Prerequisites does not call DryRun() on Actions that implement
+	// Signaler as DryRun() may have side effects.
+	a := &signalerAction{testAction: testAction{name: "A", events: EventList{StringEvent("A")}}}
+	b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+	b.Want = EventList{StringEvent("A")}
+
+	got := Prerequisites(b, []Action{a, b})
+	if diff := cmp.Diff(got, []string{"A([A])"}); diff != "" {
+		t.Errorf("Prerequisites(): -got,+want: %s", diff)
+	}
+	if a.dryRuns != 0 {
+		t.Errorf("DryRun() called %d times, want 0", a.dryRuns)
+	}
+}
+
+func TestPrerequisitesNoMetadata(t *testing.T) {
+	// Actions without Metadata are named by String().
+	a := &noMetadataAction{testAction{name: "A", events: EventList{StringEvent("A")}}}
+	b := &noMetadataAction{testAction{name: "B", events: EventList{StringEvent("B")}}}
+	c := &testAction{name: "C", events: EventList{StringEvent("C")}}
+	c.Want = EventList{StringEvent("A"), StringEvent("B")}
+
+	got := Prerequisites(c, []Action{b, a, c})
+	if diff := cmp.Diff(got, []string{a.String(), b.String()}); diff != "" {
+		t.Errorf("Prerequisites(): -got,+want: %s", diff)
+	}
+}
+
+// failedAction wraps a testAction to not return any events on error, like
+// the resource Actions. The serial executor signals the events returned by
+// Run() even if there is an error.
+type failedAction struct{ *testAction }
+
+func (a failedAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	events, err := a.testAction.Run(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// actionsWithFailures is actionsFromGraphStr where the Actions with an
+// injected error are failedActions.
+func actionsWithFailures(graphStr string) []Action {
+	actions := actionsFromGraphStr(graphStr)
+	for i, a := range actions {
+		if ta := a.(*testAction); ta.err != nil {
+			actions[i] = failedAction{ta}
+		}
+	}
+	return actions
+}
+
+func TestResultWhyBlocked(t *testing.T) {
+	type newExFunc func(cloud.Cloud, []Action) (Executor, error)
+	executors := []struct {
+		name  string
+		newEx newExFunc
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []Action) (Executor, error) {
+				return NewSerialExecutor(c, a, ErrorStrategyOption(ContinueOnError))
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []Action) (Executor, error) {
+				return NewParallelExecutor(c, a, ErrorStrategyOption(ContinueOnError))
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		graph string
+		want  map[string]BlockedReason
+	}{
+		{
+			name:  "no errors",
+			graph: "A -> B",
+		},
+		{
+			name:  "failed prerequisite",
+			graph: "!A -> B",
+			want: map[string]BlockedReason{
+				"B([B])": {
+					Failed:     []string{"A([A])"},
+					RootCauses: []string{"A([A])"},
+					Chain:      []string{"B([B])", "A([A])"},
+				},
+			},
+		},
+		{
+			name:  "transitive",
+			graph: "!A -> B -> C",
+			want: map[string]BlockedReason{
+				"B([B])": {
+					Failed:     []string{"A([A])"},
+					RootCauses: []string{"A([A])"},
+					Chain:      []string{"B([B])", "A([A])"},
+				},
+				"C([C])": {
+					Pending:    []string{"B([B])"},
+					RootCauses: []string{"A([A])"},
+					Chain:      []string{"C([C])", "B([B])", "A([A])"},
+				},
+			},
+		},
+		{
+			name:  "multiple root causes",
+			graph: "!A -> C; !B -> D -> C; E -> C",
+			want: map[string]BlockedReason{
+				"C([C])": {
+					Failed:     []string{"A([A])"},
+					Pending:    []string{"D([D])"},
+					RootCauses: []string{"A([A])", "B([B])"},
+					Chain:      []string{"C([C])", "A([A])"},
+				},
+				"D([D])": {
+					Failed:     []string{"B([B])"},
+					RootCauses: []string{"B([B])"},
+					Chain:      []string{"D([D])", "B([B])"},
+				},
+			},
+		},
+	} {
+		for _, ex := range executors {
+			t.Run(tc.name+"/"+ex.name, func(t *testing.T) {
+				mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+				e, err := ex.newEx(mockCloud, actionsWithFailures(tc.graph))
+				if err != nil {
+					t.Fatalf("newEx() = %v, want nil", err)
+				}
+				result, _ := e.Run(context.Background())
+
+				got := map[string]BlockedReason{}
+				for _, br := range result.WhyBlocked() {
+					name := br.Action.Metadata().Name
+					br.Action = nil
+					got[name] = br
+				}
+				want := tc.want
+				if want == nil {
+					want = map[string]BlockedReason{}
+				}
+				if diff := cmp.Diff(got, want); diff != "" {
+					t.Errorf("WhyBlocked(): -got,+want: %s", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestResultWhyBlockedNoMetadata(t *testing.T) {
+	a := &noMetadataAction{testAction{name: "A", events: EventList{StringEvent("A")}}}
+	b := &noMetadataAction{testAction{name: "B", events: EventList{StringEvent("B")}}}
+	b.Want = EventList{StringEvent("A")}
+	c := &testAction{name: "C", events: EventList{StringEvent("C")}}
+	c.Want = EventList{StringEvent("B")}
+	result := &Result{
+		Errors:  []ActionWithErr{{Action: a, Err: errors.New("injected")}},
+		Pending: []Action{b, c},
+	}
+
+	var got []string
+	for _, br := range result.WhyBlocked() {
+		got = append(got, br.String())
+	}
+	want := []string{
+		b.String() + ": blocked by " + a.String(),
+		"C([C]): blocked by " + b.String() + " <- " + a.String(),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("WhyBlocked(): -got,+want: %s", diff)
+	}
+}
+
+func TestBlockedReasonString(t *testing.T) {
+	mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	ex, err := NewSerialExecutor(mockCloud, actionsWithFailures("!A -> B -> C"), ErrorStrategyOption(ContinueOnError))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, _ := ex.Run(context.Background())
+
+	var got []string
+	for _, br := range result.WhyBlocked() {
+		got = append(got, br.String())
+	}
+	sort.Strings(got)
+	want := []string{
+		"B([B]): blocked by A([A])",
+		"C([C]): blocked by B([B]) <- A([A])",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("BlockedReason.String(): -got,+want: %s", diff)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import "sync"
+
+// ProgressFunc is called by the Executor before and after each Action is run.
+// done is the number of Actions that have finished (successfully or with an
+// error), total is the number of Actions given to the Executor and current is
+// the Action that is starting or has just finished. current is the zero
+// ActionMetadata for Actions without Metadata.
+type ProgressFunc func(done, total int, current ActionMetadata)
+
+// ProgressOption sets a callback that reports the progress of the execution.
+// The callback is called serially, even for the parallel executor, so it
+// should not block.
+func ProgressOption(f ProgressFunc) Option {
+	return func(c *ExecutorConfig) { c.Progress = f }
+}
+
+// progress tracks the number of finished Actions and invokes the ProgressFunc.
+type progress struct {
+	// lock serializes calls to f and guards done.
+	lock  sync.Mutex
+	f     ProgressFunc
+	done  int
+	total int
+}
+
+func newProgress(f ProgressFunc, total int) *progress {
+	return &progress{f: f, total: total}
+}
+
+// metadata returns the Metadata of the Action or the zero ActionMetadata if
+// the Action has none.
+func metadata(a Action) ActionMetadata {
+	if m := a.Metadata(); m != nil {
+		return *m
+	}
+	return ActionMetadata{}
+}
+
+// start is called before the Action is run.
+func (p *progress) start(a Action) {
+	if p.f == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.f(p.done, p.total, metadata(a))
+}
+
+// finish is called after the Action has run.
+func (p *progress) finish(a Action) {
+	if p.f == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.done++
+	p.f(p.done, p.total, metadata(a))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+func TestProgressOption(t *testing.T) {
+	type call struct {
+		done, total int
+		name        string
+	}
+
+	for _, tc := range []struct {
+		name string
+		ex   func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			ex: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			ex: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj1"})
+			actions := actionsFromGraphStr("A -> B -> C; A -> D")
+
+			var (
+				lock  sync.Mutex
+				calls []call
+			)
+			f := func(done, total int, current ActionMetadata) {
+				lock.Lock()
+				defer lock.Unlock()
+				calls = append(calls, call{done: done, total: total, name: current.Name})
+			}
+			ex, err := tc.ex(mockCloud, actions, ProgressOption(f))
+			if err != nil {
+				t.Fatalf("NewExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background()); err != nil {
+				t.Fatalf("ex.Run() = %v, want nil", err)
+			}
+
+			const total = 4
+			// Each Action is reported when it starts and when it finishes.
+			if len(calls) != 2*total {
+				t.Fatalf("len(calls) = %d, want %d (calls = %+v)", len(calls), 2*total, calls)
+			}
+			done := 0
+			for i, c := range calls {
+				if c.total != total {
+					t.Errorf("calls[%d].total = %d, want %d", i, c.total, total)
+				}
+				if c.done < done || c.done > done+1 {
+					t.Errorf("calls[%d].done = %d, want %d or %d", i, c.done, done, done+1)
+				}
+				done = c.done
+			}
+			if done != total {
+				t.Errorf("final done = %d, want %d", done, total)
+			}
+		})
+	}
+}
+
+func TestProgressOptionNoMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ex   func(cloud.Cloud, []Action, ...Option) (Executor, error)
+	}{
+		{
+			name: "serial",
+			ex: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewSerialExecutor(c, a, opts...)
+			},
+		},
+		{
+			name: "parallel",
+			ex: func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+				return NewParallelExecutor(c, a, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Actions without Metadata are reported with the zero
+			// ActionMetadata.
+			var got []ActionMetadata
+			f := func(_, _ int, current ActionMetadata) { got = append(got, current) }
+			a := &noMetadataAction{testAction{name: "A", events: EventList{StringEvent("A")}}}
+			ex, err := tc.ex(nil, []Action{a}, ProgressOption(f))
+			if err != nil {
+				t.Fatalf("NewExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background()); err != nil {
+				t.Fatalf("ex.Run() = %v, want nil", err)
+			}
+			if len(got) != 2 || got[0] != (ActionMetadata{}) || got[1] != (ActionMetadata{}) {
+				t.Errorf("ProgressFunc got %+v, want 2 calls with the zero ActionMetadata", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// runAndRefresh runs the Action (see runWithTimeout) and refreshes the resource
+// if RefreshAfterApply is set and the Action implements Refresher. A failure
+// to refresh is returned as an error for the Action.
+func (c *ExecutorConfig) runAndRefresh(ctx context.Context, gcp cloud.Cloud, a Action) (EventList, error) {
+	events, err := c.runWithTimeout(ctx, gcp, a)
+	if err != nil || !c.RefreshAfterApply {
+		return events, err
+	}
+	if r, ok := a.(Refresher); ok {
+		if err := r.Refresh(ctx, gcp); err != nil {
+			return events, fmt.Errorf("refresh %s: %w", a, err)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/google/go-cmp/cmp"
+)
+
+type refreshTestAction struct {
+	testAction
+	refreshErr error
+	log        *refreshLog
+}
+
+func (a *refreshTestAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	a.log.add("run " + a.name)
+	return a.testAction.Run(ctx, c)
+}
+
+func (a *refreshTestAction) Refresh(context.Context, cloud.Cloud) error {
+	a.log.add("refresh " + a.name)
+	return a.refreshErr
+}
+
+type refreshLog struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (l *refreshLog) add(s string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.lines = append(l.lines, s)
+}
+
+func TestRefreshAfterApplyOption(t *testing.T) {
+	newExecutors := map[string]func(cloud.Cloud, []Action, ...Option) (Executor, error){
+		"serial": func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+			return NewSerialExecutor(c, a, opts...)
+		},
+		"parallel": func(c cloud.Cloud, a []Action, opts ...Option) (Executor, error) {
+			return NewParallelExecutor(c, a, opts...)
+		},
+	}
+	for _, tc := range []struct {
+		name       string
+		opts       []Option
+		refreshErr error
+		// serialOnly is set for options that are only implemented by the
+		// serial executor.
+		serialOnly bool
+
+		wantLog     []string
+		wantErr     bool
+		wantPending int
+	}{
+		{
+			name:    "option not set",
+			wantLog: []string{"run a", "run b"},
+		},
+		{
+			name:    "refresh before dependent runs",
+			opts:    []Option{RefreshAfterApplyOption(true)},
+			wantLog: []string{"run a", "refresh a", "run b", "refresh b"},
+		},
+		{
+			name:        "refresh error",
+			opts:        []Option{RefreshAfterApplyOption(true)},
+			refreshErr:  errors.New("injected"),
+			wantLog:     []string{"run a", "refresh a"},
+			wantErr:     true,
+			wantPending: 1,
+		},
+		{
+			name:       "dry run",
+			opts:       []Option{RefreshAfterApplyOption(true), DryRunOption(true)},
+			serialOnly: true,
+			wantLog:    nil,
+		},
+	} {
+		for exName, newExecutor := range newExecutors {
+			if tc.serialOnly && exName != "serial" {
+				continue
+			}
+			t.Run(tc.name+"/"+exName, func(t *testing.T) {
+				log := &refreshLog{}
+				a := &refreshTestAction{
+					testAction: testAction{name: "a", events: EventList{StringEvent("a")}},
+					refreshErr: tc.refreshErr,
+					log:        log,
+				}
+				b := &refreshTestAction{
+					testAction: testAction{ActionBase: ActionBase{Want: EventList{StringEvent("a")}}, name: "b"},
+					log:        log,
+				}
+				ex, err := newExecutor(nil, []Action{a, b}, tc.opts...)
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(context.Background())
+				if gotErr := err != nil; gotErr != tc.wantErr {
+					t.Errorf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+				}
+				if tc.wantErr && (len(result.Errors) != 1 || !errors.Is(result.Errors[0].Err, tc.refreshErr)) {
+					t.Errorf("result.Errors = %v, want wrapped %v", result.Errors, tc.refreshErr)
+				}
+				if len(result.Pending) != tc.wantPending {
+					t.Errorf("len(result.Pending) = %d, want %d", len(result.Pending), tc.wantPending)
+				}
+				if diff := cmp.Diff(log.lines, tc.wantLog); diff != "" {
+					t.Errorf("log: diff -got,+want: %s", diff)
+				}
+			})
+		}
+	}
+}
@@ -37,8 +37,9 @@ func NewRetriableAction(a Action, canRetry func(error) (bool, time.Duration)) Ac
 }
 
 // Run executes Action. On error `canRetry` function is used to check time
-// period after which the action should be retried. If canRetry returns false or
-// context is canceled action returns with error.
+// period after which the action should be retried. If canRetry returns false,
+// the RetryBudget of the execution is exhausted or context is canceled action
+// returns with error.
 func (ra *retriableAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
 	for {
 		events, err := ra.Action.Run(ctx, c)
@@ -47,6 +48,12 @@ func (ra *retriableAction) Run(ctx context.Context, c cloud.Cloud) (EventList, e
 			return events, nil
 		}
 		if canRetry, backOffTime := ra.canRetry(err); canRetry {
+			if b := retryBudgetFrom(ctx); b != nil && !b.take() {
+				return events, fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err)
+			}
+			if m := metricsFrom(ctx); m != nil {
+				m.ObserveRetry(metricsLabels(ra))
+			}
 			timer := time.NewTimer(backOffTime)
 			select {
 			case <-timer.C:
@@ -16,6 +16,7 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -151,3 +152,55 @@ func TestRetriableActionWithContextCancel(t *testing.T) {
 		t.Errorf("retires mismatch: got %v, want 1", frp.ctr)
 	}
 }
+
+func TestRetriableActionWithRetryBudget(t *testing.T) {
+	const numActions = 4
+
+	// A budget of 0 disables retries.
+	for _, budget := range []int{0, 5} {
+		t.Run(fmt.Sprintf("budget=%d", budget), func(t *testing.T) {
+			var (
+				actions []Action
+				fakes   []*fakeAction
+			)
+			frp := &fakeRetryProvider{shouldRetry: true}
+			for i := 0; i < numActions; i++ {
+				fa := &fakeAction{errorRunThreshold: 100}
+				fakes = append(fakes, fa)
+				actions = append(actions, NewRetriableAction(fa, frp.IsRetriable))
+			}
+
+			ex, err := NewSerialExecutor(nil, actions, ErrorStrategyOption(ContinueOnError), RetryBudgetOption(budget))
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+
+			totalRuns := 0
+			for _, fa := range fakes {
+				totalRuns += fa.runCtr
+			}
+			// Each Action runs once, plus the retries allowed by the budget.
+			if want := numActions + budget; totalRuns != want {
+				t.Errorf("total runs = %d, want %d", totalRuns, want)
+			}
+			if result.RetryBudget == nil {
+				t.Fatalf("result.RetryBudget = nil, want non-nil")
+			}
+			if got := result.RetryBudget.Remaining(); got != 0 {
+				t.Errorf("result.RetryBudget.Remaining() = %d, want 0", got)
+			}
+			if len(result.Errors) != numActions {
+				t.Fatalf("len(result.Errors) = %d, want %d", len(result.Errors), numActions)
+			}
+			for _, ae := range result.Errors {
+				if !errors.Is(ae.Err, ErrRetryBudgetExhausted) {
+					t.Errorf("Action %v error = %v, want %v", ae.Action, ae.Err, ErrRetryBudgetExhausted)
+				}
+			}
+		})
+	}
+}
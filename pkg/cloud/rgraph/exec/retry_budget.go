@@ -0,0 +1,76 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRetryBudgetExhausted is returned (wrapped) by a retriable Action when it
+// could have been retried but the RetryBudget for the execution has no
+// remaining retries.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// NewRetryBudget returns a budget that allows n retries in total.
+func NewRetryBudget(n int) *RetryBudget {
+	return &RetryBudget{remaining: n}
+}
+
+// RetryBudget is a pool of retries shared across all of the retriable Actions
+// in an execution. Each retry takes one token from the budget. Once the budget
+// is exhausted, further failures fail fast instead of retrying. This prevents
+// a large number of Actions from all retrying against the API during an
+// outage. This object is thread-safe.
+type RetryBudget struct {
+	lock      sync.Mutex
+	remaining int
+}
+
+// Remaining returns the number of retries left in the budget.
+func (b *RetryBudget) Remaining() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.remaining
+}
+
+// take a retry from the budget. Returns false if the budget is exhausted.
+func (b *RetryBudget) take() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+type retryBudgetKey struct{}
+
+// withRetryBudget returns a context that carries the budget to the Actions.
+func withRetryBudget(ctx context.Context, b *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, b)
+}
+
+// retryBudgetFrom returns the budget in the context or nil if there is none.
+func retryBudgetFrom(ctx context.Context) *RetryBudget {
+	b, _ := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	return b
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+// NewCompositeTracer returns a Tracer that forwards each call to all of the
+// given tracers, in order. This can be used to output multiple formats from a
+// single execution, e.g.
+//
+//	gv := NewGraphvizTracer()
+//	js := NewJSONTracer()
+//	ex, err := NewSerialExecutor(gcp, actions, TracerOption(NewCompositeTracer(gv, js)))
+func NewCompositeTracer(tracers ...Tracer) Tracer {
+	return &compositeTracer{tracers: tracers}
+}
+
+type compositeTracer struct {
+	tracers []Tracer
+}
+
+var _ Tracer = (*compositeTracer)(nil)
+
+func (tr *compositeTracer) Record(entry *TraceEntry, err error) {
+	for _, t := range tr.tracers {
+		t.Record(entry, err)
+	}
+}
+
+func (tr *compositeTracer) Finish(pending []Action) {
+	for _, t := range tr.tracers {
+		t.Finish(pending)
+	}
+}
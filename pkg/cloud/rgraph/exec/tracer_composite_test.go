@@ -0,0 +1,127 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompositeTracer(t *testing.T) {
+	t.Parallel()
+
+	actions := actionsFromGraphStr("A -> B; !C -> D")
+
+	gv := NewGraphvizTracer()
+	js := NewJSONTracer()
+	ex, err := NewSerialExecutor(nil,
+		actions,
+		ErrorStrategyOption(ContinueOnError),
+		TracerOption(NewCompositeTracer(gv, js)))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if _, err := ex.Run(context.Background()); err == nil {
+		t.Fatalf("Run() = nil, want error")
+	}
+
+	// Graphviz output.
+	gvOut := gv.String()
+	for _, want := range []string{
+		`"A([A])" [style=filled`,
+		`"B([B])" [style=filled`,
+		`"C([C])" [style=filled`,
+		`"A([A])" -> "A"`,
+		`"A" -> "B([B])"`,
+		`"D([D])" [style=filled`,
+		`<b>Error</b>`,
+	} {
+		if !strings.Contains(gvOut, want) {
+			t.Errorf("GraphvizTracer output does not contain %q; output:\n%s", want, gvOut)
+		}
+	}
+
+	// JSON output.
+	var trace JSONTrace
+	if err := json.Unmarshal([]byte(js.String()), &trace); err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want nil", err)
+	}
+	var names, errNames []string
+	for _, e := range trace.Entries {
+		names = append(names, e.Name)
+		if e.Err != "" {
+			errNames = append(errNames, e.Name)
+		}
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff(names, []string{"A([A])", "B([B])", "C([C])", "D([D])"}); diff != "" {
+		t.Errorf("JSONTracer entries: diff -got,+want: %s", diff)
+	}
+	if diff := cmp.Diff(errNames, []string{"C([C])"}); diff != "" {
+		t.Errorf("JSONTracer errors: diff -got,+want: %s", diff)
+	}
+}
+
+func TestCompositeTracerNoMetadata(t *testing.T) {
+	t.Parallel()
+
+	// Actions without Metadata are traced by String().
+	a := &noMetadataAction{testAction{name: "A", events: EventList{StringEvent("A")}}}
+	b := &noMetadataAction{testAction{name: "B", events: EventList{StringEvent("B")}}}
+	b.Want = EventList{StringEvent("A")}
+	c := &noMetadataAction{testAction{name: "C", events: EventList{StringEvent("C")}}}
+	c.Want = EventList{StringEvent("X")}
+
+	gv := NewGraphvizTracer()
+	js := NewJSONTracer()
+	ex, err := NewSerialExecutor(nil,
+		[]Action{a, b, c},
+		ErrorStrategyOption(ContinueOnError),
+		TracerOption(NewCompositeTracer(gv, js)))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if result, _ := ex.Run(context.Background()); len(result.Pending) != 1 {
+		t.Fatalf("result.Pending = %v, want [%s]", result.Pending, c)
+	}
+
+	if out := gv.String(); !strings.Contains(out, fmt.Sprintf("%q -> %q", "A", b.String())) {
+		t.Errorf("GraphvizTracer output does not contain the signal to %s; output:\n%s", b, out)
+	}
+	trace := js.Trace()
+	var names []string
+	for _, e := range trace.Entries {
+		names = append(names, e.Name)
+	}
+	if diff := cmp.Diff(names, []string{a.String(), b.String()}); diff != "" {
+		t.Errorf("JSONTracer entries: diff -got,+want: %s", diff)
+	}
+	if len(trace.Entries) > 0 {
+		if diff := cmp.Diff(trace.Entries[0].Signaled, []JSONTraceSignal{{Event: "A", Action: b.String()}}); diff != "" {
+			t.Errorf("JSONTracer signals: diff -got,+want: %s", diff)
+		}
+	}
+	if len(trace.Pending) != 1 || trace.Pending[0].Name != c.String() {
+		t.Errorf("JSONTracer pending = %+v, want [%s]", trace.Pending, c)
+	}
+}
@@ -50,6 +50,8 @@ func actionTypeToColor(t ActionType) string {
 		return "gray90"
 	case ActionTypeUpdate:
 		return "khaki1"
+	case ActionTypePatch:
+		return "lightgoldenrod1"
 	}
 	return "magenta"
 }
@@ -62,13 +64,14 @@ func (tr *GraphvizTracer) Record(entry *TraceEntry, err error) {
 	tr.lock.Lock()
 	defer tr.lock.Unlock()
 
-	metadata := entry.Action.Metadata()
+	metadata := metadata(entry.Action)
+	name := actionName(entry.Action)
 
 	if tr.start.IsZero() {
 		tr.start = entry.Start
 	}
 
-	tr.outf("  \"%s\" [style=filled,fillcolor=%s,shape=box,label=<", metadata.Name, actionTypeToColor(metadata.Type))
+	tr.outf("  \"%s\" [style=filled,fillcolor=%s,shape=box,label=<", name, actionTypeToColor(metadata.Type))
 	tr.outf("    <table border=\"0\">")
 	tr.outf("      <tr><td colspan=\"2\">\\N</td></tr>")
 	tr.outf("      <tr><td colspan=\"2\">%s</td></tr>", metadata.Summary)
@@ -81,8 +84,8 @@ func (tr *GraphvizTracer) Record(entry *TraceEntry, err error) {
 	tr.outf("  >]")
 
 	for _, s := range entry.Signaled {
-		tr.outf("  \"%s\" -> \"%s\"", entry.Action.Metadata().Name, s.Event)
-		tr.outf("  \"%s\" -> \"%s\"", s.Event, s.SignaledAction.Metadata().Name)
+		tr.outf("  \"%s\" -> \"%s\"", name, s.Event)
+		tr.outf("  \"%s\" -> \"%s\"", s.Event, actionName(s.SignaledAction))
 	}
 }
 
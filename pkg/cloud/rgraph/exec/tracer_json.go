@@ -0,0 +1,124 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// NewJSONTracer returns a new Tracer that outputs JSON.
+func NewJSONTracer() *JSONTracer {
+	return &JSONTracer{}
+}
+
+// JSONTracer records the execution as a JSON document. This object is
+// thread-safe.
+type JSONTracer struct {
+	lock  sync.Mutex
+	trace JSONTrace
+}
+
+var _ Tracer = (*JSONTracer)(nil)
+
+// JSONTrace is the JSON output of the JSONTracer.
+type JSONTrace struct {
+	Entries []JSONTraceEntry `json:"entries"`
+	Pending []JSONPending    `json:"pending,omitempty"`
+}
+
+// JSONTraceEntry is a TraceEntry in JSON form.
+type JSONTraceEntry struct {
+	Name     string            `json:"name"`
+	Type     ActionType        `json:"type"`
+	Summary  string            `json:"summary,omitempty"`
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end"`
+	Err      string            `json:"err,omitempty"`
+	Signaled []JSONTraceSignal `json:"signaled,omitempty"`
+}
+
+// JSONTraceSignal is a TraceSignal in JSON form.
+type JSONTraceSignal struct {
+	Event  string `json:"event"`
+	Action string `json:"action"`
+}
+
+// JSONPending is an Action that was not executed.
+type JSONPending struct {
+	Name          string   `json:"name"`
+	PendingEvents []string `json:"pendingEvents,omitempty"`
+}
+
+func (tr *JSONTracer) Record(entry *TraceEntry, err error) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	// Actions without Metadata are named by String().
+	metadata := metadata(entry.Action)
+	je := JSONTraceEntry{
+		Name:    actionName(entry.Action),
+		Type:    metadata.Type,
+		Summary: metadata.Summary,
+		Start:   entry.Start,
+		End:     entry.End,
+	}
+	if err != nil {
+		je.Err = err.Error()
+	}
+	for _, s := range entry.Signaled {
+		je.Signaled = append(je.Signaled, JSONTraceSignal{
+			Event:  s.Event.String(),
+			Action: actionName(s.SignaledAction),
+		})
+	}
+	tr.trace.Entries = append(tr.trace.Entries, je)
+}
+
+func (tr *JSONTracer) Finish(pending []Action) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	for _, a := range pending {
+		jp := JSONPending{Name: actionName(a)}
+		for _, ev := range a.PendingEvents() {
+			jp.PendingEvents = append(jp.PendingEvents, ev.String())
+		}
+		tr.trace.Pending = append(tr.trace.Pending, jp)
+	}
+}
+
+// Trace returns a copy of the trace recorded so far.
+func (tr *JSONTracer) Trace() JSONTrace {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	return JSONTrace{
+		Entries: append([]JSONTraceEntry(nil), tr.trace.Entries...),
+		Pending: append([]JSONPending(nil), tr.trace.Pending...),
+	}
+}
+
+// String returns the trace as indented JSON.
+func (tr *JSONTracer) String() string {
+	b, err := json.MarshalIndent(tr.Trace(), "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
@@ -71,6 +71,16 @@ func (g *Graph) AddTombstone(n rnode.Node) error {
 	return nil
 }
 
+// AddExternal adds a node for a resource that exists but is not managed by the
+// plan. The node must have OwnershipExternal.
+func (g *Graph) AddExternal(n rnode.Node) error {
+	if n.Ownership() != rnode.OwnershipExternal {
+		return fmt.Errorf("graph: invalid external node (want ownership %s, but got %s)", rnode.OwnershipExternal, n.Ownership())
+	}
+	g.nodes[n.ID().MapKey()] = n
+	return nil
+}
+
 // add a note to the graph. This is package internal on purpose and
 // should not be used outside of internal implementation of the graph
 // package.
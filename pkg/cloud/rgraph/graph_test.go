@@ -17,6 +17,7 @@ limitations under the License.
 package rgraph
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -25,8 +26,11 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
 	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
 )
 
 type topology struct {
@@ -164,6 +168,18 @@ func TestGraph(t *testing.T) {
 			topology:     "r0 -> r1",
 			wantBuildErr: true,
 		},
+		{
+			name: "points to external object",
+			setup: func(b *Builder) {
+				b0 := fake.NewBuilder(ids[0])
+				b0.FakeOutRefs = append(b0.FakeOutRefs, rnode.ResourceRef{From: ids[0], To: ids[1]})
+				b.Add(b0)
+				b.Add(fake.NewBuilder(ids[1]))
+				b.Get(ids[0]).SetOwnership(rnode.OwnershipManaged)
+				b.Get(ids[1]).SetOwnership(rnode.OwnershipExternal)
+			},
+			topology: "r0 -> r1",
+		},
 		{
 			name: "outRef parse errors",
 			setup: func(b *Builder) {
@@ -263,6 +279,78 @@ func TestGraph(t *testing.T) {
 	}
 }
 
+func TestBuilderUnresolvedOutRefs(t *testing.T) {
+	ids := make([]*cloud.ResourceID, 4)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(fmt.Sprintf("r%d", i))}
+	}
+
+	b := NewBuilder()
+	b0 := fake.NewBuilder(ids[0])
+	b0.FakeOutRefs = append(b0.FakeOutRefs, rnode.ResourceRef{From: ids[0], To: ids[2]})
+	b.Add(b0)
+	b1 := fake.NewBuilder(ids[1])
+	b1.FakeOutRefs = append(b1.FakeOutRefs, rnode.ResourceRef{From: ids[1], To: ids[3]})
+	b.Add(b1)
+	b.Get(ids[0]).SetOwnership(rnode.OwnershipManaged)
+	b.Get(ids[1]).SetOwnership(rnode.OwnershipExternal)
+
+	_, err := b.Build()
+	if err == nil {
+		t.Fatalf("Build() = nil, want error")
+	}
+	// All of the unresolved references are reported.
+	for _, want := range []string{
+		fmt.Sprintf("%s -> %s", ids[0], ids[2]),
+		fmt.Sprintf("%s -> %s", ids[1], ids[3]),
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() = %v, want error containing %q", err, want)
+		}
+	}
+}
+
+func TestBuilderOutRefURLForms(t *testing.T) {
+	to := &cloud.ResourceID{
+		ProjectID: "proj",
+		APIGroup:  meta.APIGroupCompute,
+		Resource:  "fakes",
+		Key:       meta.GlobalKey("to"),
+	}
+	for _, url := range []string{
+		"https://www.googleapis.com/compute/v1/projects/proj/global/fakes/to",
+		"https://compute.googleapis.com/compute/v1/projects/proj/global/fakes/to",
+		"https://compute.googleapis.com/v1/projects/proj/global/fakes/to",
+		"projects/proj/global/fakes/to",
+	} {
+		t.Run(url, func(t *testing.T) {
+			refTo, err := cloud.ParseResourceURL(url)
+			if err != nil {
+				t.Fatalf("ParseResourceURL(%q) = %v, want nil", url, err)
+			}
+			from := &cloud.ResourceID{ProjectID: "proj", Resource: "fakes", Key: meta.GlobalKey("from")}
+
+			b := NewBuilder()
+			fromB := fake.NewBuilder(from)
+			fromB.FakeOutRefs = []rnode.ResourceRef{{From: from, To: refTo}}
+			fromB.SetOwnership(rnode.OwnershipManaged)
+			b.Add(fromB)
+			toB := fake.NewBuilder(to)
+			toB.SetOwnership(rnode.OwnershipManaged)
+			b.Add(toB)
+
+			g, err := b.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+			inRefs := g.Get(to).InRefs()
+			if len(inRefs) != 1 || !inRefs[0].From.Equal(from) {
+				t.Errorf("InRefs() = %v, want ref from %v", inRefs, from)
+			}
+		})
+	}
+}
+
 func TestGraphNewBuilder(t *testing.T) {
 	ids := make([]*cloud.ResourceID, 10)
 	for i := 0; i < len(ids); i++ {
@@ -338,3 +426,96 @@ func TestGraphAddTombstone(t *testing.T) {
 		t.Fatalf("g.AddTombstone() = nil, want error")
 	}
 }
+
+func TestBuilderGetScope(t *testing.T) {
+	globalID := backendservice.ID("proj", meta.GlobalKey("bs"))
+	regionalID := backendservice.ID("proj", meta.RegionalKey("bs", "us-central1"))
+
+	b := NewBuilder()
+	for _, id := range []*cloud.ResourceID{globalID, regionalID} {
+		nb := backendservice.NewBuilder(id)
+		nb.SetOwnership(rnode.OwnershipExternal)
+		b.Add(nb)
+	}
+
+	for _, tc := range []struct {
+		name string
+		id   *cloud.ResourceID
+		want *cloud.ResourceID
+	}{
+		{name: "global", id: globalID, want: globalID},
+		{name: "regional", id: regionalID, want: regionalID},
+		{name: "other region", id: backendservice.ID("proj", meta.RegionalKey("bs", "us-east1"))},
+		{name: "zonal", id: backendservice.ID("proj", meta.ZonalKey("bs", "us-central1-b"))},
+		{name: "other project", id: backendservice.ID("proj2", meta.GlobalKey("bs"))},
+		{
+			name: "other API group",
+			id: &cloud.ResourceID{
+				ProjectID: "proj",
+				APIGroup:  meta.APIGroupNetworkServices,
+				Resource:  "backendServices",
+				Key:       meta.GlobalKey("bs"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nb := b.Get(tc.id)
+			switch {
+			case tc.want == nil && nb != nil:
+				t.Errorf("Get(%v) = %v, want nil", tc.id, nb.ID())
+			case tc.want != nil && (nb == nil || !nb.ID().Equal(tc.want)):
+				t.Errorf("Get(%v) = %v, want %v", tc.id, nb, tc.want)
+			}
+		})
+	}
+
+	// Set each node through Get; the other node is unaffected.
+	b.Get(globalID).SetOwnership(rnode.OwnershipManaged)
+
+	g, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	if got := g.Get(globalID).Ownership(); got != rnode.OwnershipManaged {
+		t.Errorf("g.Get(%v).Ownership() = %v, want %v", globalID, got, rnode.OwnershipManaged)
+	}
+	if got := g.Get(regionalID).Ownership(); got != rnode.OwnershipExternal {
+		t.Errorf("g.Get(%v).Ownership() = %v, want %v", regionalID, got, rnode.OwnershipExternal)
+	}
+}
+
+func TestBuilderValidateLocationsOption(t *testing.T) {
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	for _, z := range []string{"us-central1-a", "us-central1-b"} {
+		mock.MockZones.Objects[*meta.GlobalKey(z)] = &cloud.MockZonesObj{Obj: &compute.Zone{Name: z}}
+	}
+	mock.MockRegions.Objects[*meta.GlobalKey("us-central1")] = &cloud.MockRegionsObj{Obj: &compute.Region{Name: "us-central1"}}
+
+	for _, tc := range []struct {
+		name    string
+		key     *meta.Key
+		wantErr bool
+	}{
+		{name: "global", key: meta.GlobalKey("neg")},
+		{name: "valid zone", key: meta.ZonalKey("neg", "us-central1-b")},
+		{name: "valid region", key: meta.RegionalKey("neg", "us-central1")},
+		{name: "invalid zone", key: meta.ZonalKey("neg", "us-central1-z"), wantErr: true},
+		{name: "invalid region", key: meta.RegionalKey("neg", "mars-north1"), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBuilder()
+			nb := networkendpointgroup.NewBuilder(networkendpointgroup.ID("proj", tc.key))
+			nb.SetOwnership(rnode.OwnershipExternal)
+			b.Add(nb)
+
+			// Without the option, locations are not checked.
+			if _, err := b.Build(); err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+			_, err := b.Build(ValidateLocationsOption(context.Background(), mock))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Build(ValidateLocationsOption) = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
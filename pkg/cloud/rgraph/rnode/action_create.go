@@ -46,29 +46,34 @@ func CreateActions[GA any, Alpha any, Beta any](
 		return nil, err
 	}
 	return []exec.Action{
-		newGenericCreateAction(events, ops, node.ID(), resource),
+		newGenericCreateAction(events, ops, node, resource),
 	}, nil
 }
 
 func newGenericCreateAction[GA any, Alpha any, Beta any](
 	want exec.EventList,
 	ops GenericOps[GA, Alpha, Beta],
-	id *cloud.ResourceID,
+	node Node,
 	resource api.Resource[GA, Alpha, Beta],
 ) *genericCreateAction[GA, Alpha, Beta] {
 	return &genericCreateAction[GA, Alpha, Beta]{
-		ActionBase: exec.ActionBase{Want: want},
-		ops:        ops,
-		id:         id,
-		resource:   resource,
+		ActionBase:   exec.ActionBase{Want: want},
+		syncRecorder: syncRecorder{node: node},
+		ops:          ops,
+		id:           node.ID(),
+		resource:     resource,
+		requestIDs:   newRequestIDs(node.ID()),
 	}
 }
 
 type genericCreateAction[GA any, Alpha any, Beta any] struct {
 	exec.ActionBase
+	syncRecorder
 	ops      GenericOps[GA, Alpha, Beta]
 	id       *cloud.ResourceID
 	resource api.Resource[GA, Alpha, Beta]
+	// requestIDs make the Insert idempotent across retries.
+	requestIDs requestIDs
 
 	start, end time.Time
 }
@@ -78,26 +83,54 @@ func (a *genericCreateAction[GA, Alpha, Beta]) Run(
 	c cloud.Cloud,
 ) (exec.EventList, error) {
 	a.start = time.Now()
-	err := a.ops.CreateFuncs(c).Do(ctx, a.id, a.resource)
+	err := a.ops.CreateFuncs(c).Do(a.requestIDs.context(ctx, "Insert"), a.id, a.resource)
 	a.end = time.Now()
 
 	return exec.EventList{exec.NewExistsEvent(a.id)}, err
 }
 
+// Refresh implements exec.Refresher.
+func (a *genericCreateAction[GA, Alpha, Beta]) Refresh(ctx context.Context, c cloud.Cloud) error {
+	return refreshNode(ctx, c, a.ops, a.node, a.resource.Version())
+}
+
 func (a *genericCreateAction[GA, Alpha, Beta]) DryRun() exec.EventList {
 	a.start = time.Now()
 	a.end = a.start
+	return a.Signals()
+}
+
+// Signals implements exec.Signaler.
+func (a *genericCreateAction[GA, Alpha, Beta]) Signals() exec.EventList {
 	return exec.EventList{exec.NewExistsEvent(a.id)}
 }
 
+func (a *genericCreateAction[GA, Alpha, Beta]) DryRunCalls() ([]exec.DryRunCall, error) {
+	call, err := a.Request()
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{call}, nil
+}
+
+// Request implements exec.Requester.
+func (a *genericCreateAction[GA, Alpha, Beta]) Request() (exec.DryRunCall, error) {
+	body, err := ResourceBody(a.resource)
+	if err != nil {
+		return exec.DryRunCall{}, err
+	}
+	return exec.DryRunCall{Method: "Insert", ResourceID: a.id, Body: body}, nil
+}
+
 func (a *genericCreateAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericCreateAction(%v)", a.id)
 }
 
 func (a *genericCreateAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
-		Name:    fmt.Sprintf("GenericCreateAction(%s)", a.id),
-		Type:    exec.ActionTypeCreate,
-		Summary: fmt.Sprintf("Create %s", a.id),
+		Name:       ActionName("GenericCreateAction", a.id),
+		Type:       exec.ActionTypeCreate,
+		Summary:    fmt.Sprintf("Create %s", a.id),
+		ResourceID: a.id,
 	}
 }
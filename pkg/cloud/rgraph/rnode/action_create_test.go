@@ -0,0 +1,135 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCreateActionsLastSynced(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		dryRun     bool
+		wantSynced bool
+	}{
+		{name: "run", wantSynced: true},
+		{name: "dry run", dryRun: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newBackendServiceTestNode(t, "desc")
+			res := node.resource.(api.Resource[compute.BackendService, alpha.BackendService, beta.BackendService])
+
+			actions, err := CreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&bsTestOps{}, node, res)
+			if err != nil {
+				t.Fatalf("CreateActions() = %v, want nil", err)
+			}
+			if !node.LastSynced().IsZero() {
+				t.Fatalf("LastSynced() = %v before Run, want zero", node.LastSynced())
+			}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+			ex, err := exec.NewSerialExecutor(mock, actions, exec.DryRunOption(tc.dryRun))
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background()); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if gotSynced := !node.LastSynced().IsZero(); gotSynced != tc.wantSynced {
+				t.Errorf("LastSynced() = %v; synced = %t, want %t", node.LastSynced(), gotSynced, tc.wantSynced)
+			}
+			if !tc.dryRun {
+				if _, err := mock.BackendServices().Get(context.Background(), meta.GlobalKey("bs")); err != nil {
+					t.Errorf("BackendServices().Get() = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateActionRequestID(t *testing.T) {
+	node := newBackendServiceTestNode(t, "desc")
+	res := node.resource.(api.Resource[compute.BackendService, alpha.BackendService, beta.BackendService])
+
+	newAction := func() exec.Action {
+		actions, err := CreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&bsTestOps{}, node, res)
+		if err != nil {
+			t.Fatalf("CreateActions() = %v, want nil", err)
+		}
+		if len(actions) != 1 {
+			t.Fatalf("len(actions) = %d, want 1", len(actions))
+		}
+		// Retry the Action once if there is an error.
+		var retried bool
+		return exec.NewRetriableAction(actions[0], func(error) (bool, time.Duration) {
+			if retried {
+				return false, 0
+			}
+			retried = true
+			return true, 0
+		})
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+	var requestIDs []string
+	mock.MockBackendServices.InsertHook = func(ctx context.Context, _ *meta.Key, _ *compute.BackendService, _ *cloud.MockBackendServices, _ ...cloud.Option) (bool, error) {
+		id, ok := cloud.RequestID(ctx)
+		if !ok {
+			t.Errorf("Insert() called without a request ID")
+		}
+		requestIDs = append(requestIDs, id)
+		// Fail the first call of each Action, which is then retried.
+		if len(requestIDs)%2 == 1 {
+			return true, &googleapi.Error{Code: http.StatusServiceUnavailable}
+		}
+		return true, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := newAction().Run(context.Background(), mock); err != nil {
+			t.Fatalf("Run() = %v, want nil", err)
+		}
+	}
+
+	if len(requestIDs) != 4 {
+		t.Fatalf("requestIDs = %v, want 4 calls", requestIDs)
+	}
+	if requestIDs[0] != requestIDs[1] {
+		t.Errorf("requestIDs = %v; retried Insert has a different request ID", requestIDs)
+	}
+	if requestIDs[0] == requestIDs[2] {
+		t.Errorf("requestIDs = %v; different Actions have the same request ID", requestIDs)
+	}
+	if !uuidRegexp.MatchString(requestIDs[0]) {
+		t.Errorf("request ID %q is not a UUID", requestIDs[0])
+	}
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
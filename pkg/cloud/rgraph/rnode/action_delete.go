@@ -35,6 +35,7 @@ func NewGenericDeleteAction[GA any, Alpha any, Beta any](
 		ops:        ops,
 		id:         got.ID(),
 		outRefs:    got.OutRefs(),
+		requestIDs: newRequestIDs(got.ID()),
 	}
 }
 
@@ -61,6 +62,8 @@ type genericDeleteAction[GA any, Alpha any, Beta any] struct {
 	ops     GenericOps[GA, Alpha, Beta]
 	id      *cloud.ResourceID
 	outRefs []ResourceRef
+	// requestIDs make the Delete idempotent across retries.
+	requestIDs requestIDs
 
 	start, end time.Time
 }
@@ -70,7 +73,7 @@ func (a *genericDeleteAction[GA, Alpha, Beta]) Run(
 	c cloud.Cloud,
 ) (exec.EventList, error) {
 	a.start = time.Now()
-	err := a.ops.DeleteFuncs(c).Do(ctx, a.id)
+	err := a.ops.DeleteFuncs(c).Do(a.requestIDs.context(ctx, "Delete"), a.id)
 
 	var events exec.EventList
 	// Event: Node no longer exists.
@@ -87,17 +90,27 @@ func (a *genericDeleteAction[GA, Alpha, Beta]) Run(
 func (a *genericDeleteAction[GA, Alpha, Beta]) DryRun() exec.EventList {
 	a.start = time.Now()
 	a.end = a.start
+	return a.Signals()
+}
+
+// Signals implements exec.Signaler.
+func (a *genericDeleteAction[GA, Alpha, Beta]) Signals() exec.EventList {
 	return exec.EventList{exec.NewNotExistsEvent(a.id)}
 }
 
+func (a *genericDeleteAction[GA, Alpha, Beta]) DryRunCalls() ([]exec.DryRunCall, error) {
+	return []exec.DryRunCall{{Method: "Delete", ResourceID: a.id}}, nil
+}
+
 func (a *genericDeleteAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericDeleteAction(%v)", a.id)
 }
 
 func (a *genericDeleteAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
-		Name:    fmt.Sprintf("GenericDeleteAction(%s)", a.id),
-		Type:    exec.ActionTypeDelete,
-		Summary: fmt.Sprintf("Delete %s", a.id),
+		Name:       ActionName("GenericDeleteAction", a.id),
+		Type:       exec.ActionTypeDelete,
+		Summary:    fmt.Sprintf("Delete %s", a.id),
+		ResourceID: a.id,
 	}
 }
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ActionName returns the name of an Action of the given kind operating on the
+// resource id, e.g. "GenericCreateAction(compute/backendServices:proj/global/bs)".
+// The name uses ResourceID.NodeID() so it does not collide between resources
+// with the same name in different API groups or scopes.
+func ActionName(kind string, id *cloud.ResourceID, args ...any) string {
+	ret := kind + "(" + id.NodeID()
+	for _, a := range args {
+		ret += fmt.Sprintf(", %v", a)
+	}
+	return ret + ")"
+}
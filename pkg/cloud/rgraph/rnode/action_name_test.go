@@ -0,0 +1,66 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestActionName(t *testing.T) {
+	t.Parallel()
+
+	computeID := &cloud.ResourceID{ProjectID: "proj", Resource: "backendServices", Key: meta.GlobalKey("x")}
+	nsID := &cloud.ResourceID{ProjectID: "proj", APIGroup: meta.APIGroupNetworkServices, Resource: "backendServices", Key: meta.GlobalKey("x")}
+
+	computeAction := &genericDeleteAction[compute.BackendService, alpha.BackendService, beta.BackendService]{id: computeID}
+	nsAction := &genericDeleteAction[compute.BackendService, alpha.BackendService, beta.BackendService]{id: nsID}
+
+	for _, tc := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "compute",
+			got:  computeAction.Metadata().Name,
+			want: "GenericDeleteAction(compute/backendServices:proj/global/x)",
+		},
+		{
+			name: "networkservices",
+			got:  nsAction.Metadata().Name,
+			want: "GenericDeleteAction(networkservices/backendServices:proj/global/x)",
+		},
+		{
+			name: "args",
+			got:  ActionName("ResizeAction", &cloud.ResourceID{ProjectID: "proj", Resource: "instanceGroupManagers", Key: meta.ZonalKey("x", "us-central1-b")}, 3),
+			want: "ResizeAction(compute/instanceGroupManagers:proj/zones/us-central1-b/x, 3)",
+		},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: Name = %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+	if computeAction.Metadata().Name == nsAction.Metadata().Name {
+		t.Errorf("compute and networkservices actions have the same name %q", computeAction.Metadata().Name)
+	}
+}
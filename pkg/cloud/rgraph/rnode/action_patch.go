@@ -0,0 +1,160 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+)
+
+// PatchActions returns the Actions to patch the resource from got to want.
+// Only the top-level fields that differ between got and want are sent to the
+// Patch method; the other fields are left unchanged by the API.
+func PatchActions[GA any, Alpha any, Beta any](
+	ops GenericPatchOps[GA, Alpha, Beta],
+	got, want Node,
+	resource api.Resource[GA, Alpha, Beta],
+	fingerprint string,
+) ([]exec.Action, error) {
+	gotRes, ok := got.Resource().(api.Resource[GA, Alpha, Beta])
+	if !ok {
+		return nil, fmt.Errorf("PatchActions: invalid type for got resource: %T", got.Resource())
+	}
+	diff, err := gotRes.Diff(resource)
+	if err != nil {
+		return nil, fmt.Errorf("PatchActions: %w", err)
+	}
+	return PatchActionsWithMask(ops, got, want, resource, diff.FieldMask(), fingerprint)
+}
+
+// PatchActionsWithMask is the same as PatchActions but sends the top-level
+// fields in mask (see api.DiffResult.FieldMask). This is for nodes that
+// ignore some of the differences between got and want when planning.
+func PatchActionsWithMask[GA any, Alpha any, Beta any](
+	ops GenericPatchOps[GA, Alpha, Beta],
+	got, want Node,
+	resource api.Resource[GA, Alpha, Beta],
+	mask []string,
+	fingerprint string,
+) ([]exec.Action, error) {
+	preEvents, err := updatePreconditions(got, want)
+	if err != nil {
+		return nil, err
+	}
+	postEvents := postUpdateActionEvents(got, want)
+	return []exec.Action{
+		newGenericPatchAction(preEvents, ops, want, resource, mask, postEvents, fingerprint),
+	}, nil
+}
+
+func newGenericPatchAction[GA any, Alpha any, Beta any](
+	want exec.EventList,
+	ops GenericPatchOps[GA, Alpha, Beta],
+	node Node,
+	resource api.Resource[GA, Alpha, Beta],
+	mask []string,
+	postEvents exec.EventList,
+	fingerprint string,
+) *genericPatchAction[GA, Alpha, Beta] {
+	return &genericPatchAction[GA, Alpha, Beta]{
+		ActionBase:   exec.ActionBase{Want: want},
+		syncRecorder: syncRecorder{node: node},
+		ops:          ops,
+		id:           node.ID(),
+		resource:     resource,
+		mask:         mask,
+		postEvents:   postEvents,
+		fingerprint:  fingerprint,
+		requestIDs:   newRequestIDs(node.ID()),
+	}
+}
+
+type genericPatchAction[GA any, Alpha any, Beta any] struct {
+	exec.ActionBase
+	syncRecorder
+	ops         GenericPatchOps[GA, Alpha, Beta]
+	id          *cloud.ResourceID
+	resource    api.Resource[GA, Alpha, Beta]
+	mask        []string
+	postEvents  exec.EventList
+	fingerprint string
+	// requestIDs make the Patch idempotent across retries.
+	requestIDs requestIDs
+
+	start, end time.Time
+}
+
+func (a *genericPatchAction[GA, Alpha, Beta]) Run(
+	ctx context.Context,
+	c cloud.Cloud,
+) (exec.EventList, error) {
+	a.start = time.Now()
+	err := retryOnStaleFingerprint(ctx, c, a.ops, a.node, a.resource.Version(), a.fingerprint, func(fingerprint string) error {
+		return a.ops.PatchFuncs(c).Do(a.requestIDs.context(ctx, "Patch/"+fingerprint), fingerprint, a.id, a.resource, a.mask)
+	})
+	a.end = time.Now()
+
+	// Emit DropReference events for removed references.
+	return a.postEvents, err
+}
+
+// Refresh implements exec.Refresher.
+func (a *genericPatchAction[GA, Alpha, Beta]) Refresh(ctx context.Context, c cloud.Cloud) error {
+	return refreshNode(ctx, c, a.ops, a.node, a.resource.Version())
+}
+
+func (a *genericPatchAction[GA, Alpha, Beta]) DryRun() exec.EventList {
+	// Emit DropReference events for removed references.
+	return a.postEvents
+}
+
+func (a *genericPatchAction[GA, Alpha, Beta]) DryRunCalls() ([]exec.DryRunCall, error) {
+	call, err := a.Request()
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{call}, nil
+}
+
+// Request implements exec.Requester. The body includes the fingerprint of the
+// current resource.
+func (a *genericPatchAction[GA, Alpha, Beta]) Request() (exec.DryRunCall, error) {
+	body, err := patchBody(a.resource, a.mask, a.fingerprint)
+	if err != nil {
+		return exec.DryRunCall{}, err
+	}
+	return exec.DryRunCall{Method: "Patch", ResourceID: a.id, Body: body}, nil
+}
+
+func (a *genericPatchAction[GA, Alpha, Beta]) String() string {
+	return fmt.Sprintf("GenericPatchAction(%v)", a.id)
+}
+
+func (a *genericPatchAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       ActionName("GenericPatchAction", a.id),
+		Type:       exec.ActionTypePatch,
+		Summary:    fmt.Sprintf("Patch %s (fields: %s)", a.id, strings.Join(a.mask, ", ")),
+		ResourceID: a.id,
+	}
+}
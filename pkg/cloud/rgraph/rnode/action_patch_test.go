@@ -0,0 +1,191 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/google/go-cmp/cmp"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type bsTestOps struct{}
+
+func (*bsTestOps) GetFuncs(cloud.Cloud) *GetFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return nil
+}
+func (*bsTestOps) CreateFuncs(gcp cloud.Cloud) *CreateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return &CreateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
+		GA: CreateFuncsByScope[compute.BackendService]{
+			Global: gcp.BackendServices().Insert,
+		},
+	}
+}
+func (*bsTestOps) UpdateFuncs(cloud.Cloud) *UpdateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return nil
+}
+func (*bsTestOps) DeleteFuncs(cloud.Cloud) *DeleteFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return nil
+}
+func (*bsTestOps) PatchFuncs(gcp cloud.Cloud) *PatchFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return &PatchFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
+		GA: PatchFuncsByScope[compute.BackendService]{
+			Global: gcp.BackendServices().Patch,
+		},
+	}
+}
+
+type resourceNode struct {
+	fakeNode
+	resource UntypedResource
+}
+
+func (n *resourceNode) Resource() UntypedResource { return n.resource }
+
+func newBackendServiceTestNode(t *testing.T, description string) *resourceNode {
+	t.Helper()
+
+	id := globalID("bs")
+	mr := api.NewResource[compute.BackendService, alpha.BackendService, beta.BackendService](id, nil)
+	if err := mr.Access(func(x *compute.BackendService) {
+		x.Description = description
+		x.Port = 80
+		x.Protocol = "TCP"
+	}); err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	r, err := mr.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	n := &resourceNode{resource: r}
+	n.id = id
+	n.state = NodeExists
+	n.ownership = OwnershipManaged
+	return n
+}
+
+func TestPatchActions(t *testing.T) {
+	got := newBackendServiceTestNode(t, "old")
+	want := newBackendServiceTestNode(t, "new")
+	wantRes := want.resource.(api.Resource[compute.BackendService, alpha.BackendService, beta.BackendService])
+
+	actions, err := PatchActions[compute.BackendService, alpha.BackendService, beta.BackendService](&bsTestOps{}, got, want, wantRes, "fp")
+	if err != nil {
+		t.Fatalf("PatchActions() = %v, want nil", err)
+	}
+	if len(actions) != 1 {
+		t.Fatalf("len(actions) = %d, want 1", len(actions))
+	}
+	if typ := actions[0].Metadata().Type; typ != exec.ActionTypePatch {
+		t.Errorf("Metadata().Type = %q, want %q", typ, exec.ActionTypePatch)
+	}
+	pa, ok := actions[0].(*genericPatchAction[compute.BackendService, alpha.BackendService, beta.BackendService])
+	if !ok {
+		t.Fatalf("actions[0] has type %T, want *genericPatchAction", actions[0])
+	}
+	if diff := cmp.Diff(pa.mask, []string{"Description"}); diff != "" {
+		t.Errorf("mask: -got,+want: %s", diff)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+	var patched *compute.BackendService
+	mock.MockBackendServices.PatchHook = func(_ context.Context, _ *meta.Key, obj *compute.BackendService, _ *cloud.MockBackendServices, _ ...cloud.Option) error {
+		patched = obj
+		return nil
+	}
+	if _, err := pa.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(patched, &compute.BackendService{Description: "new", Fingerprint: "fp"}); diff != "" {
+		t.Errorf("Patch(); -got,+want: %s", diff)
+	}
+}
+
+func TestPatchObject(t *testing.T) {
+	src := &compute.BackendService{
+		Name:        "bs",
+		Description: "",
+		Port:        80,
+		HealthChecks: []string{
+			"hc",
+		},
+		Backends:  []*compute.Backend{},
+		Metadatas: map[string]string{},
+	}
+	for _, tc := range []struct {
+		desc    string
+		mask    []string
+		want    *compute.BackendService
+		wantErr bool
+	}{
+		{
+			desc: "empty mask",
+			want: &compute.BackendService{},
+		},
+		{
+			desc: "non-zero fields",
+			mask: []string{"Port", "HealthChecks"},
+			want: &compute.BackendService{Port: 80, HealthChecks: []string{"hc"}},
+		},
+		{
+			desc: "zero basic field is force sent",
+			mask: []string{"Description"},
+			want: &compute.BackendService{ForceSendFields: []string{"Description"}},
+		},
+		{
+			desc: "zero pointer field is nulled",
+			mask: []string{"CdnPolicy"},
+			want: &compute.BackendService{NullFields: []string{"CdnPolicy"}},
+		},
+		{
+			desc: "empty slice field is nulled",
+			mask: []string{"Backends"},
+			want: &compute.BackendService{NullFields: []string{"Backends"}},
+		},
+		{
+			desc: "empty map field is nulled",
+			mask: []string{"Metadatas"},
+			want: &compute.BackendService{NullFields: []string{"Metadatas"}},
+		},
+		{
+			desc:    "invalid field",
+			mask:    []string{"NoSuchField"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := patchObject(src, tc.mask)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("patchObject() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("patchObject(); -got,+want: %s", diff)
+			}
+		})
+	}
+}
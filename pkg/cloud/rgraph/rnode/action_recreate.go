@@ -21,6 +21,18 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 )
 
+// RecreateActions returns the Actions to delete and then create the resource.
+//
+// The delete waits for every inRef in the "got" graph to be dropped and the
+// create waits for the delete and for its outRefs to exist. A Node that
+// references the recreated resource is itself planned for recreate (see
+// workflow/plan), so the execution order is:
+//
+//	delete(referencing) -> delete(resource) -> create(resource) -> create(referencing)
+//
+// For example, a BackendService recreate will not remove the BackendService
+// while a TcpRoute in the graph still points to it. References from resources
+// that are not in the graph are not tracked.
 func RecreateActions[GA any, Alpha any, Beta any](
 	ops GenericOps[GA, Alpha, Beta],
 	got, want Node,
@@ -34,7 +46,7 @@ func RecreateActions[GA any, Alpha any, Beta any](
 	}
 	// Condition: resource must have been deleted.
 	createEvents = append(createEvents, exec.NewNotExistsEvent(want.ID()))
-	createAction := newGenericCreateAction(createEvents, ops, want.ID(), resource)
+	createAction := newGenericCreateAction(createEvents, ops, want, resource)
 
 	return []exec.Action{deleteAction, createAction}, nil
 }
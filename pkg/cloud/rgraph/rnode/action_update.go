@@ -39,35 +39,40 @@ func UpdateActions[GA any, Alpha any, Beta any](
 	}
 	postEvents := postUpdateActionEvents(got, want)
 	return []exec.Action{
-		newGenericUpdateAction(preEvents, ops, want.ID(), resource, postEvents, fingerprint),
+		newGenericUpdateAction(preEvents, ops, want, resource, postEvents, fingerprint),
 	}, nil
 }
 
 func newGenericUpdateAction[GA any, Alpha any, Beta any](
 	want exec.EventList,
 	ops GenericOps[GA, Alpha, Beta],
-	id *cloud.ResourceID,
+	node Node,
 	resource api.Resource[GA, Alpha, Beta],
 	postEvents exec.EventList,
 	fingerprint string,
 ) *genericUpdateAction[GA, Alpha, Beta] {
 	return &genericUpdateAction[GA, Alpha, Beta]{
-		ActionBase:  exec.ActionBase{Want: want},
-		ops:         ops,
-		id:          id,
-		resource:    resource,
-		postEvents:  postEvents,
-		fingerprint: fingerprint,
+		ActionBase:   exec.ActionBase{Want: want},
+		syncRecorder: syncRecorder{node: node},
+		ops:          ops,
+		id:           node.ID(),
+		resource:     resource,
+		postEvents:   postEvents,
+		fingerprint:  fingerprint,
+		requestIDs:   newRequestIDs(node.ID()),
 	}
 }
 
 type genericUpdateAction[GA any, Alpha any, Beta any] struct {
 	exec.ActionBase
+	syncRecorder
 	ops         GenericOps[GA, Alpha, Beta]
 	id          *cloud.ResourceID
 	resource    api.Resource[GA, Alpha, Beta]
 	postEvents  exec.EventList
 	fingerprint string
+	// requestIDs make the Update idempotent across retries.
+	requestIDs requestIDs
 
 	start, end time.Time
 }
@@ -77,27 +82,55 @@ func (a *genericUpdateAction[GA, Alpha, Beta]) Run(
 	c cloud.Cloud,
 ) (exec.EventList, error) {
 	a.start = time.Now()
-	err := a.ops.UpdateFuncs(c).Do(ctx, a.fingerprint, a.id, a.resource)
+	err := retryOnStaleFingerprint(ctx, c, a.ops, a.node, a.resource.Version(), a.fingerprint, func(fingerprint string) error {
+		// The fingerprint changes if the Update is retried due to a stale
+		// fingerprint; this is a different call.
+		return a.ops.UpdateFuncs(c).Do(a.requestIDs.context(ctx, "Update/"+fingerprint), fingerprint, a.id, a.resource)
+	})
 	a.end = time.Now()
 
 	// Emit DropReference events for removed references.
 	return a.postEvents, err
 }
 
+// Refresh implements exec.Refresher.
+func (a *genericUpdateAction[GA, Alpha, Beta]) Refresh(ctx context.Context, c cloud.Cloud) error {
+	return refreshNode(ctx, c, a.ops, a.node, a.resource.Version())
+}
+
 func (a *genericUpdateAction[GA, Alpha, Beta]) DryRun() exec.EventList {
 	// Emit DropReference events for removed references.
 	return a.postEvents
 }
 
+func (a *genericUpdateAction[GA, Alpha, Beta]) DryRunCalls() ([]exec.DryRunCall, error) {
+	call, err := a.Request()
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{call}, nil
+}
+
+// Request implements exec.Requester. The body includes the fingerprint of the
+// current resource.
+func (a *genericUpdateAction[GA, Alpha, Beta]) Request() (exec.DryRunCall, error) {
+	body, err := updateBody(a.resource, a.fingerprint)
+	if err != nil {
+		return exec.DryRunCall{}, err
+	}
+	return exec.DryRunCall{Method: "Update", ResourceID: a.id, Body: body}, nil
+}
+
 func (a *genericUpdateAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericUpdateAction(%v)", a.id)
 }
 
 func (a *genericUpdateAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
-		Name:    fmt.Sprintf("GenericUpdateAction(%s)", a.id),
-		Type:    exec.ActionTypeUpdate,
-		Summary: fmt.Sprintf("Update %s", a.id),
+		Name:       ActionName("GenericUpdateAction", a.id),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("Update %s", a.id),
+		ResourceID: a.id,
 	}
 }
 
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package address
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+func newSetLabelsAction(id *cloud.ResourceID, labelFingerprint string, labels map[string]string) *setLabelsAction {
+	return &setLabelsAction{
+		id:               id,
+		labelFingerprint: labelFingerprint,
+		labels:           labels,
+	}
+}
+
+// setLabelsAction updates the .Labels of the Address. Labels can only be
+// changed with the setLabels method.
+type setLabelsAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// labelFingerprint of the current resource.
+	labelFingerprint string
+	labels           map[string]string
+}
+
+func (act *setLabelsAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	var err error
+	switch act.id.Scope() {
+	case meta.Global:
+		err = cl.GlobalAddresses().SetLabels(ctx, act.id.Key, &compute.GlobalSetLabelsRequest{
+			LabelFingerprint: act.labelFingerprint,
+			Labels:           act.labels,
+		})
+	case meta.Regional:
+		err = cl.Addresses().SetLabels(ctx, act.id.Key, &compute.RegionSetLabelsRequest{
+			LabelFingerprint: act.labelFingerprint,
+			Labels:           act.labels,
+		})
+	default:
+		err = fmt.Errorf("invalid key type %v", act.id.Scope())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	return nil, nil
+}
+
+func (act *setLabelsAction) DryRun() exec.EventList { return nil }
+
+func (act *setLabelsAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	body, err := json.Marshal(&compute.GlobalSetLabelsRequest{
+		LabelFingerprint: act.labelFingerprint,
+		Labels:           act.labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: "SetLabels", ResourceID: act.id, Body: string(body)}}, nil
+}
+
+func (act *setLabelsAction) String() string {
+	return fmt.Sprintf("AddressSetLabelsAction(%s)", act.id)
+}
+
+func (act *setLabelsAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("AddressSetLabelsAction", act.id),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("SetLabels on %s", act.id),
+		ResourceID: act.id,
+	}
+}
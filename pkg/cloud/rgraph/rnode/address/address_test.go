@@ -17,9 +17,14 @@ limitations under the License.
 package address
 
 import (
+	"context"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -61,6 +66,7 @@ func TestAddressFieldTraits(t *testing.T) {
 				Region:            "zzz",
 				SelfLink:          "zzz",
 				Users:             []string{"zzz"},
+				LabelFingerprint:  "zzz",
 			},
 			b: &compute.Address{
 				Name:    "addr-1",
@@ -107,3 +113,88 @@ func TestAddressFieldTraits(t *testing.T) {
 		})
 	}
 }
+
+func TestAddressSetLabels(t *testing.T) {
+	const (
+		proj             = "proj-1"
+		labelFingerprint = "label-fp"
+	)
+	makeNode := func(t *testing.T, key *meta.Key, x *compute.Address) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableAddress(proj, key), x, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		key    *meta.Key
+		want   *compute.Address
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "global labels",
+			key:    meta.GlobalKey("addr"),
+			want:   &compute.Address{Name: "addr", Labels: map[string]string{"k": "v2"}},
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "regional labels",
+			key:    meta.RegionalKey("addr", "us-central1"),
+			want:   &compute.Address{Name: "addr", Labels: map[string]string{"k": "v2"}},
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "labels and network tier",
+			key:    meta.GlobalKey("addr"),
+			want:   &compute.Address{Name: "addr", Labels: map[string]string{"k": "v2"}, NetworkTier: "STANDARD"},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, tc.key, &compute.Address{
+				Name:             "addr",
+				Labels:           map[string]string{"k": "v1"},
+				LabelFingerprint: labelFingerprint,
+			})
+			want := makeNode(t, tc.key, tc.want)
+
+			details, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (details: %+v)", details.Operation, tc.wantOp, details)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+
+			var gotFingerprint string
+			var gotLabels map[string]string
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			mock.MockGlobalAddresses.SetLabelsHook = func(_ context.Context, _ *meta.Key, req *compute.GlobalSetLabelsRequest, _ *cloud.MockGlobalAddresses, _ ...cloud.Option) error {
+				gotFingerprint, gotLabels = req.LabelFingerprint, req.Labels
+				return nil
+			}
+			mock.MockAddresses.SetLabelsHook = func(_ context.Context, _ *meta.Key, req *compute.RegionSetLabelsRequest, _ *cloud.MockAddresses, _ ...cloud.Option) error {
+				gotFingerprint, gotLabels = req.LabelFingerprint, req.Labels
+				return nil
+			}
+			for _, a := range actions {
+				if _, err := a.Run(context.Background(), mock); err != nil {
+					t.Fatalf("%v.Run() = %v, want nil", a, err)
+				}
+			}
+			if gotFingerprint != labelFingerprint {
+				t.Errorf("SetLabels() LabelFingerprint = %q, want %q", gotFingerprint, labelFingerprint)
+			}
+			if diff := cmp.Diff(gotLabels, tc.want.Labels); diff != "" {
+				t.Errorf("SetLabels() Labels: -got,+want: %s", diff)
+			}
+		})
+	}
+}
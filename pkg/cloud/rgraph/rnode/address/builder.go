@@ -27,6 +27,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("addresses", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.BuilderBase.Defaults(id)
@@ -39,9 +41,6 @@ func NewBuilderWithResource(r Address) rnode.Builder {
 	return b
 }
 
-// Unimplemented fields and methods:
-// - .Labels, setLabels(). Impacts CreateAction, Diff and Update.
-
 type builder struct {
 	rnode.BuilderBase
 	resource Address
@@ -65,6 +64,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 	return rnode.GenericGet[compute.Address, alpha.Address, beta.Address](ctx, gcp, "Address", &ops{}, &typeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.Address, alpha.Address, beta.Address](&typeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	// Address does not have any outgoing resource references.
 	return nil, nil
@@ -19,6 +19,7 @@ package address
 import (
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -47,10 +48,19 @@ func (n *addressNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 	}
 
 	if diff.HasDiff() {
-		// TODO: setLabels() when the field goes GA.
+		for _, item := range diff.Items {
+			if !item.Path.HasPrefix(api.Path{}.Pointer().Field("Labels")) {
+				return &rnode.PlanDetails{
+					Operation: rnode.OpRecreate,
+					Why:       "Address needs to be recreated (no update method exists)",
+					Diff:      diff,
+				}, nil
+			}
+		}
+		// Only .Labels changed, which can be updated with setLabels().
 		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "Address needs to be recreated (no update method exists)",
+			Operation: rnode.OpUpdate,
+			Why:       "Address labels need to be updated",
 			Diff:      diff,
 		}, nil
 	}
@@ -66,8 +76,6 @@ func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
 
 	switch op {
 	case rnode.OpCreate:
-		// TODO: .Labels can only be updated via the setLabels method. This is
-		// currently in Beta and we don't support it.
 		return rnode.CreateActions[compute.Address, alpha.Address, beta.Address](&ops{}, n, n.resource)
 
 	case rnode.OpDelete:
@@ -80,7 +88,7 @@ func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		return rnode.RecreateActions[compute.Address, alpha.Address, beta.Address](&ops{}, got, n, n.resource)
 
 	case rnode.OpUpdate:
-		return nil, fmt.Errorf("%s is not supported for Address", op)
+		return n.updateActions(got)
 	}
 
 	return nil, fmt.Errorf("AddressNode: invalid plan op %s", op)
@@ -89,5 +97,28 @@ func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *addressNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
+
+// updateActions returns the Action to update the .Labels of the Address. The
+// setLabels() call requires the LabelFingerprint of the current resource.
+func (n *addressNode) updateActions(got rnode.Node) ([]exec.Action, error) {
+	gotRes, ok := got.Resource().(Address)
+	if !ok {
+		return nil, fmt.Errorf("AddressNode: invalid type for updateActions: %T", got.Resource())
+	}
+	labelFingerprint, err := rnode.LabelFingerprint(gotRes)
+	if err != nil {
+		return nil, fmt.Errorf("AddressNode: updateActions %s: %w", n.ID(), err)
+	}
+	wantRes, _ := n.resource.ToGA()
+
+	return []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+		newSetLabelsAction(n.ID(), labelFingerprint, wantRes.Labels),
+	}, nil
+}
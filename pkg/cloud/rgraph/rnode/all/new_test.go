@@ -0,0 +1,54 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package all
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
+)
+
+func TestRegisteredTypes(t *testing.T) {
+	registered := map[string]bool{}
+	for _, r := range rnode.RegisteredTypes() {
+		registered[r] = true
+	}
+	for _, id := range []*cloud.ResourceID{
+		backendservice.ID("proj", meta.GlobalKey("x")),
+		healthcheck.ID("proj", meta.GlobalKey("x")),
+		networkendpointgroup.ID("proj", meta.ZonalKey("x", "us-central1-b")),
+		tcproute.ID("proj", meta.GlobalKey("x")),
+	} {
+		if !registered[id.Resource] {
+			t.Errorf("%q not in RegisteredTypes() = %v", id.Resource, rnode.RegisteredTypes())
+		}
+		b, err := NewBuilderByID(id)
+		if err != nil {
+			t.Errorf("NewBuilderByID(%v) = %v, want nil", id, err)
+			continue
+		}
+		if !b.ID().Equal(id) {
+			t.Errorf("NewBuilderByID(%v).ID() = %v, want %v", id, b.ID(), id)
+		}
+	}
+}
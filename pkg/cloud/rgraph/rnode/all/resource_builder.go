@@ -26,8 +26,19 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/httphealthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instance"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroupmanager"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancetemplate"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/securitypolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslcertificate"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslpolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targetgrpcproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targetpool"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
 	"google.golang.org/api/compute/v1"
@@ -85,13 +96,39 @@ func (b *ResourceBuilder) Address() *AddressBuilder               { return &Addr
 func (b *ResourceBuilder) BackendService() *BackendServiceBuilder { return &BackendServiceBuilder{*b} }
 func (b *ResourceBuilder) ForwardingRule() *ForwardingRuleBuilder { return &ForwardingRuleBuilder{*b} }
 func (b *ResourceBuilder) HealthCheck() *HealthCheckBuilder       { return &HealthCheckBuilder{*b} }
+func (b *ResourceBuilder) HttpHealthCheck() *HttpHealthCheckBuilder {
+	return &HttpHealthCheckBuilder{*b}
+}
+func (b *ResourceBuilder) Instance() *InstanceBuilder { return &InstanceBuilder{*b} }
+func (b *ResourceBuilder) InstanceGroupManager() *InstanceGroupManagerBuilder {
+	return &InstanceGroupManagerBuilder{*b}
+}
+func (b *ResourceBuilder) InstanceTemplate() *InstanceTemplateBuilder {
+	return &InstanceTemplateBuilder{*b}
+}
+func (b *ResourceBuilder) Network() *NetworkBuilder { return &NetworkBuilder{*b} }
 func (b *ResourceBuilder) NetworkEndpointGroup() *NetworkEndpointGroupBuilder {
 	return &NetworkEndpointGroupBuilder{*b}
 }
+func (b *ResourceBuilder) SecurityPolicy() *SecurityPolicyBuilder {
+	return &SecurityPolicyBuilder{*b}
+}
+func (b *ResourceBuilder) SslCertificate() *SslCertificateBuilder {
+	return &SslCertificateBuilder{*b}
+}
+func (b *ResourceBuilder) SslPolicy() *SslPolicyBuilder { return &SslPolicyBuilder{*b} }
+func (b *ResourceBuilder) TargetGrpcProxy() *TargetGrpcProxyBuilder {
+	return &TargetGrpcProxyBuilder{*b}
+}
 func (b *ResourceBuilder) TargetHttpProxy() *TargetHttpProxyBuilder {
 	return &TargetHttpProxyBuilder{*b}
 }
-func (b *ResourceBuilder) UrlMap() *UrlMapBuilder { return &UrlMapBuilder{*b} }
+func (b *ResourceBuilder) TargetHttpsProxy() *TargetHttpsProxyBuilder {
+	return &TargetHttpsProxyBuilder{*b}
+}
+func (b *ResourceBuilder) TargetPool() *TargetPoolBuilder { return &TargetPoolBuilder{*b} }
+func (b *ResourceBuilder) TcpRoute() *TcpRouteBuilder     { return &TcpRouteBuilder{*b} }
+func (b *ResourceBuilder) UrlMap() *UrlMapBuilder         { return &UrlMapBuilder{*b} }
 
 type AddressBuilder struct{ ResourceBuilder }
 
@@ -177,6 +214,112 @@ func (b *HealthCheckBuilder) Build(f func(*compute.HealthCheck)) rnode.Builder {
 	return nb
 }
 
+type HttpHealthCheckBuilder struct{ ResourceBuilder }
+
+func (b *HttpHealthCheckBuilder) ID() *cloud.ResourceID {
+	return httphealthcheck.ID(b.Project, b.Key())
+}
+func (b *HttpHealthCheckBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *HttpHealthCheckBuilder) Resource() httphealthcheck.MutableHttpHealthCheck {
+	return httphealthcheck.NewMutableHttpHealthCheck(b.Project, b.Key())
+}
+
+func (b *HttpHealthCheckBuilder) Build(f func(*compute.HttpHealthCheck)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := httphealthcheck.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type InstanceBuilder struct{ ResourceBuilder }
+
+func (b *InstanceBuilder) ID() *cloud.ResourceID { return instance.ID(b.Project, b.Key()) }
+func (b *InstanceBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *InstanceBuilder) Resource() instance.MutableInstance {
+	return instance.NewMutableInstance(b.Project, b.Key())
+}
+
+func (b *InstanceBuilder) Build(f func(*compute.Instance)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := instance.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type InstanceGroupManagerBuilder struct{ ResourceBuilder }
+
+func (b *InstanceGroupManagerBuilder) ID() *cloud.ResourceID {
+	return instancegroupmanager.ID(b.Project, b.Key())
+}
+func (b *InstanceGroupManagerBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *InstanceGroupManagerBuilder) Resource() instancegroupmanager.MutableInstanceGroupManager {
+	return instancegroupmanager.NewMutableInstanceGroupManager(b.Project, b.Key())
+}
+
+func (b *InstanceGroupManagerBuilder) Build(f func(*compute.InstanceGroupManager)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := instancegroupmanager.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type InstanceTemplateBuilder struct{ ResourceBuilder }
+
+func (b *InstanceTemplateBuilder) ID() *cloud.ResourceID {
+	return instancetemplate.ID(b.Project, b.Key())
+}
+func (b *InstanceTemplateBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *InstanceTemplateBuilder) Resource() instancetemplate.MutableInstanceTemplate {
+	return instancetemplate.NewMutableInstanceTemplate(b.Project, b.Key())
+}
+
+func (b *InstanceTemplateBuilder) Build(f func(*compute.InstanceTemplate)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := instancetemplate.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type NetworkBuilder struct{ ResourceBuilder }
+
+func (b *NetworkBuilder) ID() *cloud.ResourceID { return network.ID(b.Project, b.Key()) }
+func (b *NetworkBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *NetworkBuilder) Resource() network.MutableNetwork {
+	return network.NewMutableNetwork(b.Project, b.Key())
+}
+
+func (b *NetworkBuilder) Build(f func(*compute.Network)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := network.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
 type NetworkEndpointGroupBuilder struct{ ResourceBuilder }
 
 func (b *NetworkEndpointGroupBuilder) ID() *cloud.ResourceID {
@@ -199,6 +342,92 @@ func (b *NetworkEndpointGroupBuilder) Build(f func(*compute.NetworkEndpointGroup
 	return nb
 }
 
+type SecurityPolicyBuilder struct{ ResourceBuilder }
+
+func (b *SecurityPolicyBuilder) ID() *cloud.ResourceID {
+	return securitypolicy.ID(b.Project, b.Key())
+}
+func (b *SecurityPolicyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *SecurityPolicyBuilder) Resource() securitypolicy.MutableSecurityPolicy {
+	return securitypolicy.NewMutableSecurityPolicy(b.Project, b.Key())
+}
+
+func (b *SecurityPolicyBuilder) Build(f func(*compute.SecurityPolicy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := securitypolicy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type SslCertificateBuilder struct{ ResourceBuilder }
+
+func (b *SslCertificateBuilder) ID() *cloud.ResourceID {
+	return sslcertificate.ID(b.Project, b.Key())
+}
+func (b *SslCertificateBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *SslCertificateBuilder) Resource() sslcertificate.MutableSslCertificate {
+	return sslcertificate.NewMutableSslCertificate(b.Project, b.Key())
+}
+
+func (b *SslCertificateBuilder) Build(f func(*compute.SslCertificate)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := sslcertificate.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type SslPolicyBuilder struct{ ResourceBuilder }
+
+func (b *SslPolicyBuilder) ID() *cloud.ResourceID { return sslpolicy.ID(b.Project, b.Key()) }
+func (b *SslPolicyBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *SslPolicyBuilder) Resource() sslpolicy.MutableSslPolicy {
+	return sslpolicy.NewMutableSslPolicy(b.Project, b.Key())
+}
+
+func (b *SslPolicyBuilder) Build(f func(*compute.SslPolicy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := sslpolicy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type TargetGrpcProxyBuilder struct{ ResourceBuilder }
+
+func (b *TargetGrpcProxyBuilder) ID() *cloud.ResourceID {
+	return targetgrpcproxy.ID(b.Project, b.Key())
+}
+func (b *TargetGrpcProxyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetGrpcProxyBuilder) Resource() targetgrpcproxy.MutableTargetGrpcProxy {
+	return targetgrpcproxy.NewMutableTargetGrpcProxy(b.Project, b.Key())
+}
+
+func (b *TargetGrpcProxyBuilder) Build(f func(*compute.TargetGrpcProxy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targetgrpcproxy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
 type TargetHttpProxyBuilder struct{ ResourceBuilder }
 
 func (b *TargetHttpProxyBuilder) ID() *cloud.ResourceID {
@@ -221,6 +450,48 @@ func (b *TargetHttpProxyBuilder) Build(f func(*compute.TargetHttpProxy)) rnode.B
 	return nb
 }
 
+type TargetHttpsProxyBuilder struct{ ResourceBuilder }
+
+func (b *TargetHttpsProxyBuilder) ID() *cloud.ResourceID {
+	return targethttpsproxy.ID(b.Project, b.Key())
+}
+func (b *TargetHttpsProxyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetHttpsProxyBuilder) Resource() targethttpsproxy.MutableTargetHttpsProxy {
+	return targethttpsproxy.NewMutableTargetHttpsProxy(b.Project, b.Key())
+}
+
+func (b *TargetHttpsProxyBuilder) Build(f func(*compute.TargetHttpsProxy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targethttpsproxy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type TargetPoolBuilder struct{ ResourceBuilder }
+
+func (b *TargetPoolBuilder) ID() *cloud.ResourceID { return targetpool.ID(b.Project, b.Key()) }
+func (b *TargetPoolBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetPoolBuilder) Resource() targetpool.MutableTargetPool {
+	return targetpool.NewMutableTargetPool(b.Project, b.Key())
+}
+
+func (b *TargetPoolBuilder) Build(f func(*compute.TargetPool)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targetpool.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
 type UrlMapBuilder struct{ ResourceBuilder }
 
 func (b *UrlMapBuilder) ID() *cloud.ResourceID { return urlmap.ID(b.Project, b.Key()) }
@@ -18,7 +18,11 @@ package backendservice
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -31,12 +35,15 @@ import (
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
 )
 
 const (
 	proj           = "proj-1"
 	hcSelfLink     = "https://www.googleapis.com/compute/v1/projects/proj-1/global/healthChecks/hcName"
 	fingerprintStr = "abcds"
+	netSelfLink    = "https://www.googleapis.com/compute/v1/projects/proj-1/global/networks/default"
+	newNetSelfLink = "https://www.googleapis.com/compute/v1/projects/proj-1/global/networks/new-net"
 )
 
 func TestBackendServiceSchema(t *testing.T) {
@@ -47,6 +54,105 @@ func TestBackendServiceSchema(t *testing.T) {
 	}
 }
 
+func TestBackendServiceAllowedValues(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		f       func(x *compute.BackendService)
+		wantErr []string
+	}{
+		{
+			name: "valid enums",
+			f: func(x *compute.BackendService) {
+				x.LoadBalancingScheme = "INTERNAL_MANAGED"
+				x.Protocol = "HTTP"
+				x.SessionAffinity = "CLIENT_IP"
+			},
+		},
+		{
+			name:    "invalid Protocol",
+			f:       func(x *compute.BackendService) { x.Protocol = "FOO" },
+			wantErr: []string{"Protocol", `"FOO"`, "[GRPC HTTP HTTP2 HTTPS SSL TCP UDP UNSPECIFIED]"},
+		},
+		{
+			name:    "invalid LoadBalancingScheme",
+			f:       func(x *compute.BackendService) { x.LoadBalancingScheme = "FOO" },
+			wantErr: []string{"LoadBalancingScheme"},
+		},
+		{
+			name:    "invalid SessionAffinity",
+			f:       func(x *compute.BackendService) { x.SessionAffinity = "FOO" },
+			wantErr: []string{"SessionAffinity"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := NewMutableBackendService(proj, meta.GlobalKey("bs"))
+			err := x.Access(func(x *compute.BackendService) {
+				x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+				x.Protocol = "TCP"
+				x.SessionAffinity = "NONE"
+				x.TimeoutSec = 30
+				tc.f(x)
+			})
+			if gotErr := err != nil; gotErr != (tc.wantErr != nil) {
+				t.Fatalf("Access() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr != nil)
+			}
+			for _, s := range tc.wantErr {
+				if !strings.Contains(err.Error(), s) {
+					t.Errorf("Access() = %v, want error containing %q", err, s)
+				}
+			}
+		})
+	}
+}
+
+func TestBackendServiceExportSchema(t *testing.T) {
+	for _, tc := range []struct {
+		ver  meta.Version
+		want map[string]api.FieldSchema
+	}{
+		{
+			ver: meta.VersionGA,
+			want: map[string]api.FieldSchema{
+				"Fingerprint": {Path: "Fingerprint", Type: api.FieldTypeOutputOnly},
+				"SelfLink":    {Path: "SelfLink", Type: api.FieldTypeOutputOnly},
+				"Network":     {Path: "Network", Immutable: true},
+			},
+		},
+		{
+			ver: meta.VersionAlpha,
+			want: map[string]api.FieldSchema{
+				"SelfLinkWithId": {Path: "SelfLinkWithId", Type: api.FieldTypeOutputOnly},
+			},
+		},
+	} {
+		t.Run(string(tc.ver), func(t *testing.T) {
+			x := NewMutableBackendService(proj, meta.GlobalKey("bs"))
+			b, err := x.ExportSchema(tc.ver)
+			if err != nil {
+				t.Fatalf("ExportSchema(%s) = %v, want nil", tc.ver, err)
+			}
+			var schema api.Schema
+			if err := json.Unmarshal(b, &schema); err != nil {
+				t.Fatalf("json.Unmarshal() = %v, want nil", err)
+			}
+			fields := map[string]api.FieldSchema{}
+			for _, f := range schema.Fields {
+				fields[f.Path] = f
+			}
+
+			lbs := fields["LoadBalancingScheme"]
+			if !lbs.Immutable || lbs.Type != api.FieldTypeNonZeroValue || len(lbs.AllowedValues) == 0 {
+				t.Errorf("LoadBalancingScheme = %+v, want Immutable NonZeroValue with AllowedValues", lbs)
+			}
+			for path, want := range tc.want {
+				if diff := cmp.Diff(fields[path], want); diff != "" {
+					t.Errorf("%s: diff -got,+want: %s", path, diff)
+				}
+			}
+		})
+	}
+}
+
 func createBackendServiceNode(name string, setFun func(x MutableBackendService) error) (*backendServiceNode, error) {
 	bsID := ID(proj, meta.GlobalKey(name))
 	bsMutResource := NewMutableBackendService(proj, bsID.Key)
@@ -132,7 +238,7 @@ func TestActionUpdate(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -157,7 +263,7 @@ func TestActionUpdate(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -218,6 +324,375 @@ func TestActionUpdate(t *testing.T) {
 	}
 }
 
+func TestActionUpdateRequest(t *testing.T) {
+	gotNode, err := createBackendServiceNode("bs-name", func(m MutableBackendService) error {
+		return m.Access(func(x *compute.BackendService) {
+			x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+			x.Protocol = "TCP"
+			x.Port = 80
+			x.HealthChecks = []string{hcSelfLink}
+			x.CompressionMode = "DISABLED"
+			x.ConnectionDraining = &compute.ConnectionDraining{}
+			x.SessionAffinity = "NONE"
+			x.TimeoutSec = 30
+		})
+	})
+	if err != nil {
+		t.Fatalf("createBackendServiceNode(bs-name, _) = %v, want nil", err)
+	}
+	bsID := ID(proj, meta.GlobalKey("bs-name"))
+	wantResource := createBackendServiceResource(t, bsID, func(m MutableBackendService) error {
+		return m.Access(func(x *compute.BackendService) {
+			x.HealthChecks = []string{hcSelfLink}
+			x.TimeoutSec = 60
+		})
+	}).(BackendService)
+
+	f, err := fingerprint(gotNode)
+	if err != nil {
+		t.Fatalf("fingerprint(_) = %v, want nil", err)
+	}
+	actions, err := rnode.UpdateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, gotNode, gotNode, wantResource, f)
+	if err != nil {
+		t.Fatalf("rnode.UpdateActions[]() = %v, want nil", err)
+	}
+
+	call, ok, err := exec.ActionRequest(actions[0])
+	if !ok || err != nil {
+		t.Fatalf("ActionRequest(%v) = _, %t, %v, want _, true, nil", actions[0], ok, err)
+	}
+	if call.Method != "Update" {
+		t.Errorf("call.Method = %q, want Update", call.Method)
+	}
+	var body compute.BackendService
+	if err := json.Unmarshal([]byte(call.Body), &body); err != nil {
+		t.Fatalf("json.Unmarshal(%q) = %v, want nil", call.Body, err)
+	}
+	if body.Fingerprint != fingerprintStr {
+		t.Errorf("body.Fingerprint = %q, want %q", body.Fingerprint, fingerprintStr)
+	}
+	if body.TimeoutSec != 60 {
+		t.Errorf("body.TimeoutSec = %d, want 60", body.TimeoutSec)
+	}
+	// The request body must not modify the want resource.
+	if obj, _ := wantResource.ToGA(); obj.Fingerprint != "" {
+		t.Errorf("wantResource Fingerprint = %q, want empty", obj.Fingerprint)
+	}
+}
+
+// afterAction is an Action that also waits for the events in after.
+type afterAction struct {
+	exec.Action
+	after exec.ActionBase
+}
+
+func (a *afterAction) CanRun() bool { return a.Action.CanRun() && a.after.CanRun() }
+
+func (a *afterAction) Signal(ev exec.Event) bool {
+	signalled := a.Action.Signal(ev)
+	return a.after.Signal(ev) || signalled
+}
+
+func (a *afterAction) PendingEvents() exec.EventList {
+	return append(a.Action.PendingEvents(), a.after.PendingEvents()...)
+}
+
+func (a *afterAction) Refresh(ctx context.Context, c cloud.Cloud) error {
+	return a.Action.(exec.Refresher).Refresh(ctx, c)
+}
+
+func TestActionUpdateRefresh(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		refresh      bool
+		wantUpdates  int
+		wantRejected int
+	}{
+		{desc: "refresh", refresh: true, wantUpdates: 2},
+		// Without the refresh, the second update uses the fingerprint from
+		// planning that was changed by the first update. The update is
+		// rejected and retried with the fresh fingerprint.
+		{desc: "no refresh", wantUpdates: 2, wantRejected: 1},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			node, err := createBackendServiceNode("bs-name", func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+					x.Protocol = "TCP"
+					x.Port = 80
+					x.CompressionMode = "DISABLED"
+					x.ConnectionDraining = &compute.ConnectionDraining{}
+					x.SessionAffinity = "NONE"
+					x.TimeoutSec = 30
+				})
+			})
+			if err != nil {
+				t.Fatalf("createBackendServiceNode(bs-name, _) = %v, want nil", err)
+			}
+
+			mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			key := node.ID().Key
+			if err := mockCloud.BackendServices().Insert(context.Background(), key, &compute.BackendService{Name: key.Name, Fingerprint: fingerprintStr}); err != nil {
+				t.Fatalf("Insert() = %v, want nil", err)
+			}
+			var updates, rejected int
+			mockCloud.MockBackendServices.UpdateHook = func(ctx context.Context, key *meta.Key, bs *compute.BackendService, m *cloud.MockBackendServices, o ...cloud.Option) error {
+				cur, err := m.Get(ctx, key)
+				if err != nil {
+					return err
+				}
+				if bs.Fingerprint != cur.Fingerprint {
+					rejected++
+					return &googleapi.Error{Code: http.StatusPreconditionFailed}
+				}
+				updates++
+				obj := *bs
+				obj.Fingerprint = fmt.Sprintf("fingerprint-%d", updates)
+				m.Objects[*key] = &cloud.MockBackendServicesObj{Obj: &obj}
+				return nil
+			}
+
+			var actions []exec.Action
+			for i := 0; i < 2; i++ {
+				a, err := rnode.UpdateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, node, node, node.resource, fingerprintStr)
+				if err != nil {
+					t.Fatalf("rnode.UpdateActions[]() = %v, want nil", err)
+				}
+				actions = append(actions, a...)
+			}
+			// The second update runs after the first.
+			actions[1] = &afterAction{
+				Action: actions[1],
+				after:  exec.ActionBase{Want: exec.EventList{exec.NewExistsEvent(node.ID())}},
+			}
+
+			ex, err := exec.NewSerialExecutor(mockCloud, actions, exec.RefreshAfterApplyOption(tc.refresh))
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if len(result.Completed) != 2 || updates != tc.wantUpdates || rejected != tc.wantRejected {
+				t.Errorf("len(result.Completed) = %d, updates = %d, rejected = %d; want 2, %d, %d", len(result.Completed), updates, rejected, tc.wantUpdates, tc.wantRejected)
+			}
+			if !tc.refresh {
+				return
+			}
+			refreshed, ok := node.Refreshed().(BackendService)
+			if !ok {
+				t.Fatalf("node.Refreshed() = %T, want BackendService", node.Refreshed())
+			}
+			if obj, _ := refreshed.ToGA(); obj.Fingerprint != "fingerprint-2" {
+				t.Errorf("refreshed Fingerprint = %q, want %q", obj.Fingerprint, "fingerprint-2")
+			}
+		})
+	}
+}
+
+func TestActionUpdateStaleFingerprint(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		// alwaysReject makes every update fail with 412.
+		alwaysReject bool
+		wantErr      bool
+	}{
+		{desc: "retry with refreshed fingerprint"},
+		{desc: "retry once only", alwaysReject: true, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			node, err := createBackendServiceNode("bs-name", func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+					x.Protocol = "TCP"
+					x.SessionAffinity = "NONE"
+					x.TimeoutSec = 30
+				})
+			})
+			if err != nil {
+				t.Fatalf("createBackendServiceNode(bs-name, _) = %v, want nil", err)
+			}
+
+			// The resource was changed by someone else after planning, so
+			// the planned fingerprint is stale.
+			const concurrentFingerprint = "fingerprint-concurrent"
+			mockCloud := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			key := node.ID().Key
+			if err := mockCloud.BackendServices().Insert(context.Background(), key, &compute.BackendService{Name: key.Name, Fingerprint: concurrentFingerprint}); err != nil {
+				t.Fatalf("Insert() = %v, want nil", err)
+			}
+			var fingerprints []string
+			mockCloud.MockBackendServices.UpdateHook = func(ctx context.Context, key *meta.Key, bs *compute.BackendService, m *cloud.MockBackendServices, o ...cloud.Option) error {
+				fingerprints = append(fingerprints, bs.Fingerprint)
+				if tc.alwaysReject || bs.Fingerprint != concurrentFingerprint {
+					return &googleapi.Error{Code: http.StatusPreconditionFailed}
+				}
+				return nil
+			}
+
+			actions, err := rnode.UpdateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, node, node, node.resource, fingerprintStr)
+			if err != nil {
+				t.Fatalf("rnode.UpdateActions[]() = %v, want nil", err)
+			}
+			_, err = actions[0].Run(context.Background(), mockCloud)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if tc.wantErr && !errors.Is(err, rnode.ErrStaleFingerprint) {
+				t.Errorf("Run() = %v, want ErrStaleFingerprint", err)
+			}
+			if diff := cmp.Diff(fingerprints, []string{fingerprintStr, concurrentFingerprint}); diff != "" {
+				t.Errorf("Update fingerprints: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestActionUpdatePreferPatch(t *testing.T) {
+	setUpResource := func(m MutableBackendService) error {
+		return m.Access(func(x *compute.BackendService) {
+			x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+			x.Protocol = "TCP"
+			x.Port = 80
+			x.HealthChecks = []string{hcSelfLink}
+			x.CompressionMode = "DISABLED"
+			x.ConnectionDraining = &compute.ConnectionDraining{DrainingTimeoutSec: 10}
+			x.SessionAffinity = "NONE"
+			x.TimeoutSec = 30
+		})
+	}
+	gotNode, err := createBackendServiceNode("bs-name", setUpResource)
+	if err != nil {
+		t.Fatalf("createBackendServiceNode(bs-name, _) = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		desc        string
+		preferPatch bool
+		modify      func(x *compute.BackendService)
+		wantType    exec.ActionType
+		wantPatch   *compute.BackendService
+	}{
+		{
+			desc:        "metadata change with patch",
+			preferPatch: true,
+			modify:      func(x *compute.BackendService) { x.Description = "new" },
+			wantType:    exec.ActionTypePatch,
+			wantPatch:   &compute.BackendService{Description: "new", Fingerprint: fingerprintStr},
+		},
+		{
+			desc:     "metadata change without patch",
+			modify:   func(x *compute.BackendService) { x.Description = "new" },
+			wantType: exec.ActionTypeUpdate,
+		},
+		{
+			desc:        "nested field cleared falls back to update",
+			preferPatch: true,
+			modify: func(x *compute.BackendService) {
+				x.ConnectionDraining.DrainingTimeoutSec = 0
+				x.ConnectionDraining.ForceSendFields = []string{"DrainingTimeoutSec"}
+			},
+			wantType: exec.ActionTypeUpdate,
+		},
+		{
+			desc:        "list change with patch",
+			preferPatch: true,
+			modify: func(x *compute.BackendService) {
+				x.HealthChecks = nil
+				x.NullFields = []string{"HealthChecks"}
+			},
+			wantType: exec.ActionTypePatch,
+			wantPatch: &compute.BackendService{
+				Fingerprint: fingerprintStr,
+				NullFields:  []string{"HealthChecks"},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			wantNode, err := createBackendServiceNode("bs-name", func(m MutableBackendService) error {
+				if err := setUpResource(m); err != nil {
+					return err
+				}
+				return m.Access(tc.modify)
+			})
+			if err != nil {
+				t.Fatalf("createBackendServiceNode(bs-name, _) = %v, want nil", err)
+			}
+			b := wantNode.Builder()
+			b.SetResource(wantNode.resource)
+			if err := SetPreferPatch(b, tc.preferPatch); err != nil {
+				t.Fatalf("SetPreferPatch(_, %t) = %v, want nil", tc.preferPatch, err)
+			}
+			n, err := b.Build()
+			if err != nil {
+				t.Fatalf("b.Build() = %v, want nil", err)
+			}
+			n.Plan().Set(rnode.PlanDetails{Operation: rnode.OpUpdate, Why: "test plan"})
+
+			actions, err := n.Actions(gotNode)
+			if err != nil {
+				t.Fatalf("n.Actions(_) = %v, want nil", err)
+			}
+			if len(actions) != 1 {
+				t.Fatalf("len(actions) = %d, want 1", len(actions))
+			}
+			if typ := actions[0].Metadata().Type; typ != tc.wantType {
+				t.Fatalf("Metadata().Type = %q, want %q", typ, tc.wantType)
+			}
+			if tc.wantPatch == nil {
+				return
+			}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			var patched *compute.BackendService
+			mock.MockBackendServices.PatchHook = func(_ context.Context, _ *meta.Key, obj *compute.BackendService, _ *cloud.MockBackendServices, _ ...cloud.Option) error {
+				patched = obj
+				return nil
+			}
+			if _, err := actions[0].Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(patched, tc.wantPatch); diff != "" {
+				t.Errorf("Patch(); -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildResourceIDMismatch(t *testing.T) {
+	res := createBackendServiceResource(t, ID(proj, meta.GlobalKey("bs1")), nil)
+
+	for _, tc := range []struct {
+		name    string
+		id      *cloud.ResourceID
+		wantErr bool
+	}{
+		{name: "same key", id: ID(proj, meta.GlobalKey("bs1"))},
+		{name: "different name", id: ID(proj, meta.GlobalKey("bs2")), wantErr: true},
+		{name: "different scope", id: ID(proj, meta.RegionalKey("bs1", "us-central1")), wantErr: true},
+		{name: "different project", id: ID("proj-2", meta.GlobalKey("bs1")), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBuilder(tc.id)
+			b.SetState(rnode.NodeExists)
+			if err := b.SetResource(res); err != nil {
+				t.Fatalf("SetResource(_) = %v, want nil", err)
+			}
+			_, err := b.Build()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Build() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetPreferPatchInvalidBuilder(t *testing.T) {
+	if err := SetPreferPatch(fake.NewBuilder(fake.ID(proj, meta.GlobalKey("f"))), true); err == nil {
+		t.Errorf("SetPreferPatch(fake.Builder, true) = nil, want error")
+	}
+}
+
 func TestBackendServiceDiff(t *testing.T) {
 	bsName := "bs-name"
 	for _, tc := range []struct {
@@ -238,7 +713,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -251,11 +726,41 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
+					x.SessionAffinity = "NONE"
+					x.TimeoutSec = 30
+				})
+			},
+		},
+		{
+			desc:       "no diff for TimeoutSec server default",
+			expectedOp: rnode.OpNothing,
+			setUpFn: func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+					x.Protocol = "TCP"
+					x.Port = 80
+					x.HealthChecks = []string{hcSelfLink}
+					x.ConnectionDraining = &compute.ConnectionDraining{}
+					x.CompressionMode = "DISABLED"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
 			},
+			updateFn: func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+					x.Protocol = "TCP"
+					x.Port = 80
+					x.HealthChecks = []string{hcSelfLink}
+					x.ConnectionDraining = &compute.ConnectionDraining{}
+					x.CompressionMode = "DISABLED"
+					x.Network = netSelfLink
+					x.SessionAffinity = "NONE"
+					x.ForceSendFields = []string{"TimeoutSec"}
+				})
+			},
 		},
 		{
 			desc:         "expected recreation on internal schema change",
@@ -269,7 +774,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -282,7 +787,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -300,7 +805,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -313,7 +818,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "new-net"
+					x.Network = newNetSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -331,7 +836,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -344,7 +849,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -361,7 +866,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -375,7 +880,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -394,7 +899,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -411,7 +916,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -433,7 +938,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -450,7 +955,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.SecuritySettings = &compute.SecuritySettings{
@@ -471,7 +976,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -484,7 +989,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &beta.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -505,7 +1010,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -525,7 +1030,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -550,7 +1055,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -570,7 +1075,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -595,7 +1100,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -608,7 +1113,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -633,7 +1138,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &alpha.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 					x.IpAddressSelectionPolicy = "NONE"
@@ -653,7 +1158,7 @@ func TestBackendServiceDiff(t *testing.T) {
 					x.HealthChecks = []string{hcSelfLink}
 					x.ConnectionDraining = &compute.ConnectionDraining{}
 					x.CompressionMode = "DISABLED"
-					x.Network = "default"
+					x.Network = netSelfLink
 					x.SessionAffinity = "NONE"
 					x.TimeoutSec = 30
 				})
@@ -681,6 +1186,23 @@ func TestBackendServiceDiff(t *testing.T) {
 			if tc.expectedDiff && (plan.Diff == nil || len(plan.Diff.Items) == 0) {
 				t.Errorf("Result did not returned diff")
 			}
+			if plan.Diff != nil {
+				// Only changes to LoadBalancingScheme and Network require the
+				// BackendService to be recreated.
+				for _, item := range plan.Diff.Items {
+					wantClass := api.DiffItemInPlace
+					if item.Path.Equal(api.Path{}.Pointer().Field("LoadBalancingScheme")) ||
+						item.Path.Equal(api.Path{}.Pointer().Field("Network")) {
+						wantClass = api.DiffItemRequiresRecreate
+					}
+					if item.Class != wantClass {
+						t.Errorf("item %s Class = %s, want %s", item.Path, item.Class, wantClass)
+					}
+				}
+				if got, want := plan.Diff.RequiresRecreate(), tc.expectedOp == rnode.OpRecreate; got != want {
+					t.Errorf("plan.Diff.RequiresRecreate() = %t, want %t", got, want)
+				}
+			}
 			t.Logf("Diff results %+v", plan)
 		})
 	}
@@ -696,7 +1218,7 @@ func TestBackendServiceDiffError(t *testing.T) {
 			x.HealthChecks = []string{hcSelfLink}
 			x.ConnectionDraining = &compute.ConnectionDraining{}
 			x.CompressionMode = "DISABLED"
-			x.Network = "default"
+			x.Network = netSelfLink
 			x.SessionAffinity = "NONE"
 			x.TimeoutSec = 30
 		})
@@ -723,7 +1245,7 @@ func TestGAFields(t *testing.T) {
 	bsMutResource := NewMutableBackendService(proj, bsID.Key)
 	err := bsMutResource.Access(func(x *compute.BackendService) {
 		x.HealthChecks = []string{hcSelfLink}
-		x.Network = "default"
+		x.Network = netSelfLink
 		x.SessionAffinity = "NONE"
 		x.TimeoutSec = 3
 	})
@@ -776,7 +1298,7 @@ func TestAlphaFields(t *testing.T) {
 		x.HealthChecks = []string{hcSelfLink}
 		x.ConnectionDraining = &compute.ConnectionDraining{}
 		x.CompressionMode = "DISABLED"
-		x.Network = "default"
+		x.Network = netSelfLink
 		x.SessionAffinity = "NONE"
 		x.TimeoutSec = 30
 	})
@@ -844,7 +1366,7 @@ func TestBetaFields(t *testing.T) {
 		x.HealthChecks = []string{hcSelfLink}
 		x.ConnectionDraining = &compute.ConnectionDraining{}
 		x.CompressionMode = "DISABLED"
-		x.Network = "default"
+		x.Network = netSelfLink
 		x.SessionAffinity = "NONE"
 		x.TimeoutSec = 30
 	})
@@ -1012,6 +1534,24 @@ func TestOutRefs(t *testing.T) {
 		ProjectID: proj,
 		Key:       meta.GlobalKey("esp-name"),
 	}
+	netID := &cloud.ResourceID{
+		Resource:  "networks",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("net-name"),
+	}
+	sbID1 := &cloud.ResourceID{
+		Resource:  "serviceBindings",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("sb-1"),
+	}
+	sbID2 := &cloud.ResourceID{
+		Resource:  "serviceBindings",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("sb-2"),
+	}
 	for _, tc := range []struct {
 		desc        string
 		resource    rnode.UntypedResource
@@ -1125,6 +1665,62 @@ func TestOutRefs(t *testing.T) {
 			}),
 			wantErr: true,
 		},
+		{
+			desc: "with network",
+			resource: createBackendServiceResource(t, bsID, func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.Network = netID.SelfLink(meta.VersionGA)
+				})
+			}),
+			wantOutRefs: []rnode.ResourceRef{
+				{
+					From: bsID,
+					Path: api.Path{}.Field("Network"),
+					To:   netID,
+				},
+			},
+		},
+		{
+			desc: "with network wrong format",
+			resource: createBackendServiceResource(t, bsID, func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.Network = "default"
+				})
+			}),
+			wantErr: true,
+		},
+		{
+			desc: "with service bindings",
+			resource: createBackendServiceResource(t, bsID, func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.ServiceBindings = []string{
+						sbID1.SelfLink(meta.VersionGA),
+						sbID2.SelfLink(meta.VersionGA),
+					}
+				})
+			}),
+			wantOutRefs: []rnode.ResourceRef{
+				{
+					From: bsID,
+					Path: api.Path{}.Field("ServiceBindings").Index(0),
+					To:   sbID1,
+				},
+				{
+					From: bsID,
+					Path: api.Path{}.Field("ServiceBindings").Index(1),
+					To:   sbID2,
+				},
+			},
+		},
+		{
+			desc: "with service bindings wrong format",
+			resource: createBackendServiceResource(t, bsID, func(m MutableBackendService) error {
+				return m.Access(func(x *compute.BackendService) {
+					x.ServiceBindings = []string{"https://apigroup.googleapis.com/alpha/projects/proj1/global/sb/sbname"}
+				})
+			}),
+			wantErr: true,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			bsBuilder := NewBuilder(bsID)
@@ -1145,3 +1741,140 @@ func TestOutRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestStripOutputOnly(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	key := meta.RegionalKey("bs-name", "us-central1")
+	if err := mock.RegionBackendServices().Insert(ctx, key, &compute.BackendService{
+		Name:                "bs-name",
+		CreationTimestamp:   "2024-01-01T00:00:00Z",
+		Fingerprint:         fingerprintStr,
+		Id:                  1234,
+		Region:              "us-central1",
+		LoadBalancingScheme: "INTERNAL_MANAGED",
+		Protocol:            "HTTP",
+		HealthChecks:        []string{hcSelfLink},
+		TimeoutSec:          30,
+	}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	obj, err := mock.RegionBackendServices().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+	if obj.SelfLink == "" {
+		t.Fatalf("Get().SelfLink is empty, want it to be set by the server")
+	}
+
+	m := NewMutableBackendService(proj, key)
+	if err := m.Set(obj); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	if err := m.StripOutputOnly(); err != nil {
+		t.Fatalf("StripOutputOnly() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+
+	body, err := rnode.ResourceBody(r)
+	if err != nil {
+		t.Fatalf("ResourceBody() = %v, want nil", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		t.Fatalf("json.Unmarshal(%q) = %v, want nil", body, err)
+	}
+	for _, f := range []string{"selfLink", "id", "creationTimestamp", "fingerprint", "region", "kind"} {
+		if v, ok := fields[f]; ok {
+			t.Errorf("Insert body has %s = %v, want it to be omitted (body = %s)", f, v, body)
+		}
+	}
+	for _, f := range []string{"name", "loadBalancingScheme", "protocol", "healthChecks"} {
+		if _, ok := fields[f]; !ok {
+			t.Errorf("Insert body is missing %s (body = %s)", f, body)
+		}
+	}
+
+	// Output only fields are also stripped from the other versions.
+	betaObj, err := r.ToBeta()
+	if err != nil {
+		t.Fatalf("ToBeta() = %v, want nil", err)
+	}
+	if betaObj.SelfLink != "" || betaObj.Id != 0 || betaObj.Fingerprint != "" {
+		t.Errorf("ToBeta() = %+v, want output only fields to be zero", betaObj)
+	}
+}
+
+func TestResourceFromGA(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	key := meta.RegionalKey("bs-name", "us-central1")
+	if err := mock.RegionBackendServices().Insert(ctx, key, &compute.BackendService{
+		Name:                "bs-name",
+		Region:              "us-central1",
+		LoadBalancingScheme: "INTERNAL_MANAGED",
+		Protocol:            "HTTP",
+		HealthChecks:        []string{hcSelfLink},
+		TimeoutSec:          30,
+	}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	obj, err := mock.RegionBackendServices().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		key          *meta.Key
+		opts         []api.ResourceFromOption
+		wantName     string
+		wantSelfLink string
+	}{
+		{
+			name:         "no options",
+			key:          key,
+			wantName:     "bs-name",
+			wantSelfLink: obj.SelfLink,
+		},
+		{
+			name:     "without output only",
+			key:      key,
+			opts:     []api.ResourceFromOption{api.WithoutOutputOnly()},
+			wantName: "bs-name",
+		},
+		{
+			name:         "name from resource ID",
+			key:          meta.RegionalKey("other-bs", "us-central1"),
+			opts:         []api.ResourceFromOption{api.NameFromResourceID()},
+			wantName:     "other-bs",
+			wantSelfLink: obj.SelfLink,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := api.ResourceFromGA(ID(proj, tc.key), obj, &typeTrait{}, tc.opts...)
+			if err != nil {
+				t.Fatalf("ResourceFromGA() = %v, want nil", err)
+			}
+			if r.Version() != meta.VersionGA {
+				t.Errorf("Version() = %v, want %v", r.Version(), meta.VersionGA)
+			}
+			got, err := r.ToGA()
+			if err != nil {
+				t.Fatalf("ToGA() = %v, want nil", err)
+			}
+			if got.Name != tc.wantName {
+				t.Errorf("Name = %q, want %q", got.Name, tc.wantName)
+			}
+			if got.SelfLink != tc.wantSelfLink {
+				t.Errorf("SelfLink = %q, want %q", got.SelfLink, tc.wantSelfLink)
+			}
+			if got.Protocol != "HTTP" || got.TimeoutSec != 30 {
+				t.Errorf("ToGA() = %+v, want Protocol and TimeoutSec from the fetched object", got)
+			}
+		})
+	}
+}
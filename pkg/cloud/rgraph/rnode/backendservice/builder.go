@@ -18,6 +18,7 @@ package backendservice
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -28,6 +29,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("backendServices", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
@@ -42,11 +45,44 @@ func NewBuilderWithResource(r BackendService) rnode.Builder {
 
 type builder struct {
 	rnode.BuilderBase
-	resource BackendService
+	resource    BackendService
+	preferPatch bool
+}
+
+// SetPreferPatch configures the BackendService node built by b to use the
+// Patch method, sending only the fields that have changed, for OpUpdate. This
+// is safer against concurrent modifications of large backend services. Update
+// is still used if the change cannot be expressed as a Patch.
+//
+// This option is only available for BackendService. Whether other nodes use
+// Patch or Update is fixed by their type (see rnode.GenericPatchOps).
+func SetPreferPatch(b rnode.Builder, preferPatch bool) error {
+	bb, ok := b.(*builder)
+	if !ok {
+		return fmt.Errorf("SetPreferPatch: invalid type %T, want BackendService builder", b)
+	}
+	bb.preferPatch = preferPatch
+	return nil
+}
+
+// options are the BackendService specific options that are serialized with
+// the graph (see rnode.OptionsMarshaler).
+type options struct {
+	PreferPatch bool `json:"preferPatch,omitempty"`
 }
 
 // builder implements node.Builder.
 var _ rnode.Builder = (*builder)(nil)
+var _ rnode.OptionsUnmarshaler = (*builder)(nil)
+
+func (b *builder) UnmarshalOptions(data []byte) error {
+	var o options
+	if err := json.Unmarshal(data, &o); err != nil {
+		return fmt.Errorf("BackendService UnmarshalOptions: %w", err)
+	}
+	b.preferPatch = o.PreferPatch
+	return nil
+}
 
 func (b *builder) Resource() rnode.UntypedResource { return b.resource }
 
@@ -64,6 +100,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, "BackendService", &ops{}, &typeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.BackendService, alpha.BackendService, beta.BackendService](&typeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.resource == nil {
 		return nil, nil
@@ -125,6 +165,32 @@ func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 		})
 	}
 
+	// Network
+	if obj.Network != "" {
+		id, err := cloud.ParseResourceURL(obj.Network)
+		if err != nil {
+			return nil, fmt.Errorf("BackendServiceNode Network: %w", err)
+		}
+		ret = append(ret, rnode.ResourceRef{
+			From: b.ID(),
+			Path: api.Path{}.Field("Network"),
+			To:   id,
+		})
+	}
+
+	// ServiceBindings[]
+	for idx, sb := range obj.ServiceBindings {
+		id, err := cloud.ParseResourceURL(sb)
+		if err != nil {
+			return nil, fmt.Errorf("BackendServiceNode ServiceBindings: %w", err)
+		}
+		ret = append(ret, rnode.ResourceRef{
+			From: b.ID(),
+			Path: api.Path{}.Field("ServiceBindings").Index(idx),
+			To:   id,
+		})
+	}
+
 	return ret, nil
 }
 
@@ -133,7 +199,7 @@ func (b *builder) Build() (rnode.Node, error) {
 		return nil, fmt.Errorf("BackendService %s resource is nil with state %s", b.ID(), b.State())
 	}
 
-	ret := &backendServiceNode{resource: b.resource}
+	ret := &backendServiceNode{resource: b.resource, preferPatch: b.preferPatch}
 	if err := ret.InitFromBuilder(b); err != nil {
 		return nil, err
 	}
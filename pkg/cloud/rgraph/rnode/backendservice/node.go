@@ -17,8 +17,9 @@ limitations under the License.
 package backendservice
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
+	"reflect"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -31,10 +32,12 @@ import (
 
 type backendServiceNode struct {
 	rnode.NodeBase
-	resource BackendService
+	resource    BackendService
+	preferPatch bool
 }
 
 var _ rnode.Node = (*backendServiceNode)(nil)
+var _ rnode.OptionsMarshaler = (*backendServiceNode)(nil)
 
 func (n *backendServiceNode) Resource() rnode.UntypedResource { return n.resource }
 
@@ -48,50 +51,12 @@ func (n *backendServiceNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error
 		return nil, fmt.Errorf("BackendServiceNode: Diff %w", err)
 	}
 
-	if !diff.HasDiff() {
-		return &rnode.PlanDetails{
-			Operation: rnode.OpNothing,
-			Why:       "No diff between got and want",
-		}, nil
+	// Immutable fields are marked in the FieldTraits (see typeTrait).
+	ret := rnode.PlanFromDiff(diff, nil)
+	if ret.Operation != rnode.OpNothing {
+		ret.Why = "BackendService " + ret.Why
 	}
-
-	var (
-		needsRecreate bool
-		details       []string
-	)
-
-	planRecreate := func(s string, args ...any) {
-		details = append(details, fmt.Sprintf(s, args...))
-		needsRecreate = true
-	}
-	planUpdate := func(s string, args ...any) {
-		details = append(details, fmt.Sprintf(s, args...))
-	}
-
-	for _, delta := range diff.Items {
-		// These fields cannot be changed in place and require the
-		// resource to be recreated.
-		switch {
-		case delta.Path.Equal(api.Path{}.Pointer().Field("LoadBalancingScheme")),
-			delta.Path.Equal(api.Path{}.Pointer().Field("Network")):
-			planRecreate("LoadBalancingScheme change: '%v' -> '%v'", delta.A, delta.B)
-		default:
-			planUpdate("%s change: '%v' -> '%v'", delta.Path, delta.A, delta.B)
-		}
-	}
-
-	if needsRecreate {
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "BackendService needs to be recreated: " + strings.Join(details, ", "),
-			Diff:      diff,
-		}, nil
-	}
-	return &rnode.PlanDetails{
-		Operation: rnode.OpUpdate,
-		Why:       "BackendService needs to be updated: " + strings.Join(details, ", "),
-		Diff:      diff,
-	}, nil
+	return ret, nil
 }
 
 func fingerprint(gotNode *backendServiceNode) (string, error) {
@@ -142,18 +107,75 @@ func (n *backendServiceNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		if err != nil {
 			return nil, fmt.Errorf("Cannot get fingerprint from BackendService: %w", err)
 		}
+		if n.preferPatch {
+			diff, err := gotNode.resource.Diff(n.resource)
+			if err != nil {
+				return nil, fmt.Errorf("BackendServiceNode: Diff %w", err)
+			}
+			if canPatch(diff) {
+				return rnode.PatchActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, got, n, n.resource, f)
+			}
+		}
 		return rnode.UpdateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, got, n, n.resource, f)
 	}
 
 	return nil, fmt.Errorf("BackendServiceNode: invalid plan op %s", op)
 }
 
+func (n *backendServiceNode) MarshalOptions() ([]byte, error) {
+	if !n.preferPatch {
+		return nil, nil
+	}
+	return json.Marshal(options{PreferPatch: n.preferPatch})
+}
+
 func (n *backendServiceNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	b.preferPatch = n.preferPatch
 	return b
 }
 
+// canPatch returns true if the changes in diff can be made with Patch.
+//
+// Patch uses JSON merge semantics: the top-level fields in the mask are
+// replaced, as are lists, but nested structs and maps are merged. A field
+// nested in a struct or map that is cleared in want would be left unchanged by
+// the Patch, so these changes must use Update instead.
+func canPatch(diff *api.DiffResult) bool {
+	for _, item := range diff.Items {
+		if !nestedInStructOrMap(item.Path) {
+			continue
+		}
+		if item.State == api.DiffItemOnlyInA || item.B == nil || reflect.ValueOf(item.B).IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// nestedInStructOrMap is true if p refers to a field or map element below the
+// top-level field that is not contained in a list.
+func nestedInStructOrMap(p api.Path) bool {
+	topLevel := false
+	for _, elem := range p {
+		switch elem[0] {
+		case '!':
+			// Lists are replaced as a whole by the Patch.
+			return false
+		case '.', ':':
+			if topLevel {
+				return true
+			}
+			topLevel = true
+		}
+	}
+	return false
+}
+
 /*
 name
 string
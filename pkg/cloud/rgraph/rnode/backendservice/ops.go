@@ -27,6 +27,9 @@ import (
 
 type ops struct{}
 
+// ops implements GenericPatchOps.
+var _ rnode.GenericPatchOps[compute.BackendService, alpha.BackendService, beta.BackendService] = (*ops)(nil)
+
 func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
 	return &rnode.GetFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
 		GA: rnode.GetFuncsByScope[compute.BackendService]{
@@ -94,3 +97,20 @@ func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.BackendServi
 		},
 	}
 }
+
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return &rnode.PatchFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
+		GA: rnode.PatchFuncsByScope[compute.BackendService]{
+			Global:   gcp.BackendServices().Patch,
+			Regional: gcp.RegionBackendServices().Patch,
+		},
+		Alpha: rnode.PatchFuncsByScope[alpha.BackendService]{
+			Global:   gcp.AlphaBackendServices().Patch,
+			Regional: gcp.AlphaRegionBackendServices().Patch,
+		},
+		Beta: rnode.PatchFuncsByScope[beta.BackendService]{
+			Global:   gcp.BetaBackendServices().Patch,
+			Regional: gcp.BetaRegionBackendServices().Patch,
+		},
+	}
+}
@@ -44,16 +44,50 @@ func (*typeTrait) FieldTraits(v meta.Version) *api.FieldTraits {
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
 
 	dt.OutputOnly(api.Path{}.Pointer().Field("Iap").Pointer().Field("Oauth2ClientSecretSha256"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("CdnPolicy").Field("SignedUrlKeyNames"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("CdnPolicy").Pointer().Field("CacheKeyPolicy").Pointer().Field("SignedUrlKeyNames"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("CdnPolicy").Pointer().Field("SignedUrlKeyNames"))
 
 	dt.NonZeroValue(api.Path{}.Pointer().Field("LoadBalancingScheme"))
 	dt.NonZeroValue(api.Path{}.Pointer().Field("Protocol"))
+
+	// These fields cannot be changed in place and require the resource to be
+	// recreated.
+	dt.Immutable(api.Path{}.Pointer().Field("LoadBalancingScheme"))
+	dt.Immutable(api.Path{}.Pointer().Field("Network"))
+
+	// Enums.
+	dt.AllowedValues(api.Path{}.Pointer().Field("LoadBalancingScheme"), []string{
+		"EXTERNAL",
+		"EXTERNAL_MANAGED",
+		"INTERNAL",
+		"INTERNAL_MANAGED",
+		"INTERNAL_SELF_MANAGED",
+		"INVALID_LOAD_BALANCING_SCHEME",
+	})
+	protocols := []string{"GRPC", "HTTP", "HTTP2", "HTTPS", "SSL", "TCP", "UDP", "UNSPECIFIED"}
+	sessionAffinities := []string{
+		"CLIENT_IP",
+		"CLIENT_IP_NO_DESTINATION",
+		"CLIENT_IP_PORT_PROTO",
+		"CLIENT_IP_PROTO",
+		"GENERATED_COOKIE",
+		"HEADER_FIELD",
+		"HTTP_COOKIE",
+		"NONE",
+	}
+	if v == meta.VersionAlpha {
+		protocols = append(protocols, "ALL")
+		sessionAffinities = append(sessionAffinities, "STRONG_COOKIE_AFFINITY")
+	}
+	dt.AllowedValues(api.Path{}.Pointer().Field("Protocol"), protocols)
+	dt.AllowedValues(api.Path{}.Pointer().Field("SessionAffinity"), sessionAffinities)
+
 	// TODO(kl52752) change this field to mandatory after fixing type traits check.
 	// Type traits check should be per path and not inherited from parent.
 	dt.AllowZeroValue(api.Path{}.Pointer().Field("ConnectionDraining"))
 	dt.NonZeroValue(api.Path{}.Pointer().Field("SessionAffinity"))
 	dt.NonZeroValue(api.Path{}.Pointer().Field("TimeoutSec"))
+	// The server sets TimeoutSec to 30 if it is not specified.
+	dt.ServerDefault(api.Path{}.Pointer().Field("TimeoutSec"), 30)
 
 	if v == meta.VersionBeta {
 		dt.NonZeroValue(api.Path{}.Pointer().Field("IpAddressSelectionPolicy"))
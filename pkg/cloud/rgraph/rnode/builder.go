@@ -38,10 +38,29 @@ type Builder interface {
 	// SetOwnership of this resource.
 	SetOwnership(os OwnershipStatus)
 
+	// DeletionProtected is true if planning must not delete (or
+	// recreate) this resource.
+	DeletionProtected() bool
+	// SetDeletionProtected of this resource.
+	SetDeletionProtected(bool)
+	// ForceRecreate is true if planning must recreate this resource even
+	// if there is no diff.
+	ForceRecreate() bool
+	// SetForceRecreate of this resource.
+	SetForceRecreate(bool)
+	// AllowRecreate is true if planning may recreate this resource when
+	// the diff cannot be applied with an update.
+	AllowRecreate() bool
+	// SetAllowRecreate of this resource.
+	SetAllowRecreate(bool)
+
 	// Resource (cloud type) for this Node.
 	Resource() UntypedResource
 	// SetResource to a new value.
 	SetResource(UntypedResource) error
+	// UnmarshalResource sets the Resource from JSON created by
+	// Resource.MarshalJSON.
+	UnmarshalResource(data []byte) error
 
 	// Version of the resource. This is used when fetching the
 	// resource from the Cloud.
@@ -56,7 +75,8 @@ type Builder interface {
 	// may result in one or more blocking calls to the GCE APIs.
 	SyncFromCloud(ctx context.Context, cl cloud.Cloud) error
 
-	// Build the node, converting this to a Node in a Graph.
+	// Build the node, converting this to a Node in a Graph. Returns an error
+	// if the ResourceID of the Resource does not match ID().
 	Build() (Node, error)
 
 	// inRefs that have been computed so far. This method is
@@ -64,6 +84,17 @@ type Builder interface {
 	// been computed from a complete set of nodes in the graph
 	// Builder.
 	inRefs() []ResourceRef
+	// setVersion of the resource. This is package private and is used
+	// when the resource is set outside of Init (see
+	// GenericUnmarshalResource).
+	setVersion(v meta.Version)
+}
+
+// OptionsUnmarshaler is implemented by the Builder of a Node that implements
+// OptionsMarshaler. UnmarshalOptions sets the options from the JSON created by
+// MarshalOptions.
+type OptionsUnmarshaler interface {
+	UnmarshalOptions(data []byte) error
 }
 
 // BuilderBase implements the non-type specific fields.
@@ -73,6 +104,10 @@ type BuilderBase struct {
 	ownership OwnershipStatus
 	version   meta.Version
 
+	deletionProtected bool
+	forceRecreate     bool
+	allowRecreate     bool
+
 	curInRefs []ResourceRef
 }
 
@@ -82,6 +117,16 @@ func (b *BuilderBase) SetState(state NodeState)        { b.state = state }
 func (b *BuilderBase) Ownership() OwnershipStatus      { return b.ownership }
 func (b *BuilderBase) SetOwnership(os OwnershipStatus) { b.ownership = os }
 func (b *BuilderBase) Version() meta.Version           { return b.version }
+func (b *BuilderBase) setVersion(v meta.Version)       { b.version = v }
+
+func (b *BuilderBase) DeletionProtected() bool     { return b.deletionProtected }
+func (b *BuilderBase) SetDeletionProtected(p bool) { b.deletionProtected = p }
+
+func (b *BuilderBase) ForceRecreate() bool     { return b.forceRecreate }
+func (b *BuilderBase) SetForceRecreate(f bool) { b.forceRecreate = f }
+
+func (b *BuilderBase) AllowRecreate() bool     { return b.allowRecreate }
+func (b *BuilderBase) SetAllowRecreate(a bool) { b.allowRecreate = a }
 
 func (b *BuilderBase) AddInRef(ref ResourceRef) { b.curInRefs = append(b.curInRefs, ref) }
 func (b *BuilderBase) inRefs() []ResourceRef    { return b.curInRefs }
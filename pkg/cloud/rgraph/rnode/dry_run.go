@@ -0,0 +1,129 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// ResourceBody returns the JSON serialization of the resource in its
+// Version(). This is the body that would be sent to the API.
+func ResourceBody[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta]) (string, error) {
+	var (
+		obj any
+		err error
+	)
+	switch r.Version() {
+	case meta.VersionGA:
+		obj, err = r.ToGA()
+	case meta.VersionAlpha:
+		obj, err = r.ToAlpha()
+	case meta.VersionBeta:
+		obj, err = r.ToBeta()
+	default:
+		return "", fmt.Errorf("ResourceBody: unsupported version %q", r.Version())
+	}
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("ResourceBody: %w", err)
+	}
+	return string(b), nil
+}
+
+// updateBody returns the JSON serialization of the Update request body for the
+// resource. The Fingerprint is set to fingerprint if the resource has one.
+func updateBody[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta], fingerprint string) (string, error) {
+	switch r.Version() {
+	case meta.VersionGA:
+		raw, err := r.ToGA()
+		return marshalUpdate(raw, err, fingerprint)
+	case meta.VersionAlpha:
+		raw, err := r.ToAlpha()
+		return marshalUpdate(raw, err, fingerprint)
+	case meta.VersionBeta:
+		raw, err := r.ToBeta()
+		return marshalUpdate(raw, err, fingerprint)
+	}
+	return "", fmt.Errorf("updateBody: unsupported version %q", r.Version())
+}
+
+func marshalUpdate[T any](raw *T, err error, fingerprint string) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	// Copy the object as raw is owned by the Resource.
+	obj := *raw
+	setFingerprint(&obj, fingerprint)
+	b, err := json.Marshal(&obj)
+	if err != nil {
+		return "", fmt.Errorf("updateBody: %w", err)
+	}
+	return string(b), nil
+}
+
+// patchBody returns the JSON serialization of the Patch request body for the
+// resource, restricted to the fields in mask. The Fingerprint is set to
+// fingerprint if the resource has one.
+func patchBody[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta], mask []string, fingerprint string) (string, error) {
+	switch r.Version() {
+	case meta.VersionGA:
+		raw, err := r.ToGA()
+		return marshalPatch(raw, err, mask, fingerprint)
+	case meta.VersionAlpha:
+		raw, err := r.ToAlpha()
+		return marshalPatch(raw, err, mask, fingerprint)
+	case meta.VersionBeta:
+		raw, err := r.ToBeta()
+		return marshalPatch(raw, err, mask, fingerprint)
+	}
+	return "", fmt.Errorf("patchBody: unsupported version %q", r.Version())
+}
+
+func marshalPatch[T any](raw *T, err error, mask []string, fingerprint string) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	obj, err := patchObject(raw, mask)
+	if err != nil {
+		return "", err
+	}
+	setFingerprint(obj, fingerprint)
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("patchBody: %w", err)
+	}
+	return string(b), nil
+}
+
+// setFingerprint sets the Fingerprint field of obj. This is a no-op if
+// fingerprint is empty or obj does not have a Fingerprint field.
+func setFingerprint[T any](obj *T, fingerprint string) {
+	if fingerprint == "" {
+		return
+	}
+	if fv, err := fingerprintField(reflect.ValueOf(obj)); err == nil {
+		fv.SetString(fingerprint)
+	}
+}
@@ -26,6 +26,10 @@ import (
 	"k8s.io/klog/v2"
 )
 
+func init() {
+	rnode.Register("fakes", func(id *cloud.ResourceID) rnode.Builder { return NewBuilder(id) })
+}
+
 // NewBuilder returns a Node builder.
 func NewBuilder(id *cloud.ResourceID) *Builder {
 	b := &Builder{}
@@ -64,6 +68,10 @@ func (b *Builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 	return b.FakeSyncError
 }
 
+func (b *Builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[FakeResource, FakeResource, FakeResource](&fakeTypeTrait{}, b, data)
+}
+
 func (b *Builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.OutRefsErr != nil {
 		return nil, b.OutRefsErr
@@ -78,5 +78,8 @@ func (n *fakeNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *fakeNode) Builder() rnode.Builder {
 	b := &Builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), nil)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
@@ -18,14 +18,51 @@ package forwardingrule
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"google.golang.org/api/compute/v1"
 )
 
+// serviceInfo returns the GA service for forwarding rules in the scope of key
+// (GlobalForwardingRules or ForwardingRules).
+func serviceInfo(key *meta.Key) (*meta.ServiceInfo, error) {
+	for _, s := range meta.AllServices {
+		if s.APIGroup != meta.APIGroupCompute || s.Object != "ForwardingRule" || s.Version() != meta.VersionGA {
+			continue
+		}
+		switch {
+		case key.Type() == meta.Global && s.KeyIsGlobal(),
+			key.Type() == meta.Regional && s.KeyIsRegional():
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("no forwarding rule service for scope %s", key.Type())
+}
+
+// checkMethods returns an error if any of the methods are not supported by
+// the forwarding rule service for the scope of key.
+func checkMethods(key *meta.Key, methods ...string) error {
+	s, err := serviceInfo(key)
+	if err != nil {
+		return err
+	}
+	supported := map[string]bool{}
+	for _, m := range s.Methods() {
+		supported[m.Name()] = true
+	}
+	for _, m := range methods {
+		if !supported[m] {
+			return fmt.Errorf("%s is not supported by %s (scope %s)", m, s.Service, key.Type())
+		}
+	}
+	return nil
+}
+
 func forwardingRuleSetLabels(
 	ctx context.Context,
 	cl cloud.Cloud,
@@ -70,7 +107,10 @@ func (act *forwardingRuleCreateAction) Run(ctx context.Context, cl cloud.Cloud)
 		return nil, err
 	}
 
-	ga, _ := act.res.ToGA()
+	ga, err := act.res.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
 	labels := ga.Labels
 	if labels != nil && len(labels) > 0 {
 		res, err := ops.GetFuncs(cl).Do(ctx, meta.VersionGA, act.id, &typeTrait{})
@@ -78,8 +118,11 @@ func (act *forwardingRuleCreateAction) Run(ctx context.Context, cl cloud.Cloud)
 			return nil, err
 
 		}
-		ga, _ = res.ToGA()
-		if err := forwardingRuleSetLabels(ctx, cl, act.id.Key, ga.LabelFingerprint, labels); err != nil {
+		labelFingerprint, err := rnode.LabelFingerprint(res)
+		if err != nil {
+			return nil, err
+		}
+		if err := forwardingRuleSetLabels(ctx, cl, act.id.Key, labelFingerprint, labels); err != nil {
 			return nil, err
 		}
 	}
@@ -91,15 +134,37 @@ func (act *forwardingRuleCreateAction) DryRun() exec.EventList {
 	return exec.EventList{exec.NewExistsEvent(act.id)}
 }
 
+func (act *forwardingRuleCreateAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	body, err := rnode.ResourceBody(act.res)
+	if err != nil {
+		return nil, err
+	}
+	ret := []exec.DryRunCall{{Method: "Insert", ResourceID: act.id, Body: body}}
+	ga, err := act.res.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	if len(ga.Labels) > 0 {
+		// The LabelFingerprint is only known after the resource is created.
+		body, err := json.Marshal(&compute.GlobalSetLabelsRequest{Labels: ga.Labels})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, exec.DryRunCall{Method: "SetLabels", ResourceID: act.id, Body: string(body)})
+	}
+	return ret, nil
+}
+
 func (act *forwardingRuleCreateAction) String() string {
 	return fmt.Sprintf("ForwardingRuleCreateAction(%s)", act.id)
 }
 
 func (act *forwardingRuleCreateAction) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
-		Name:    fmt.Sprintf("ForwardingRuleCreateAction(%s)", act.id),
-		Type:    exec.ActionTypeCreate,
-		Summary: fmt.Sprintf("Create %s", act.id),
+		Name:       rnode.ActionName("ForwardingRuleCreateAction", act.id),
+		Type:       exec.ActionTypeCreate,
+		Summary:    fmt.Sprintf("Create %s", act.id),
+		ResourceID: act.id,
 	}
 }
 
@@ -121,25 +186,8 @@ type forwardingRuleUpdateAction struct {
 func (act *forwardingRuleUpdateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
 	// TODO: project routing.
 	if act.labels != nil {
-		switch act.id.Key.Type() {
-		case meta.Global:
-			err := cl.GlobalForwardingRules().SetLabels(ctx, act.id.Key, &compute.GlobalSetLabelsRequest{
-				LabelFingerprint: act.labelFingerprint,
-				Labels:           act.labels,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
-			}
-		case meta.Regional:
-			err := cl.ForwardingRules().SetLabels(ctx, act.id.Key, &compute.RegionSetLabelsRequest{
-				LabelFingerprint: act.labelFingerprint,
-				Labels:           act.labels,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
-			}
-		default:
-			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): invalid key type", act.id)
+		if err := forwardingRuleSetLabels(ctx, cl, act.id.Key, act.labelFingerprint, act.labels); err != nil {
+			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
 		}
 	}
 
@@ -153,7 +201,7 @@ func (act *forwardingRuleUpdateAction) Run(ctx context.Context, cl cloud.Cloud)
 				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetTarget: %w", act.id, err)
 			}
 		case meta.Regional:
-			err := cl.GlobalForwardingRules().SetTarget(ctx, act.id.Key, &compute.TargetReference{
+			err := cl.ForwardingRules().SetTarget(ctx, act.id.Key, &compute.TargetReference{
 				Target: act.target.SelfLink(meta.VersionGA),
 			})
 			if err != nil {
@@ -180,14 +228,37 @@ func (act *forwardingRuleUpdateAction) DryRun() exec.EventList {
 	return events
 }
 
+func (act *forwardingRuleUpdateAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	var ret []exec.DryRunCall
+	if act.labels != nil {
+		body, err := json.Marshal(&compute.GlobalSetLabelsRequest{
+			LabelFingerprint: act.labelFingerprint,
+			Labels:           act.labels,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, exec.DryRunCall{Method: "SetLabels", ResourceID: act.id, Body: string(body)})
+	}
+	if act.target != nil {
+		body, err := json.Marshal(&compute.TargetReference{Target: act.target.SelfLink(meta.VersionGA)})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, exec.DryRunCall{Method: "SetTarget", ResourceID: act.id, Body: string(body)})
+	}
+	return ret, nil
+}
+
 func (act *forwardingRuleUpdateAction) String() string {
 	return fmt.Sprintf("ForwardingRuleUpdateAction(%s)", act.id)
 }
 
 func (act *forwardingRuleUpdateAction) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
-		Name:    fmt.Sprintf("ForwardingRuleUpdateAction(%s)", act.id),
-		Type:    exec.ActionTypeUpdate,
-		Summary: fmt.Sprintf("Update %s", act.id),
+		Name:       rnode.ActionName("ForwardingRuleUpdateAction", act.id),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("Update %s", act.id),
+		ResourceID: act.id,
 	}
 }
@@ -23,13 +23,79 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/google/go-cmp/cmp"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
 func TestCreateAction(t *testing.T) {
 	// TODO
 }
 
+func TestCreateActionDryRunCalls(t *testing.T) {
+	id := ID("proj", meta.GlobalKey("fr"))
+
+	for _, tc := range []struct {
+		name        string
+		f           func(m MutableForwardingRule) error
+		wantMethods []string
+		wantErr     bool
+	}{
+		{
+			name: "no labels",
+			f: func(m MutableForwardingRule) error {
+				return m.Access(func(x *compute.ForwardingRule) { x.Name = "fr" })
+			},
+			wantMethods: []string{"Insert"},
+		},
+		{
+			name: "labels",
+			f: func(m MutableForwardingRule) error {
+				return m.Access(func(x *compute.ForwardingRule) {
+					x.Name = "fr"
+					x.Labels = map[string]string{"k": "v"}
+				})
+			},
+			wantMethods: []string{"Insert", "SetLabels"},
+		},
+		{
+			name: "beta only field cannot be converted to GA",
+			f: func(m MutableForwardingRule) error {
+				return m.AccessBeta(func(x *beta.ForwardingRule) {
+					x.Name = "fr"
+					x.AllowPscPacketInjection = true
+				})
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMutableForwardingRule("proj", id.Key)
+			if err := tc.f(m); err != nil {
+				t.Fatalf("Access() = %v, want nil", err)
+			}
+			r, err := m.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			act := newForwardingRuleCreateAction(id, r, nil).(exec.DryRunCaller)
+			calls, err := act.DryRunCalls()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("DryRunCalls() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			var methods []string
+			for _, c := range calls {
+				methods = append(methods, c.Method)
+			}
+			if diff := cmp.Diff(methods, tc.wantMethods); diff != "" {
+				t.Errorf("DryRunCalls() methods: -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateAction(t *testing.T) {
 	id := ID("proj", meta.GlobalKey("fr"))
 	targetID := targethttpproxy.ID("proj", meta.GlobalKey("tp"))
@@ -76,3 +142,86 @@ func TestUpdateAction(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTargetByScope(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		key        *meta.Key
+		wantGlobal bool
+	}{
+		{name: "global", key: meta.GlobalKey("fr"), wantGlobal: true},
+		{name: "regional", key: meta.RegionalKey("fr", "us-central1")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			id := ID("proj", tc.key)
+			makeNode := func(target string) rnode.Node {
+				t.Helper()
+				return newTestNode(t, id, func(x *compute.ForwardingRule) { x.Target = target })
+			}
+			oldTarget := targethttpproxy.ID("proj", meta.GlobalKey("tp-old"))
+			newTarget := targethttpproxy.ID("proj", meta.GlobalKey("tp-new"))
+			got := makeNode(oldTarget.SelfLink(meta.VersionGA))
+			want := makeNode(newTarget.SelfLink(meta.VersionGA))
+
+			pd, err := want.Diff(got)
+			if err != nil || pd.Operation != rnode.OpUpdate {
+				t.Fatalf("Diff() = %+v, %v; want OpUpdate, nil", pd, err)
+			}
+			want.Plan().Set(*pd)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			var gotGlobal, gotRegional []string
+			mock.MockGlobalForwardingRules.SetTargetHook = func(_ context.Context, _ *meta.Key, ref *compute.TargetReference, _ *cloud.MockGlobalForwardingRules, _ ...cloud.Option) error {
+				gotGlobal = append(gotGlobal, ref.Target)
+				return nil
+			}
+			mock.MockForwardingRules.SetTargetHook = func(_ context.Context, _ *meta.Key, ref *compute.TargetReference, _ *cloud.MockForwardingRules, _ ...cloud.Option) error {
+				gotRegional = append(gotRegional, ref.Target)
+				return nil
+			}
+			for _, a := range actions {
+				if _, err := a.Run(context.Background(), mock); err != nil {
+					t.Fatalf("%v.Run() = %v, want nil", a, err)
+				}
+			}
+
+			wantCalls := []string{newTarget.SelfLink(meta.VersionGA)}
+			wantGlobal, wantRegional := wantCalls, []string(nil)
+			if !tc.wantGlobal {
+				wantGlobal, wantRegional = nil, wantCalls
+			}
+			if diff := cmp.Diff(gotGlobal, wantGlobal); diff != "" {
+				t.Errorf("GlobalForwardingRules.SetTarget(): -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(gotRegional, wantRegional); diff != "" {
+				t.Errorf("ForwardingRules.SetTarget(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckMethods(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		key     *meta.Key
+		methods []string
+		wantErr bool
+	}{
+		{name: "global", key: meta.GlobalKey("fr"), methods: []string{"SetTarget", "SetLabels"}},
+		{name: "regional", key: meta.RegionalKey("fr", "us-central1"), methods: []string{"SetTarget", "SetLabels"}},
+		{name: "no methods", key: meta.GlobalKey("fr")},
+		{name: "unsupported method", key: meta.GlobalKey("fr"), methods: []string{"SetTarget", "SetFoo"}, wantErr: true},
+		{name: "unsupported scope", key: meta.ZonalKey("fr", "us-central1-b"), methods: []string{"SetTarget"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkMethods(tc.key, tc.methods...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("checkMethods(%v, %v) = %v; gotErr = %t, want %t", tc.key, tc.methods, err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
@@ -29,6 +29,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("forwardingRules", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
@@ -65,6 +67,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, "ForwardingRule", &ops{}, &typeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](&typeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.resource == nil {
 		return nil, nil
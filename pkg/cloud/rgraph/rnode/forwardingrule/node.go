@@ -18,11 +18,13 @@ package forwardingrule
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
@@ -33,12 +35,57 @@ func nodeErr(s string, args ...any) error { return fmt.Errorf("forwardingRule: "
 type forwardingRuleNode struct {
 	rnode.NodeBase
 	resource ForwardingRule
+
+	// resolvedIP is the IP of the Address referenced by .IPAddress. This is
+	// set by ResolveRefs and is empty if .IPAddress is a literal IP or the
+	// Address has not been resolved.
+	resolvedIP string
 }
 
 var _ rnode.Node = (*forwardingRuleNode)(nil)
+var _ rnode.RefResolver = (*forwardingRuleNode)(nil)
 
 func (n *forwardingRuleNode) Resource() rnode.UntypedResource { return n.resource }
 
+// ResolveRefs implements rnode.RefResolver. This looks up the IP of the
+// Address referenced by .IPAddress so that Diff can treat it as equal to the
+// same IP given as a literal.
+func (n *forwardingRuleNode) ResolveRefs(lookup func(*cloud.ResourceID) rnode.Node) {
+	n.resolvedIP = ""
+	for _, ref := range n.OutRefs() {
+		if !ref.Path.Equal(ipAddressPath) {
+			continue
+		}
+		addrNode := lookup(ref.To)
+		if addrNode == nil || addrNode.State() != rnode.NodeExists {
+			return
+		}
+		addr, ok := addrNode.Resource().(address.Address)
+		if !ok {
+			return
+		}
+		// Ignore conversion errors as .Address is available in GA.
+		obj, _ := addr.ToGA()
+		n.resolvedIP = obj.Address
+		return
+	}
+}
+
+var ipAddressPath = api.Path{}.Pointer().Field("IPAddress")
+
+// sameIPAddress is true if the diff item for .IPAddress is between a literal
+// IP (got) and a reference to an Address (want) that has the same IP.
+func (n *forwardingRuleNode) sameIPAddress(item api.DiffItem) bool {
+	if !ipAddressPath.Equal(item.Path) || n.resolvedIP == "" {
+		return false
+	}
+	gotIP, ok := item.A.(string)
+	if !ok || net.ParseIP(gotIP) == nil {
+		return false
+	}
+	return gotIP == n.resolvedIP
+}
+
 // changedFields is a helper that interprets the set of fields that have been changed in a Diff.
 type changedFields struct {
 	target bool
@@ -81,6 +128,7 @@ func (n *forwardingRuleNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error
 	if err != nil {
 		return nil, nodeErr("Diff: %w", err)
 	}
+	diff = n.filterDiff(diff)
 
 	if diff.HasDiff() {
 		var changed changedFields
@@ -109,6 +157,19 @@ func (n *forwardingRuleNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error
 	}, nil
 }
 
+// filterDiff removes the items from diff that are equivalent in the Cloud
+// (see sameIPAddress).
+func (n *forwardingRuleNode) filterDiff(diff *api.DiffResult) *api.DiffResult {
+	ret := &api.DiffResult{StatusItems: diff.StatusItems}
+	for _, item := range diff.Items {
+		if n.sameIPAddress(item) {
+			continue
+		}
+		ret.Items = append(ret.Items, item)
+	}
+	return ret
+}
+
 func (n *forwardingRuleNode) Actions(got rnode.Node) ([]exec.Action, error) {
 	op := n.Plan().Op()
 
@@ -134,6 +195,9 @@ func (n *forwardingRuleNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *forwardingRuleNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
 
@@ -142,6 +206,11 @@ func (n *forwardingRuleNode) createActions() ([]exec.Action, error) {
 	if err != nil {
 		return nil, err
 	}
+	if obj, _ := n.resource.ToGA(); len(obj.Labels) > 0 {
+		if err := checkMethods(n.ID().Key, "SetLabels"); err != nil {
+			return nil, nodeErr("createActions %s: %w", n.ID(), err)
+		}
+	}
 	return []exec.Action{
 		newForwardingRuleCreateAction(n.ID(), n.resource, want),
 	}, nil
@@ -165,6 +234,17 @@ func (n *forwardingRuleNode) updateActions(ngot rnode.Node) ([]exec.Action, erro
 			return nil, nodeErr("updateActions %s: field %s cannot be updated in place", n.ID(), item.Path)
 		}
 	}
+	var methods []string
+	if changed.target {
+		methods = append(methods, "SetTarget")
+	}
+	if changed.labels {
+		methods = append(methods, "SetLabels")
+	}
+	if err := checkMethods(n.ID().Key, methods...); err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
 	if changed.target {
 		oldTarget, err := parseTarget(fmt.Sprintf("updateActions %s", n.ID()), got)
 		if err != nil {
@@ -180,9 +260,12 @@ func (n *forwardingRuleNode) updateActions(ngot rnode.Node) ([]exec.Action, erro
 	}
 
 	if changed.labels {
-		gotRes, _ := got.resource.ToGA()
+		labelFingerprint, err := rnode.LabelFingerprint(got.resource)
+		if err != nil {
+			return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+		}
 		wantRes, _ := n.resource.ToGA()
-		act.labelFingerprint = gotRes.LabelFingerprint
+		act.labelFingerprint = labelFingerprint
 		act.labels = wantRes.Labels
 	}
 
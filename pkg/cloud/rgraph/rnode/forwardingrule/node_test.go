@@ -20,9 +20,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
 	"google.golang.org/api/compute/v1"
@@ -264,3 +268,112 @@ func TestDiffAndActions(t *testing.T) {
 		})
 	}
 }
+
+// newTestNode returns a Managed ForwardingRule Node that Exists, named "fr"
+// and changed by f.
+func newTestNode(t *testing.T, id *cloud.ResourceID, f func(x *compute.ForwardingRule)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableForwardingRule(id.ProjectID, id.Key)
+	if err := m.Access(func(x *compute.ForwardingRule) {
+		x.Name = "fr"
+		f(x)
+	}); err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	return rnodetest.NewNode(t, m, nil, NewBuilderWithResource)
+}
+
+func TestDiffIPAddressReference(t *testing.T) {
+	id := ID("proj", meta.GlobalKey("fr"))
+	addrID := address.ID("proj", meta.GlobalKey("addr"))
+	targetID := targethttpproxy.ID("proj", meta.GlobalKey("tp"))
+
+	makeNode := func(ipAddress string) rnode.Node {
+		t.Helper()
+		return newTestNode(t, id, func(x *compute.ForwardingRule) {
+			x.IPAddress = ipAddress
+			x.Target = targetID.SelfLink(meta.VersionGA)
+		})
+	}
+	makeAddr := func(ip string) rnode.Node {
+		t.Helper()
+		ma := address.NewMutableAddress(addrID.ProjectID, addrID.Key)
+		ma.Access(func(x *compute.Address) {
+			x.Name = "addr"
+			x.Address = ip
+		})
+		return rnodetest.NewNode(t, ma, nil, address.NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		gotIP  string
+		wantIP string
+		addr   rnode.Node
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "literal IP and Address with the same IP",
+			gotIP:  "1.2.3.4",
+			wantIP: addrID.SelfLink(meta.VersionGA),
+			addr:   makeAddr("1.2.3.4"),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "literal IP and Address with a different IP",
+			gotIP:  "1.2.3.4",
+			wantIP: addrID.SelfLink(meta.VersionGA),
+			addr:   makeAddr("5.6.7.8"),
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "Address not in graph",
+			gotIP:  "1.2.3.4",
+			wantIP: addrID.SelfLink(meta.VersionGA),
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "different literal IPs",
+			gotIP:  "1.2.3.4",
+			wantIP: "5.6.7.8",
+			addr:   makeAddr("5.6.7.8"),
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(tc.gotIP)
+			want := makeNode(tc.wantIP)
+
+			want.(rnode.RefResolver).ResolveRefs(func(id *cloud.ResourceID) rnode.Node {
+				if tc.addr != nil && id.Equal(tc.addr.ID()) {
+					return tc.addr
+				}
+				return nil
+			})
+			pd, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if pd.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (details: %s)", pd.Operation, tc.wantOp, pretty.Sprint(pd))
+			}
+		})
+	}
+}
+
+func TestFilterDiffKeepsStatusItems(t *testing.T) {
+	status := []api.DiffItem{{Path: api.Path{}.Pointer().Field("PscConnectionStatus"), A: "a", B: "b"}}
+	diff := &api.DiffResult{
+		Items:       []api.DiffItem{{Path: ipAddressPath, A: "10.0.0.1", B: "addr"}},
+		StatusItems: status,
+	}
+	n := &forwardingRuleNode{resolvedIP: "10.0.0.1"}
+	got := n.filterDiff(diff)
+	if got.HasDiff() {
+		t.Errorf("filterDiff().Items = %v, want none", got.Items)
+	}
+	if diff := cmp.Diff(got.StatusItems, status); diff != "" {
+		t.Errorf("filterDiff().StatusItems: -got,+want: %s", diff)
+	}
+}
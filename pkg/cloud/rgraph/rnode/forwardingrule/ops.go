@@ -26,6 +26,10 @@ import (
 
 type ops struct{}
 
+// ops implements GenericPatchOps. Note that the node itself uses the
+// SetTarget and SetLabels methods for OpUpdate.
+var _ rnode.GenericPatchOps[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] = (*ops)(nil)
+
 func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
 	return &rnode.GetFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
 		GA: rnode.GetFuncsByScope[compute.ForwardingRule]{
@@ -80,3 +84,20 @@ func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.ForwardingRu
 		},
 	}
 }
+
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return &rnode.PatchFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
+		GA: rnode.PatchFuncsByScope[compute.ForwardingRule]{
+			Global:   gcp.GlobalForwardingRules().Patch,
+			Regional: gcp.ForwardingRules().Patch,
+		},
+		Alpha: rnode.PatchFuncsByScope[alpha.ForwardingRule]{
+			Global:   gcp.AlphaGlobalForwardingRules().Patch,
+			Regional: gcp.AlphaForwardingRules().Patch,
+		},
+		Beta: rnode.PatchFuncsByScope[beta.ForwardingRule]{
+			Global:   gcp.BetaGlobalForwardingRules().Patch,
+			Regional: gcp.BetaForwardingRules().Patch,
+		},
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
@@ -195,6 +196,10 @@ const (
 	// API conventions but these exceptions occur throughout the
 	// GCE APIs and we have to work around them.
 	UpdateFuncsNoFingerprint = 1 << iota
+	// Patch takes the fields to change as an updateMask parameter (see
+	// cloud.WithUpdateMask) instead of from the fields in the body. This is
+	// only used by PatchFuncs.
+	UpdateFuncsUpdateMask
 )
 
 type UpdateFuncs[GA any, Alpha any, Beta any] struct {
@@ -291,6 +296,173 @@ func (f *UpdateFuncs[GA, Alpha, Beta]) Do(
 	return fmt.Errorf("updateFuncs.do unsupported version %q", desired.Version())
 }
 
+// GenericPatchOps is implemented by resources that support the Patch verb in
+// addition to the standard CRUD verbs. Nodes that do not implement it use
+// Update or resource specific methods (e.g. SetTarget) for OpUpdate.
+type GenericPatchOps[GA any, Alpha any, Beta any] interface {
+	GenericOps[GA, Alpha, Beta]
+	PatchFuncs(gcp cloud.Cloud) *PatchFuncs[GA, Alpha, Beta]
+}
+
+type PatchFuncsByScope[T any] struct {
+	Global   func(context.Context, *meta.Key, *T, ...cloud.Option) error
+	Regional func(context.Context, *meta.Key, *T, ...cloud.Option) error
+	Zonal    func(context.Context, *meta.Key, *T, ...cloud.Option) error
+}
+
+func (s *PatchFuncsByScope[T]) Do(ctx context.Context, key *meta.Key, x *T, options ...cloud.Option) error {
+	switch {
+	case key.Type() == meta.Global && s.Global != nil:
+		return s.Global(ctx, key, x, options...)
+	case key.Type() == meta.Regional && s.Regional != nil:
+		return s.Regional(ctx, key, x, options...)
+	case key.Type() == meta.Zonal && s.Zonal != nil:
+		return s.Zonal(ctx, key, x, options...)
+	}
+	return fmt.Errorf("unsupported scope (key = %s)", key)
+}
+
+type PatchFuncs[GA any, Alpha any, Beta any] struct {
+	GA    PatchFuncsByScope[GA]
+	Alpha PatchFuncsByScope[Alpha]
+	Beta  PatchFuncsByScope[Beta]
+
+	// Options are the same as UpdateFuncs.Options.
+	Options int
+}
+
+// patchObject returns a copy of src with only the fields named in mask set.
+// Fields in the mask that have a zero value are added to the metafields so
+// that the Patch will clear them instead of leaving them unchanged. Empty
+// (non-nil) slices and maps are treated the same as nil.
+func patchObject[T any](src *T, mask []string) (*T, error) {
+	ret := new(T)
+	sv := reflect.ValueOf(src).Elem()
+	dv := reflect.ValueOf(ret).Elem()
+
+	for _, name := range mask {
+		sf := sv.FieldByName(name)
+		if !sf.IsValid() {
+			return nil, fmt.Errorf("patchObject: no field %q in %T", name, src)
+		}
+		metaField := "ForceSendFields"
+		switch sf.Kind() {
+		case reflect.Pointer:
+			metaField = "NullFields"
+		case reflect.Slice, reflect.Map:
+			metaField = "NullFields"
+			// An empty collection is omitted from the JSON in the same way
+			// as nil and must be nulled explicitly to clear it.
+			if sf.Len() == 0 {
+				sf = reflect.Zero(sf.Type())
+			}
+		}
+		dv.FieldByName(name).Set(sf)
+		if !sf.IsZero() {
+			continue
+		}
+		if mf := dv.FieldByName(metaField); mf.IsValid() {
+			mf.Set(reflect.Append(mf, reflect.ValueOf(name)))
+		}
+	}
+	return ret, nil
+}
+
+// withPatchMask returns a context with the updateMask for mask if options has
+// UpdateFuncsUpdateMask. The updateMask uses the JSON names of the fields.
+func withPatchMask[T any](ctx context.Context, raw *T, mask []string, options int) (context.Context, error) {
+	if options&UpdateFuncsUpdateMask == 0 {
+		return ctx, nil
+	}
+	t := reflect.TypeOf(raw).Elem()
+	var names []string
+	for _, name := range mask {
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("withPatchMask: no field %q in %T", name, raw)
+		}
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if jsonName == "" {
+			return nil, fmt.Errorf("withPatchMask: field %q in %T has no JSON name", name, raw)
+		}
+		names = append(names, jsonName)
+	}
+	return cloud.WithUpdateMask(ctx, strings.Join(names, ",")), nil
+}
+
+func preparePatch[T any](raw *T, mask []string, fingerprint string, options int) (*T, error) {
+	obj, err := patchObject(raw, mask)
+	if err != nil {
+		return nil, err
+	}
+	if options&UpdateFuncsNoFingerprint == 0 {
+		fv, err := fingerprintField(reflect.ValueOf(obj))
+		if err != nil {
+			return nil, err
+		}
+		fv.Set(reflect.ValueOf(fingerprint))
+	}
+	return obj, nil
+}
+
+// Do the Patch operation, sending only the fields in mask.
+func (f *PatchFuncs[GA, Alpha, Beta]) Do(
+	ctx context.Context,
+	fingerprint string,
+	id *cloud.ResourceID,
+	desired api.Resource[GA, Alpha, Beta],
+	mask []string,
+) error {
+	switch desired.Version() {
+	case meta.VersionGA:
+		raw, err := desired.ToGA()
+		if err != nil {
+			return err
+		}
+		obj, err := preparePatch(raw, mask, fingerprint, f.Options)
+		if err != nil {
+			return err
+		}
+		ctx, err = withPatchMask(ctx, raw, mask, f.Options)
+		if err != nil {
+			return err
+		}
+		return f.GA.Do(ctx, id.Key, obj, cloud.ForceProjectID(id.ProjectID))
+
+	case meta.VersionAlpha:
+		raw, err := desired.ToAlpha()
+		if err != nil {
+			return err
+		}
+		obj, err := preparePatch(raw, mask, fingerprint, f.Options)
+		if err != nil {
+			return err
+		}
+		ctx, err = withPatchMask(ctx, raw, mask, f.Options)
+		if err != nil {
+			return err
+		}
+		return f.Alpha.Do(ctx, id.Key, obj, cloud.ForceProjectID(id.ProjectID))
+
+	case meta.VersionBeta:
+		raw, err := desired.ToBeta()
+		if err != nil {
+			return err
+		}
+		obj, err := preparePatch(raw, mask, fingerprint, f.Options)
+		if err != nil {
+			return err
+		}
+		ctx, err = withPatchMask(ctx, raw, mask, f.Options)
+		if err != nil {
+			return err
+		}
+		return f.Beta.Do(ctx, id.Key, obj, cloud.ForceProjectID(id.ProjectID))
+	}
+
+	return fmt.Errorf("patchFuncs.do unsupported version %q", desired.Version())
+}
+
 type DeleteFuncsByScope[T any] struct {
 	Global   func(context.Context, *meta.Key, ...cloud.Option) error
 	Regional func(context.Context, *meta.Key, ...cloud.Option) error
@@ -336,7 +508,15 @@ func GenericGet[GA any, Alpha any, Beta any](
 		// TODO: handle this by returning an error.
 		panic("XXX")
 	}
-	r, err := ops.GetFuncs(gcp).Do(ctx, b.Version(), b.ID(), typeTrait)
+	var (
+		r   api.Resource[GA, Alpha, Beta]
+		err error
+	)
+	if res, ok := takePrefetched(gcp, b.Version(), b.ID()); ok {
+		r, err = prefetchedResource(b.Version(), b.ID(), typeTrait, res)
+	} else {
+		r, err = ops.GetFuncs(gcp).Do(ctx, b.Version(), b.ID(), typeTrait)
+	}
 
 	switch {
 	case cerrors.IsGoogleAPINotFound(err):
@@ -353,3 +533,22 @@ func GenericGet[GA any, Alpha any, Beta any](
 		return nil
 	}
 }
+
+// GenericUnmarshalResource sets the resource in b from JSON created by
+// Resource.MarshalJSON. The Version of b is set to the Version of the
+// resource.
+func GenericUnmarshalResource[GA any, Alpha any, Beta any](
+	typeTrait api.TypeTrait[GA, Alpha, Beta],
+	b Builder,
+	data []byte,
+) error {
+	r, err := api.UnmarshalResource(b.ID(), typeTrait, data)
+	if err != nil {
+		return err
+	}
+	if err := b.SetResource(r); err != nil {
+		return err
+	}
+	b.setVersion(r.Version())
+	return nil
+}
@@ -27,6 +27,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("healthChecks", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
@@ -63,9 +65,15 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, "HealthCheck", &healthCheckOps{}, &typeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](&typeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	// No references.
-	return nil, nil
+	// HealthCheck does not reference other resources: Region and
+	// SourceRegions are locations and not resources. HealthCheck is a leaf
+	// that is referenced by BackendServices.
+	return []rnode.ResourceRef{}, nil
 }
 
 func (b *builder) Build() (rnode.Node, error) {
@@ -17,8 +17,10 @@ limitations under the License.
 package healthcheck
 
 import (
+	"context"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
@@ -103,6 +105,10 @@ func TestHealthCheckBuilder(t *testing.T) {
 	if outs := hcNode.OutRefs(); len(outs) != 0 {
 		t.Fatalf("Health check Out Refs length mismatch: got %d want 0", len(outs))
 	}
+	outs, err := b.OutRefs()
+	if err != nil || outs == nil || len(outs) != 0 {
+		t.Fatalf("b.OutRefs() = %v, %v; want [], nil", outs, err)
+	}
 
 }
 
@@ -246,7 +252,11 @@ func TestHealthCheckBeta(t *testing.T) {
 }
 
 func buildHCNode(t *testing.T, name string, hc compute.HealthCheck) rnode.Node {
-	id := ID(projectID, meta.GlobalKey(name))
+	return buildHCNodeWithKey(t, meta.GlobalKey(name), hc)
+}
+
+func buildHCNodeWithKey(t *testing.T, key *meta.Key, hc compute.HealthCheck) rnode.Node {
+	id := ID(projectID, key)
 	hcMutRes := NewMutableHealthCheck(projectID, id.Key)
 	err := hcMutRes.Access(func(x *compute.HealthCheck) {
 		*x = hc
@@ -409,3 +419,69 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+func TestRegionalHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	key := meta.RegionalKey("hc-1", "us-central1")
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: projectID})
+	var calls []string
+	mock.MockRegionHealthChecks.InsertHook = func(_ context.Context, _ *meta.Key, _ *compute.HealthCheck, _ *cloud.MockRegionHealthChecks, _ ...cloud.Option) (bool, error) {
+		calls = append(calls, "Insert")
+		return false, nil
+	}
+	mock.MockRegionHealthChecks.UpdateHook = func(_ context.Context, _ *meta.Key, _ *compute.HealthCheck, _ *cloud.MockRegionHealthChecks, _ ...cloud.Option) error {
+		calls = append(calls, "Update")
+		return nil
+	}
+	mock.MockRegionHealthChecks.DeleteHook = func(_ context.Context, _ *meta.Key, _ *cloud.MockRegionHealthChecks, _ ...cloud.Option) (bool, error) {
+		calls = append(calls, "Delete")
+		return false, nil
+	}
+	mock.MockHealthChecks.InsertHook = func(_ context.Context, key *meta.Key, _ *compute.HealthCheck, _ *cloud.MockHealthChecks, _ ...cloud.Option) (bool, error) {
+		t.Errorf("HealthChecks().Insert(%v) called for a regional HealthCheck", key)
+		return true, nil
+	}
+	mock.MockHealthChecks.UpdateHook = func(_ context.Context, key *meta.Key, _ *compute.HealthCheck, _ *cloud.MockHealthChecks, _ ...cloud.Option) error {
+		t.Errorf("HealthChecks().Update(%v) called for a regional HealthCheck", key)
+		return nil
+	}
+	mock.MockHealthChecks.DeleteHook = func(_ context.Context, key *meta.Key, _ *cloud.MockHealthChecks, _ ...cloud.Option) (bool, error) {
+		t.Errorf("HealthChecks().Delete(%v) called for a regional HealthCheck", key)
+		return true, nil
+	}
+
+	run := func(want, got rnode.Node, op rnode.Operation) {
+		t.Helper()
+		want.Plan().Set(rnode.PlanDetails{Operation: op, Why: "test plan"})
+		actions, err := want.Actions(got)
+		if err != nil {
+			t.Fatalf("Actions(_) = %v, want nil", err)
+		}
+		for _, a := range actions {
+			if _, err := a.Run(ctx, mock); err != nil {
+				t.Fatalf("%s: Run() = %v, want nil", a, err)
+			}
+		}
+	}
+
+	hc := newDefaultHC()
+	n1 := buildHCNodeWithKey(t, key, hc)
+	run(n1, n1, rnode.OpCreate)
+	if _, err := mock.RegionHealthChecks().Get(ctx, key); err != nil {
+		t.Fatalf("RegionHealthChecks().Get(%v) = %v, want nil", key, err)
+	}
+
+	hc.CheckIntervalSec = 10
+	n2 := buildHCNodeWithKey(t, key, hc)
+	run(n2, n1, rnode.OpUpdate)
+	run(n2, n2, rnode.OpDelete)
+
+	if diff := cmp.Diff(calls, []string{"Insert", "Update", "Delete"}); diff != "" {
+		t.Errorf("RegionHealthChecks calls: diff -got,+want: %s", diff)
+	}
+	const wantSelfLink = "https://www.googleapis.com/compute/v1/projects/proj-1/regions/us-central1/healthChecks/hc-1"
+	if got := ID(projectID, key).SelfLink(meta.VersionGA); got != wantSelfLink {
+		t.Errorf("ID(%v).SelfLink() = %q, want %q", key, got, wantSelfLink)
+	}
+}
@@ -86,5 +86,8 @@ func (n *healthCheckNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *healthCheckNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
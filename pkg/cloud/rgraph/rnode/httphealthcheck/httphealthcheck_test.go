@@ -0,0 +1,78 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphealthcheck
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func TestHttpHealthCheckSchema(t *testing.T) {
+	x := NewMutableHttpHealthCheck(proj, meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestHttpHealthCheckDiffAndActions(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.HttpHealthCheck) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableHttpHealthCheck(proj, meta.GlobalKey("hc")), x, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		got    *compute.HttpHealthCheck
+		want   *compute.HttpHealthCheck
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "same",
+			got:    &compute.HttpHealthCheck{Name: "hc", Port: 80, Kind: "compute#httpHealthCheck"},
+			want:   &compute.HttpHealthCheck{Name: "hc", Port: 80},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "port changed",
+			got:    &compute.HttpHealthCheck{Name: "hc", Port: 80},
+			want:   &compute.HttpHealthCheck{Name: "hc", Port: 8080},
+			wantOp: rnode.OpUpdate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, tc.got)
+			want := makeNode(t, tc.want)
+			details, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (%s)", details.Operation, tc.wantOp, details.Why)
+			}
+			want.Plan().Set(*details)
+			if _, err := want.Actions(got); err != nil {
+				t.Errorf("Actions() = %v, want nil", err)
+			}
+		})
+	}
+}
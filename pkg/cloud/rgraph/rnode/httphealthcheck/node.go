@@ -0,0 +1,92 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphealthcheck
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type httpHealthCheckNode struct {
+	rnode.NodeBase
+	resource HttpHealthCheck
+}
+
+var _ rnode.Node = (*httpHealthCheckNode)(nil)
+
+func (n *httpHealthCheckNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *httpHealthCheckNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(HttpHealthCheck)
+	if !ok {
+		return nil, fmt.Errorf("HttpHealthCheckNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("HttpHealthCheckNode: Diff %w", err)
+	}
+
+	if diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpUpdate,
+			Why:       "HttpHealthCheck update",
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpNothing,
+		Why:       "No diff between got and want",
+	}, nil
+}
+
+func (n *httpHealthCheckNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.HttpHealthCheck, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.HttpHealthCheck, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.HttpHealthCheck, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[compute.HttpHealthCheck, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource, "")
+	}
+
+	return nil, fmt.Errorf("HttpHealthCheckNode: invalid plan op %s", op)
+}
+
+func (n *httpHealthCheckNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
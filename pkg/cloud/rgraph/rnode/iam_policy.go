@@ -0,0 +1,282 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	"google.golang.org/api/compute/v1"
+)
+
+// IAMPolicyOps gets and sets the IAM policy of a resource. This is
+// implemented for resource types whose service has the GetIamPolicy and
+// SetIamPolicy methods (see meta.ServiceInfo.AdditionalMethods).
+type IAMPolicyOps interface {
+	// GetIAMPolicy returns the current policy of the resource.
+	GetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key) (*compute.Policy, error)
+	// SetIAMPolicy replaces the policy of the resource. policy.Etag must be
+	// the Etag of the current policy.
+	SetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key, policy *compute.Policy) error
+}
+
+// ImagesIAMPolicyOps is the IAMPolicyOps for Images.
+type ImagesIAMPolicyOps struct{}
+
+// GetIAMPolicy implements IAMPolicyOps.
+func (ImagesIAMPolicyOps) GetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key) (*compute.Policy, error) {
+	return cl.Images().GetIamPolicy(ctx, key)
+}
+
+// SetIAMPolicy implements IAMPolicyOps.
+func (ImagesIAMPolicyOps) SetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key, policy *compute.Policy) error {
+	_, err := cl.Images().SetIamPolicy(ctx, key, &compute.GlobalSetPolicyRequest{Policy: policy})
+	return err
+}
+
+// NetworkFirewallPoliciesIAMPolicyOps is the IAMPolicyOps for global and
+// regional network FirewallPolicies. These are only available in the Alpha
+// API; the policy is converted to and from GA.
+type NetworkFirewallPoliciesIAMPolicyOps struct{}
+
+// GetIAMPolicy implements IAMPolicyOps.
+func (NetworkFirewallPoliciesIAMPolicyOps) GetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key) (*compute.Policy, error) {
+	var (
+		p   *alpha.Policy
+		err error
+	)
+	switch key.Type() {
+	case meta.Global:
+		p, err = cl.AlphaNetworkFirewallPolicies().GetIamPolicy(ctx, key)
+	case meta.Regional:
+		p, err = cl.AlphaRegionNetworkFirewallPolicies().GetIamPolicy(ctx, key)
+	default:
+		return nil, fmt.Errorf("NetworkFirewallPoliciesIAMPolicyOps: invalid scope %v", key.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := &compute.Policy{}
+	if err := convertPolicy(p, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SetIAMPolicy implements IAMPolicyOps.
+func (NetworkFirewallPoliciesIAMPolicyOps) SetIAMPolicy(ctx context.Context, cl cloud.Cloud, key *meta.Key, policy *compute.Policy) error {
+	p := &alpha.Policy{}
+	if err := convertPolicy(policy, p); err != nil {
+		return err
+	}
+	var err error
+	switch key.Type() {
+	case meta.Global:
+		_, err = cl.AlphaNetworkFirewallPolicies().SetIamPolicy(ctx, key, &alpha.GlobalSetPolicyRequest{Policy: p})
+	case meta.Regional:
+		_, err = cl.AlphaRegionNetworkFirewallPolicies().SetIamPolicy(ctx, key, &alpha.RegionSetPolicyRequest{Policy: p})
+	default:
+		return fmt.Errorf("NetworkFirewallPoliciesIAMPolicyOps: invalid scope %v", key.Type())
+	}
+	return err
+}
+
+// convertPolicy between API versions. The Policy types have the same JSON
+// schema.
+func convertPolicy(src, dest any) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("convertPolicy: %w", err)
+	}
+	if err := json.Unmarshal(b, dest); err != nil {
+		return fmt.Errorf("convertPolicy: %w", err)
+	}
+	return nil
+}
+
+// IAMPolicyHolder is implemented by the Builder and the Node of resource types
+// that have an IAM policy, usually by embedding IAMPolicyBase. The policy is
+// not part of NodeBase: the node type must copy it from the Builder in Build()
+// and back in Node.Builder().
+type IAMPolicyHolder interface {
+	IAMPolicy() *compute.Policy
+	SetIAMPolicy(*compute.Policy)
+}
+
+// IAMPolicyBase implements IAMPolicyHolder.
+type IAMPolicyBase struct {
+	iamPolicy *compute.Policy
+}
+
+// IAMPolicy of the resource. This is nil unless it was fetched with
+// SyncIAMPolicyFromCloud() or set.
+func (b *IAMPolicyBase) IAMPolicy() *compute.Policy { return b.iamPolicy }
+
+// SetIAMPolicy of the resource.
+func (b *IAMPolicyBase) SetIAMPolicy(p *compute.Policy) { b.iamPolicy = p }
+
+// SyncIAMPolicyFromCloud fetches the IAM policy of the resource with
+// ops.GetIAMPolicy and stores it in b, which must implement IAMPolicyHolder.
+// Builders for resources with an IAM policy call this from SyncFromCloud() so
+// that the policy is part of the "got" graph; planning (IAMPolicyActions)
+// does not call the cloud.
+func SyncIAMPolicyFromCloud(ctx context.Context, cl cloud.Cloud, ops IAMPolicyOps, b Builder) error {
+	pb, ok := b.(IAMPolicyHolder)
+	if !ok {
+		return fmt.Errorf("SyncIAMPolicyFromCloud(%v): %T does not hold an IAM policy", b.ID(), b)
+	}
+	policy, err := ops.GetIAMPolicy(ctx, cl, b.ID().Key)
+	if err != nil {
+		return fmt.Errorf("SyncIAMPolicyFromCloud(%v): %w", b.ID(), err)
+	}
+	pb.SetIAMPolicy(policy)
+	return nil
+}
+
+// IAMPolicyActions returns the Actions to reconcile the IAM policy got, as
+// fetched into the got graph with SyncIAMPolicyFromCloud(), with want. If the
+// Bindings or the Version differ, a single Action that calls SetIamPolicy is
+// returned; otherwise no Actions are returned. Bindings are compared as
+// sets, i.e. the order of the Bindings and their Members is ignored; the
+// Condition is part of the identity of a Binding.
+//
+// The policy that is set is got (for the Etag) with the Bindings and Version
+// from want. If want.Version is not set, the Version of got is kept. Version
+// 3 is required for Bindings with a Condition, so a lower Version is raised
+// to 3 in that case.
+func IAMPolicyActions(
+	ops IAMPolicyOps,
+	id *cloud.ResourceID,
+	got *compute.Policy,
+	want *compute.Policy,
+) ([]exec.Action, error) {
+	if got == nil {
+		return nil, fmt.Errorf("IAMPolicyActions(%v): IAM policy was not fetched", id)
+	}
+	if want == nil {
+		return nil, fmt.Errorf("IAMPolicyActions(%v): want is nil", id)
+	}
+	version := policyVersion(got, want)
+	if version == got.Version && equalBindings(got.Bindings, want.Bindings) {
+		return nil, nil
+	}
+	policy := *got
+	policy.Bindings = want.Bindings
+	policy.Version = version
+	return []exec.Action{&setIAMPolicyAction{ops: ops, id: id, policy: &policy}}, nil
+}
+
+// conditionalPolicyVersion is the minimum policy Version for Bindings with a
+// Condition.
+const conditionalPolicyVersion = 3
+
+// policyVersion returns the Version of the policy to set.
+func policyVersion(got, want *compute.Policy) int64 {
+	ret := want.Version
+	if ret == 0 {
+		ret = got.Version
+	}
+	if ret < conditionalPolicyVersion {
+		for _, b := range want.Bindings {
+			if b != nil && b.Condition != nil {
+				return conditionalPolicyVersion
+			}
+		}
+	}
+	return ret
+}
+
+// equalBindings compares the Bindings as sets.
+func equalBindings(a, b []*compute.Binding) bool {
+	na, nb := normalizeBindings(a), normalizeBindings(b)
+	if len(na) != len(nb) {
+		return false
+	}
+	for k, v := range na {
+		if nb[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeBindings returns a map of role and condition to the sorted list
+// of members (joined as a string). Bindings without members are dropped as
+// they have no effect.
+func normalizeBindings(bindings []*compute.Binding) map[string]string {
+	members := map[string][]string{}
+	for _, b := range bindings {
+		if b == nil || len(b.Members) == 0 {
+			continue
+		}
+		k := b.Role
+		if c := b.Condition; c != nil {
+			k += fmt.Sprintf("/%q/%q/%q/%q", c.Expression, c.Title, c.Description, c.Location)
+		}
+		members[k] = append(members[k], b.Members...)
+	}
+	ret := map[string]string{}
+	for k, m := range members {
+		sort.Strings(m)
+		ret[k] = strings.Join(m, ",")
+	}
+	return ret
+}
+
+type setIAMPolicyAction struct {
+	exec.ActionBase
+	ops    IAMPolicyOps
+	id     *cloud.ResourceID
+	policy *compute.Policy
+}
+
+func (a *setIAMPolicyAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if err := a.ops.SetIAMPolicy(ctx, cl, a.id.Key, a.policy); err != nil {
+		return nil, fmt.Errorf("SetIAMPolicyAction(%v): %w", a.id, err)
+	}
+	return nil, nil
+}
+
+func (a *setIAMPolicyAction) DryRun() exec.EventList { return nil }
+
+func (a *setIAMPolicyAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	body, err := json.Marshal(a.policy)
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: "SetIamPolicy", ResourceID: a.id, Body: string(body)}}, nil
+}
+
+func (a *setIAMPolicyAction) String() string {
+	return fmt.Sprintf("SetIAMPolicyAction(%v)", a.id)
+}
+
+func (a *setIAMPolicyAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       ActionName("SetIAMPolicyAction", a.id),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("Set IAM policy for %s", a.id),
+		ResourceID: a.id,
+	}
+}
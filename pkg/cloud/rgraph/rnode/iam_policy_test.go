@@ -0,0 +1,229 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+// iamBuilder is a fakeBuilder for a resource type with an IAM policy.
+type iamBuilder struct {
+	fakeBuilder
+	IAMPolicyBase
+}
+
+func (b *iamBuilder) Build() (Node, error) {
+	ret := &iamNode{}
+	ret.InitFromBuilder(b)
+	ret.SetIAMPolicy(b.IAMPolicy())
+	return ret, nil
+}
+
+type iamNode struct {
+	fakeNode
+	IAMPolicyBase
+}
+
+func TestSyncIAMPolicyFromCloud(t *testing.T) {
+	const proj = "proj-1"
+	id := &cloud.ResourceID{ProjectID: proj, Resource: "images", Key: meta.GlobalKey("img")}
+	policy := &compute.Policy{
+		Etag:    "etag-1",
+		Version: 3,
+		Bindings: []*compute.Binding{{
+			Role:      "roles/compute.imageUser",
+			Members:   []string{"user:a@example.com"},
+			Condition: &compute.Expr{Title: "expires", Expression: `request.time < timestamp("2030-01-01T00:00:00Z")`},
+		}},
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.MockImages.GetIamPolicyHook = func(context.Context, *meta.Key, *cloud.MockImages, ...cloud.Option) (*compute.Policy, error) {
+		return policy, nil
+	}
+
+	b := &iamBuilder{fakeBuilder: fakeBuilder{BuilderBase: BuilderBase{id: id, state: NodeExists}}}
+	if err := SyncIAMPolicyFromCloud(context.Background(), mock, ImagesIAMPolicyOps{}, b); err != nil {
+		t.Fatalf("SyncIAMPolicyFromCloud() = %v, want nil", err)
+	}
+	if err := SyncIAMPolicyFromCloud(context.Background(), mock, ImagesIAMPolicyOps{}, &fakeBuilder{}); err == nil {
+		t.Errorf("SyncIAMPolicyFromCloud() = nil, want error for a Builder without an IAM policy")
+	}
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	got, ok := n.(IAMPolicyHolder)
+	if !ok {
+		t.Fatalf("%T does not have IAMPolicy()", n)
+	}
+	if diff := cmp.Diff(got.IAMPolicy(), policy); diff != "" {
+		t.Errorf("IAMPolicy(): -got,+want: %s", diff)
+	}
+}
+
+func TestIAMPolicyActions(t *testing.T) {
+	const proj = "proj-1"
+	id := &cloud.ResourceID{ProjectID: proj, Resource: "images", Key: meta.GlobalKey("img")}
+
+	viewers := &compute.Binding{Role: "roles/compute.imageUser", Members: []string{"user:a@example.com", "user:b@example.com"}}
+	expires := &compute.Expr{Title: "expires", Expression: `request.time < timestamp("2030-01-01T00:00:00Z")`}
+	conditional := &compute.Binding{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}, Condition: expires}
+	current := &compute.Policy{Etag: "etag-1", Version: 1, Bindings: []*compute.Binding{viewers}}
+	currentV3 := &compute.Policy{Etag: "etag-1", Version: 3, Bindings: []*compute.Binding{viewers, conditional}}
+
+	for _, tc := range []struct {
+		name string
+		got  *compute.Policy
+		want *compute.Policy
+		// wantPolicy is the policy that is set. nil means no Action.
+		wantPolicy *compute.Policy
+	}{
+		{
+			name: "no change",
+			got:  current,
+			want: &compute.Policy{Bindings: []*compute.Binding{viewers}},
+		},
+		{
+			name: "members in different order",
+			got:  current,
+			want: &compute.Policy{Bindings: []*compute.Binding{
+				{Role: "roles/compute.imageUser", Members: []string{"user:b@example.com", "user:a@example.com"}},
+			}},
+		},
+		{
+			name: "add binding",
+			got:  current,
+			want: &compute.Policy{Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}},
+			}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 1, Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}},
+			}},
+		},
+		{
+			name: "add member",
+			got:  current,
+			want: &compute.Policy{Bindings: []*compute.Binding{
+				{Role: "roles/compute.imageUser", Members: []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}},
+			}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 1, Bindings: []*compute.Binding{
+				{Role: "roles/compute.imageUser", Members: []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}},
+			}},
+		},
+		{
+			name:       "remove all bindings",
+			got:        current,
+			want:       &compute.Policy{},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 1},
+		},
+		{
+			name:       "add conditional binding raises Version",
+			got:        current,
+			want:       &compute.Policy{Bindings: []*compute.Binding{viewers, conditional}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 3, Bindings: []*compute.Binding{viewers, conditional}},
+		},
+		{
+			name: "conditional binding no change",
+			got:  currentV3,
+			want: &compute.Policy{Bindings: []*compute.Binding{conditional, viewers}},
+		},
+		{
+			name: "condition changed",
+			got:  currentV3,
+			want: &compute.Policy{Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}, Condition: &compute.Expr{Title: "expires", Expression: `request.time < timestamp("2031-01-01T00:00:00Z")`}},
+			}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 3, Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}, Condition: &compute.Expr{Title: "expires", Expression: `request.time < timestamp("2031-01-01T00:00:00Z")`}},
+			}},
+		},
+		{
+			name: "condition removed",
+			got:  currentV3,
+			want: &compute.Policy{Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}},
+			}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 3, Bindings: []*compute.Binding{
+				viewers,
+				{Role: "roles/compute.admin", Members: []string{"user:c@example.com"}},
+			}},
+		},
+		{
+			name:       "Version changed",
+			got:        current,
+			want:       &compute.Policy{Version: 3, Bindings: []*compute.Binding{viewers}},
+			wantPolicy: &compute.Policy{Etag: "etag-1", Version: 3, Bindings: []*compute.Binding{viewers}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			mock.MockImages.GetIamPolicyHook = func(context.Context, *meta.Key, *cloud.MockImages, ...cloud.Option) (*compute.Policy, error) {
+				t.Errorf("GetIamPolicy called during planning")
+				return nil, nil
+			}
+			var gotReq *compute.GlobalSetPolicyRequest
+			mock.MockImages.SetIamPolicyHook = func(_ context.Context, _ *meta.Key, req *compute.GlobalSetPolicyRequest, _ *cloud.MockImages, _ ...cloud.Option) (*compute.Policy, error) {
+				gotReq = req
+				return req.Policy, nil
+			}
+
+			actions, err := IAMPolicyActions(ImagesIAMPolicyOps{}, id, tc.got, tc.want)
+			if err != nil {
+				t.Fatalf("IAMPolicyActions() = %v, want nil", err)
+			}
+			wantActions := 0
+			if tc.wantPolicy != nil {
+				wantActions = 1
+			}
+			if len(actions) != wantActions {
+				t.Fatalf("len(actions) = %d, want %d (actions = %v)", len(actions), wantActions, actions)
+			}
+			if wantActions == 0 {
+				return
+			}
+
+			if _, err := actions[0].Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if gotReq == nil {
+				t.Fatalf("SetIamPolicy was not called")
+			}
+			if diff := cmp.Diff(gotReq.Policy, tc.wantPolicy); diff != "" {
+				t.Errorf("SetIamPolicy(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIAMPolicyActionsNotFetched(t *testing.T) {
+	id := &cloud.ResourceID{ProjectID: "proj-1", Resource: "images", Key: meta.GlobalKey("img")}
+	if _, err := IAMPolicyActions(ImagesIAMPolicyOps{}, id, nil, &compute.Policy{}); err == nil {
+		t.Errorf("IAMPolicyActions(got = nil) = nil, want error")
+	}
+}
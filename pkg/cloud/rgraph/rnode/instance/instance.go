@@ -0,0 +1,52 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "instances",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+// Instances are only available in the GA API. The node exists so that other
+// resources (e.g. TargetPool) can reference instances; instances are usually
+// owned by something else (e.g. an InstanceGroupManager) and should be added
+// to the graph with OwnershipExternal. Like any other resource, an instance
+// that is only discovered while fetching the graph is treated as managed.
+type MutableInstance = api.MutableResource[compute.Instance, api.PlaceholderType, api.PlaceholderType]
+
+func NewMutableInstance(project string, key *meta.Key) MutableInstance {
+	id := ID(project, key)
+	return api.NewResource[
+		compute.Instance,
+		api.PlaceholderType,
+		api.PlaceholderType,
+	](id, &typeTrait{})
+}
+
+type Instance = api.Resource[compute.Instance, api.PlaceholderType, api.PlaceholderType]
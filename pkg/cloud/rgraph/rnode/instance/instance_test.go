@@ -0,0 +1,112 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	proj = "proj-1"
+	zone = "us-central1-b"
+)
+
+func TestInstanceSchema(t *testing.T) {
+	x := NewMutableInstance(proj, meta.ZonalKey("key-1", zone))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestInstanceDiff(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.Instance, forceRecreate, allowRecreate bool) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableInstance(proj, meta.ZonalKey("inst", zone)), x, NewBuilderWithResource, func(b rnode.Builder) {
+			b.SetForceRecreate(forceRecreate)
+			b.SetAllowRecreate(allowRecreate)
+		})
+	}
+
+	for _, tc := range []struct {
+		name          string
+		got           *compute.Instance
+		want          *compute.Instance
+		forceRecreate bool
+		allowRecreate bool
+		wantOp        rnode.Operation
+		wantErr       bool
+	}{
+		{
+			name: "same",
+			got: &compute.Instance{
+				Name:        "inst",
+				MachineType: "e2-small",
+				Status:      "RUNNING",
+				Fingerprint: "abc",
+			},
+			want:   &compute.Instance{Name: "inst", MachineType: "e2-small"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:    "machine type changed",
+			got:     &compute.Instance{Name: "inst", MachineType: "e2-small"},
+			want:    &compute.Instance{Name: "inst", MachineType: "e2-medium"},
+			wantErr: true,
+		},
+		{
+			name:          "machine type changed with ForceRecreate",
+			got:           &compute.Instance{Name: "inst", MachineType: "e2-small"},
+			want:          &compute.Instance{Name: "inst", MachineType: "e2-medium"},
+			forceRecreate: true,
+			wantOp:        rnode.OpRecreate,
+		},
+		{
+			name:          "machine type changed with AllowRecreate",
+			got:           &compute.Instance{Name: "inst", MachineType: "e2-small"},
+			want:          &compute.Instance{Name: "inst", MachineType: "e2-medium"},
+			allowRecreate: true,
+			wantOp:        rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			details, err := makeNode(t, tc.want, tc.forceRecreate, tc.allowRecreate).Diff(makeNode(t, tc.got, false, false))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if details.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (%s)", details.Operation, tc.wantOp, details.Why)
+			}
+		})
+	}
+}
+
+func TestBuildRegional(t *testing.T) {
+	b := NewBuilder(ID(proj, meta.RegionalKey("inst", "us-central1")))
+	b.SetOwnership(rnode.OwnershipExternal)
+	b.SetState(rnode.NodeDoesNotExist)
+	if _, err := b.Build(); err == nil {
+		t.Errorf("Build() = nil, want error for a regional Instance")
+	}
+}
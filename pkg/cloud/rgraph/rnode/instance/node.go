@@ -0,0 +1,94 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type instanceNode struct {
+	rnode.NodeBase
+	resource Instance
+}
+
+var _ rnode.Node = (*instanceNode)(nil)
+
+func (n *instanceNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *instanceNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(Instance)
+	if !ok {
+		return nil, fmt.Errorf("InstanceNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceNode: Diff %w", err)
+	}
+
+	if !diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	// There is no generic update for Instances. Recreating an instance
+	// loses its state so this is only done when explicitly allowed.
+	if !n.AllowRecreate() && !n.ForceRecreate() {
+		return nil, fmt.Errorf("InstanceNode: %s: update is not supported (set AllowRecreate to recreate the instance)", n.ID())
+	}
+	return &rnode.PlanDetails{
+		Operation: rnode.OpRecreate,
+		Why:       "Instance needs to be recreated (no update method exists)",
+		Diff:      diff,
+	}, nil
+}
+
+func (n *instanceNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.Instance, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.Instance, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.Instance, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("InstanceNode: invalid plan op %s", op)
+}
+
+func (n *instanceNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+func newResizeAction(id *cloud.ResourceID, size int64) *resizeAction {
+	return &resizeAction{id: id, size: size}
+}
+
+// resizeAction changes the TargetSize of the InstanceGroupManager.
+type resizeAction struct {
+	exec.ActionBase
+
+	id   *cloud.ResourceID
+	size int64
+}
+
+func (act *resizeAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if err := cl.InstanceGroupManagers().Resize(ctx, act.id.Key, act.size); err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	return nil, nil
+}
+
+func (act *resizeAction) DryRun() exec.EventList { return nil }
+
+func (act *resizeAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	// Resize passes the size as a query parameter and has no request body.
+	return []exec.DryRunCall{{Method: "Resize", ResourceID: act.id}}, nil
+}
+
+func (act *resizeAction) String() string {
+	return fmt.Sprintf("InstanceGroupManagerResizeAction(%s, %d)", act.id, act.size)
+}
+
+func (act *resizeAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("InstanceGroupManagerResizeAction", act.id, act.size),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("Resize %s to %d", act.id, act.size),
+		ResourceID: act.id,
+	}
+}
+
+func newSetInstanceTemplateAction(id *cloud.ResourceID, gotURL, wantURL string) (*setInstanceTemplateAction, error) {
+	gotID, err := parseTemplate(gotURL)
+	if err != nil {
+		return nil, err
+	}
+	wantID, err := parseTemplate(wantURL)
+	if err != nil {
+		return nil, err
+	}
+	act := &setInstanceTemplateAction{
+		id:     id,
+		url:    wantURL,
+		gotID:  gotID,
+		wantID: wantID,
+	}
+	if wantID != nil {
+		// Condition: the new template must exist before it is set.
+		act.Want = exec.EventList{exec.NewExistsEvent(wantID)}
+	}
+	return act, nil
+}
+
+// setInstanceTemplateAction changes the InstanceTemplate of the
+// InstanceGroupManager.
+type setInstanceTemplateAction struct {
+	exec.ActionBase
+
+	id     *cloud.ResourceID
+	url    string
+	gotID  *cloud.ResourceID
+	wantID *cloud.ResourceID
+}
+
+func (act *setInstanceTemplateAction) request() *compute.InstanceGroupManagersSetInstanceTemplateRequest {
+	return &compute.InstanceGroupManagersSetInstanceTemplateRequest{InstanceTemplate: act.url}
+}
+
+func (act *setInstanceTemplateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if err := cl.InstanceGroupManagers().SetInstanceTemplate(ctx, act.id.Key, act.request()); err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	return act.events(), nil
+}
+
+func (act *setInstanceTemplateAction) DryRun() exec.EventList {
+	return act.events()
+}
+
+func (act *setInstanceTemplateAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	body, err := json.Marshal(act.request())
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: "SetInstanceTemplate", ResourceID: act.id, Body: string(body)}}, nil
+}
+
+func (act *setInstanceTemplateAction) events() exec.EventList {
+	if act.gotID == nil {
+		return nil
+	}
+	// Event: the InstanceGroupManager no longer references the old template.
+	return exec.EventList{exec.NewDropRefEvent(act.id, act.gotID)}
+}
+
+func (act *setInstanceTemplateAction) String() string {
+	return fmt.Sprintf("InstanceGroupManagerSetInstanceTemplateAction(%s, %v)", act.id, act.wantID)
+}
+
+func (act *setInstanceTemplateAction) Metadata() *exec.ActionMetadata {
+	// wantID is nil when the template is cleared.
+	var template string
+	if act.wantID != nil {
+		template = act.wantID.NodeID()
+	}
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("InstanceGroupManagerSetInstanceTemplateAction", act.id, template),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("SetInstanceTemplate %v on %s", act.wantID, act.id),
+		ResourceID: act.id,
+	}
+}
+
+// NewCreateInstancesAction returns an Action that creates the named instances
+// in the InstanceGroupManager. The instances are not part of the
+// InstanceGroupManager resource so the Action is not generated by the plan;
+// add it to the Actions from the plan to run it after the
+// InstanceGroupManager exists.
+func NewCreateInstancesAction(id *cloud.ResourceID, names []string) exec.Action {
+	return &instancesAction{
+		ActionBase: exec.ActionBase{Want: exec.EventList{exec.NewExistsEvent(id)}},
+		id:         id,
+		method:     "CreateInstances",
+		instances:  names,
+	}
+}
+
+// NewDeleteInstancesAction returns an Action that deletes the instances (by
+// URL) from the InstanceGroupManager. See NewCreateInstancesAction.
+func NewDeleteInstancesAction(id *cloud.ResourceID, urls []string) exec.Action {
+	return &instancesAction{
+		ActionBase: exec.ActionBase{Want: exec.EventList{exec.NewExistsEvent(id)}},
+		id:         id,
+		method:     "DeleteInstances",
+		instances:  urls,
+	}
+}
+
+// instancesAction creates or deletes specific instances in the
+// InstanceGroupManager.
+type instancesAction struct {
+	exec.ActionBase
+
+	id        *cloud.ResourceID
+	method    string
+	instances []string
+}
+
+// request returns the request body for the method.
+func (act *instancesAction) request() (any, error) {
+	switch act.method {
+	case "CreateInstances":
+		req := &compute.InstanceGroupManagersCreateInstancesRequest{}
+		for _, name := range act.instances {
+			req.Instances = append(req.Instances, &compute.PerInstanceConfig{Name: name})
+		}
+		return req, nil
+	case "DeleteInstances":
+		return &compute.InstanceGroupManagersDeleteInstancesRequest{Instances: act.instances}, nil
+	}
+	return nil, fmt.Errorf("invalid method %q", act.method)
+}
+
+func (act *instancesAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	req, err := act.request()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	switch r := req.(type) {
+	case *compute.InstanceGroupManagersCreateInstancesRequest:
+		err = cl.InstanceGroupManagers().CreateInstances(ctx, act.id.Key, r)
+	case *compute.InstanceGroupManagersDeleteInstancesRequest:
+		err = cl.InstanceGroupManagers().DeleteInstances(ctx, act.id.Key, r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+	return nil, nil
+}
+
+func (act *instancesAction) DryRun() exec.EventList { return nil }
+
+func (act *instancesAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	req, err := act.request()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: act.method, ResourceID: act.id, Body: string(body)}}, nil
+}
+
+func (act *instancesAction) String() string {
+	return fmt.Sprintf("InstanceGroupManager%sAction(%s, %v)", act.method, act.id, act.instances)
+}
+
+func (act *instancesAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("InstanceGroupManager"+act.method+"Action", act.id, act.instances),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("%s %v on %s", act.method, act.instances, act.id),
+		ResourceID: act.id,
+	}
+}
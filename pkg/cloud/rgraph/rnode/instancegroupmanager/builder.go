@@ -0,0 +1,125 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+func init() { rnode.Register("instanceGroupManagers", NewBuilder) }
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r InstanceGroupManager) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource InstanceGroupManager
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(InstanceGroupManager)
+	if !ok {
+		return fmt.Errorf("SetResource: invalid type: %T, want InstanceGroupManager", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType](
+		ctx, gcp, "InstanceGroupManager", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType](&typeTrait{}, b, data)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	if b.resource == nil {
+		return nil, nil
+	}
+
+	var ret []rnode.ResourceRef
+	obj, _ := b.resource.ToGA()
+
+	// InstanceTemplate
+	if obj.InstanceTemplate != "" {
+		id, err := cloud.ParseResourceURL(obj.InstanceTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("InstanceGroupManagerNode InstanceTemplate: %w", err)
+		}
+		ret = append(ret, rnode.ResourceRef{
+			From: b.ID(),
+			Path: api.Path{}.Field("InstanceTemplate"),
+			To:   id,
+		})
+	}
+
+	// TargetPools[]
+	for idx, tp := range obj.TargetPools {
+		id, err := cloud.ParseResourceURL(tp)
+		if err != nil {
+			return nil, fmt.Errorf("InstanceGroupManagerNode TargetPools: %w", err)
+		}
+		ret = append(ret, rnode.ResourceRef{
+			From: b.ID(),
+			Path: api.Path{}.Field("TargetPools").Index(idx),
+			To:   id,
+		})
+	}
+
+	return ret, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("InstanceGroupManager %s resource is nil with state %s", b.ID(), b.State())
+	}
+	// The regionInstanceGroupManagers service is not available in
+	// pkg/cloud.
+	if b.ID().Key.Type() != meta.Zonal {
+		return nil, fmt.Errorf("InstanceGroupManager %s: only zonal InstanceGroupManagers are supported", b.ID())
+	}
+
+	ret := &instanceGroupManagerNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
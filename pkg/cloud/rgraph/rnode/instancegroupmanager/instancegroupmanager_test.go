@@ -0,0 +1,366 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	proj = "proj-1"
+	zone = "us-central1-b"
+)
+
+var (
+	igmID = ID(proj, meta.ZonalKey("igm", zone))
+	it1ID = &cloud.ResourceID{
+		Resource:  "instanceTemplates",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("it1"),
+	}
+	it2ID = &cloud.ResourceID{
+		Resource:  "instanceTemplates",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("it2"),
+	}
+	tpID = &cloud.ResourceID{
+		Resource:  "targetPools",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.RegionalKey("tp", "us-central1"),
+	}
+)
+
+func TestInstanceGroupManagerSchema(t *testing.T) {
+	x := NewMutableInstanceGroupManager(proj, igmID.Key)
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func createNode(t *testing.T, f func(x *compute.InstanceGroupManager)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableInstanceGroupManager(proj, igmID.Key)
+	x := &compute.InstanceGroupManager{
+		Name:             "igm",
+		BaseInstanceName: "inst",
+		InstanceTemplate: it1ID.SelfLink(meta.VersionGA),
+		TargetSize:       3,
+	}
+	if f != nil {
+		f(x)
+	}
+	if err := m.Set(x); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
+
+func TestOutRefs(t *testing.T) {
+	n := createNode(t, func(x *compute.InstanceGroupManager) {
+		x.TargetPools = []string{tpID.SelfLink(meta.VersionGA)}
+	})
+	want := []rnode.ResourceRef{
+		{From: igmID, Path: api.Path{}.Field("InstanceTemplate"), To: it1ID},
+		{From: igmID, Path: api.Path{}.Field("TargetPools").Index(0), To: tpID},
+	}
+	if diff := cmp.Diff(n.OutRefs(), want); diff != "" {
+		t.Errorf("OutRefs(): -got,+want: %s", diff)
+	}
+
+	b := NewBuilder(igmID)
+	m := NewMutableInstanceGroupManager(proj, igmID.Key)
+	m.Access(func(x *compute.InstanceGroupManager) { x.InstanceTemplate = "invalid" })
+	r, _ := m.Freeze()
+	b.SetResource(r)
+	if _, err := b.OutRefs(); err == nil {
+		t.Errorf("OutRefs() = nil, want error for invalid template URL")
+	}
+}
+
+func TestDiffAndActions(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		got           func(x *compute.InstanceGroupManager)
+		want          func(x *compute.InstanceGroupManager)
+		forceRecreate bool
+		allowRecreate bool
+		wantOp        rnode.Operation
+		wantErr       bool
+		wantActions   []string
+	}{
+		{
+			name:   "no diff",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "resize",
+			want:   func(x *compute.InstanceGroupManager) { x.TargetSize = 5 },
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"InstanceGroupManagerResizeAction(compute/instanceGroupManagers:proj-1/us-central1-b/igm, 5)",
+			},
+		},
+		{
+			name:   "template swap",
+			want:   func(x *compute.InstanceGroupManager) { x.InstanceTemplate = it2ID.SelfLink(meta.VersionGA) },
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"InstanceGroupManagerSetInstanceTemplateAction(compute/instanceGroupManagers:proj-1/us-central1-b/igm, compute/instanceTemplates:proj-1/it2)",
+			},
+		},
+		{
+			name: "resize and template swap",
+			want: func(x *compute.InstanceGroupManager) {
+				x.TargetSize = 0
+				x.InstanceTemplate = it2ID.SelfLink(meta.VersionGA)
+				x.ForceSendFields = []string{"TargetSize"}
+			},
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"InstanceGroupManagerResizeAction(compute/instanceGroupManagers:proj-1/us-central1-b/igm, 0)",
+				"InstanceGroupManagerSetInstanceTemplateAction(compute/instanceGroupManagers:proj-1/us-central1-b/igm, compute/instanceTemplates:proj-1/it2)",
+			},
+		},
+		{
+			name: "server populated fields",
+			got: func(x *compute.InstanceGroupManager) {
+				x.ListManagedInstancesResults = "PAGELESS"
+				x.Versions = []*compute.InstanceGroupManagerVersion{{InstanceTemplate: x.InstanceTemplate}}
+				x.UpdatePolicy = &compute.InstanceGroupManagerUpdatePolicy{Type: "OPPORTUNISTIC"}
+				x.InstanceLifecyclePolicy = &compute.InstanceGroupManagerInstanceLifecyclePolicy{ForceUpdateOnRepair: "NO"}
+			},
+			want:   func(x *compute.InstanceGroupManager) { x.BaseInstanceName = "" },
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:    "base instance name change",
+			want:    func(x *compute.InstanceGroupManager) { x.BaseInstanceName = "other" },
+			wantErr: true,
+		},
+		{
+			name:          "base instance name change with ForceRecreate",
+			want:          func(x *compute.InstanceGroupManager) { x.BaseInstanceName = "other" },
+			forceRecreate: true,
+			wantOp:        rnode.OpRecreate,
+		},
+		{
+			name:          "base instance name change with AllowRecreate",
+			want:          func(x *compute.InstanceGroupManager) { x.BaseInstanceName = "other" },
+			allowRecreate: true,
+			wantOp:        rnode.OpRecreate,
+		},
+		{
+			name:          "resize with AllowRecreate",
+			want:          func(x *compute.InstanceGroupManager) { x.TargetSize = 5 },
+			allowRecreate: true,
+			wantOp:        rnode.OpUpdate,
+			wantActions: []string{
+				"InstanceGroupManagerResizeAction(compute/instanceGroupManagers:proj-1/us-central1-b/igm, 5)",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := createNode(t, tc.got)
+			want := createNode(t, tc.want)
+			if tc.forceRecreate || tc.allowRecreate {
+				b := NewBuilderWithResource(want.Resource().(InstanceGroupManager))
+				b.SetOwnership(rnode.OwnershipManaged)
+				b.SetState(rnode.NodeExists)
+				b.SetForceRecreate(tc.forceRecreate)
+				b.SetAllowRecreate(tc.allowRecreate)
+				var err error
+				if want, err = b.Build(); err != nil {
+					t.Fatalf("Build() = %v, want nil", err)
+				}
+			}
+
+			details, err := want.Diff(got)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (details: %+v)", details.Operation, tc.wantOp, details)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			var gotActions []string
+			for _, act := range actions {
+				switch act.(type) {
+				case *resizeAction, *setInstanceTemplateAction:
+					gotActions = append(gotActions, act.String())
+				}
+			}
+			if diff := cmp.Diff(gotActions, tc.wantActions); diff != "" {
+				t.Errorf("Actions(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestActionsRun(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	var (
+		gotSize int64
+		gotReq  *compute.InstanceGroupManagersSetInstanceTemplateRequest
+	)
+	mock.MockInstanceGroupManagers.ResizeHook = func(_ context.Context, _ *meta.Key, size int64, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		gotSize = size
+		return nil
+	}
+	mock.MockInstanceGroupManagers.SetInstanceTemplateHook = func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		gotReq = req
+		return nil
+	}
+
+	if _, err := newResizeAction(igmID, 7).Run(ctx, mock); err != nil {
+		t.Fatalf("resizeAction.Run() = %v, want nil", err)
+	}
+	if gotSize != 7 {
+		t.Errorf("Resize() size = %d, want 7", gotSize)
+	}
+
+	act, err := newSetInstanceTemplateAction(igmID, it1ID.SelfLink(meta.VersionGA), it2ID.SelfLink(meta.VersionGA))
+	if err != nil {
+		t.Fatalf("newSetInstanceTemplateAction() = %v, want nil", err)
+	}
+	if act.CanRun() {
+		t.Errorf("CanRun() = true, want false before the template exists")
+	}
+	act.Signal(exec.NewExistsEvent(it2ID))
+	if !act.CanRun() {
+		t.Fatalf("CanRun() = false, want true")
+	}
+	events, err := act.Run(ctx, mock)
+	if err != nil {
+		t.Fatalf("setInstanceTemplateAction.Run() = %v, want nil", err)
+	}
+	want := &compute.InstanceGroupManagersSetInstanceTemplateRequest{InstanceTemplate: it2ID.SelfLink(meta.VersionGA)}
+	if diff := cmp.Diff(gotReq, want); diff != "" {
+		t.Errorf("SetInstanceTemplate(): -got,+want: %s", diff)
+	}
+	wantEvents := exec.EventList{exec.NewDropRefEvent(igmID, it1ID)}
+	if diff := cmp.Diff(events, wantEvents); diff != "" {
+		t.Errorf("Run() events: -got,+want: %s", diff)
+	}
+}
+
+func TestBuildRegional(t *testing.T) {
+	m := NewMutableInstanceGroupManager(proj, meta.RegionalKey("igm", "us-central1"))
+	m.Access(func(x *compute.InstanceGroupManager) { x.Name = "igm" })
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	if _, err := b.Build(); err == nil {
+		t.Errorf("Build() = nil, want error for a regional InstanceGroupManager")
+	}
+}
+
+func TestInstancesActionRun(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	var (
+		gotCreate *compute.InstanceGroupManagersCreateInstancesRequest
+		gotDelete *compute.InstanceGroupManagersDeleteInstancesRequest
+	)
+	mock.MockInstanceGroupManagers.CreateInstancesHook = func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupManagersCreateInstancesRequest, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		gotCreate = req
+		return nil
+	}
+	mock.MockInstanceGroupManagers.DeleteInstancesHook = func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupManagersDeleteInstancesRequest, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		gotDelete = req
+		return nil
+	}
+
+	createAct := NewCreateInstancesAction(igmID, []string{"inst-a", "inst-b"})
+	if createAct.CanRun() {
+		t.Errorf("CanRun() = true, want false before the InstanceGroupManager exists")
+	}
+	createAct.Signal(exec.NewExistsEvent(igmID))
+	if _, err := createAct.Run(ctx, mock); err != nil {
+		t.Fatalf("CreateInstances Run() = %v, want nil", err)
+	}
+	wantCreate := &compute.InstanceGroupManagersCreateInstancesRequest{
+		Instances: []*compute.PerInstanceConfig{{Name: "inst-a"}, {Name: "inst-b"}},
+	}
+	if diff := cmp.Diff(gotCreate, wantCreate); diff != "" {
+		t.Errorf("CreateInstances(): -got,+want: %s", diff)
+	}
+
+	url := "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-b/instances/inst-a"
+	deleteAct := NewDeleteInstancesAction(igmID, []string{url})
+	deleteAct.Signal(exec.NewExistsEvent(igmID))
+	if _, err := deleteAct.Run(ctx, mock); err != nil {
+		t.Fatalf("DeleteInstances Run() = %v, want nil", err)
+	}
+	wantDelete := &compute.InstanceGroupManagersDeleteInstancesRequest{Instances: []string{url}}
+	if diff := cmp.Diff(gotDelete, wantDelete); diff != "" {
+		t.Errorf("DeleteInstances(): -got,+want: %s", diff)
+	}
+}
+
+func TestSetInstanceTemplateActionEmptyTemplate(t *testing.T) {
+	act, err := newSetInstanceTemplateAction(igmID, it1ID.SelfLink(meta.VersionGA), "")
+	if err != nil {
+		t.Fatalf("newSetInstanceTemplateAction() = %v, want nil", err)
+	}
+	if !act.CanRun() {
+		t.Errorf("CanRun() = false, want true with no target template")
+	}
+	want := rnode.ActionName("InstanceGroupManagerSetInstanceTemplateAction", igmID, "")
+	if got := act.Metadata().Name; got != want {
+		t.Errorf("Metadata().Name = %q, want %q", got, want)
+	}
+}
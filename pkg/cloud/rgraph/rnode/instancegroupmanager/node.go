@@ -0,0 +1,160 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("instanceGroupManager: "+s, args...) }
+
+type instanceGroupManagerNode struct {
+	rnode.NodeBase
+	resource InstanceGroupManager
+}
+
+var _ rnode.Node = (*instanceGroupManagerNode)(nil)
+
+func (n *instanceGroupManagerNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *instanceGroupManagerNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*instanceGroupManagerNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	if !diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	var (
+		unsupported []string
+		details     []string
+	)
+	for _, item := range diff.Items {
+		details = append(details, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+		switch {
+		// TargetSize and InstanceTemplate have dedicated methods (Resize,
+		// SetInstanceTemplate).
+		case item.Path.Equal(api.Path{}.Pointer().Field("TargetSize")),
+			item.Path.Equal(api.Path{}.Pointer().Field("InstanceTemplate")):
+		default:
+			unsupported = append(unsupported, item.Path.String())
+		}
+	}
+
+	// Other fields would need Patch, which is not supported. Recreating a
+	// group deletes all of its instances so this is only done when explicitly
+	// allowed.
+	if len(unsupported) > 0 {
+		if !n.AllowRecreate() && !n.ForceRecreate() {
+			return nil, nodeErr("%s: update of %s is not supported (set AllowRecreate to recreate the group)", n.ID(), strings.Join(unsupported, ", "))
+		}
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "InstanceGroupManager needs to be recreated: " + strings.Join(details, ", "),
+			Diff:      diff,
+		}, nil
+	}
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       "InstanceGroupManager update: " + strings.Join(details, ", "),
+		Diff:      diff,
+	}, nil
+}
+
+func (n *instanceGroupManagerNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *instanceGroupManagerNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
+
+// updateActions returns a Resize and/or SetInstanceTemplate Action for the
+// fields that changed.
+func (n *instanceGroupManagerNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	got, ok := ngot.(*instanceGroupManagerNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+	gotObj, _ := got.resource.ToGA()
+	wantObj, _ := n.resource.ToGA()
+
+	ret := []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+	}
+	if gotObj.TargetSize != wantObj.TargetSize {
+		ret = append(ret, newResizeAction(n.ID(), wantObj.TargetSize))
+	}
+	if gotObj.InstanceTemplate != wantObj.InstanceTemplate {
+		act, err := newSetInstanceTemplateAction(n.ID(), gotObj.InstanceTemplate, wantObj.InstanceTemplate)
+		if err != nil {
+			return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+		}
+		ret = append(ret, act)
+	}
+	return ret, nil
+}
+
+// parseTemplate returns the ResourceID for the template URL or nil if url is
+// empty.
+func parseTemplate(url string) (*cloud.ResourceID, error) {
+	if url == "" {
+		return nil, nil
+	}
+	return cloud.ParseResourceURL(url)
+}
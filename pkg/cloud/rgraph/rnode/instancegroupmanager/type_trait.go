@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroupManagers
+type typeTrait struct {
+	api.BaseTypeTrait[compute.InstanceGroupManager, api.PlaceholderType, api.PlaceholderType]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	// [Output Only]
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("CurrentActions"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("InstanceGroup"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SatisfiesPzi"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SatisfiesPzs"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Status"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Zone"))
+
+	// Filled in by the server when not set: BaseInstanceName is derived from
+	// the Name, Versions from InstanceTemplate and the policies get the
+	// server defaults.
+	dt.ServerPopulated(api.Path{}.Pointer().Field("BaseInstanceName"))
+	dt.ServerPopulated(api.Path{}.Pointer().Field("InstanceLifecyclePolicy"))
+	dt.ServerPopulated(api.Path{}.Pointer().Field("UpdatePolicy"))
+	dt.ServerPopulated(api.Path{}.Pointer().Field("Versions"))
+	dt.ServerDefault(api.Path{}.Pointer().Field("ListManagedInstancesResults"), "PAGELESS")
+
+	return dt
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package instancetemplate
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func TestInstanceTemplateSchema(t *testing.T) {
+	x := NewMutableInstanceTemplate(proj, meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestInstanceTemplateDiff(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.InstanceTemplate) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableInstanceTemplate(proj, meta.GlobalKey("tmpl")), x, NewBuilderWithResource)
+	}
+	props := func(machineType string) *compute.InstanceProperties {
+		return &compute.InstanceProperties{MachineType: machineType}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		got    *compute.InstanceTemplate
+		want   *compute.InstanceTemplate
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "same",
+			got:    &compute.InstanceTemplate{Name: "tmpl", Properties: props("e2-small")},
+			want:   &compute.InstanceTemplate{Name: "tmpl", Properties: props("e2-small")},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "properties changed",
+			got:    &compute.InstanceTemplate{Name: "tmpl", Properties: props("e2-small")},
+			want:   &compute.InstanceTemplate{Name: "tmpl", Properties: props("e2-medium")},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			details, err := makeNode(t, tc.want).Diff(makeNode(t, tc.got))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (%s)", details.Operation, tc.wantOp, details.Why)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package instancetemplate
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type instanceTemplateNode struct {
+	rnode.NodeBase
+	resource InstanceTemplate
+}
+
+var _ rnode.Node = (*instanceTemplateNode)(nil)
+
+func (n *instanceTemplateNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *instanceTemplateNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(InstanceTemplate)
+	if !ok {
+		return nil, fmt.Errorf("InstanceTemplateNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceTemplateNode: Diff %w", err)
+	}
+
+	if diff.HasDiff() {
+		// InstanceTemplates cannot be modified. A template is changed by
+		// creating a new InstanceTemplate and changing the references to it
+		// (see instancegroupmanager SetInstanceTemplate).
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "InstanceTemplate needs to be recreated (no update method exists)",
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpNothing,
+		Why:       "No diff between got and want",
+	}, nil
+}
+
+func (n *instanceTemplateNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("InstanceTemplateNode: invalid plan op %s", op)
+}
+
+func (n *instanceTemplateNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package instancetemplate
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.GetFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.GetFuncsByScope[compute.InstanceTemplate]{
+			Global: gcp.InstanceTemplates().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.CreateFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.CreateFuncsByScope[compute.InstanceTemplate]{
+			Global: gcp.InstanceTemplates().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(cloud.Cloud) *rnode.UpdateFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType] {
+	return nil // InstanceTemplates are immutable.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.DeleteFuncs[compute.InstanceTemplate, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.DeleteFuncsByScope[compute.InstanceTemplate]{
+			Global: gcp.InstanceTemplates().Delete,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// LabelFingerprint returns the .LabelFingerprint of the resource in its
+// Version(). The LabelFingerprint of the current ("got") resource must be
+// passed to SetLabels calls; it is separate from the .Fingerprint used by
+// Update and Patch.
+func LabelFingerprint[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta]) (string, error) {
+	var (
+		obj any
+		err error
+	)
+	switch r.Version() {
+	case meta.VersionGA:
+		obj, err = r.ToGA()
+	case meta.VersionAlpha:
+		obj, err = r.ToAlpha()
+	case meta.VersionBeta:
+		obj, err = r.ToBeta()
+	default:
+		return "", fmt.Errorf("LabelFingerprint: unsupported version %q", r.Version())
+	}
+	if err != nil {
+		return "", fmt.Errorf("LabelFingerprint: %w", err)
+	}
+
+	v := reflect.ValueOf(obj)
+	if !(v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct) {
+		return "", fmt.Errorf("LabelFingerprint: invalid type %T", obj)
+	}
+	fv := v.Elem().FieldByName("LabelFingerprint")
+	if !fv.IsValid() || fv.Kind() != reflect.String {
+		return "", fmt.Errorf("LabelFingerprint: no LabelFingerprint field (%T)", obj)
+	}
+	return fv.String(), nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func TestNetworkSchema(t *testing.T) {
+	key := meta.GlobalKey("key-1")
+	x := NewMutableNetwork(proj, key)
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestID(t *testing.T) {
+	id := ID(proj, meta.GlobalKey("net"))
+	const want = "https://www.googleapis.com/compute/v1/projects/proj-1/global/networks/net"
+	if got := id.SelfLink(meta.VersionGA); got != want {
+		t.Errorf("SelfLink() = %q, want %q", got, want)
+	}
+}
+
+func TestNetworkDiff(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.Network) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableNetwork(proj, meta.GlobalKey("net")), x, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		got     *compute.Network
+		want    *compute.Network
+		wantOp  rnode.Operation
+		wantErr bool
+	}{
+		{
+			name:   "same",
+			got:    &compute.Network{Name: "net", Mtu: 1460},
+			want:   &compute.Network{Name: "net", Mtu: 1460},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "output only fields",
+			got: &compute.Network{
+				Name:        "net",
+				Id:          123,
+				GatewayIPv4: "10.0.0.1",
+				SelfLink:    "zzz",
+				Subnetworks: []string{"zzz"},
+			},
+			want:   &compute.Network{Name: "net"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "description",
+			got:    &compute.Network{Name: "net", Description: "a"},
+			want:   &compute.Network{Name: "net", Description: "b"},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "mtu",
+			got:    &compute.Network{Name: "net", Mtu: 1460},
+			want:   &compute.Network{Name: "net", Mtu: 1500},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:    "auto create subnetworks",
+			got:     &compute.Network{Name: "net", AutoCreateSubnetworks: true},
+			want:    &compute.Network{Name: "net", AutoCreateSubnetworks: false},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, tc.got)
+			want := makeNode(t, tc.want)
+
+			details, err := want.Diff(got)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if details.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s", details.Operation, tc.wantOp)
+			}
+		})
+	}
+}
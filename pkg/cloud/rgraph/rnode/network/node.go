@@ -0,0 +1,103 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type networkNode struct {
+	rnode.NodeBase
+	resource Network
+}
+
+var _ rnode.Node = (*networkNode)(nil)
+
+func (n *networkNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *networkNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(Network)
+	if !ok {
+		return nil, fmt.Errorf("NetworkNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("NetworkNode: Diff %w", err)
+	}
+
+	for _, item := range diff.Items {
+		// The subnet mode of a network cannot be changed. Recreating the
+		// network would delete all of its subnetworks, so this is treated
+		// as an error.
+		if item.Path.Equal(api.Path{}.Pointer().Field("AutoCreateSubnetworks")) {
+			return nil, fmt.Errorf("NetworkNode: AutoCreateSubnetworks cannot be changed (%v -> %v)", item.A, item.B)
+		}
+	}
+
+	if diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "Network needs to be recreated (no update method exists)",
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpNothing,
+		Why:       "No diff between got and want",
+	}, nil
+}
+
+func (n *networkNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.Network, alpha.Network, beta.Network](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.Network, alpha.Network, beta.Network](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.Network, alpha.Network, beta.Network](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return nil, fmt.Errorf("%s is not supported for Network", op)
+	}
+
+	return nil, fmt.Errorf("NetworkNode: invalid plan op %s", op)
+}
+
+func (n *networkNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
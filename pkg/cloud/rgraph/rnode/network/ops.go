@@ -0,0 +1,59 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.Network, alpha.Network, beta.Network] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.GetFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA:    rnode.GetFuncsByScope[compute.Network]{Global: gcp.Networks().Get},
+		Alpha: rnode.GetFuncsByScope[alpha.Network]{Global: gcp.AlphaNetworks().Get},
+		Beta:  rnode.GetFuncsByScope[beta.Network]{Global: gcp.BetaNetworks().Get},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.CreateFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA:    rnode.CreateFuncsByScope[compute.Network]{Global: gcp.Networks().Insert},
+		Alpha: rnode.CreateFuncsByScope[alpha.Network]{Global: gcp.AlphaNetworks().Insert},
+		Beta:  rnode.CreateFuncsByScope[beta.Network]{Global: gcp.BetaNetworks().Insert},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.Network, alpha.Network, beta.Network] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.DeleteFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA:    rnode.DeleteFuncsByScope[compute.Network]{Global: gcp.Networks().Delete},
+		Alpha: rnode.DeleteFuncsByScope[alpha.Network]{Global: gcp.AlphaNetworks().Delete},
+		Beta:  rnode.DeleteFuncsByScope[beta.Network]{Global: gcp.BetaNetworks().Delete},
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("networkEndpointGroups", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
@@ -63,6 +65,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, "NetworkEndpointGroup", &ops{}, &typeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](&typeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	// No references.
 	return nil, nil
@@ -20,6 +20,10 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
 func TestNetworkEndpointGroupSchema(t *testing.T) {
@@ -30,3 +34,145 @@ func TestNetworkEndpointGroupSchema(t *testing.T) {
 		t.Fatalf("CheckSchema() = %v, want nil", err)
 	}
 }
+
+func TestNetworkEndpointGroupValidate(t *testing.T) {
+	const proj = "proj-1"
+	const pscTarget = "projects/proj-2/regions/us-central1/serviceAttachments/sa"
+
+	for _, tc := range []struct {
+		name    string
+		f       func(x *compute.NetworkEndpointGroup)
+		fBeta   func(x *beta.NetworkEndpointGroup)
+		wantErr bool
+	}{
+		{
+			name: "GCE_VM_IP_PORT",
+			f:    func(x *compute.NetworkEndpointGroup) { x.NetworkEndpointType = "GCE_VM_IP_PORT" },
+		},
+		{
+			name: "GCE_VM_IP_PORT with PscTargetService",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "GCE_VM_IP_PORT"
+				x.PscTargetService = pscTarget
+			},
+			wantErr: true,
+		},
+		{
+			name: "PSC",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "PRIVATE_SERVICE_CONNECT"
+				x.PscTargetService = pscTarget
+			},
+		},
+		{
+			name:    "PSC without PscTargetService",
+			f:       func(x *compute.NetworkEndpointGroup) { x.NetworkEndpointType = "PRIVATE_SERVICE_CONNECT" },
+			wantErr: true,
+		},
+		{
+			name: "PSC with CloudRun",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "PRIVATE_SERVICE_CONNECT"
+				x.PscTargetService = pscTarget
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "serverless CloudRun",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+			},
+		},
+		{
+			name: "serverless AppEngine",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.AppEngine = &compute.NetworkEndpointGroupAppEngine{Service: "svc"}
+			},
+		},
+		{
+			name:    "serverless without config",
+			f:       func(x *compute.NetworkEndpointGroup) { x.NetworkEndpointType = "SERVERLESS" },
+			wantErr: true,
+		},
+		{
+			name: "serverless with two configs",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+				x.AppEngine = &compute.NetworkEndpointGroupAppEngine{Service: "svc"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "beta serverless deployment",
+			fBeta: func(x *beta.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.ServerlessDeployment = &beta.NetworkEndpointGroupServerlessDeployment{Platform: "apigateway.googleapis.com"}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := NewMutableNetworkEndpointGroup(proj, meta.ZonalKey("neg", "us-central1-b"))
+			var err error
+			if tc.fBeta != nil {
+				err = x.AccessBeta(tc.fBeta)
+			} else {
+				err = x.Access(tc.f)
+			}
+			if err != nil {
+				t.Fatalf("Access() = %v, want nil", err)
+			}
+			_, err = x.Freeze()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Freeze() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPSCNetworkEndpointGroupCreate(t *testing.T) {
+	const proj = "proj-1"
+	x := NewMutableNetworkEndpointGroup(proj, meta.RegionalKey("neg", "us-central1"))
+	x.Access(func(x *compute.NetworkEndpointGroup) {
+		x.NetworkEndpointType = "PRIVATE_SERVICE_CONNECT"
+		x.PscTargetService = "projects/proj-2/regions/us-central1/serviceAttachments/sa"
+	})
+	r, err := x.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	want, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	got, err := NewBuilder(r.ResourceID()).Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	want.Plan().Set(rnode.PlanDetails{Operation: rnode.OpCreate})
+
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	// There is a single create Action; no endpoints are attached.
+	if len(actions) != 1 {
+		t.Fatalf("len(actions) = %d, want 1 (%v)", len(actions), actions)
+	}
+	if typ := actions[0].Metadata().Type; typ != exec.ActionTypeCreate {
+		t.Errorf("actions[0].Metadata().Type = %q, want %q", typ, exec.ActionTypeCreate)
+	}
+	calls, err := actions[0].(exec.DryRunCaller).DryRunCalls()
+	if err != nil {
+		t.Fatalf("DryRunCalls() = %v, want nil", err)
+	}
+	if len(calls) != 1 || calls[0].Method != "Insert" {
+		t.Errorf("DryRunCalls() = %+v, want a single Insert", calls)
+	}
+}
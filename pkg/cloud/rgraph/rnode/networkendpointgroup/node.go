@@ -19,6 +19,7 @@ package networkendpointgroup
 import (
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -47,10 +48,18 @@ func (n *networkEndpointGroupNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails,
 	}
 
 	if diff.HasDiff() {
+		gotType, wantType := endpointType(got.resource), endpointType(n.resource)
+		if gotType != wantType {
+			return &rnode.PlanDetails{
+				Operation: rnode.OpRecreate,
+				Why:       fmt.Sprintf("NetworkEndpointType changed from %q to %q", gotType, wantType),
+				Diff:      diff,
+			}, nil
+		}
 		// TODO: handle set labels with an update operation.
 		return &rnode.PlanDetails{
 			Operation: rnode.OpRecreate,
-			Why:       "NetworkEndpointGroup needs to be recreated (no update method exists)",
+			Why:       fmt.Sprintf("NetworkEndpointGroup (%s) needs to be recreated (no update method exists)", wantType),
 			Diff:      diff,
 		}, nil
 	}
@@ -62,6 +71,9 @@ func (n *networkEndpointGroupNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails,
 
 }
 
+// Actions for the NEG. The network endpoints of the NEG are not part of the
+// resource and are not attached or detached by these Actions. This matters for
+// PSC and serverless NEGs, which have no endpoint membership.
 func (n *networkEndpointGroupNode) Actions(got rnode.Node) ([]exec.Action, error) {
 
 	op := n.Plan().Op()
@@ -89,8 +101,31 @@ func (n *networkEndpointGroupNode) Actions(got rnode.Node) ([]exec.Action, error
 	return nil, fmt.Errorf("NetworkEndpointGroupNode: invalid plan op %s", op)
 }
 
+// endpointType returns the NetworkEndpointType of the resource in its
+// Version().
+func endpointType(r NetworkEndpointGroup) string {
+	switch r.Version() {
+	case meta.VersionAlpha:
+		if obj, err := r.ToAlpha(); err == nil {
+			return obj.NetworkEndpointType
+		}
+	case meta.VersionBeta:
+		if obj, err := r.ToBeta(); err == nil {
+			return obj.NetworkEndpointType
+		}
+	default:
+		if obj, err := r.ToGA(); err == nil {
+			return obj.NetworkEndpointType
+		}
+	}
+	return ""
+}
+
 func (n *networkEndpointGroupNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
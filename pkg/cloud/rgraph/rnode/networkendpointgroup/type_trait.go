@@ -17,6 +17,8 @@ limitations under the License.
 package networkendpointgroup
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -31,8 +33,6 @@ type typeTrait struct {
 
 func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt := api.NewFieldTraits()
-	// Built-ins
-	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
 	// [Output Only]
 	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
@@ -46,3 +46,78 @@ func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	// TODO: handle alpha/beta
 	return dt
 }
+
+const (
+	endpointTypePSC        = "PRIVATE_SERVICE_CONNECT"
+	endpointTypeServerless = "SERVERLESS"
+)
+
+// Validate the fields that depend on the NetworkEndpointType. PSC NEGs must
+// have a PscTargetService and serverless NEGs must have exactly one of the
+// serverless configs (e.g. CloudRun, AppEngine). The other types must not set
+// these fields.
+func (*typeTrait) Validate(_ meta.Version, obj any) error {
+	var c endpointConfig
+	switch x := obj.(type) {
+	case *compute.NetworkEndpointGroup:
+		c = endpointConfig{
+			endpointType:     x.NetworkEndpointType,
+			pscTargetService: x.PscTargetService,
+			serverless:       countSet(x.CloudRun != nil, x.AppEngine != nil, x.CloudFunction != nil),
+		}
+	case *alpha.NetworkEndpointGroup:
+		c = endpointConfig{
+			endpointType:     x.NetworkEndpointType,
+			pscTargetService: x.PscTargetService,
+			serverless:       countSet(x.CloudRun != nil, x.AppEngine != nil, x.CloudFunction != nil, x.ServerlessDeployment != nil),
+		}
+	case *beta.NetworkEndpointGroup:
+		c = endpointConfig{
+			endpointType:     x.NetworkEndpointType,
+			pscTargetService: x.PscTargetService,
+			serverless:       countSet(x.CloudRun != nil, x.AppEngine != nil, x.CloudFunction != nil, x.ServerlessDeployment != nil),
+		}
+	default:
+		return fmt.Errorf("NetworkEndpointGroup: invalid type %T", obj)
+	}
+	return c.validate()
+}
+
+// endpointConfig are the fields of the resource that depend on the
+// NetworkEndpointType.
+type endpointConfig struct {
+	endpointType     string
+	pscTargetService string
+	// serverless is the number of serverless configs that are set.
+	serverless int
+}
+
+func (c *endpointConfig) validate() error {
+	switch c.endpointType {
+	case endpointTypePSC:
+		if c.pscTargetService == "" {
+			return fmt.Errorf("NetworkEndpointGroup: %s requires PscTargetService", c.endpointType)
+		}
+	case endpointTypeServerless:
+		if c.serverless != 1 {
+			return fmt.Errorf("NetworkEndpointGroup: %s requires exactly one serverless config (got %d)", c.endpointType, c.serverless)
+		}
+	}
+	if c.endpointType != endpointTypePSC && c.pscTargetService != "" {
+		return fmt.Errorf("NetworkEndpointGroup: PscTargetService is only valid for %s (type is %q)", endpointTypePSC, c.endpointType)
+	}
+	if c.endpointType != endpointTypeServerless && c.serverless > 0 {
+		return fmt.Errorf("NetworkEndpointGroup: serverless configs are only valid for %s (type is %q)", endpointTypeServerless, c.endpointType)
+	}
+	return nil
+}
+
+func countSet(fields ...bool) int {
+	var n int
+	for _, f := range fields {
+		if f {
+			n++
+		}
+	}
+	return n
+}
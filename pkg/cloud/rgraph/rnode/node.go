@@ -17,6 +17,9 @@ limitations under the License.
 package rnode
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
@@ -36,6 +39,23 @@ type Node interface {
 	State() NodeState
 	// Ownership of this resource.
 	Ownership() OwnershipStatus
+	// DeletionProtected is true if planning must not delete (or
+	// recreate) this resource.
+	DeletionProtected() bool
+	// ForceRecreate is true if planning must recreate this resource even
+	// if there is no diff (e.g. to recover from bad server state).
+	ForceRecreate() bool
+	// SetForceRecreate of this resource. This must be called before the
+	// graph is planned.
+	SetForceRecreate(f bool)
+	// AllowRecreate is true if planning may recreate this resource when the
+	// diff cannot be applied with an update (e.g. an immutable field
+	// changed). Unlike ForceRecreate, changes that can be applied with an
+	// update are still planned as updates.
+	AllowRecreate() bool
+	// SetAllowRecreate of this resource. This must be called before the
+	// graph is planned.
+	SetAllowRecreate(a bool)
 	// OutRefs of this resource pointing to other resources.
 	OutRefs() []ResourceRef
 	// InRefs pointing to this resource.
@@ -56,6 +76,35 @@ type Node interface {
 	// have not been planned. "got" is the current state of the Node in the
 	// "got" graph.
 	Actions(got Node) ([]exec.Action, error)
+	// LastSynced is the time the last Action for this Node completed
+	// successfully. This is zero if no Action has been run.
+	LastSynced() time.Time
+	// SetLastSynced is called when an Action for this Node completes.
+	SetLastSynced(t time.Time)
+	// Refreshed is the resource read back from the Cloud after the last
+	// Action for this Node was applied (see exec.RefreshAfterApplyOption).
+	// This is nil if the resource has not been refreshed.
+	Refreshed() UntypedResource
+	// SetRefreshed is called with the resource read back from the Cloud.
+	SetRefreshed(r UntypedResource)
+}
+
+// RefResolver is optionally implemented by a Node whose Diff depends on the
+// current state of the resources it references (e.g. a ForwardingRule
+// IPAddress that refers to an Address). The planner calls ResolveRefs with a
+// lookup into the "got" graph before calling Diff. lookup returns nil if the
+// resource is not in the graph.
+type RefResolver interface {
+	ResolveRefs(lookup func(id *cloud.ResourceID) Node)
+}
+
+// OptionsMarshaler is optionally implemented by a Node that has type specific
+// options that are not part of the resource (e.g.
+// backendservice.SetPreferPatch). The options are saved by Graph.ExportJSON
+// and given to the Builder with OptionsUnmarshaler on import. MarshalOptions
+// returns nil if all of the options have their default value.
+type OptionsMarshaler interface {
+	MarshalOptions() ([]byte, error)
 }
 
 // NodeBase are common non-typed fields for implementing a Node in the graph.
@@ -66,6 +115,13 @@ type NodeBase struct {
 	outRefs   []ResourceRef
 	inRefs    []ResourceRef
 	plan      Plan
+
+	deletionProtected bool
+	forceRecreate     bool
+	allowRecreate     bool
+
+	lastSynced time.Time
+	refreshed  UntypedResource
 }
 
 func (n *NodeBase) ID() *cloud.ResourceID      { return n.id }
@@ -74,13 +130,29 @@ func (n *NodeBase) Ownership() OwnershipStatus { return n.ownership }
 func (n *NodeBase) OutRefs() []ResourceRef     { return n.outRefs }
 func (n *NodeBase) InRefs() []ResourceRef      { return n.inRefs }
 func (n *NodeBase) Plan() *Plan                { return &n.plan }
+func (n *NodeBase) LastSynced() time.Time      { return n.lastSynced }
+func (n *NodeBase) SetLastSynced(t time.Time)  { n.lastSynced = t }
+func (n *NodeBase) DeletionProtected() bool    { return n.deletionProtected }
+func (n *NodeBase) ForceRecreate() bool        { return n.forceRecreate }
+func (n *NodeBase) SetForceRecreate(f bool)    { n.forceRecreate = f }
+func (n *NodeBase) AllowRecreate() bool        { return n.allowRecreate }
+func (n *NodeBase) SetAllowRecreate(a bool)    { n.allowRecreate = a }
+
+func (n *NodeBase) Refreshed() UntypedResource     { return n.refreshed }
+func (n *NodeBase) SetRefreshed(r UntypedResource) { n.refreshed = r }
 
 // InitFromBuilder is an rgraph library internal method for common
 // initialization from a Builder.
 func (n *NodeBase) InitFromBuilder(b Builder) error {
+	if r := b.Resource(); r != nil && !r.ResourceID().Equal(b.ID()) {
+		return fmt.Errorf("resource ID %v does not match the builder ID %v", r.ResourceID(), b.ID())
+	}
 	n.id = b.ID()
 	n.state = b.State()
 	n.ownership = b.Ownership()
+	n.deletionProtected = b.DeletionProtected()
+	n.forceRecreate = b.ForceRecreate()
+	n.allowRecreate = b.AllowRecreate()
 	outRefs, err := b.OutRefs()
 	if err != nil {
 		return err
@@ -90,3 +162,11 @@ func (n *NodeBase) InitFromBuilder(b Builder) error {
 
 	return nil
 }
+
+// syncRecorder implements exec.SyncRecorder for Actions that reconcile a
+// Node.
+type syncRecorder struct {
+	node Node
+}
+
+func (r *syncRecorder) RecordSync(t time.Time) { r.node.SetLastSynced(t) }
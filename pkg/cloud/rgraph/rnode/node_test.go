@@ -30,6 +30,7 @@ type fakeBuilder struct{ BuilderBase }
 
 func (*fakeBuilder) Resource() UntypedResource                               { return nil }
 func (*fakeBuilder) SetResource(UntypedResource) error                       { return nil }
+func (*fakeBuilder) UnmarshalResource([]byte) error                          { return nil }
 func (*fakeBuilder) OutRefs() ([]ResourceRef, error)                         { return nil, nil }
 func (*fakeBuilder) SyncFromCloud(ctx context.Context, cl cloud.Cloud) error { return nil }
 
@@ -19,12 +19,17 @@ package rnode
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 )
 
-// Plan for what will be done to the Node.
+// Plan for what will be done to the Node. Plan is safe for concurrent use,
+// allowing Nodes to be planned in parallel.
 type Plan struct {
+	// lock guards details.
+	lock sync.Mutex
 	// details is a history of Actions that were planned,
 	// including previous values Set(). The current plan is at the
 	// end of this list. We keep the previous values for debug
@@ -66,6 +71,57 @@ type PlanDetails struct {
 	Diff *api.DiffResult
 }
 
+// PlanFromDiff returns the PlanDetails for a diff between the got and want
+// resources:
+//
+//   - OpNothing if there is no diff. Differences in Status fields are
+//     informational and are kept in the returned Diff.
+//   - OpRecreate if any item in the diff is at (or under) one of the
+//     immutablePaths or is classified as api.DiffItemRequiresRecreate by the
+//     FieldTraits.
+//   - OpUpdate otherwise.
+func PlanFromDiff(diff *api.DiffResult, immutablePaths []api.Path) *PlanDetails {
+	if diff == nil || !diff.HasDiff() {
+		ret := &PlanDetails{
+			Operation: OpNothing,
+			Why:       "No diff between got and want",
+		}
+		if diff != nil && len(diff.StatusItems) > 0 {
+			ret.Diff = diff
+		}
+		return ret
+	}
+
+	var (
+		details  []string
+		recreate bool
+	)
+	for _, item := range diff.Items {
+		details = append(details, fmt.Sprintf("%s change: '%v' -> '%v'", item.Path, item.A, item.B))
+		if item.Class == api.DiffItemRequiresRecreate {
+			recreate = true
+		}
+		for _, p := range immutablePaths {
+			if item.Path.HasPrefix(p) {
+				recreate = true
+			}
+		}
+	}
+
+	if recreate {
+		return &PlanDetails{
+			Operation: OpRecreate,
+			Why:       "needs to be recreated: " + strings.Join(details, ", "),
+			Diff:      diff,
+		}
+	}
+	return &PlanDetails{
+		Operation: OpUpdate,
+		Why:       "needs to be updated: " + strings.Join(details, ", "),
+		Diff:      diff,
+	}
+}
+
 // Op to perform.
 func (p *Plan) Op() Operation {
 	details := p.Details()
@@ -77,6 +133,8 @@ func (p *Plan) Op() Operation {
 
 // Details returns details on the current plan.
 func (p *Plan) Details() *PlanDetails {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 	if len(p.details) == 0 {
 		return nil
 	}
@@ -86,22 +144,31 @@ func (p *Plan) Details() *PlanDetails {
 
 // Set the plan to the specified action.
 func (p *Plan) Set(a PlanDetails) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 	p.details = append(p.details, a)
 }
 
 func (p *Plan) String() string {
-	if p == nil || len(p.details) == 0 {
+	if p == nil {
+		return "no plan"
+	}
+	details := p.Details()
+	if details == nil {
 		return "no plan"
 	}
-	return fmt.Sprintf("%+v", p.Details())
+	return fmt.Sprintf("%+v", details)
 }
 
 // GraphvizString returns a Graphviz-formatted summary of the plan.
 func (p *Plan) GraphvizString() string {
-	if p == nil || len(p.details) == 0 {
+	if p == nil {
 		return "no plan"
 	}
 	curAction := p.Details()
+	if curAction == nil {
+		return "no plan"
+	}
 	var s string
 	s += fmt.Sprintf("%s: %s", curAction.Operation, curAction.Why)
 	if curAction.Diff != nil {
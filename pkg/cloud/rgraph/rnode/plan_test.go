@@ -19,6 +19,8 @@ package rnode
 import (
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -60,3 +62,69 @@ func TestPlan(t *testing.T) {
 		}
 	}
 }
+
+func TestPlanFromDiff(t *testing.T) {
+	immutable := []api.Path{api.Path{}.Pointer().Field("Network")}
+
+	for _, tc := range []struct {
+		name   string
+		diff   *api.DiffResult
+		wantOp Operation
+	}{
+		{
+			name:   "nil diff",
+			wantOp: OpNothing,
+		},
+		{
+			name:   "no diff",
+			diff:   &api.DiffResult{},
+			wantOp: OpNothing,
+		},
+		{
+			name: "status field",
+			diff: &api.DiffResult{StatusItems: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("Status"), A: "a", B: "b"},
+			}},
+			wantOp: OpNothing,
+		},
+		{
+			name: "mutable field",
+			diff: &api.DiffResult{Items: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("Description"), A: "a", B: "b"},
+			}},
+			wantOp: OpUpdate,
+		},
+		{
+			name: "immutable field",
+			diff: &api.DiffResult{Items: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("Description"), A: "a", B: "b"},
+				{Path: api.Path{}.Pointer().Field("Network"), A: "n1", B: "n2"},
+			}},
+			wantOp: OpRecreate,
+		},
+		{
+			name: "under immutable field",
+			diff: &api.DiffResult{Items: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("Network").Field("X"), A: "n1", B: "n2"},
+			}},
+			wantOp: OpRecreate,
+		},
+		{
+			name: "RequiresRecreate class",
+			diff: &api.DiffResult{Items: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("Name"), Class: api.DiffItemRequiresRecreate},
+			}},
+			wantOp: OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := PlanFromDiff(tc.diff, immutable)
+			if got.Operation != tc.wantOp {
+				t.Errorf("PlanFromDiff().Operation = %s, want %s (%s)", got.Operation, tc.wantOp, got.Why)
+			}
+			if (tc.wantOp != OpNothing || tc.diff != nil && len(tc.diff.StatusItems) > 0) && got.Diff != tc.diff {
+				t.Errorf("PlanFromDiff().Diff = %v, want %v", got.Diff, tc.diff)
+			}
+		})
+	}
+}
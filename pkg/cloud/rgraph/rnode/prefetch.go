@@ -0,0 +1,142 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog/v2"
+)
+
+// Prefetch fetches the resources for the builders from gcp using
+// cloud.BatchGet, issuing one batch per API version. The returned Cloud
+// forwards all calls to gcp; GenericGet will use the prefetched result (once)
+// instead of calling Get for the resources in the batch.
+func Prefetch(ctx context.Context, gcp cloud.Cloud, builders []Builder) (cloud.Cloud, error) {
+	idsByVer := map[meta.Version][]*cloud.ResourceID{}
+	for _, b := range builders {
+		if b.Version() == "" {
+			continue
+		}
+		idsByVer[b.Version()] = append(idsByVer[b.Version()], b.ID())
+	}
+
+	// Issue the batches in a deterministic order.
+	var vers []meta.Version
+	for ver := range idsByVer {
+		vers = append(vers, ver)
+	}
+	sort.Slice(vers, func(i, j int) bool { return vers[i] < vers[j] })
+
+	ret := &prefetchCloud{
+		Cloud:   gcp,
+		results: map[prefetchKey]cloud.BatchGetResult{},
+	}
+	for _, ver := range vers {
+		ids := idsByVer[ver]
+		results, err := cloud.BatchGet(ctx, gcp, ver, ids)
+		if err != nil {
+			return nil, fmt.Errorf("Prefetch: %w", err)
+		}
+		klog.V(2).Infof("Prefetch: BatchGet(%s, %d resources)", ver, len(ids))
+		for i, id := range ids {
+			ret.results[prefetchKey{ver: ver, key: id.MapKey()}] = results[i]
+		}
+	}
+
+	return ret, nil
+}
+
+type prefetchKey struct {
+	ver meta.Version
+	key cloud.ResourceMapKey
+}
+
+// prefetchCloud is a cloud.Cloud that holds the results of a BatchGet.
+type prefetchCloud struct {
+	cloud.Cloud
+
+	lock    sync.Mutex
+	results map[prefetchKey]cloud.BatchGetResult
+}
+
+// take removes and returns the prefetched result for id. Subsequent calls for
+// the same resource will go to the Cloud.
+func (c *prefetchCloud) take(ver meta.Version, id *cloud.ResourceID) (cloud.BatchGetResult, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	k := prefetchKey{ver: ver, key: id.MapKey()}
+	res, ok := c.results[k]
+	delete(c.results, k)
+	return res, ok
+}
+
+// takePrefetched returns the prefetched result if gcp was returned by Prefetch.
+func takePrefetched(gcp cloud.Cloud, ver meta.Version, id *cloud.ResourceID) (cloud.BatchGetResult, bool) {
+	pc, ok := gcp.(*prefetchCloud)
+	if !ok {
+		return cloud.BatchGetResult{}, false
+	}
+	return pc.take(ver, id)
+}
+
+// prefetchedResource converts the prefetched result into a Resource.
+func prefetchedResource[GA any, Alpha any, Beta any](
+	ver meta.Version,
+	id *cloud.ResourceID,
+	tt api.TypeTrait[GA, Alpha, Beta],
+	res cloud.BatchGetResult,
+) (api.Resource[GA, Alpha, Beta], error) {
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	current := api.NewResource(id, tt)
+	var err error
+	switch ver {
+	case meta.VersionGA:
+		raw, ok := res.Obj.(*GA)
+		if !ok {
+			return nil, fmt.Errorf("prefetchedResource: invalid type %T for %v", res.Obj, id)
+		}
+		err = current.Set(raw)
+	case meta.VersionAlpha:
+		raw, ok := res.Obj.(*Alpha)
+		if !ok {
+			return nil, fmt.Errorf("prefetchedResource: invalid type %T for %v", res.Obj, id)
+		}
+		err = current.SetAlpha(raw)
+	case meta.VersionBeta:
+		raw, ok := res.Obj.(*Beta)
+		if !ok {
+			return nil, fmt.Errorf("prefetchedResource: invalid type %T for %v", res.Obj, id)
+		}
+		err = current.SetBeta(raw)
+	default:
+		return nil, fmt.Errorf("prefetchedResource: unsupported version %q", ver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current.Freeze()
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/cerrors"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// refreshNode reads the resource for the node back from the Cloud and stores
+// it with SetRefreshed(). The refreshed resource is used for the server
+// assigned values (e.g. the fingerprint) and does not have the TypeTrait of
+// the node, so it should not be used for a Diff.
+func refreshNode[GA any, Alpha any, Beta any](
+	ctx context.Context,
+	c cloud.Cloud,
+	ops GenericOps[GA, Alpha, Beta],
+	node Node,
+	ver meta.Version,
+) error {
+	r, err := ops.GetFuncs(c).Do(ctx, ver, node.ID(), &api.BaseTypeTrait[GA, Alpha, Beta]{})
+	if err != nil {
+		return fmt.Errorf("refreshNode %s: %w", node.ID(), err)
+	}
+	node.SetRefreshed(r)
+	return nil
+}
+
+// ErrStaleFingerprint is returned by the update Actions if the resource was
+// changed in the Cloud (e.g. by another controller) and the update was
+// rejected even after retrying with the fresh fingerprint.
+var ErrStaleFingerprint = errors.New("resource fingerprint is stale")
+
+// retryOnStaleFingerprint calls do with the current fingerprint of the node
+// (see currentFingerprint). If do fails with 412 Precondition Failed, the
+// fingerprint is stale (e.g. the resource was changed by another controller):
+// the node is refreshed from the Cloud and do is retried once with the fresh
+// fingerprint. ErrStaleFingerprint is returned if the retry is also rejected.
+func retryOnStaleFingerprint[GA any, Alpha any, Beta any](
+	ctx context.Context,
+	c cloud.Cloud,
+	ops GenericOps[GA, Alpha, Beta],
+	node Node,
+	ver meta.Version,
+	planned string,
+	do func(fingerprint string) error,
+) error {
+	err := do(currentFingerprint[GA, Alpha, Beta](node, planned))
+	if !cerrors.IsGoogleAPIPreconditionFailed(err) {
+		return err
+	}
+	if rerr := refreshNode(ctx, c, ops, node, ver); rerr != nil {
+		return fmt.Errorf("%w (refresh after stale fingerprint: %v)", err, rerr)
+	}
+	err = do(currentFingerprint[GA, Alpha, Beta](node, planned))
+	if cerrors.IsGoogleAPIPreconditionFailed(err) {
+		return fmt.Errorf("%v: %w: %w", node.ID(), ErrStaleFingerprint, err)
+	}
+	return err
+}
+
+// currentFingerprint returns the .Fingerprint of the refreshed resource for
+// the node. planned is returned if the node has not been refreshed or the
+// resource does not have a .Fingerprint.
+func currentFingerprint[GA any, Alpha any, Beta any](node Node, planned string) string {
+	r, ok := node.Refreshed().(api.Resource[GA, Alpha, Beta])
+	if !ok {
+		return planned
+	}
+	var (
+		obj any
+		err error
+	)
+	switch r.Version() {
+	case meta.VersionGA:
+		obj, err = r.ToGA()
+	case meta.VersionAlpha:
+		obj, err = r.ToAlpha()
+	case meta.VersionBeta:
+		obj, err = r.ToBeta()
+	default:
+		return planned
+	}
+	if err != nil {
+		return planned
+	}
+	fv, err := fingerprintField(reflect.ValueOf(obj))
+	if err != nil {
+		return planned
+	}
+	return fv.String()
+}
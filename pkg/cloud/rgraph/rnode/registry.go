@@ -0,0 +1,75 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// NewBuilderFunc creates an empty Builder for the resource with the given ID.
+type NewBuilderFunc func(id *cloud.ResourceID) Builder
+
+var registry = struct {
+	lock     sync.RWMutex
+	builders map[string]NewBuilderFunc
+}{
+	builders: map[string]NewBuilderFunc{},
+}
+
+// Register the Builder constructor for the resource type (the
+// cloud.ResourceID.Resource, e.g. "backendServices"). Node packages call this
+// from init(). Registering the same resource twice will panic.
+func Register(resource string, f NewBuilderFunc) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	if _, ok := registry.builders[resource]; ok {
+		panic(fmt.Sprintf("rnode.Register: %q already registered", resource))
+	}
+	registry.builders[resource] = f
+}
+
+// RegisteredTypes returns the sorted list of resource types that have a
+// registered node implementation.
+func RegisteredTypes() []string {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	var ret []string
+	for k := range registry.builders {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// NewBuilderByID returns an empty Builder for the id using the registered
+// constructor for id.Resource.
+func NewBuilderByID(id *cloud.ResourceID) (Builder, error) {
+	registry.lock.RLock()
+	f, ok := registry.builders[id.Resource]
+	registry.lock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("NewBuilderByID: invalid Resource %q", id.Resource)
+	}
+	return f(id), nil
+}
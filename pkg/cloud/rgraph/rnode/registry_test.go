@@ -0,0 +1,64 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+func TestRegistry(t *testing.T) {
+	const resource = "registryTestResources"
+
+	newFakeBuilder := func(id *cloud.ResourceID) Builder {
+		b := &fakeBuilder{}
+		b.Defaults(id)
+		return b
+	}
+	Register(resource, newFakeBuilder)
+
+	var found bool
+	for _, r := range RegisteredTypes() {
+		found = found || r == resource
+	}
+	if !found {
+		t.Errorf("RegisteredTypes() = %v, does not contain %q", RegisteredTypes(), resource)
+	}
+
+	id := &cloud.ResourceID{Resource: resource, ProjectID: "proj", Key: meta.GlobalKey("x")}
+	b, err := NewBuilderByID(id)
+	if err != nil {
+		t.Fatalf("NewBuilderByID(%v) = %v, want nil", id, err)
+	}
+	if !b.ID().Equal(id) {
+		t.Errorf("NewBuilderByID(%v).ID() = %v, want %v", id, b.ID(), id)
+	}
+
+	invalidID := &cloud.ResourceID{Resource: "invalid", ProjectID: "proj", Key: meta.GlobalKey("x")}
+	if _, err := NewBuilderByID(invalidID); err == nil {
+		t.Errorf("NewBuilderByID(%v) = nil, want error", invalidID)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Register(%q) twice did not panic", resource)
+		}
+	}()
+	Register(resource, newFakeBuilder)
+}
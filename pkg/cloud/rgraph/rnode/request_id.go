@@ -0,0 +1,63 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// requestIDs generates the request IDs (see cloud.WithRequestID) for the API
+// calls made by an Action. The ID of a call is a hash of the resource ID, the
+// call and a generation that is unique to the Action. Retries of the Action
+// send the same ID, making the call idempotent. Different Actions (e.g. from
+// a later plan for the same resource) and different calls send different IDs.
+type requestIDs struct {
+	id         *cloud.ResourceID
+	generation string
+}
+
+func newRequestIDs(id *cloud.ResourceID) requestIDs {
+	var b [16]byte
+	// crypto/rand.Read does not fail on supported platforms.
+	rand.Read(b[:])
+	return requestIDs{id: id, generation: hex.EncodeToString(b[:])}
+}
+
+// context returns ctx with the request ID for call. call must identify the
+// call within the Action, e.g. the method name and any arguments that can
+// change between retries.
+func (r requestIDs) context(ctx context.Context, call string) context.Context {
+	if r.generation == "" {
+		return ctx
+	}
+	return cloud.WithRequestID(ctx, hashUUID(fmt.Sprintf("%s/%s/%s", r.id, call, r.generation)))
+}
+
+// hashUUID returns a (version 5 style) UUID from the hash of s. The API
+// requires request IDs to be UUIDs.
+func hashUUID(s string) string {
+	h := sha1.Sum([]byte(s))
+	h[6] = (h[6] & 0x0f) | 0x50 // Version 5.
+	h[8] = (h[8] & 0x3f) | 0x80 // RFC 4122 variant.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/cerrors"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/klog/v2"
+)
+
+// ruleMethod is the SecurityPolicies API method used to change a rule.
+type ruleMethod string
+
+const (
+	methodAddRule    ruleMethod = "AddRule"
+	methodPatchRule  ruleMethod = "PatchRule"
+	methodRemoveRule ruleMethod = "RemoveRule"
+)
+
+func newRuleAction(id *cloud.ResourceID, method ruleMethod, rule *compute.SecurityPolicyRule) *ruleAction {
+	return &ruleAction{
+		id:     id,
+		method: method,
+		rule:   rule,
+	}
+}
+
+// ruleAction adds, patches or removes a single rule of a SecurityPolicy.
+//
+// The Action is idempotent so that a plan can be applied again after a
+// partial run: an AddRule that finds the rule already exists and a
+// RemoveRule that finds no rule are treated as success.
+type ruleAction struct {
+	exec.ActionBase
+
+	id     *cloud.ResourceID
+	method ruleMethod
+	rule   *compute.SecurityPolicyRule
+}
+
+func (act *ruleAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	// PatchRule and RemoveRule select the rule by the priority parameter.
+	ctx = cloud.WithRulePriority(ctx, act.rule.Priority)
+
+	var err error
+	switch act.method {
+	case methodAddRule:
+		err = cl.SecurityPolicies().AddRule(ctx, act.id.Key, act.rule)
+		if cerrors.IsGoogleAPIAlreadyExists(err) {
+			klog.V(2).Infof("%s: rule already exists (%v), treating as success", act, err)
+			err = nil
+		}
+	case methodPatchRule:
+		err = cl.SecurityPolicies().PatchRule(ctx, act.id.Key, act.rule)
+	case methodRemoveRule:
+		err = cl.SecurityPolicies().RemoveRule(ctx, act.id.Key)
+		if cerrors.IsGoogleAPINotFound(err) {
+			klog.V(2).Infof("%s: rule not found (%v), treating as success", act, err)
+			err = nil
+		}
+	default:
+		err = fmt.Errorf("invalid method %q", act.method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+
+	return nil, nil
+}
+
+func (act *ruleAction) DryRun() exec.EventList {
+	return nil
+}
+
+func (act *ruleAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	call := exec.DryRunCall{Method: string(act.method), ResourceID: act.id}
+	if act.method != methodRemoveRule {
+		body, err := json.Marshal(act.rule)
+		if err != nil {
+			return nil, err
+		}
+		call.Body = string(body)
+	}
+	return []exec.DryRunCall{call}, nil
+}
+
+func (act *ruleAction) String() string {
+	return fmt.Sprintf("SecurityPolicy%sAction(%s, %d)", act.method, act.id, act.rule.Priority)
+}
+
+func (act *ruleAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("SecurityPolicy"+string(act.method)+"Action", act.id, act.rule.Priority),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("%s %d on %s", act.method, act.rule.Priority, act.id),
+		ResourceID: act.id,
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// defaultRulePriority is the priority of the default rule. Every
+// SecurityPolicy has a default rule; the API creates one if it is not given
+// and it cannot be removed.
+const defaultRulePriority = 2147483647
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("securityPolicy: "+s, args...) }
+
+type securityPolicyNode struct {
+	rnode.NodeBase
+	resource SecurityPolicy
+}
+
+var _ rnode.Node = (*securityPolicyNode)(nil)
+
+func (n *securityPolicyNode) Resource() rnode.UntypedResource { return n.resource }
+
+// Diff plans an OpUpdate if only the Rules differ. The rules in got are the
+// rules currently in the cloud, so the Update applies only the rules that
+// are missing, changed or removed (see ruleDelta). Changes to other fields
+// recreate the policy.
+func (n *securityPolicyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*securityPolicyNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	if !diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	var details []string
+	for _, item := range diff.Items {
+		if !item.Path.HasPrefix(api.Path{}.Pointer().Field("Rules")) {
+			details = append(details, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+		}
+	}
+	if len(details) > 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "SecurityPolicy needs to be recreated: " + strings.Join(details, ", "),
+			Diff:      diff,
+		}, nil
+	}
+
+	delta, err := n.ruleDelta(got)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+	if delta.empty() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "Rules differ only in order",
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       "SecurityPolicy rules update: " + delta.String(),
+		Diff:      diff,
+	}, nil
+}
+
+func (n *securityPolicyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *securityPolicyNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
+
+// updateActions returns an Action for each rule that is added, patched or
+// removed.
+func (n *securityPolicyNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	got, ok := ngot.(*securityPolicyNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+	delta, err := n.ruleDelta(got)
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	ret := []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+	}
+	for _, r := range delta.add {
+		ret = append(ret, newRuleAction(n.ID(), methodAddRule, r))
+	}
+	for _, r := range delta.patch {
+		ret = append(ret, newRuleAction(n.ID(), methodPatchRule, r))
+	}
+	for _, r := range delta.remove {
+		ret = append(ret, newRuleAction(n.ID(), methodRemoveRule, r))
+	}
+	return ret, nil
+}
+
+// ruleDelta are the changes to the rules of a SecurityPolicy. Rules are
+// identified by their priority.
+type ruleDelta struct {
+	add, patch, remove []*compute.SecurityPolicyRule
+}
+
+func (d *ruleDelta) empty() bool {
+	return len(d.add) == 0 && len(d.patch) == 0 && len(d.remove) == 0
+}
+
+func (d *ruleDelta) String() string {
+	var parts []string
+	for _, s := range []struct {
+		name  string
+		rules []*compute.SecurityPolicyRule
+	}{
+		{"add", d.add},
+		{"patch", d.patch},
+		{"remove", d.remove},
+	} {
+		for _, r := range s.rules {
+			parts = append(parts, fmt.Sprintf("%s rule %d", s.name, r.Priority))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ruleDelta computes the rules to add, patch and remove to change the rules
+// of got to the rules of n. The default rule is not removed if n does not
+// list it.
+func (n *securityPolicyNode) ruleDelta(got *securityPolicyNode) (*ruleDelta, error) {
+	gotObj, err := got.resource.ToGA()
+	if err != nil {
+		return nil, err
+	}
+	wantObj, err := n.resource.ToGA()
+	if err != nil {
+		return nil, err
+	}
+	gotRules, err := rulesByPriority(gotObj.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("got: %w", err)
+	}
+	wantRules, err := rulesByPriority(wantObj.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("want: %w", err)
+	}
+
+	ret := &ruleDelta{}
+	for _, p := range sortedPriorities(wantRules) {
+		gotRule, ok := gotRules[p]
+		if !ok {
+			ret.add = append(ret.add, wantRules[p])
+			continue
+		}
+		eq, err := rulesEqual(gotRule, wantRules[p])
+		if err != nil {
+			return nil, err
+		}
+		if !eq {
+			ret.patch = append(ret.patch, wantRules[p])
+		}
+	}
+	for _, p := range sortedPriorities(gotRules) {
+		if _, ok := wantRules[p]; !ok && p != defaultRulePriority {
+			ret.remove = append(ret.remove, gotRules[p])
+		}
+	}
+	return ret, nil
+}
+
+func rulesByPriority(rules []*compute.SecurityPolicyRule) (map[int64]*compute.SecurityPolicyRule, error) {
+	ret := map[int64]*compute.SecurityPolicyRule{}
+	for _, r := range rules {
+		if _, ok := ret[r.Priority]; ok {
+			return nil, fmt.Errorf("duplicate rule priority %d", r.Priority)
+		}
+		ret[r.Priority] = r
+	}
+	return ret, nil
+}
+
+func sortedPriorities(rules map[int64]*compute.SecurityPolicyRule) []int64 {
+	var ret []int64
+	for p := range rules {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// rulesEqual compares the rules, ignoring the [Output Only] Kind.
+func rulesEqual(a, b *compute.SecurityPolicyRule) (bool, error) {
+	ca, cb := *a, *b
+	ca.Kind, cb.Kind = "", ""
+	ja, err := json.Marshal(&ca)
+	if err != nil {
+		return false, err
+	}
+	jb, err := json.Marshal(&cb)
+	if err != nil {
+		return false, err
+	}
+	return string(ja) == string(jb), nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+// ops implements GenericPatchOps. Note that the node itself updates the
+// Rules with the AddRule/PatchRule/RemoveRule methods.
+var _ rnode.GenericPatchOps[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.GetFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.GetFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.CreateFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.CreateFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(cloud.Cloud) *rnode.UpdateFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return nil // Rules are updated with the AddRule/PatchRule/RemoveRule methods.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.DeleteFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.DeleteFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Delete,
+		},
+	}
+}
+
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.PatchFuncs[compute.SecurityPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.PatchFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Patch,
+		},
+	}
+}
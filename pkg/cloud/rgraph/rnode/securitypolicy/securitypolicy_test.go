@@ -0,0 +1,258 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+const proj = "proj-1"
+
+var spID = ID(proj, meta.GlobalKey("sp"))
+
+func rule(priority int64, action string) *compute.SecurityPolicyRule {
+	return &compute.SecurityPolicyRule{
+		Action:   action,
+		Priority: priority,
+		Match: &compute.SecurityPolicyRuleMatcher{
+			VersionedExpr: "SRC_IPS_V1",
+			Config:        &compute.SecurityPolicyRuleMatcherConfig{SrcIpRanges: []string{"*"}},
+		},
+	}
+}
+
+func createSecurityPolicyNode(t *testing.T, f func(x *compute.SecurityPolicy)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableSecurityPolicy(proj, spID.Key)
+	err := m.Access(func(x *compute.SecurityPolicy) {
+		x.Name = "sp"
+		x.Type = "CLOUD_ARMOR"
+		x.Rules = []*compute.SecurityPolicyRule{rule(defaultRulePriority, "allow")}
+		if f != nil {
+			f(x)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
+
+func TestSecurityPolicySchema(t *testing.T) {
+	x := NewMutableSecurityPolicy(proj, spID.Key)
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestDiffAndActions(t *testing.T) {
+	withRules := func(rules ...*compute.SecurityPolicyRule) func(x *compute.SecurityPolicy) {
+		return func(x *compute.SecurityPolicy) { x.Rules = rules }
+	}
+	defaultRule := rule(defaultRulePriority, "allow")
+
+	for _, tc := range []struct {
+		name        string
+		got         func(x *compute.SecurityPolicy)
+		want        func(x *compute.SecurityPolicy)
+		wantOp      rnode.Operation
+		wantErr     bool
+		wantActions []string
+	}{
+		{
+			name:   "no diff",
+			got:    withRules(rule(10, "deny(403)"), defaultRule),
+			want:   withRules(rule(10, "deny(403)"), defaultRule),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "rules in a different order",
+			got:    withRules(rule(10, "deny(403)"), rule(20, "allow"), defaultRule),
+			want:   withRules(defaultRule, rule(20, "allow"), rule(10, "deny(403)")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "default rule is not removed",
+			got:    withRules(rule(10, "deny(403)"), defaultRule),
+			want:   withRules(rule(10, "deny(403)")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			// Only the rule that is missing is added; the rule at 10 was
+			// already added (e.g. by a previous partial run).
+			name:   "add rule",
+			got:    withRules(rule(10, "deny(403)"), defaultRule),
+			want:   withRules(rule(10, "deny(403)"), rule(20, "allow"), defaultRule),
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"SecurityPolicyAddRuleAction(compute/securityPolicies:proj-1/sp, 20)",
+			},
+		},
+		{
+			name:   "patch, remove and add rules",
+			got:    withRules(rule(10, "deny(403)"), rule(20, "allow"), defaultRule),
+			want:   withRules(rule(10, "deny(404)"), rule(30, "allow"), rule(defaultRulePriority, "deny(403)")),
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"SecurityPolicyAddRuleAction(compute/securityPolicies:proj-1/sp, 30)",
+				"SecurityPolicyPatchRuleAction(compute/securityPolicies:proj-1/sp, 10)",
+				"SecurityPolicyPatchRuleAction(compute/securityPolicies:proj-1/sp, 2147483647)",
+				"SecurityPolicyRemoveRuleAction(compute/securityPolicies:proj-1/sp, 20)",
+			},
+		},
+		{
+			name:    "duplicate priority",
+			got:     withRules(defaultRule),
+			want:    withRules(rule(10, "allow"), rule(10, "deny(403)"), defaultRule),
+			wantErr: true,
+		},
+		{
+			name:   "description change",
+			want:   func(x *compute.SecurityPolicy) { x.Description = "new" },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := createSecurityPolicyNode(t, tc.got)
+			want := createSecurityPolicyNode(t, tc.want)
+
+			details, err := want.Diff(got)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (details: %+v)", details.Operation, tc.wantOp, details)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			var gotActions []string
+			for _, act := range actions {
+				if _, ok := act.(*ruleAction); ok {
+					gotActions = append(gotActions, act.String())
+				}
+			}
+			if diff := cmp.Diff(gotActions, tc.wantActions); diff != "" {
+				t.Errorf("Actions(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleActionRun(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		method  ruleMethod
+		apiErr  error
+		wantErr bool
+	}{
+		{
+			name:   "add",
+			method: methodAddRule,
+		},
+		{
+			name:   "add rule that already exists",
+			method: methodAddRule,
+			apiErr: &googleapi.Error{Code: http.StatusConflict},
+		},
+		{
+			name:    "add error",
+			method:  methodAddRule,
+			apiErr:  &googleapi.Error{Code: http.StatusBadRequest},
+			wantErr: true,
+		},
+		{
+			name:   "patch",
+			method: methodPatchRule,
+		},
+		{
+			name:    "patch rule that does not exist",
+			method:  methodPatchRule,
+			apiErr:  &googleapi.Error{Code: http.StatusNotFound},
+			wantErr: true,
+		},
+		{
+			name:   "remove",
+			method: methodRemoveRule,
+		},
+		{
+			name:   "remove rule that does not exist",
+			method: methodRemoveRule,
+			apiErr: &googleapi.Error{Code: http.StatusNotFound},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			var calls []string
+			record := func(ctx context.Context, method string) error {
+				p, _ := cloud.RulePriority(ctx)
+				calls = append(calls, fmt.Sprintf("%s/%d", method, p))
+				return tc.apiErr
+			}
+			mock.MockSecurityPolicies.AddRuleHook = func(ctx context.Context, _ *meta.Key, r *compute.SecurityPolicyRule, _ *cloud.MockSecurityPolicies, _ ...cloud.Option) error {
+				return record(ctx, "AddRule")
+			}
+			mock.MockSecurityPolicies.PatchRuleHook = func(ctx context.Context, _ *meta.Key, r *compute.SecurityPolicyRule, _ *cloud.MockSecurityPolicies, _ ...cloud.Option) error {
+				return record(ctx, "PatchRule")
+			}
+			mock.MockSecurityPolicies.RemoveRuleHook = func(ctx context.Context, _ *meta.Key, _ *cloud.MockSecurityPolicies, _ ...cloud.Option) error {
+				return record(ctx, "RemoveRule")
+			}
+
+			act := newRuleAction(spID, tc.method, rule(10, "allow"))
+			_, err := act.Run(context.Background(), mock)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			// The rule is selected by priority.
+			if diff := cmp.Diff(calls, []string{string(tc.method) + "/10"}); diff != "" {
+				t.Errorf("calls: -got,+want: %s", diff)
+			}
+		})
+	}
+}
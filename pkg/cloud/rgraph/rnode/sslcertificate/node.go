@@ -0,0 +1,120 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcertificate
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type sslCertificateNode struct {
+	rnode.NodeBase
+	resource SslCertificate
+}
+
+var _ rnode.Node = (*sslCertificateNode)(nil)
+
+// privateKeyPaths are input only; the private key is never returned by the
+// API so it cannot be compared with the value in the Cloud.
+var privateKeyPaths = []api.Path{
+	api.Path{}.Pointer().Field("PrivateKey"),
+	api.Path{}.Pointer().Field("SelfManaged").Pointer().Field("PrivateKey"),
+}
+
+func (n *sslCertificateNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *sslCertificateNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(SslCertificate)
+	if !ok {
+		return nil, fmt.Errorf("SslCertificateNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SslCertificateNode: Diff %w", err)
+	}
+	diff = filterDiff(diff)
+
+	if diff.HasDiff() {
+		// SslCertificates cannot be modified. A certificate is rotated by
+		// creating a new SslCertificate and changing the references to it
+		// (see targethttpsproxy.ReplaceSslCertificate).
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "SslCertificate needs to be recreated (no update method exists)",
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpNothing,
+		Why:       "No diff between got and want",
+	}, nil
+}
+
+// filterDiff removes the privateKeyPaths from diff.
+func filterDiff(diff *api.DiffResult) *api.DiffResult {
+	ret := &api.DiffResult{StatusItems: diff.StatusItems}
+	for _, item := range diff.Items {
+		var skip bool
+		for _, p := range privateKeyPaths {
+			if p.Equal(item.Path) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			ret.Items = append(ret.Items, item)
+		}
+	}
+	return ret
+}
+
+func (n *sslCertificateNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("SslCertificateNode: invalid plan op %s", op)
+}
+
+func (n *sslCertificateNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcertificate
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func TestSslCertificateSchema(t *testing.T) {
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("key-1"),
+		meta.RegionalKey("key-1", "us-central1"),
+	} {
+		x := NewMutableSslCertificate(proj, key)
+		if err := x.CheckSchema(); err != nil {
+			t.Fatalf("CheckSchema() = %v, want nil", err)
+		}
+	}
+}
+
+func TestSslCertificateDiff(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.SslCertificate) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableSslCertificate(proj, meta.GlobalKey("cert")), x, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		got    *compute.SslCertificate
+		want   *compute.SslCertificate
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "same",
+			got:    &compute.SslCertificate{Name: "cert", Certificate: "abc"},
+			want:   &compute.SslCertificate{Name: "cert", Certificate: "abc"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "output only fields",
+			got: &compute.SslCertificate{
+				Name:                    "cert",
+				Certificate:             "abc",
+				ExpireTime:              "2030-01-01T00:00:00Z",
+				SubjectAlternativeNames: []string{"example.com"},
+			},
+			want:   &compute.SslCertificate{Name: "cert", Certificate: "abc"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			// The private key is not returned by the API.
+			name:   "private key",
+			got:    &compute.SslCertificate{Name: "cert", Certificate: "abc"},
+			want:   &compute.SslCertificate{Name: "cert", Certificate: "abc", PrivateKey: "key"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "certificate changed",
+			got:    &compute.SslCertificate{Name: "cert", Certificate: "abc"},
+			want:   &compute.SslCertificate{Name: "cert", Certificate: "def"},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			details, err := makeNode(t, tc.want).Diff(makeNode(t, tc.got))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (%s)", details.Operation, tc.wantOp, details.Why)
+			}
+		})
+	}
+}
+
+func TestFilterDiffKeepsStatusItems(t *testing.T) {
+	status := []api.DiffItem{{Path: api.Path{}.Pointer().Field("ExpireTime"), A: "a", B: "b"}}
+	diff := &api.DiffResult{
+		Items:       []api.DiffItem{{Path: privateKeyPaths[0], A: "a", B: "b"}},
+		StatusItems: status,
+	}
+	got := filterDiff(diff)
+	if got.HasDiff() {
+		t.Errorf("filterDiff().Items = %v, want none", got.Items)
+	}
+	if len(got.StatusItems) != 1 || !got.StatusItems[0].Path.Equal(status[0].Path) {
+		t.Errorf("filterDiff().StatusItems = %v, want %v", got.StatusItems, status)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslpolicy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type sslPolicyNode struct {
+	rnode.NodeBase
+	resource SslPolicy
+}
+
+var _ rnode.Node = (*sslPolicyNode)(nil)
+
+func (n *sslPolicyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *sslPolicyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	gotRes, ok := gotNode.Resource().(SslPolicy)
+	if !ok {
+		return nil, fmt.Errorf("SslPolicyNode: invalid type to Diff: %T", gotNode.Resource())
+	}
+
+	diff, err := gotRes.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SslPolicyNode: Diff %w", err)
+	}
+
+	// All of the fields (MinTlsVersion, Profile, CustomFeatures) can be
+	// changed with Patch.
+	if diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpUpdate,
+			Why:       "SslPolicy needs to be updated",
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpNothing,
+		Why:       "No diff between got and want",
+	}, nil
+}
+
+func (n *sslPolicyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		gotRes, ok := got.Resource().(SslPolicy)
+		if !ok {
+			return nil, fmt.Errorf("SslPolicyNode: invalid type for got: %T", got.Resource())
+		}
+		obj, err := gotRes.ToGA()
+		if err != nil {
+			return nil, fmt.Errorf("SslPolicyNode: cannot get fingerprint: %w", err)
+		}
+		return rnode.PatchActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource, obj.Fingerprint)
+	}
+
+	return nil, fmt.Errorf("SslPolicyNode: invalid plan op %s", op)
+}
+
+func (n *sslPolicyNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
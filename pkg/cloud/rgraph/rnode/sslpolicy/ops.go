@@ -0,0 +1,69 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslpolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+// ops implements GenericPatchOps.
+var _ rnode.GenericPatchOps[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.GetFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.GetFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Get,
+			Regional: gcp.RegionSslPolicies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.CreateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.CreateFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Insert,
+			Regional: gcp.RegionSslPolicies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(cloud.Cloud) *rnode.UpdateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return nil // Does not support generic Update, use Patch.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.DeleteFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.DeleteFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Delete,
+			Regional: gcp.RegionSslPolicies().Delete,
+		},
+	}
+}
+
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.PatchFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.PatchFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Patch,
+			Regional: gcp.RegionSslPolicies().Patch,
+		},
+	}
+}
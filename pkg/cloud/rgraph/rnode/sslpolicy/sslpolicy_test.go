@@ -0,0 +1,143 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func TestSslPolicySchema(t *testing.T) {
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("key-1"),
+		meta.RegionalKey("key-1", "us-central1"),
+	} {
+		x := NewMutableSslPolicy(proj, key)
+		if err := x.CheckSchema(); err != nil {
+			t.Fatalf("CheckSchema() = %v, want nil", err)
+		}
+	}
+}
+
+func TestID(t *testing.T) {
+	for _, tc := range []struct {
+		key  *meta.Key
+		want string
+	}{
+		{
+			key:  meta.GlobalKey("sp"),
+			want: "https://www.googleapis.com/compute/v1/projects/proj-1/global/sslPolicies/sp",
+		},
+		{
+			key:  meta.RegionalKey("sp", "us-central1"),
+			want: "https://www.googleapis.com/compute/v1/projects/proj-1/regions/us-central1/sslPolicies/sp",
+		},
+	} {
+		if got := ID(proj, tc.key).SelfLink(meta.VersionGA); got != tc.want {
+			t.Errorf("ID(%v).SelfLink() = %q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestSslPolicyDiff(t *testing.T) {
+	makeNode := func(t *testing.T, x *compute.SslPolicy) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableSslPolicy(proj, meta.GlobalKey("sp")), x, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		got         *compute.SslPolicy
+		want        *compute.SslPolicy
+		wantOp      rnode.Operation
+		wantActions []string
+	}{
+		{
+			name:   "same",
+			got:    &compute.SslPolicy{Name: "sp", MinTlsVersion: "TLS_1_0"},
+			want:   &compute.SslPolicy{Name: "sp", MinTlsVersion: "TLS_1_0"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "output only fields",
+			got: &compute.SslPolicy{
+				Name:            "sp",
+				Id:              123,
+				EnabledFeatures: []string{"TLS_RSA_WITH_AES_128_GCM_SHA256"},
+				Fingerprint:     "abc",
+				SelfLink:        "zzz",
+			},
+			want:   &compute.SslPolicy{Name: "sp"},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:        "min tls version",
+			got:         &compute.SslPolicy{Name: "sp", MinTlsVersion: "TLS_1_0", Fingerprint: "abc"},
+			want:        &compute.SslPolicy{Name: "sp", MinTlsVersion: "TLS_1_2"},
+			wantOp:      rnode.OpUpdate,
+			wantActions: []string{"GenericPatchAction"},
+		},
+		{
+			name: "custom features",
+			got:  &compute.SslPolicy{Name: "sp", Profile: "MODERN"},
+			want: &compute.SslPolicy{
+				Name:           "sp",
+				Profile:        "CUSTOM",
+				CustomFeatures: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			},
+			wantOp:      rnode.OpUpdate,
+			wantActions: []string{"GenericPatchAction"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, tc.got)
+			want := makeNode(t, tc.want)
+
+			details, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s", details.Operation, tc.wantOp)
+			}
+			if tc.wantActions == nil {
+				return
+			}
+
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != len(tc.wantActions) {
+				t.Fatalf("len(Actions()) = %d, want %d (%v)", len(actions), len(tc.wantActions), actions)
+			}
+			for i, a := range actions {
+				if wantName := fmt.Sprintf("%s(%s)", tc.wantActions[i], want.ID().NodeID()); a.Metadata().Name != wantName {
+					t.Errorf("actions[%d] = %q, want %q", i, a.Metadata().Name, wantName)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetgrpcproxy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+// ops implements GenericPatchOps.
+var _ rnode.GenericPatchOps[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] {
+	return &rnode.GetFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]{
+		GA:    rnode.GetFuncsByScope[compute.TargetGrpcProxy]{Global: gcp.TargetGrpcProxies().Get},
+		Alpha: rnode.GetFuncsByScope[alpha.TargetGrpcProxy]{Global: gcp.AlphaTargetGrpcProxies().Get},
+		Beta:  rnode.GetFuncsByScope[beta.TargetGrpcProxy]{Global: gcp.BetaTargetGrpcProxies().Get},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] {
+	return &rnode.CreateFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]{
+		GA:    rnode.CreateFuncsByScope[compute.TargetGrpcProxy]{Global: gcp.TargetGrpcProxies().Insert},
+		Alpha: rnode.CreateFuncsByScope[alpha.TargetGrpcProxy]{Global: gcp.AlphaTargetGrpcProxies().Insert},
+		Beta:  rnode.CreateFuncsByScope[beta.TargetGrpcProxy]{Global: gcp.BetaTargetGrpcProxies().Insert},
+	}
+}
+
+func (*ops) UpdateFuncs(cloud.Cloud) *rnode.UpdateFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] {
+	return nil // Does not support generic Update, use Patch.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] {
+	return &rnode.DeleteFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]{
+		GA:    rnode.DeleteFuncsByScope[compute.TargetGrpcProxy]{Global: gcp.TargetGrpcProxies().Delete},
+		Alpha: rnode.DeleteFuncsByScope[alpha.TargetGrpcProxy]{Global: gcp.AlphaTargetGrpcProxies().Delete},
+		Beta:  rnode.DeleteFuncsByScope[beta.TargetGrpcProxy]{Global: gcp.BetaTargetGrpcProxies().Delete},
+	}
+}
+
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy] {
+	return &rnode.PatchFuncs[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]{
+		GA:    rnode.PatchFuncsByScope[compute.TargetGrpcProxy]{Global: gcp.TargetGrpcProxies().Patch},
+		Alpha: rnode.PatchFuncsByScope[alpha.TargetGrpcProxy]{Global: gcp.AlphaTargetGrpcProxies().Patch},
+		Beta:  rnode.PatchFuncsByScope[beta.TargetGrpcProxy]{Global: gcp.BetaTargetGrpcProxies().Patch},
+	}
+}
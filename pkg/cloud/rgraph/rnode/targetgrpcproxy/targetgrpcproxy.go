@@ -0,0 +1,50 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetgrpcproxy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID for the TargetGrpcProxy. TargetGrpcProxies are only Global.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "targetGrpcProxies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTargetGrpcProxy = api.MutableResource[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]
+
+func NewMutableTargetGrpcProxy(project string, key *meta.Key) MutableTargetGrpcProxy {
+	id := ID(project, key)
+	return api.NewResource[
+		compute.TargetGrpcProxy,
+		alpha.TargetGrpcProxy,
+		beta.TargetGrpcProxy,
+	](id, &typeTrait{})
+}
+
+type TargetGrpcProxy = api.Resource[compute.TargetGrpcProxy, alpha.TargetGrpcProxy, beta.TargetGrpcProxy]
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetgrpcproxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func urlMapLink(name string) string {
+	id := &cloud.ResourceID{
+		Resource:  "urlMaps",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey(name),
+	}
+	return id.SelfLink(meta.VersionGA)
+}
+
+func makeNode(t *testing.T, x *compute.TargetGrpcProxy) rnode.Node {
+	t.Helper()
+	m := NewMutableTargetGrpcProxy(proj, meta.GlobalKey("tgp"))
+	if err := m.Set(x); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
+
+func TestTargetGrpcProxySchema(t *testing.T) {
+	x := NewMutableTargetGrpcProxy(proj, meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestTargetGrpcProxyOutRefs(t *testing.T) {
+	m := NewMutableTargetGrpcProxy(proj, meta.GlobalKey("tgp"))
+	m.Access(func(x *compute.TargetGrpcProxy) { x.UrlMap = urlMapLink("um") })
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	got, err := NewBuilderWithResource(r).OutRefs()
+	if err != nil {
+		t.Fatalf("OutRefs() = %v, want nil", err)
+	}
+	umID, err := cloud.ParseResourceURL(urlMapLink("um"))
+	if err != nil {
+		t.Fatalf("ParseResourceURL() = %v, want nil", err)
+	}
+	want := []rnode.ResourceRef{{From: r.ResourceID(), Path: api.Path{}.Field("UrlMap"), To: umID}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("OutRefs(): -got,+want: %s", diff)
+	}
+}
+
+func TestTargetGrpcProxyDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		got         *compute.TargetGrpcProxy
+		want        *compute.TargetGrpcProxy
+		wantOp      rnode.Operation
+		wantActions []string
+	}{
+		{
+			name:   "same",
+			got:    &compute.TargetGrpcProxy{Name: "tgp", UrlMap: urlMapLink("um1")},
+			want:   &compute.TargetGrpcProxy{Name: "tgp", UrlMap: urlMapLink("um1")},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "output only fields",
+			got: &compute.TargetGrpcProxy{
+				Name:        "tgp",
+				UrlMap:      urlMapLink("um1"),
+				Id:          123,
+				Fingerprint: "abc",
+				SelfLink:    "zzz",
+			},
+			want:   &compute.TargetGrpcProxy{Name: "tgp", UrlMap: urlMapLink("um1")},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:        "url map",
+			got:         &compute.TargetGrpcProxy{Name: "tgp", UrlMap: urlMapLink("um1"), Fingerprint: "abc"},
+			want:        &compute.TargetGrpcProxy{Name: "tgp", UrlMap: urlMapLink("um2")},
+			wantOp:      rnode.OpUpdate,
+			wantActions: []string{"GenericPatchAction"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, tc.got)
+			want := makeNode(t, tc.want)
+
+			details, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s", details.Operation, tc.wantOp)
+			}
+			if tc.wantActions == nil {
+				return
+			}
+
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != len(tc.wantActions) {
+				t.Fatalf("len(Actions()) = %d, want %d (%v)", len(actions), len(tc.wantActions), actions)
+			}
+			for i, a := range actions {
+				if wantName := fmt.Sprintf("%s(%s)", tc.wantActions[i], want.ID().NodeID()); a.Metadata().Name != wantName {
+					t.Errorf("actions[%d] = %q, want %q", i, a.Metadata().Name, wantName)
+				}
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() { rnode.Register("targetHttpProxies", NewBuilder) }
+
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
@@ -64,6 +66,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, "TargetHttpProxy", &targetHttpProxyOps{}, &targetHttpProxyTypeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[compute.TargetHttpProxy, alpha.TargetHttpProxy, beta.TargetHttpProxy](&targetHttpProxyTypeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.resource == nil {
 		return nil, nil
@@ -87,5 +87,8 @@ func (n *targetHttpProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *targetHttpProxyNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// setSslCertificatesAction calls SetSslCertificates on the proxy.
+type setSslCertificatesAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// certs to set on the proxy.
+	certs []*cloud.ResourceID
+	// dropped are the certificates that are no longer referenced after the
+	// update.
+	dropped []*cloud.ResourceID
+}
+
+func (act *setSslCertificatesAction) links() []string {
+	var ret []string
+	for _, id := range act.certs {
+		ret = append(ret, id.SelfLink(meta.VersionGA))
+	}
+	return ret
+}
+
+func (act *setSslCertificatesAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	// TODO: project routing.
+	var err error
+	switch act.id.Key.Type() {
+	case meta.Global:
+		err = cl.TargetHttpsProxies().SetSslCertificates(ctx, act.id.Key, &compute.TargetHttpsProxiesSetSslCertificatesRequest{
+			SslCertificates: act.links(),
+		})
+	case meta.Regional:
+		err = cl.RegionTargetHttpsProxies().SetSslCertificates(ctx, act.id.Key, &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{
+			SslCertificates: act.links(),
+		})
+	default:
+		return nil, fmt.Errorf("setSslCertificatesAction Run(%s): invalid key type", act.id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("setSslCertificatesAction Run(%s): SetSslCertificates: %w", act.id, err)
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *setSslCertificatesAction) DryRun() exec.EventList {
+	var events exec.EventList
+	for _, id := range act.dropped {
+		events = append(events, exec.NewDropRefEvent(act.id, id))
+	}
+	return events
+}
+
+func (act *setSslCertificatesAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	body, err := json.Marshal(&compute.TargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: act.links()})
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: "SetSslCertificates", ResourceID: act.id, Body: string(body)}}, nil
+}
+
+func (act *setSslCertificatesAction) String() string {
+	return fmt.Sprintf("TargetHttpsProxySetSslCertificatesAction(%s)", act.id)
+}
+
+func (act *setSslCertificatesAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("TargetHttpsProxySetSslCertificatesAction", act.id),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("SetSslCertificates %s", act.id),
+		ResourceID: act.id,
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type targetHttpsProxyNode struct {
+	rnode.NodeBase
+	resource TargetHttpsProxy
+}
+
+var _ rnode.Node = (*targetHttpsProxyNode)(nil)
+
+var sslCertificatesPath = api.Path{}.Pointer().Field("SslCertificates")
+
+func (n *targetHttpsProxyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *targetHttpsProxyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*targetHttpsProxyNode)
+	if !ok {
+		return nil, fmt.Errorf("TargetHttpsProxyNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("TargetHttpsProxyNode: Diff %w", err)
+	}
+
+	if !diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	for _, item := range diff.Items {
+		if item.Path.HasPrefix(sslCertificatesPath) {
+			continue
+		}
+		if n.ID().Key.Type() == meta.Regional {
+			return &rnode.PlanDetails{
+				Operation: rnode.OpUpdate,
+				Why:       fmt.Sprintf("TargetHttpsProxy needs to be patched (%s changed)", item.Path),
+				Diff:      diff,
+			}, nil
+		}
+		// TODO: handle the other Set*() methods for global proxies.
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       fmt.Sprintf("TargetHttpsProxy needs to be recreated (%s cannot be updated)", item.Path),
+			Diff:      diff,
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       "SslCertificates changed (SetSslCertificates)",
+		Diff:      diff,
+	}, nil
+}
+
+func (n *targetHttpsProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		if n.ID().Key.Type() == meta.Regional {
+			return n.patchActions(got)
+		}
+		return n.updateActions(got)
+	}
+
+	return nil, fmt.Errorf("TargetHttpsProxyNode: invalid plan op %s", op)
+}
+
+func (n *targetHttpsProxyNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
+
+// updateActions sets the SslCertificates of the proxy. The update waits for
+// the new certificates to exist and signals that the references to the old
+// certificates have been dropped, so that a certificate can be replaced
+// without downtime (create new, SetSslCertificates, delete old).
+func (n *targetHttpsProxyNode) updateActions(got rnode.Node) ([]exec.Action, error) {
+	if got.State() != rnode.NodeExists || n.State() != rnode.NodeExists {
+		return nil, fmt.Errorf("TargetHttpsProxyNode: updateActions %s: node does not exist", n.ID())
+	}
+
+	act := &setSslCertificatesAction{id: n.ID()}
+	for _, ref := range n.OutRefs() {
+		if !ref.Path.HasPrefix(api.Path{}.Field("SslCertificates")) {
+			continue
+		}
+		act.Want = append(act.Want, exec.NewExistsEvent(ref.To))
+		act.certs = append(act.certs, ref.To)
+	}
+	for _, ref := range got.OutRefs() {
+		if !ref.Path.HasPrefix(api.Path{}.Field("SslCertificates")) || containsID(act.certs, ref.To) {
+			continue
+		}
+		act.dropped = append(act.dropped, ref.To)
+	}
+
+	return []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+		// Action: Do the updates.
+		act,
+	}, nil
+}
+
+// patchActions patches a regional proxy. Only the regional proxies have a
+// Patch method; it updates all fields, including the SslCertificates.
+func (n *targetHttpsProxyNode) patchActions(got rnode.Node) ([]exec.Action, error) {
+	gotNode, ok := got.(*targetHttpsProxyNode)
+	if !ok {
+		return nil, fmt.Errorf("TargetHttpsProxyNode: invalid type for got: %T", got)
+	}
+	f, err := fingerprint(gotNode.resource)
+	if err != nil {
+		return nil, fmt.Errorf("TargetHttpsProxyNode: cannot get fingerprint: %w", err)
+	}
+	return rnode.PatchActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, got, n, n.resource, f)
+}
+
+func fingerprint(res TargetHttpsProxy) (string, error) {
+	switch res.Version() {
+	case meta.VersionGA:
+		obj, err := res.ToGA()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	case meta.VersionAlpha:
+		obj, err := res.ToAlpha()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	case meta.VersionBeta:
+		obj, err := res.ToBeta()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	}
+	return "", fmt.Errorf("unsupported TargetHttpsProxy resource version %v", res.Version())
+}
+
+func containsID(ids []*cloud.ResourceID, id *cloud.ResourceID) bool {
+	for _, x := range ids {
+		if x.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID for the TargetHttpsProxy. key can be either Global or Regional.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "targetHttpsProxies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTargetHttpsProxy = api.MutableResource[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]
+
+func NewMutableTargetHttpsProxy(project string, key *meta.Key) MutableTargetHttpsProxy {
+	id := ID(project, key)
+	return api.NewResource[
+		compute.TargetHttpsProxy,
+		alpha.TargetHttpsProxy,
+		beta.TargetHttpsProxy,
+	](id, &typeTrait{})
+}
+
+type TargetHttpsProxy = api.Resource[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]
+
+// ReplaceSslCertificate changes the reference to oldCert in the
+// SslCertificates of r to newCert, keeping the order of the certificates.
+//
+// Planning a graph with the updated TargetHttpsProxy (and newCert) rotates the
+// certificate without downtime: newCert is created, the SslCertificates of the
+// proxy are set with SetSslCertificates and then oldCert is deleted, as it is
+// no longer referenced.
+func ReplaceSslCertificate(r MutableTargetHttpsProxy, oldCert, newCert *cloud.ResourceID) error {
+	var errs []error
+	var found bool
+	err := r.Access(func(x *compute.TargetHttpsProxy) {
+		for i, url := range x.SslCertificates {
+			id, err := cloud.ParseResourceURL(url)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if id.Equal(oldCert) {
+				x.SslCertificates[i] = newCert.SelfLink(meta.VersionGA)
+				found = true
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("ReplaceSslCertificate: %w", err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("ReplaceSslCertificate: %w", errs[0])
+	}
+	if !found {
+		return fmt.Errorf("ReplaceSslCertificate: %v is not referenced by %v", oldCert, r.ResourceID())
+	}
+	return nil
+}
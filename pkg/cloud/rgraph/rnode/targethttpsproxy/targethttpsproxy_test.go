@@ -0,0 +1,157 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj-1"
+
+func certID(name string) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "sslCertificates",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey(name),
+	}
+}
+
+func TestTargetHttpsProxySchema(t *testing.T) {
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("key-1"),
+		meta.RegionalKey("key-1", "us-central1"),
+	} {
+		x := NewMutableTargetHttpsProxy(proj, key)
+		if err := x.CheckSchema(); err != nil {
+			t.Fatalf("CheckSchema() = %v, want nil", err)
+		}
+	}
+}
+
+func TestReplaceSslCertificate(t *testing.T) {
+	link := func(name string) string { return certID(name).SelfLink(meta.VersionGA) }
+
+	for _, tc := range []struct {
+		name    string
+		certs   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "replace",
+			certs: []string{link("a")},
+			want:  []string{link("b")},
+		},
+		{
+			name:  "keeps order",
+			certs: []string{link("x"), link("a"), link("y")},
+			want:  []string{link("x"), link("b"), link("y")},
+		},
+		{
+			name:    "not referenced",
+			certs:   []string{link("x")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMutableTargetHttpsProxy(proj, meta.GlobalKey("tp"))
+			m.Access(func(x *compute.TargetHttpsProxy) { x.SslCertificates = tc.certs })
+
+			err := ReplaceSslCertificate(m, certID("a"), certID("b"))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ReplaceSslCertificate() = %v, want error = %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			got, _ := m.ToGA()
+			if diff := cmp.Diff(got.SslCertificates, tc.want); diff != "" {
+				t.Errorf("SslCertificates: -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetHttpsProxyDiff(t *testing.T) {
+	makeNode := func(t *testing.T, key *meta.Key, x *compute.TargetHttpsProxy) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableTargetHttpsProxy(proj, key), x, NewBuilderWithResource)
+	}
+	certs := func(names ...string) []string {
+		var ret []string
+		for _, n := range names {
+			ret = append(ret, certID(n).SelfLink(meta.VersionGA))
+		}
+		return ret
+	}
+
+	for _, tc := range []struct {
+		name   string
+		key    *meta.Key
+		got    *compute.TargetHttpsProxy
+		want   *compute.TargetHttpsProxy
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "same",
+			got:    &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a")},
+			want:   &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a")},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "certificates changed",
+			got:    &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a")},
+			want:   &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a", "b")},
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "other field changed",
+			got:    &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a")},
+			want:   &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("b"), QuicOverride: "ENABLE"},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "regional other field changed",
+			key:    meta.RegionalKey("tp", "us-central1"),
+			got:    &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("a")},
+			want:   &compute.TargetHttpsProxy{Name: "tp", SslCertificates: certs("b"), QuicOverride: "ENABLE"},
+			wantOp: rnode.OpUpdate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key := tc.key
+			if key == nil {
+				key = meta.GlobalKey("tp")
+			}
+			details, err := makeNode(t, key, tc.want).Diff(makeNode(t, key, tc.got))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (%s)", details.Operation, tc.wantOp, details.Why)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// memberMethod is the TargetPools API method used to change the membership of
+// the pool.
+type memberMethod string
+
+const (
+	methodAddInstance       memberMethod = "AddInstance"
+	methodRemoveInstance    memberMethod = "RemoveInstance"
+	methodAddHealthCheck    memberMethod = "AddHealthCheck"
+	methodRemoveHealthCheck memberMethod = "RemoveHealthCheck"
+)
+
+func newMemberAction(id *cloud.ResourceID, method memberMethod, m member) *memberAction {
+	act := &memberAction{
+		id:     id,
+		method: method,
+		member: m,
+	}
+	switch method {
+	case methodAddInstance, methodAddHealthCheck:
+		// Condition: the member must exist before it is added.
+		act.Want = exec.EventList{exec.NewExistsEvent(m.id)}
+	}
+	return act
+}
+
+// memberAction adds or removes a single instance or health check from a
+// TargetPool.
+type memberAction struct {
+	exec.ActionBase
+
+	id     *cloud.ResourceID
+	method memberMethod
+	member member
+}
+
+// request returns the request body for the method.
+func (act *memberAction) request() (any, error) {
+	switch act.method {
+	case methodAddInstance:
+		return &compute.TargetPoolsAddInstanceRequest{
+			Instances: []*compute.InstanceReference{{Instance: act.member.url}},
+		}, nil
+	case methodRemoveInstance:
+		return &compute.TargetPoolsRemoveInstanceRequest{
+			Instances: []*compute.InstanceReference{{Instance: act.member.url}},
+		}, nil
+	case methodAddHealthCheck:
+		return &compute.TargetPoolsAddHealthCheckRequest{
+			HealthChecks: []*compute.HealthCheckReference{{HealthCheck: act.member.url}},
+		}, nil
+	case methodRemoveHealthCheck:
+		return &compute.TargetPoolsRemoveHealthCheckRequest{
+			HealthChecks: []*compute.HealthCheckReference{{HealthCheck: act.member.url}},
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid method %q", act.method)
+}
+
+func (act *memberAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	req, err := act.request()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+
+	switch r := req.(type) {
+	case *compute.TargetPoolsAddInstanceRequest:
+		err = cl.TargetPools().AddInstance(ctx, act.id.Key, r)
+	case *compute.TargetPoolsRemoveInstanceRequest:
+		err = cl.TargetPools().RemoveInstance(ctx, act.id.Key, r)
+	case *compute.TargetPoolsAddHealthCheckRequest:
+		err = cl.TargetPools().AddHealthCheck(ctx, act.id.Key, r)
+	case *compute.TargetPoolsRemoveHealthCheckRequest:
+		err = cl.TargetPools().RemoveHealthCheck(ctx, act.id.Key, r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", act, err)
+	}
+
+	return act.events(), nil
+}
+
+func (act *memberAction) DryRun() exec.EventList {
+	return act.events()
+}
+
+func (act *memberAction) events() exec.EventList {
+	switch act.method {
+	case methodRemoveInstance, methodRemoveHealthCheck:
+		// Event: the TargetPool no longer references the member.
+		return exec.EventList{exec.NewDropRefEvent(act.id, act.member.id)}
+	}
+	return nil
+}
+
+func (act *memberAction) DryRunCalls() ([]exec.DryRunCall, error) {
+	req, err := act.request()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return []exec.DryRunCall{{Method: string(act.method), ResourceID: act.id, Body: string(body)}}, nil
+}
+
+func (act *memberAction) String() string {
+	return fmt.Sprintf("TargetPool%sAction(%s, %s)", act.method, act.id, act.member.id)
+}
+
+func (act *memberAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:       rnode.ActionName("TargetPool"+string(act.method)+"Action", act.id, act.member.id.NodeID()),
+		Type:       exec.ActionTypeUpdate,
+		Summary:    fmt.Sprintf("%s %s on %s", act.method, act.member.id, act.id),
+		ResourceID: act.id,
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetpool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("targetPool: "+s, args...) }
+
+type targetPoolNode struct {
+	rnode.NodeBase
+	resource TargetPool
+}
+
+var _ rnode.Node = (*targetPoolNode)(nil)
+
+func (n *targetPoolNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *targetPoolNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*targetPoolNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	if !diff.HasDiff() {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	gotObj, _ := got.resource.ToGA()
+	wantObj, _ := n.resource.ToGA()
+	// Membership is a set: a change to Instances or HealthChecks that only
+	// reorders the members is not a diff.
+	membersChanged := map[string]bool{}
+	for _, spec := range []struct {
+		field     string
+		got, want []string
+	}{
+		{"Instances", gotObj.Instances, wantObj.Instances},
+		{"HealthChecks", gotObj.HealthChecks, wantObj.HealthChecks},
+	} {
+		add, remove, err := membershipDelta(spec.got, spec.want)
+		if err != nil {
+			return nil, nodeErr("Diff %s: %w", spec.field, err)
+		}
+		membersChanged[spec.field] = len(add) > 0 || len(remove) > 0
+	}
+
+	var (
+		needsRecreate bool
+		details       []string
+		items         []api.DiffItem
+	)
+	for _, item := range diff.Items {
+		switch {
+		// Membership can be changed in place with the Add/Remove methods.
+		case item.Path.HasPrefix(api.Path{}.Pointer().Field("Instances")):
+			if !membersChanged["Instances"] {
+				continue
+			}
+		case item.Path.HasPrefix(api.Path{}.Pointer().Field("HealthChecks")):
+			if !membersChanged["HealthChecks"] {
+				continue
+			}
+		default:
+			// Other fields (e.g. BackupPool, FailoverRatio) cannot be
+			// changed without recreating the resource.
+			needsRecreate = true
+		}
+		details = append(details, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "Instances and HealthChecks differ only in order",
+		}, nil
+	}
+	diff.Items = items
+
+	if needsRecreate {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       "TargetPool needs to be recreated: " + strings.Join(details, ", "),
+			Diff:      diff,
+		}, nil
+	}
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       "TargetPool membership update: " + strings.Join(details, ", "),
+		Diff:      diff,
+	}, nil
+}
+
+func (n *targetPoolNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.TargetPool, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.TargetPool, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.TargetPool, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *targetPoolNode) Builder() rnode.Builder {
+	b := &builder{}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
+	return b
+}
+
+// updateActions returns an Action for each instance and health check that is
+// added or removed from the pool.
+func (n *targetPoolNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	got, ok := ngot.(*targetPoolNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+	gotObj, _ := got.resource.ToGA()
+	wantObj, _ := n.resource.ToGA()
+
+	ret := []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+	}
+	for _, spec := range []struct {
+		got, want   []string
+		add, remove memberMethod
+	}{
+		{gotObj.Instances, wantObj.Instances, methodAddInstance, methodRemoveInstance},
+		{gotObj.HealthChecks, wantObj.HealthChecks, methodAddHealthCheck, methodRemoveHealthCheck},
+	} {
+		add, remove, err := membershipDelta(spec.got, spec.want)
+		if err != nil {
+			return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+		}
+		for _, m := range add {
+			ret = append(ret, newMemberAction(n.ID(), spec.add, m))
+		}
+		for _, m := range remove {
+			ret = append(ret, newMemberAction(n.ID(), spec.remove, m))
+		}
+	}
+	return ret, nil
+}
+
+// member of a TargetPool (an instance or a health check).
+type member struct {
+	url string
+	id  *cloud.ResourceID
+}
+
+// membershipDelta returns the members that are in want but not in got (add)
+// and the members in got but not in want (remove). Members are compared by
+// resource ID so that differences in the URL format (e.g. API version) are
+// ignored.
+func membershipDelta(got, want []string) (add, remove []member, err error) {
+	parse := func(urls []string) ([]member, map[cloud.ResourceMapKey]bool, error) {
+		var (
+			members []member
+			keys    = map[cloud.ResourceMapKey]bool{}
+		)
+		for _, url := range urls {
+			id, err := cloud.ParseResourceURL(url)
+			if err != nil {
+				return nil, nil, err
+			}
+			members = append(members, member{url: url, id: id})
+			keys[id.MapKey()] = true
+		}
+		return members, keys, nil
+	}
+
+	gotMembers, gotKeys, err := parse(got)
+	if err != nil {
+		return nil, nil, err
+	}
+	wantMembers, wantKeys, err := parse(want)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, m := range wantMembers {
+		if !gotKeys[m.id.MapKey()] {
+			add = append(add, m)
+		}
+	}
+	for _, m := range gotMembers {
+		if !wantKeys[m.id.MapKey()] {
+			remove = append(remove, m)
+		}
+	}
+	return add, remove, nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	proj   = "proj-1"
+	region = "us-central1"
+)
+
+var (
+	tpID    = ID(proj, meta.RegionalKey("tp", region))
+	inst1ID = &cloud.ResourceID{
+		Resource:  "instances",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.ZonalKey("inst1", "us-central1-b"),
+	}
+	inst2ID = &cloud.ResourceID{
+		Resource:  "instances",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.ZonalKey("inst2", "us-central1-b"),
+	}
+	hcID = &cloud.ResourceID{
+		Resource:  "httpHealthChecks",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: proj,
+		Key:       meta.GlobalKey("hc"),
+	}
+)
+
+func createTargetPoolNode(t *testing.T, f func(x *compute.TargetPool)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableTargetPool(proj, tpID.Key)
+	err := m.Access(func(x *compute.TargetPool) {
+		x.Name = "tp"
+		x.FailoverRatio = 0.5
+		x.SessionAffinity = "NONE"
+		x.ForceSendFields = []string{"BackupPool", "Description", "HealthChecks", "Instances"}
+		if f != nil {
+			f(x)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
+
+func TestOutRefs(t *testing.T) {
+	n := createTargetPoolNode(t, func(x *compute.TargetPool) {
+		x.Instances = []string{inst1ID.SelfLink(meta.VersionGA)}
+		x.HealthChecks = []string{hcID.SelfLink(meta.VersionGA)}
+	})
+	want := []rnode.ResourceRef{
+		{From: tpID, Path: api.Path{}.Field("Instances").Index(0), To: inst1ID},
+		{From: tpID, Path: api.Path{}.Field("HealthChecks").Index(0), To: hcID},
+	}
+	if diff := cmp.Diff(n.OutRefs(), want); diff != "" {
+		t.Errorf("OutRefs(): -got,+want: %s", diff)
+	}
+
+	b := NewBuilder(tpID)
+	m := NewMutableTargetPool(proj, tpID.Key)
+	m.Access(func(x *compute.TargetPool) { x.Instances = []string{"invalid"} })
+	r, _ := m.Freeze()
+	b.SetResource(r)
+	if _, err := b.OutRefs(); err == nil {
+		t.Errorf("OutRefs() = nil, want error for invalid instance URL")
+	}
+}
+
+func TestDiffAndActions(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		got         func(x *compute.TargetPool)
+		want        func(x *compute.TargetPool)
+		wantOp      rnode.Operation
+		wantActions []string
+	}{
+		{
+			name:   "no diff",
+			got:    func(x *compute.TargetPool) { x.Instances = []string{inst1ID.SelfLink(meta.VersionGA)} },
+			want:   func(x *compute.TargetPool) { x.Instances = []string{inst1ID.SelfLink(meta.VersionGA)} },
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "instances reordered",
+			got: func(x *compute.TargetPool) {
+				x.Instances = []string{inst1ID.SelfLink(meta.VersionGA), inst2ID.SelfLink(meta.VersionGA)}
+			},
+			want: func(x *compute.TargetPool) {
+				x.Instances = []string{inst2ID.SelfLink(meta.VersionGA), inst1ID.SelfLink(meta.VersionGA)}
+			},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "instances reordered and failover ratio change",
+			got: func(x *compute.TargetPool) {
+				x.Instances = []string{inst1ID.SelfLink(meta.VersionGA), inst2ID.SelfLink(meta.VersionGA)}
+			},
+			want: func(x *compute.TargetPool) {
+				x.Instances = []string{inst2ID.SelfLink(meta.VersionGA), inst1ID.SelfLink(meta.VersionGA)}
+				x.FailoverRatio = 0.1
+			},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name: "add instance",
+			got:  func(x *compute.TargetPool) { x.Instances = []string{inst1ID.SelfLink(meta.VersionGA)} },
+			want: func(x *compute.TargetPool) {
+				x.Instances = []string{inst1ID.SelfLink(meta.VersionGA), inst2ID.SelfLink(meta.VersionGA)}
+			},
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"TargetPoolAddInstanceAction(compute/targetPools:proj-1/us-central1/tp, compute/instances:proj-1/us-central1-b/inst2)",
+			},
+		},
+		{
+			name: "remove instance and add health check",
+			got: func(x *compute.TargetPool) {
+				x.Instances = []string{inst1ID.SelfLink(meta.VersionGA), inst2ID.SelfLink(meta.VersionGA)}
+			},
+			want: func(x *compute.TargetPool) {
+				x.Instances = []string{inst2ID.SelfLink(meta.VersionGA)}
+				x.HealthChecks = []string{hcID.SelfLink(meta.VersionGA)}
+			},
+			wantOp: rnode.OpUpdate,
+			wantActions: []string{
+				"TargetPoolRemoveInstanceAction(compute/targetPools:proj-1/us-central1/tp, compute/instances:proj-1/us-central1-b/inst1)",
+				"TargetPoolAddHealthCheckAction(compute/targetPools:proj-1/us-central1/tp, compute/httpHealthChecks:proj-1/hc)",
+			},
+		},
+		{
+			name: "backup pool change",
+			want: func(x *compute.TargetPool) {
+				x.BackupPool = ID(proj, meta.RegionalKey("backup", region)).SelfLink(meta.VersionGA)
+			},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "failover ratio change",
+			want:   func(x *compute.TargetPool) { x.FailoverRatio = 0.1 },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := createTargetPoolNode(t, tc.got)
+			want := createTargetPoolNode(t, tc.want)
+
+			details, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if details.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (details: %+v)", details.Operation, tc.wantOp, details)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*details)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			var gotActions []string
+			for _, act := range actions {
+				if _, ok := act.(*memberAction); ok {
+					gotActions = append(gotActions, act.String())
+				}
+			}
+			if diff := cmp.Diff(gotActions, tc.wantActions); diff != "" {
+				t.Errorf("Actions(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMemberActionRun(t *testing.T) {
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	var gotReq *compute.TargetPoolsAddInstanceRequest
+	mock.MockTargetPools.AddInstanceHook = func(_ context.Context, _ *meta.Key, req *compute.TargetPoolsAddInstanceRequest, _ *cloud.MockTargetPools, _ ...cloud.Option) error {
+		gotReq = req
+		return nil
+	}
+
+	url := inst1ID.SelfLink(meta.VersionGA)
+	act := newMemberAction(tpID, methodAddInstance, member{url: url, id: inst1ID})
+	if act.CanRun() {
+		t.Errorf("CanRun() = true, want false before the instance exists")
+	}
+	act.Signal(exec.NewExistsEvent(inst1ID))
+	if !act.CanRun() {
+		t.Fatalf("CanRun() = false, want true")
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	want := &compute.TargetPoolsAddInstanceRequest{
+		Instances: []*compute.InstanceReference{{Instance: url}},
+	}
+	if diff := cmp.Diff(gotReq, want); diff != "" {
+		t.Errorf("AddInstance(): -got,+want: %s", diff)
+	}
+
+	remove := newMemberAction(tpID, methodRemoveInstance, member{url: url, id: inst1ID})
+	if !remove.CanRun() {
+		t.Fatalf("CanRun() = false, want true for remove")
+	}
+	events, err := remove.Run(context.Background(), mock)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	wantEvents := exec.EventList{exec.NewDropRefEvent(tpID, inst1ID)}
+	if diff := cmp.Diff(events, wantEvents); diff != "" {
+		t.Errorf("Run() events: -got,+want: %s", diff)
+	}
+}
@@ -31,6 +31,8 @@ const (
 	resourceName = "TcpRoute"
 )
 
+func init() { rnode.Register("tcpRoutes", NewBuilder) }
+
 // NewBuilder creates builder for tcp route.
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
@@ -70,6 +72,10 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 		ctx, gcp, resourceName, &tcpRouteOps{}, &tcpRouteTypeTrait{}, b)
 }
 
+func (b *builder) UnmarshalResource(data []byte) error {
+	return rnode.GenericUnmarshalResource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&tcpRouteTypeTrait{}, b, data)
+}
+
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.resource == nil {
 		return nil, nil
@@ -18,6 +18,7 @@ package tcproute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
@@ -53,9 +54,16 @@ func (n *tcpRouteNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 		}
 	}
 	if diff.HasDiff() {
+		// Rules are diffed element by element, so a change to a single rule
+		// (e.g. an added destination) is reported at the path of that rule
+		// rather than for the entire route.
+		var paths []string
+		for _, item := range diff.Items {
+			paths = append(paths, item.Path.String())
+		}
 		return &rnode.PlanDetails{
 			Operation: rnode.OpUpdate,
-			Why:       "TcpRoute needs to be recreated",
+			Why:       "TcpRoute update: " + strings.Join(paths, ", "),
 			Diff:      diff,
 		}, nil
 	}
@@ -81,8 +89,14 @@ func (n *tcpRouteNode) runOp(got rnode.Node, op rnode.Operation) ([]exec.Action,
 		return rnode.RecreateActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&tcpRouteOps{}, got, n, n.resource)
 
 	case rnode.OpUpdate:
-		// TCP route does not support fingerprint
-		return rnode.UpdateActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&tcpRouteOps{}, got, n, n.resource, "")
+		// TCP route does not support fingerprint. Only the fields that
+		// changed are sent in the updateMask. The mask comes from the
+		// planned diff, which does not include the Name.
+		details := n.Plan().Details()
+		if details == nil || details.Diff == nil {
+			return nil, fmt.Errorf("TcpRouteNode: no diff for update of %s", n.ID())
+		}
+		return rnode.PatchActionsWithMask[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&tcpRouteOps{}, got, n, n.resource, details.Diff.FieldMask(), "")
 	}
 
 	return nil, fmt.Errorf("TcpRouteNode: invalid plan op %s", op)
@@ -100,5 +114,8 @@ func (n *tcpRouteNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *tcpRouteNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
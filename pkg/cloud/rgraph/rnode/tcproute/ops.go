@@ -26,6 +26,9 @@ import (
 
 type tcpRouteOps struct{}
 
+// tcpRouteOps implements GenericPatchOps.
+var _ rnode.GenericPatchOps[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] = (*tcpRouteOps)(nil)
+
 func (*tcpRouteOps) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
 	return &rnode.GetFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
 		GA: rnode.GetFuncsByScope[networkservices.TcpRoute]{
@@ -70,3 +73,15 @@ func (*tcpRouteOps) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservi
 		},
 	}
 }
+
+func (*tcpRouteOps) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
+	return &rnode.PatchFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
+		GA: rnode.PatchFuncsByScope[networkservices.TcpRoute]{
+			Global: gcp.TcpRoutes().Patch,
+		},
+		Beta: rnode.PatchFuncsByScope[beta.TcpRoute]{
+			Global: gcp.BetaTcpRoutes().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint | rnode.UpdateFuncsUpdateMask,
+	}
+}
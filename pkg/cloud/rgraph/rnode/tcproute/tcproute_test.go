@@ -22,8 +22,11 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/networkservices/v1"
 )
 
@@ -117,6 +120,58 @@ func TestNodeDiffResource(t *testing.T) {
 	}
 }
 
+func TestNodeDiffAddDestination(t *testing.T) {
+	id := ID(projectID, meta.GlobalKey("tcproute-1"))
+	got := createTcpNode(t, id, rnode.NodeExists)
+
+	mutRes := defaultTCPRouteResource(t, id)
+	err := mutRes.Access(func(x *networkservices.TcpRoute) {
+		// Rules in the default resource share the same object, replace the
+		// first rule so that only it changes.
+		x.Rules[0] = &networkservices.TcpRouteRouteRule{
+			Action: &networkservices.TcpRouteRouteAction{
+				Destinations: []*networkservices.TcpRouteRouteDestination{
+					x.Rules[0].Action.Destinations[0],
+					{
+						ServiceName: "https://networkservices.googleapis.com/v1/projects/proj-1/global/backendServices/bs2",
+						Weight:      10,
+					},
+				},
+				IdleTimeout: x.Rules[0].Action.IdleTimeout,
+			},
+			Matches: x.Rules[0].Matches,
+		}
+	})
+	if err != nil {
+		t.Fatalf("mutRes.Access(_) = %v, want nil", err)
+	}
+	r, err := mutRes.Freeze()
+	if err != nil {
+		t.Fatalf("mutRes.Freeze() = %v, want nil", err)
+	}
+	b := got.Builder()
+	b.SetResource(r)
+	want, err := b.Build()
+	if err != nil {
+		t.Fatalf("b.Build() = %v, want nil", err)
+	}
+
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("want.Diff(_) = %v, want nil", err)
+	}
+	if p.Operation != rnode.OpUpdate {
+		t.Fatalf("p.Operation = %q, want %q", p.Operation, rnode.OpUpdate)
+	}
+	if p.Diff == nil || len(p.Diff.Items) != 1 {
+		t.Fatalf("p.Diff = %+v, want 1 item", p.Diff)
+	}
+	wantPath := api.Path{}.Pointer().Field("Rules").Index(0).Pointer().Field("Action").Pointer().Field("Destinations")
+	if gotPath := p.Diff.Items[0].Path; !gotPath.Equal(wantPath) {
+		t.Errorf("p.Diff.Items[0].Path = %v, want %v", gotPath, wantPath)
+	}
+}
+
 func TestNodeDiffTheSameResource(t *testing.T) {
 	id := ID(projectID, meta.GlobalKey("tcproute-1"))
 	n1 := createTcpNode(t, id, rnode.NodeExists)
@@ -201,6 +256,61 @@ func TestAction(t *testing.T) {
 	}
 }
 
+func TestUpdateActionMask(t *testing.T) {
+	ctx := context.Background()
+	id := ID(projectID, meta.GlobalKey("tcp-n1"))
+	got := createTcpNode(t, id, rnode.NodeExists)
+
+	mutRes := defaultTCPRouteResource(t, id)
+	if err := mutRes.Access(func(x *networkservices.TcpRoute) { x.Description = "new desc" }); err != nil {
+		t.Fatalf("mutRes.Access(_) = %v, want nil", err)
+	}
+	r, err := mutRes.Freeze()
+	if err != nil {
+		t.Fatalf("mutRes.Freeze() = %v, want nil", err)
+	}
+	b := got.Builder()
+	b.SetResource(r)
+	want, err := b.Build()
+	if err != nil {
+		t.Fatalf("b.Build() = %v, want nil", err)
+	}
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("want.Diff(_) = %v, want nil", err)
+	}
+	want.Plan().Set(*p)
+
+	acts, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("want.Actions(_) = %v, want nil", err)
+	}
+	if len(acts) != 1 || acts[0].Metadata().Type != exec.ActionTypePatch {
+		t.Fatalf("want.Actions(_) = %v, want 1 Patch action", acts)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: projectID})
+	var (
+		gotMask string
+		gotObj  *networkservices.TcpRoute
+	)
+	mock.MockTcpRoutes.PatchHook = func(ctx context.Context, _ *meta.Key, obj *networkservices.TcpRoute, _ *cloud.MockTcpRoutes, _ ...cloud.Option) error {
+		gotMask, _ = cloud.UpdateMask(ctx)
+		gotObj = obj
+		return nil
+	}
+	if _, err := acts[0].Run(ctx, mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	// Only the changed field is sent.
+	if gotMask != "description" {
+		t.Errorf("updateMask = %q, want %q", gotMask, "description")
+	}
+	if diff := cmp.Diff(gotObj, &networkservices.TcpRoute{Description: "new desc"}); diff != "" {
+		t.Errorf("Patch object: diff -got,+want: %s", diff)
+	}
+}
+
 func TestSyncFromCloud(t *testing.T) {
 	ctx := context.Background()
 	cl := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: projectID})
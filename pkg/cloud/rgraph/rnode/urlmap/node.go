@@ -19,6 +19,7 @@ package urlmap
 import (
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -46,19 +47,37 @@ func (n *urlMapNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 		return nil, fmt.Errorf("UrlMapNode: Diff %w", err)
 	}
 
-	if diff.HasDiff() {
-		// TODO: handle set labels with an update operation.
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "UrlMap needs to be recreated (no update method exists)",
-			Diff:      diff,
-		}, nil
+	// All fields other than the Name can be changed with Patch.
+	ret := rnode.PlanFromDiff(diff, nil)
+	if ret.Operation != rnode.OpNothing {
+		ret.Why = "UrlMap " + ret.Why
 	}
+	return ret, nil
+}
 
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+func fingerprint(gotNode *urlMapNode) (string, error) {
+	gotRes := gotNode.resource
+	switch gotRes.Version() {
+	case meta.VersionGA:
+		obj, err := gotRes.ToGA()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	case meta.VersionAlpha:
+		obj, err := gotRes.ToAlpha()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	case meta.VersionBeta:
+		obj, err := gotRes.ToBeta()
+		if err != nil {
+			return "", err
+		}
+		return obj.Fingerprint, nil
+	}
+	return "", fmt.Errorf("unsupported UrlMap resource version %v", gotRes.Version())
 }
 
 func (n *urlMapNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -78,7 +97,15 @@ func (n *urlMapNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		return rnode.RecreateActions[compute.UrlMap, alpha.UrlMap, beta.UrlMap](&urlMapOps{}, got, n, n.resource)
 
 	case rnode.OpUpdate:
-		// TODO
+		gotNode, ok := got.(*urlMapNode)
+		if !ok {
+			return nil, fmt.Errorf("UrlMapNode: invalid type for got: %T", got)
+		}
+		f, err := fingerprint(gotNode)
+		if err != nil {
+			return nil, fmt.Errorf("UrlMapNode: cannot get fingerprint: %w", err)
+		}
+		return rnode.PatchActions[compute.UrlMap, alpha.UrlMap, beta.UrlMap](&urlMapOps{}, got, n, n.resource, f)
 	}
 
 	return nil, fmt.Errorf("UrlMapNode: invalid plan op %s", op)
@@ -87,5 +114,8 @@ func (n *urlMapNode) Actions(got rnode.Node) ([]exec.Action, error) {
 func (n *urlMapNode) Builder() rnode.Builder {
 	b := &builder{}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	b.SetDeletionProtected(n.DeletionProtected())
+	b.SetForceRecreate(n.ForceRecreate())
+	b.SetAllowRecreate(n.AllowRecreate())
 	return b
 }
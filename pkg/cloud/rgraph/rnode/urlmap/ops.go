@@ -27,6 +27,9 @@ import (
 
 type urlMapOps struct{}
 
+// urlMapOps implements GenericPatchOps.
+var _ rnode.GenericPatchOps[compute.UrlMap, alpha.UrlMap, beta.UrlMap] = (*urlMapOps)(nil)
+
 func (*urlMapOps) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.UrlMap, alpha.UrlMap, beta.UrlMap] {
 	return &rnode.GetFuncs[compute.UrlMap, alpha.UrlMap, beta.UrlMap]{
 		GA: rnode.GetFuncsByScope[compute.UrlMap]{
@@ -94,3 +97,20 @@ func (*urlMapOps) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.UrlMap
 		},
 	}
 }
+
+func (*urlMapOps) PatchFuncs(gcp cloud.Cloud) *rnode.PatchFuncs[compute.UrlMap, alpha.UrlMap, beta.UrlMap] {
+	return &rnode.PatchFuncs[compute.UrlMap, alpha.UrlMap, beta.UrlMap]{
+		GA: rnode.PatchFuncsByScope[compute.UrlMap]{
+			Global:   gcp.UrlMaps().Patch,
+			Regional: gcp.RegionUrlMaps().Patch,
+		},
+		Alpha: rnode.PatchFuncsByScope[alpha.UrlMap]{
+			Global:   gcp.AlphaUrlMaps().Patch,
+			Regional: gcp.AlphaRegionUrlMaps().Patch,
+		},
+		Beta: rnode.PatchFuncsByScope[beta.UrlMap]{
+			Global:   gcp.BetaUrlMaps().Patch,
+			Regional: gcp.BetaRegionUrlMaps().Patch,
+		},
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package urlmap
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -41,3 +43,60 @@ func (*urlMapTypeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 
 	return dt
 }
+
+// Validate that the UrlMap and each of its PathMatchers have a default: one
+// of DefaultService, DefaultRouteAction (with WeightedBackendServices) or
+// DefaultUrlRedirect must be set.
+func (*urlMapTypeTrait) Validate(_ meta.Version, obj any) error {
+	var d defaults
+	switch x := obj.(type) {
+	case *compute.UrlMap:
+		d.top = x.DefaultService != "" || x.DefaultUrlRedirect != nil ||
+			(x.DefaultRouteAction != nil && len(x.DefaultRouteAction.WeightedBackendServices) > 0)
+		for _, pm := range x.PathMatchers {
+			d.add(pm.Name, pm.DefaultService != "" || pm.DefaultUrlRedirect != nil ||
+				(pm.DefaultRouteAction != nil && len(pm.DefaultRouteAction.WeightedBackendServices) > 0))
+		}
+	case *alpha.UrlMap:
+		d.top = x.DefaultService != "" || x.DefaultUrlRedirect != nil ||
+			(x.DefaultRouteAction != nil && len(x.DefaultRouteAction.WeightedBackendServices) > 0)
+		for _, pm := range x.PathMatchers {
+			d.add(pm.Name, pm.DefaultService != "" || pm.DefaultUrlRedirect != nil ||
+				(pm.DefaultRouteAction != nil && len(pm.DefaultRouteAction.WeightedBackendServices) > 0))
+		}
+	case *beta.UrlMap:
+		d.top = x.DefaultService != "" || x.DefaultUrlRedirect != nil ||
+			(x.DefaultRouteAction != nil && len(x.DefaultRouteAction.WeightedBackendServices) > 0)
+		for _, pm := range x.PathMatchers {
+			d.add(pm.Name, pm.DefaultService != "" || pm.DefaultUrlRedirect != nil ||
+				(pm.DefaultRouteAction != nil && len(pm.DefaultRouteAction.WeightedBackendServices) > 0))
+		}
+	default:
+		return fmt.Errorf("UrlMap: invalid type %T", obj)
+	}
+	return d.validate()
+}
+
+// defaults records whether the UrlMap and its PathMatchers have a default.
+type defaults struct {
+	top bool
+	// pathMatchersWithout are the names of the PathMatchers without a
+	// default.
+	pathMatchersWithout []string
+}
+
+func (d *defaults) add(pathMatcher string, hasDefault bool) {
+	if !hasDefault {
+		d.pathMatchersWithout = append(d.pathMatchersWithout, pathMatcher)
+	}
+}
+
+func (d *defaults) validate() error {
+	if !d.top {
+		return fmt.Errorf("UrlMap: missing default (one of DefaultService, DefaultRouteAction, DefaultUrlRedirect)")
+	}
+	if len(d.pathMatchersWithout) > 0 {
+		return fmt.Errorf("UrlMap: PathMatchers %q missing default (one of DefaultService, DefaultRouteAction, DefaultUrlRedirect)", d.pathMatchersWithout)
+	}
+	return nil
+}
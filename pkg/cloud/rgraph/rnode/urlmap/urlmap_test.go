@@ -17,9 +17,18 @@ limitations under the License.
 package urlmap
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/rnodetest"
+	"github.com/google/go-cmp/cmp"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
 func TestUrlMapSchema(t *testing.T) {
@@ -30,3 +39,144 @@ func TestUrlMapSchema(t *testing.T) {
 		t.Fatalf("CheckSchema() = %v, want nil", err)
 	}
 }
+
+func TestUrlMapValidate(t *testing.T) {
+	const (
+		proj = "proj-1"
+		bs   = "https://www.googleapis.com/compute/v1/projects/proj-1/global/backendServices/bs"
+	)
+
+	for _, tc := range []struct {
+		name    string
+		f       func(x *compute.UrlMap)
+		fBeta   func(x *beta.UrlMap)
+		wantErr string
+	}{
+		{
+			name: "DefaultService",
+			f:    func(x *compute.UrlMap) { x.DefaultService = bs },
+		},
+		{
+			name: "DefaultRouteAction",
+			f: func(x *compute.UrlMap) {
+				x.DefaultRouteAction = &compute.HttpRouteAction{
+					WeightedBackendServices: []*compute.WeightedBackendService{{BackendService: bs, Weight: 100}},
+				}
+			},
+		},
+		{
+			name: "DefaultUrlRedirect",
+			f: func(x *compute.UrlMap) {
+				x.DefaultUrlRedirect = &compute.HttpRedirectAction{HttpsRedirect: true}
+			},
+		},
+		{
+			name:    "no default",
+			f:       func(x *compute.UrlMap) {},
+			wantErr: "UrlMap: missing default",
+		},
+		{
+			name: "DefaultRouteAction without backends",
+			f: func(x *compute.UrlMap) {
+				x.DefaultRouteAction = &compute.HttpRouteAction{
+					UrlRewrite: &compute.UrlRewrite{PathPrefixRewrite: "/"},
+				}
+			},
+			wantErr: "UrlMap: missing default",
+		},
+		{
+			name: "PathMatchers with defaults",
+			f: func(x *compute.UrlMap) {
+				x.DefaultService = bs
+				x.PathMatchers = []*compute.PathMatcher{
+					{Name: "pm1", DefaultService: bs},
+					{Name: "pm2", DefaultUrlRedirect: &compute.HttpRedirectAction{HttpsRedirect: true}},
+				}
+			},
+		},
+		{
+			name: "PathMatcher missing default",
+			f: func(x *compute.UrlMap) {
+				x.DefaultService = bs
+				x.PathMatchers = []*compute.PathMatcher{
+					{Name: "pm1", DefaultService: bs},
+					{Name: "pm2", PathRules: []*compute.PathRule{{Paths: []string{"/a"}, Service: bs}}},
+				}
+			},
+			wantErr: `UrlMap: PathMatchers ["pm2"] missing default`,
+		},
+		{
+			name: "beta PathMatcher missing default",
+			fBeta: func(x *beta.UrlMap) {
+				x.DefaultService = bs
+				x.PathMatchers = []*beta.PathMatcher{{Name: "pm1"}}
+			},
+			wantErr: `UrlMap: PathMatchers ["pm1"] missing default`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := NewMutableUrlMap(proj, meta.GlobalKey("um"))
+			var err error
+			if tc.fBeta != nil {
+				err = x.AccessBeta(tc.fBeta)
+			} else {
+				err = x.Access(tc.f)
+			}
+			if err != nil {
+				t.Fatalf("Access() = %v, want nil", err)
+			}
+			_, err = x.Freeze()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Freeze() = %v, want nil", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("Freeze() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestUrlMapDiffAndActions(t *testing.T) {
+	const (
+		proj = "proj-1"
+		bs1  = "https://www.googleapis.com/compute/v1/projects/proj-1/global/backendServices/bs1"
+		bs2  = "https://www.googleapis.com/compute/v1/projects/proj-1/global/backendServices/bs2"
+	)
+	key := meta.GlobalKey("um")
+	makeNode := func(t *testing.T, x *compute.UrlMap) rnode.Node {
+		t.Helper()
+		return rnodetest.NewNode(t, NewMutableUrlMap(proj, key), x, NewBuilderWithResource)
+	}
+
+	got := makeNode(t, &compute.UrlMap{Name: "um", DefaultService: bs1, Fingerprint: "fp"})
+	want := makeNode(t, &compute.UrlMap{Name: "um", DefaultService: bs2})
+
+	details, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	if details.Operation != rnode.OpUpdate {
+		t.Fatalf("Diff().Operation = %s, want %s (%s)", details.Operation, rnode.OpUpdate, details.Why)
+	}
+	want.Plan().Set(*details)
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	if len(actions) != 1 || actions[0].Metadata().Type != exec.ActionTypePatch {
+		t.Fatalf("Actions() = %v, want a single Patch action", actions)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	var patched *compute.UrlMap
+	mock.MockUrlMaps.PatchHook = func(_ context.Context, _ *meta.Key, obj *compute.UrlMap, _ *cloud.MockUrlMaps, _ ...cloud.Option) error {
+		patched = obj
+		return nil
+	}
+	if _, err := actions[0].Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(patched, &compute.UrlMap{DefaultService: bs2, Fingerprint: "fp"}); diff != "" {
+		t.Errorf("Patch(): -got,+want: %s", diff)
+	}
+}
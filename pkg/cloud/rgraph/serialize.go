@@ -0,0 +1,156 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// graphJSON is the serialized form of a Graph.
+type graphJSON struct {
+	Nodes []nodeJSON `json:"nodes"`
+}
+
+type nodeJSON struct {
+	ID                *cloud.ResourceID     `json:"id"`
+	State             rnode.NodeState       `json:"state"`
+	Ownership         rnode.OwnershipStatus `json:"ownership"`
+	DeletionProtected bool                  `json:"deletionProtected,omitempty"`
+	ForceRecreate     bool                  `json:"forceRecreate,omitempty"`
+	AllowRecreate     bool                  `json:"allowRecreate,omitempty"`
+	// Options is the JSON from rnode.OptionsMarshaler. This is empty if the
+	// node has no type specific options set.
+	Options json.RawMessage `json:"options,omitempty"`
+	// Resource is the JSON from Resource.MarshalJSON. This is empty if the
+	// node has no resource (e.g. the resource does not exist).
+	Resource json.RawMessage `json:"resource,omitempty"`
+	// OutRefs are recomputed from Resource on import; they are included
+	// to check that the import is consistent.
+	OutRefs []rnode.ResourceRef `json:"outRefs,omitempty"`
+}
+
+// ExportJSON serializes the Graph (nodes, resources, ownership, state and
+// type specific options) to JSON. Use ImportJSON to reconstruct a Builder
+// from the result, e.g. in a different process. The plan and sync status of
+// the nodes are not exported.
+func (g *Graph) ExportJSON() ([]byte, error) {
+	var gj graphJSON
+	for _, n := range g.All() {
+		nj := nodeJSON{
+			ID:                n.ID(),
+			State:             n.State(),
+			Ownership:         n.Ownership(),
+			DeletionProtected: n.DeletionProtected(),
+			ForceRecreate:     n.ForceRecreate(),
+			AllowRecreate:     n.AllowRecreate(),
+			OutRefs:           n.OutRefs(),
+		}
+		if r := n.Resource(); r != nil {
+			m, ok := r.(json.Marshaler)
+			if !ok {
+				return nil, fmt.Errorf("ExportJSON: resource %s (%T) is not a json.Marshaler", n.ID(), r)
+			}
+			data, err := m.MarshalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("ExportJSON: %w", err)
+			}
+			nj.Resource = data
+		}
+		if om, ok := n.(rnode.OptionsMarshaler); ok {
+			data, err := om.MarshalOptions()
+			if err != nil {
+				return nil, fmt.Errorf("ExportJSON: %w", err)
+			}
+			nj.Options = data
+		}
+		gj.Nodes = append(gj.Nodes, nj)
+	}
+	// Sort for a stable output.
+	sort.Slice(gj.Nodes, func(i, j int) bool {
+		return gj.Nodes[i].ID.String() < gj.Nodes[j].ID.String()
+	})
+	return json.MarshalIndent(gj, "", "  ")
+}
+
+// ImportJSON returns a Builder with the nodes from data created by
+// Graph.ExportJSON. Node types are created using the rnode registry, so the
+// resource packages must be linked in (e.g. by importing rnode/all).
+func ImportJSON(data []byte) (*Builder, error) {
+	var gj graphJSON
+	if err := json.Unmarshal(data, &gj); err != nil {
+		return nil, fmt.Errorf("ImportJSON: %w", err)
+	}
+
+	ret := NewBuilder()
+	for _, nj := range gj.Nodes {
+		if nj.ID == nil {
+			return nil, fmt.Errorf("ImportJSON: node with nil ID")
+		}
+		if ret.Get(nj.ID) != nil {
+			return nil, fmt.Errorf("ImportJSON: duplicate node %s", nj.ID)
+		}
+		nb, err := rnode.NewBuilderByID(nj.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ImportJSON: %w", err)
+		}
+		nb.SetState(nj.State)
+		nb.SetOwnership(nj.Ownership)
+		nb.SetDeletionProtected(nj.DeletionProtected)
+		nb.SetForceRecreate(nj.ForceRecreate)
+		nb.SetAllowRecreate(nj.AllowRecreate)
+		if len(nj.Options) > 0 {
+			ou, ok := nb.(rnode.OptionsUnmarshaler)
+			if !ok {
+				return nil, fmt.Errorf("ImportJSON: node %s (%T) does not have options", nj.ID, nb)
+			}
+			if err := ou.UnmarshalOptions(nj.Options); err != nil {
+				return nil, fmt.Errorf("ImportJSON: %w", err)
+			}
+		}
+		if len(nj.Resource) > 0 {
+			if err := nb.UnmarshalResource(nj.Resource); err != nil {
+				return nil, fmt.Errorf("ImportJSON: %w", err)
+			}
+		}
+		outRefs, err := nb.OutRefs()
+		if err != nil {
+			return nil, fmt.Errorf("ImportJSON: %w", err)
+		}
+		if !sameRefs(outRefs, nj.OutRefs) {
+			return nil, fmt.Errorf("ImportJSON: OutRefs for %s do not match the resource (got %v, want %v)", nj.ID, outRefs, nj.OutRefs)
+		}
+		ret.Add(nb)
+	}
+	return ret, nil
+}
+
+func sameRefs(a, b []rnode.ResourceRef) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].From.Equal(b[i].From) || !a[i].To.Equal(b[i].To) || !a[i].Path.Equal(b[i].Path) {
+			return false
+		}
+	}
+	return true
+}
@@ -71,13 +71,7 @@ func (m idMap) legacySelfLink(name string) string {
 	if !ok {
 		panicf("selfLink: %q is not in the map", name)
 	}
-	apiGroup := r.APIGroup
-	if apiGroup == "" {
-		apiGroup = meta.APIGroupCompute
-	}
-	relName := cloud.RelativeResourceName(r.ProjectID, r.Resource, r.Key)
-	prefix := fmt.Sprintf("https://%s.googleapis.com/v1", apiGroup)
-	return prefix + "/" + relName
+	return cloud.LegacySelfLinkForID(r, meta.VersionGA)
 }
 
 // Clone a copy of the Graph.
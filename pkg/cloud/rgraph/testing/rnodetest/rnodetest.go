@@ -0,0 +1,62 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rnodetest has helpers for the tests of the rnode packages. It
+// must not import any of the rnode resource packages, as their tests
+// import it.
+package rnodetest
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// NewNode builds a Managed Node that Exists from the resource m. If x is not
+// nil, it is Set on m first. newBuilder is the NewBuilderWithResource() of
+// the resource package. opts can change the Builder before Build(). Any
+// error fails the test.
+func NewNode[GA any, Alpha any, Beta any](
+	t *testing.T,
+	m api.MutableResource[GA, Alpha, Beta],
+	x *GA,
+	newBuilder func(api.Resource[GA, Alpha, Beta]) rnode.Builder,
+	opts ...func(rnode.Builder),
+) rnode.Node {
+	t.Helper()
+
+	if x != nil {
+		if err := m.Set(x); err != nil {
+			t.Fatalf("Set() = %v, want nil", err)
+		}
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := newBuilder(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	for _, opt := range opts {
+		opt(b)
+	}
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rgraph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// TopoOrder returns the IDs of the resources in the Graph grouped into levels
+// in dependency order: a resource only references (OutRefs) resources in
+// earlier levels. Resources in the same level do not depend on each other and
+// can be operated on in parallel. IDs within a level are sorted by their
+// String().
+//
+// Creates should be done in the returned order and deletes in the reverse
+// order. An error is returned if the references contain a cycle.
+func (g *Graph) TopoOrder() ([][]*cloud.ResourceID, error) {
+	// deps is the set of resources referenced by each resource.
+	deps := map[cloud.ResourceMapKey]map[cloud.ResourceMapKey]bool{}
+	// dependents is the inverse of deps.
+	dependents := map[cloud.ResourceMapKey][]cloud.ResourceMapKey{}
+
+	for key, n := range g.nodes {
+		deps[key] = map[cloud.ResourceMapKey]bool{}
+		for _, ref := range n.OutRefs() {
+			toKey := ref.To.MapKey()
+			if _, ok := g.nodes[toKey]; !ok {
+				return nil, fmt.Errorf("TopoOrder: %v references %v which is not in the graph", ref.From, ref.To)
+			}
+			if deps[key][toKey] {
+				continue
+			}
+			deps[key][toKey] = true
+			dependents[toKey] = append(dependents[toKey], key)
+		}
+	}
+
+	var (
+		ret  [][]*cloud.ResourceID
+		cur  []cloud.ResourceMapKey
+		done int
+	)
+	for key, d := range deps {
+		if len(d) == 0 {
+			cur = append(cur, key)
+		}
+	}
+	for len(cur) > 0 {
+		var (
+			level []*cloud.ResourceID
+			next  []cloud.ResourceMapKey
+		)
+		for _, key := range cur {
+			level = append(level, g.nodes[key].ID())
+			for _, dep := range dependents[key] {
+				delete(deps[dep], key)
+				if len(deps[dep]) == 0 {
+					next = append(next, dep)
+				}
+			}
+		}
+		sort.Slice(level, func(i, j int) bool { return level[i].String() < level[j].String() })
+		ret = append(ret, level)
+		done += len(cur)
+		cur = next
+	}
+
+	if done != len(g.nodes) {
+		// These are the resources in or depending on a cycle.
+		var cycle []string
+		for key, d := range deps {
+			if len(d) > 0 {
+				cycle = append(cycle, g.nodes[key].ID().String())
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("TopoOrder: references contain a cycle, could not order %v", cycle)
+	}
+
+	return ret, nil
+}
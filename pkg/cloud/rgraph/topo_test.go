@@ -0,0 +1,153 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rgraph
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestGraphTopoOrderBackendService(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+
+	gb := NewBuilder()
+	gb.Add(b.N("hc").HealthCheck().Build(nil))
+	gb.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	gr, err := gb.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	levels, err := gr.TopoOrder()
+	if err != nil {
+		t.Fatalf("TopoOrder() = %v, want nil", err)
+	}
+	levelOf := map[string]int{}
+	for i, level := range levels {
+		for _, id := range level {
+			levelOf[id.String()] = i
+		}
+	}
+	hcLevel, ok := levelOf[b.N("hc").HealthCheck().ID().String()]
+	if !ok {
+		t.Fatalf("HealthCheck not in TopoOrder() = %v", levels)
+	}
+	bsLevel, ok := levelOf[b.N("bs").BackendService().ID().String()]
+	if !ok {
+		t.Fatalf("BackendService not in TopoOrder() = %v", levels)
+	}
+	if hcLevel >= bsLevel {
+		t.Errorf("TopoOrder() = %v; HealthCheck level = %d, want < BackendService level = %d", levels, hcLevel, bsLevel)
+	}
+}
+
+func TestGraphTopoOrder(t *testing.T) {
+	ids := make([]*cloud.ResourceID, 5)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(fmt.Sprintf("r%d", i))}
+	}
+
+	for _, tc := range []struct {
+		name string
+		// refs are edges a -> b, given as pairs of indices into ids.
+		refs    [][2]int
+		nodes   []int
+		want    [][]string
+		wantErr string
+	}{
+		{
+			name:  "no references",
+			nodes: []int{0, 1},
+			want:  [][]string{{"fake:r0", "fake:r1"}},
+		},
+		{
+			name:  "chain",
+			nodes: []int{0, 1, 2},
+			refs:  [][2]int{{0, 1}, {1, 2}},
+			want:  [][]string{{"fake:r2"}, {"fake:r1"}, {"fake:r0"}},
+		},
+		{
+			name:  "diamond",
+			nodes: []int{0, 1, 2, 3},
+			refs:  [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}},
+			want:  [][]string{{"fake:r3"}, {"fake:r1", "fake:r2"}, {"fake:r0"}},
+		},
+		{
+			name:  "duplicate references",
+			nodes: []int{0, 1},
+			refs:  [][2]int{{0, 1}, {0, 1}},
+			want:  [][]string{{"fake:r1"}, {"fake:r0"}},
+		},
+		{
+			name:    "cycle",
+			nodes:   []int{0, 1, 2, 3},
+			refs:    [][2]int{{0, 1}, {1, 2}, {2, 1}, {3, 0}},
+			wantErr: "cycle",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gb := NewBuilder()
+			for _, i := range tc.nodes {
+				nb := fake.NewBuilder(ids[i])
+				for _, ref := range tc.refs {
+					if ref[0] == i {
+						nb.FakeOutRefs = append(nb.FakeOutRefs, rnode.ResourceRef{From: ids[ref[0]], To: ids[ref[1]]})
+					}
+				}
+				nb.SetOwnership(rnode.OwnershipManaged)
+				gb.Add(nb)
+			}
+			gr, err := gb.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			levels, err := gr.TopoOrder()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("TopoOrder() = %v, %v; want error containing %q", levels, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TopoOrder() = %v, want nil", err)
+			}
+			var got [][]string
+			for _, level := range levels {
+				var names []string
+				for _, id := range level {
+					names = append(names, id.Resource+":"+id.Key.Name)
+				}
+				got = append(got, names)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("TopoOrder(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// DriftItem is a Node whose state in Cloud differs from the wanted state.
+type DriftItem struct {
+	// ID of the resource.
+	ID *cloud.ResourceID
+	// Details of the operation that would sync the resource to "want".
+	Details *rnode.PlanDetails
+}
+
+// Drift returns the resources in the graph that differ from the current state
+// in Cloud. This computes the same plan as Do() but does not generate any
+// Actions; it only reads from Cloud. Resources that are in sync
+// (rnode.OpNothing) are not returned.
+//
+// The plan is computed on a copy of want; want is not modified.
+//
+// Items are sorted by resource ID.
+func Drift(ctx context.Context, c cloud.Cloud, want *rgraph.Graph) ([]DriftItem, error) {
+	b := rgraph.NewBuilder()
+	for _, n := range want.All() {
+		nb, err := cloneBuilder(n)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+		b.Add(nb)
+	}
+	wantCopy, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+
+	pl := planner{
+		cloud: c,
+		want:  wantCopy,
+	}
+	if err := pl.planGraph(ctx); err != nil {
+		return nil, err
+	}
+
+	var ret []DriftItem
+	for _, n := range pl.want.All() {
+		if n.Plan().Op() == rnode.OpNothing {
+			continue
+		}
+		ret = append(ret, DriftItem{ID: n.ID(), Details: n.Plan().Details()})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID.String() < ret[j].ID.String() })
+
+	return ret, nil
+}
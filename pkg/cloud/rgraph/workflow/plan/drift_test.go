@@ -0,0 +1,103 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDrift(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	hcSelfLink := b.N("hc").HealthCheck().SelfLink()
+
+	newGraph := func() *rgraph.Graph {
+		gr := rgraph.NewBuilder()
+		gr.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {
+			x.CheckIntervalSec = 5
+		}))
+		gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+			x.Port = 80
+			x.HealthChecks = []string{hcSelfLink}
+		}))
+		g, err := gr.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		return g
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, b.N("hc").HealthCheck().Key(), &compute.HealthCheck{
+		Name:             "hc",
+		CheckIntervalSec: 5,
+	})
+	mock.BackendServices().Insert(ctx, b.N("bs").BackendService().Key(), &compute.BackendService{
+		Name:         "bs",
+		Port:         80,
+		HealthChecks: []string{hcSelfLink},
+	})
+
+	drift, err := Drift(ctx, mock, newGraph())
+	if err != nil {
+		t.Fatalf("Drift() = %v, want nil", err)
+	}
+	if len(drift) != 0 {
+		t.Fatalf("Drift() = %+v, want no drift", drift)
+	}
+
+	// Change the port out-of-band.
+	bs, err := mock.BackendServices().Get(ctx, b.N("bs").BackendService().Key())
+	if err != nil {
+		t.Fatalf("BackendServices().Get() = %v, want nil", err)
+	}
+	bs.Port = 8080
+	if err := mock.BackendServices().Update(ctx, b.N("bs").BackendService().Key(), bs); err != nil {
+		t.Fatalf("BackendServices().Update() = %v, want nil", err)
+	}
+
+	want := newGraph()
+	drift, err = Drift(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Drift() = %v, want nil", err)
+	}
+	// Drift must not modify want.
+	if len(want.All()) != 2 {
+		t.Errorf("len(want.All()) = %d, want 2", len(want.All()))
+	}
+	for _, n := range want.All() {
+		if op := n.Plan().Op(); op != rnode.OpUnknown {
+			t.Errorf("want node %v has plan %s, want %s", n.ID(), op, rnode.OpUnknown)
+		}
+	}
+	if len(drift) != 1 {
+		t.Fatalf("Drift() = %+v, want 1 item", drift)
+	}
+	if !drift[0].ID.Equal(b.N("bs").BackendService().ID()) {
+		t.Errorf("Drift()[0].ID = %v, want %v", drift[0].ID, b.N("bs").BackendService().ID())
+	}
+	if drift[0].Details.Operation != rnode.OpUpdate {
+		t.Errorf("Drift()[0].Details.Operation = %s, want %s", drift[0].Details.Operation, rnode.OpUpdate)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// PlanLine summarizes the planned change to a single resource.
+type PlanLine struct {
+	// ID is the NodeID of the resource.
+	ID string
+	// Operation planned for the resource.
+	Operation rnode.Operation
+	// Details are the changed fields as "Path A->B" separated by commas, or
+	// the reason for the plan if there are no diff items.
+	Details string
+}
+
+// String returns the line in the format used by LogLines.
+func (l PlanLine) String() string {
+	return fmt.Sprintf("%s %s: %s", strings.ToUpper(string(l.Operation)), l.ID, l.Details)
+}
+
+// PlanLines returns a PlanLine for each resource that will be changed by the
+// plan, sorted by resource. Resources with rnode.OpNothing are omitted.
+func (r *Result) PlanLines() []PlanLine {
+	var ret []PlanLine
+	for _, n := range r.Want.All() {
+		details := n.Plan().Details()
+		if details == nil || details.Operation == rnode.OpNothing {
+			continue
+		}
+		ret = append(ret, PlanLine{
+			ID:        n.ID().NodeID(),
+			Operation: details.Operation,
+			Details:   logDetails(details),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+	return ret
+}
+
+// LogLines returns a one line summary for each resource that will be changed
+// by the plan (see PlanLines). This is intended for logging in environments
+// where the graphviz output is not available.
+//
+// Example:
+//
+//	CREATE compute/healthChecks:proj/global/hc1: Node doesn't exist in got, but exists in want
+//	UPDATE compute/backendServices:proj/global/bs1: Port 80->100
+func (r *Result) LogLines() []string {
+	var ret []string
+	for _, l := range r.PlanLines() {
+		ret = append(ret, l.String())
+	}
+	return ret
+}
+
+// logDetails returns the diff items in details as "Path A->B" separated by
+// commas. Why is returned if there are no diff items.
+func logDetails(details *rnode.PlanDetails) string {
+	if details.Diff == nil || !details.Diff.HasDiff() {
+		return details.Why
+	}
+	var items []string
+	for _, item := range details.Diff.Items {
+		items = append(items, fmt.Sprintf("%s %v->%v", item.Path.DisplayString(), item.A, item.B))
+	}
+	return strings.Join(items, ", ")
+}
@@ -19,6 +19,8 @@ package plan
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
@@ -34,47 +36,262 @@ type Result struct {
 	Got     *rgraph.Graph
 	Want    *rgraph.Graph
 	Actions []exec.Action
+	// WouldDelete are the Nodes that would have been deleted but were left
+	// unchanged because of NoDeleteOption. The Plan of these Nodes is
+	// OpNothing.
+	WouldDelete []rnode.Node
+}
+
+// Option for planning.
+type Option func(*planner)
+
+// NoDeleteOption plans creates and updates but never deletes: Nodes that
+// would be deleted are left unchanged and reported in Result.WouldDelete
+// instead. This is useful for a two-phase rollout where stale resources are
+// removed in a later pass. Do returns an error if a Node would be recreated
+// (which deletes the resource), as the wanted change cannot be made without
+// the delete.
+func NoDeleteOption() Option {
+	return func(pl *planner) { pl.noDelete = true }
+}
+
+// MaxActionsOption limits the number of mutating Actions (all Actions other
+// than ActionTypeMeta) in the plan. A recreate counts as two Actions (delete
+// and create). Do returns an error if the plan exceeds n. This is a safety
+// valve against a misconfiguration that would change a large number of
+// resources.
+func MaxActionsOption(n int) Option {
+	return func(pl *planner) { pl.maxActions = &n }
+}
+
+// ParallelOption computes the plan for up to workers Nodes concurrently.
+// The result is the same as planning serially.
+func ParallelOption(workers int) Option {
+	return func(pl *planner) { pl.workers = workers }
+}
+
+// DeletionProtectedOption protects the resources ids from being deleted or
+// recreated by the plan, in addition to the Nodes in want that are
+// DeletionProtected. Use this to protect resources that are not in want: a
+// resource that is only found in the Cloud (e.g. it is no longer referenced)
+// does not carry the DeletionProtected setting from want and would otherwise
+// be deleted.
+func DeletionProtectedOption(ids ...*cloud.ResourceID) Option {
+	return func(pl *planner) {
+		if pl.protected == nil {
+			pl.protected = map[cloud.ResourceMapKey]bool{}
+		}
+		for _, id := range ids {
+			pl.protected[id.MapKey()] = true
+		}
+	}
 }
 
 // Do will plan updates to cloud resources wanted in graph. Returns the set of
 // Actions needed to sync to "want".
-func Do(ctx context.Context, c cloud.Cloud, want *rgraph.Graph) (*Result, error) {
+func Do(ctx context.Context, c cloud.Cloud, want *rgraph.Graph, opts ...Option) (*Result, error) {
 	w := planner{
 		cloud: c,
 		want:  want,
 	}
+	for _, o := range opts {
+		o(&w)
+	}
+	return w.plan(ctx)
+}
+
+// DoWithGot is like Do but uses got as the current state of the resources
+// instead of fetching it from the Cloud. got must contain a Node for every
+// Node in want (Nodes that do not exist have State NodeDoesNotExist), e.g. the
+// Result.Got from a previous Do, saved with Graph.ExportJSON and loaded with
+// rgraph.ImportJSON. This is used to replay or debug a plan without making
+// any calls to the Cloud.
+func DoWithGot(ctx context.Context, got, want *rgraph.Graph, opts ...Option) (*Result, error) {
+	for _, n := range want.All() {
+		if got.Get(n.ID()) == nil {
+			return nil, fmt.Errorf("%s: %v is in want but not in got", errPrefix, n.ID())
+		}
+	}
+	w := planner{
+		got:  got,
+		want: want,
+	}
+	for _, o := range opts {
+		o(&w)
+	}
 	return w.plan(ctx)
 }
 
+// DoSubset is like Do but only plans the Nodes in want for which selector
+// returns true, along with the Nodes that they transitively reference
+// (OutRefs). The remaining Nodes in want are left unchanged: they are not
+// planned and resources they reference will not be deleted. It is an error if
+// the plan would recreate or delete a resource that is referenced by one of
+// the Nodes that were not selected.
+//
+// Result.Want contains only the planned subset of want.
+func DoSubset(ctx context.Context, c cloud.Cloud, want *rgraph.Graph, selector func(rnode.Node) bool, opts ...Option) (*Result, error) {
+	b := rgraph.NewBuilder()
+	for _, n := range want.All() {
+		if !selector(n) {
+			continue
+		}
+		deps, err := traversal.TransitiveOutRefs(want, n)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+		for _, dep := range deps {
+			nb, err := cloneBuilder(dep)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", errPrefix, err)
+			}
+			b.Add(nb)
+		}
+	}
+	subset, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+
+	unselected := map[cloud.ResourceMapKey]bool{}
+	for _, n := range want.All() {
+		if subset.Get(n.ID()) == nil {
+			unselected[n.ID().MapKey()] = true
+		}
+	}
+
+	w := planner{
+		cloud:    c,
+		want:     subset,
+		external: unselected,
+	}
+	for _, o := range opts {
+		o(&w)
+	}
+	result, err := w.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nodes outside of the subset may reference Nodes in the subset. These
+	// references would be left dangling if the resource is removed.
+	for _, n := range want.All() {
+		if !unselected[n.ID().MapKey()] {
+			continue
+		}
+		for _, ref := range n.OutRefs() {
+			to := subset.Get(ref.To)
+			if to == nil {
+				continue
+			}
+			switch to.Plan().Op() {
+			case rnode.OpRecreate, rnode.OpDelete:
+				return nil, fmt.Errorf("%s: %v planned for %s, but is referenced by %v which is not in the subset", errPrefix, to.ID(), to.Plan().Op(), n.ID())
+			}
+		}
+	}
+
+	return result, nil
+}
+
 const errPrefix = "Plan"
 
+// cloneBuilder returns a Builder for n. Unlike n.Builder(), the Builder will
+// also have the resource value of n.
+func cloneBuilder(n rnode.Node) (rnode.Builder, error) {
+	nb := n.Builder()
+	if r := n.Resource(); r != nil {
+		if err := nb.SetResource(r); err != nil {
+			return nil, fmt.Errorf("cloneBuilder(%v): %w", n.ID(), err)
+		}
+	}
+	return nb, nil
+}
+
 type planner struct {
 	cloud cloud.Cloud
 	got   *rgraph.Graph
 	want  *rgraph.Graph
+	// external are resources that will be treated as OwnershipExternal if
+	// they are found while fetching the "got" graph.
+	external map[cloud.ResourceMapKey]bool
+	// noDelete is set by NoDeleteOption.
+	noDelete bool
+	// wouldDelete are the Nodes that were not deleted due to noDelete.
+	wouldDelete []rnode.Node
+	// maxActions is set by MaxActionsOption.
+	maxActions *int
+	// workers is set by ParallelOption.
+	workers int
+	// protected is set by DeletionProtectedOption.
+	protected map[cloud.ResourceMapKey]bool
 }
 
 func (pl *planner) plan(ctx context.Context) (*Result, error) {
+	if err := pl.planGraph(ctx); err != nil {
+		return nil, err
+	}
+
+	acts, err := actions.Do(pl.got, pl.want)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	if err := pl.checkMaxActions(acts); err != nil {
+		return nil, err
+	}
+	return &Result{
+		Got:         pl.got,
+		Want:        pl.want,
+		Actions:     acts,
+		WouldDelete: pl.wouldDelete,
+	}, nil
+}
+
+// fetchGot assembles the "got" graph from the Cloud.
+func (pl *planner) fetchGot(ctx context.Context) error {
 	// Assemble the "got" graph. This will get the current state of any
 	// resources and also enumerate any resouces that are currently linked that
 	// are not in the "want" graph.
 	gotBuilder := pl.want.NewBuilderWithEmptyNodes()
 
+	// Fetch the resources in "want" with batched calls rather than a Get per
+	// Node. Resources discovered during the traversal are fetched
+	// individually.
+	cl, err := rnode.Prefetch(ctx, pl.cloud, gotBuilder.All())
+	if err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, err)
+	}
+
 	// Fetch the current resource graph from Cloud.
 	// TODO: resource_prefix, ownership due to prefix etc.
-	err := trclosure.Do(ctx, pl.cloud, gotBuilder,
+	err = trclosure.Do(ctx, cl, gotBuilder,
 		trclosure.OnGetFunc(func(n rnode.Builder) error {
+			if pl.external[n.ID().MapKey()] {
+				n.SetOwnership(rnode.OwnershipExternal)
+				return nil
+			}
 			n.SetOwnership(rnode.OwnershipManaged)
 			return nil
 		}),
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	pl.got, err = gotBuilder.Build()
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		return fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	return nil
+}
+
+// planGraph fetches the "got" graph from Cloud (if it was not given) and sets
+// the Plan for each Node in "want".
+func (pl *planner) planGraph(ctx context.Context) error {
+	if pl.got == nil {
+		if err := pl.fetchGot(ctx); err != nil {
+			return err
+		}
 	}
 
 	// Figure out what to do with Nodes in "got" that aren't in "want". These
@@ -84,46 +301,55 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 		case pl.want.Get(gotNode.ID()) != nil:
 			// Node exists in "want", don't need to do anything.
 		case gotNode.Ownership() == rnode.OwnershipExternal:
-			// TODO: clone the node from the "got" graph for "want" unchanged.
+			// Clone the node from the "got" graph for "want" unchanged.
+			nb, err := cloneBuilder(gotNode)
+			if err != nil {
+				return err
+			}
+			wantNode, err := nb.Build()
+			if err != nil {
+				return err
+			}
+			if err := pl.want.AddExternal(wantNode); err != nil {
+				return err
+			}
 		case gotNode.Ownership() == rnode.OwnershipManaged:
 			// Nodes that are no longer referenced should be deleted.
 			wantNodeBuilder := gotNode.Builder()
 			wantNodeBuilder.SetState(rnode.NodeDoesNotExist)
 			wantNode, err := wantNodeBuilder.Build()
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = pl.want.AddTombstone(wantNode)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		default:
-			return nil, fmt.Errorf("%s: node %s has invalid ownership %s", errPrefix, gotNode.ID(), gotNode.Ownership())
+			return fmt.Errorf("%s: node %s has invalid ownership %s", errPrefix, gotNode.ID(), gotNode.Ownership())
 		}
 	}
 
 	// Compute the local plan for each resource.
-	if err := localplan.PlanWantGraph(pl.got, pl.want); err != nil {
-		return nil, err
+	if err := localplan.PlanWantGraph(pl.got, pl.want, localplan.ParallelOption(pl.workers)); err != nil {
+		return err
 	}
 
 	if err := pl.propagateRecreates(); err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := pl.sanityCheck(); err != nil {
-		return nil, err
+	if pl.noDelete {
+		if err := pl.suppressDeletes(); err != nil {
+			return err
+		}
 	}
 
-	acts, err := actions.Do(pl.got, pl.want)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	if err := pl.checkDeletionProtection(); err != nil {
+		return err
 	}
-	return &Result{
-		Got:     pl.got,
-		Want:    pl.want,
-		Actions: acts,
-	}, nil
+
+	return pl.sanityCheck()
 }
 
 // propagateRecreates through inbound references. If a resource needs to be
@@ -171,6 +397,81 @@ func (pl *planner) propagateRecreates() error {
 	return nil
 }
 
+// suppressDeletes changes OpDelete to OpNothing for NoDeleteOption. Returns an
+// error if any Node would be recreated.
+func (pl *planner) suppressDeletes() error {
+	var recreates []string
+	for _, n := range pl.want.All() {
+		switch n.Plan().Op() {
+		case rnode.OpDelete:
+			details := n.Plan().Details()
+			n.Plan().Set(rnode.PlanDetails{
+				Operation: rnode.OpNothing,
+				Why:       fmt.Sprintf("NoDeleteOption: would %s (%s)", details.Operation, details.Why),
+				Diff:      details.Diff,
+			})
+			pl.wouldDelete = append(pl.wouldDelete, n)
+		case rnode.OpRecreate:
+			recreates = append(recreates, n.ID().String())
+		}
+	}
+	if len(recreates) == 0 {
+		return nil
+	}
+	sort.Strings(recreates)
+	return fmt.Errorf("%s: NoDeleteOption: resources need to be recreated: %s", errPrefix, strings.Join(recreates, ", "))
+}
+
+// checkDeletionProtection returns an error if a Node that is DeletionProtected
+// (or protected by DeletionProtectedOption) is planned to be deleted.
+// Recreating a resource also deletes it.
+func (pl *planner) checkDeletionProtection() error {
+	for _, n := range pl.want.All() {
+		if !n.DeletionProtected() && !pl.protected[n.ID().MapKey()] {
+			continue
+		}
+		switch n.Plan().Op() {
+		case rnode.OpDelete, rnode.OpRecreate:
+			return fmt.Errorf("%s: node %v is deletion protected but is planned for %s (%s)", errPrefix, n.ID(), n.Plan().Op(), n.Plan().Details().Why)
+		}
+	}
+	return nil
+}
+
+// checkMaxActions returns an error if the number of mutating Actions exceeds
+// the limit set by MaxActionsOption.
+func (pl *planner) checkMaxActions(acts []exec.Action) error {
+	if pl.maxActions == nil {
+		return nil
+	}
+	counts := map[exec.ActionType]int{}
+	var total int
+	for _, a := range acts {
+		md := a.Metadata()
+		if md == nil || md.Type == exec.ActionTypeMeta {
+			continue
+		}
+		counts[md.Type]++
+		total++
+	}
+	if total <= *pl.maxActions {
+		return nil
+	}
+	var summary []string
+	for _, t := range []exec.ActionType{
+		exec.ActionTypeCreate,
+		exec.ActionTypeUpdate,
+		exec.ActionTypePatch,
+		exec.ActionTypeCustom,
+		exec.ActionTypeDelete,
+	} {
+		if counts[t] > 0 {
+			summary = append(summary, fmt.Sprintf("%s: %d", t, counts[t]))
+		}
+	}
+	return fmt.Errorf("%s: %d mutating operations exceeds the limit of %d (%s)", errPrefix, total, *pl.maxActions, strings.Join(summary, ", "))
+}
+
 func (pl *planner) sanityCheck() error {
 	for _, n := range pl.want.All() {
 		switch n.Plan().Op() {
@@ -18,10 +18,15 @@ package plan
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	cloudmock "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/graphviz"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
@@ -31,10 +36,15 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroupmanager"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancetemplate"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/networkservices/v1"
 )
 
 func TestLB(t *testing.T) {
@@ -120,7 +130,9 @@ func TestLB(t *testing.T) {
 		UrlMap: b.N("umx").UrlMap().SelfLink(),
 	})
 
-	mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("umx"), &compute.UrlMap{})
+	mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("umx"), &compute.UrlMap{
+		DefaultService: b.N("bs").BackendService().SelfLink(),
+	})
 
 	want, err := gr.Build()
 	if err != nil {
@@ -150,3 +162,1585 @@ func TestLB(t *testing.T) {
 	t.Logf("got: %s", graphviz.Do(res.Got))
 	t.Logf("want: %s", graphviz.Do(res.Want))
 }
+
+func TestDryRunCalls(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("addr").Address().Build(func(x *compute.Address) {
+		x.Description = "addr-desc"
+	}))
+	gr.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {
+		x.CheckIntervalSec = 5
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	res, err := Do(context.Background(), mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions, exec.DryRunOption(true))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	execResult, err := ex.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	gotCalls := map[string]exec.DryRunCall{}
+	for _, c := range execResult.DryRunCalls {
+		gotCalls[c.ResourceID.String()] = c
+	}
+	for _, wantCall := range []exec.DryRunCall{
+		{
+			Method:     "Insert",
+			ResourceID: b.N("addr").Address().ID(),
+			Body:       `{"description":"addr-desc","name":"addr"}`,
+		},
+		{
+			Method:     "Insert",
+			ResourceID: b.N("hc").HealthCheck().ID(),
+			Body:       `{"checkIntervalSec":5,"name":"hc"}`,
+		},
+	} {
+		gotCall, ok := gotCalls[wantCall.ResourceID.String()]
+		if !ok {
+			t.Errorf("missing DryRunCall for %v (got %v)", wantCall.ResourceID, execResult.DryRunCalls)
+			continue
+		}
+		if diff := cmp.Diff(gotCall, wantCall); diff != "" {
+			t.Errorf("DryRunCall for %v: -got,+want: %s", wantCall.ResourceID, diff)
+		}
+	}
+	if len(execResult.DryRunCalls) != 2 {
+		t.Errorf("len(DryRunCalls) = %d, want 2 (got %v)", len(execResult.DryRunCalls), execResult.DryRunCalls)
+	}
+
+	// Nothing should have been created.
+	if _, err := mock.GlobalAddresses().Get(context.Background(), meta.GlobalKey("addr")); err == nil {
+		t.Errorf("Address was created in dry run mode")
+	}
+}
+
+func TestRecreateOrdering(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+	trID := b.N("tr").TcpRoute().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+		Name:                "bs",
+		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+	})
+	newTcpRoute := func(x *networkservices.TcpRoute) {
+		x.Rules = []*networkservices.TcpRouteRouteRule{{
+			Action: &networkservices.TcpRouteRouteAction{
+				Destinations: []*networkservices.TcpRouteRouteDestination{
+					{ServiceName: b.N("bs").BackendService().SelfLink()},
+				},
+			},
+		}}
+	}
+	tr := &networkservices.TcpRoute{Name: "tr"}
+	newTcpRoute(tr)
+	mock.TcpRoutes().Insert(ctx, trID.Key, tr)
+
+	// The BackendService must not be deleted while the TcpRoute still
+	// references it.
+	mock.MockBackendServices.DeleteHook = func(ctx context.Context, key *meta.Key, _ *cloud.MockBackendServices, _ ...cloud.Option) (bool, error) {
+		if _, err := mock.TcpRoutes().Get(ctx, trID.Key); err == nil {
+			t.Errorf("BackendService %v deleted while TcpRoute %v references it", key, trID)
+		}
+		return false, nil
+	}
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		// LoadBalancingScheme cannot be changed in place.
+		x.LoadBalancingScheme = "INTERNAL_MANAGED"
+	}))
+	gr.Add(b.N("tr").TcpRoute().Build(newTcpRoute))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	for _, id := range []*cloud.ResourceID{bsID, trID} {
+		if op := res.Want.Get(id).Plan().Op(); op != rnode.OpRecreate {
+			t.Fatalf("Plan().Op() for %v = %s, want %s", id, op, rnode.OpRecreate)
+		}
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	execResult, err := ex.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	var got []string
+	for _, a := range execResult.Completed {
+		got = append(got, a.Metadata().Name)
+	}
+	wantOrder := []string{
+		fmt.Sprintf("GenericDeleteAction(%s)", trID.NodeID()),
+		fmt.Sprintf("GenericDeleteAction(%s)", bsID.NodeID()),
+		fmt.Sprintf("GenericCreateAction(%s)", bsID.NodeID()),
+		fmt.Sprintf("GenericCreateAction(%s)", trID.NodeID()),
+	}
+	if diff := cmp.Diff(got, wantOrder); diff != "" {
+		t.Errorf("Completed actions: -got,+want: %s", diff)
+	}
+
+	bs, err := mock.BackendServices().Get(ctx, bsID.Key)
+	if err != nil {
+		t.Fatalf("BackendServices().Get() = %v, want nil", err)
+	}
+	if bs.LoadBalancingScheme != "INTERNAL_MANAGED" {
+		t.Errorf("LoadBalancingScheme = %q, want INTERNAL_MANAGED", bs.LoadBalancingScheme)
+	}
+}
+
+func TestRecordingCloudCreatePlan(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {
+		x.CheckIntervalSec = 5
+	}))
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	rc := cloud.NewRecordingCloud(mock)
+	res, err := Do(ctx, rc, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	ex, err := exec.NewSerialExecutor(rc, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if _, err := ex.Run(ctx); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	// Planning fetches the current state of each resource. The order of
+	// the Gets is not significant.
+	var gotGets []string
+	for _, c := range rc.Filter(func(c cloud.RecordedCall) bool { return c.Method == "Get" }) {
+		gotGets = append(gotGets, c.String())
+	}
+	sort.Strings(gotGets)
+	wantGets := []string{
+		`ga.BackendServices.Get(Key{"bs"})`,
+		`ga.HealthChecks.Get(Key{"hc"})`,
+	}
+	if diff := cmp.Diff(gotGets, wantGets); diff != "" {
+		t.Errorf("Get calls: -got,+want: %s", diff)
+	}
+
+	// The HealthCheck must be created before the BackendService that
+	// references it.
+	var gotMutations []string
+	for _, c := range rc.Filter(func(c cloud.RecordedCall) bool { return c.Method != "Get" }) {
+		gotMutations = append(gotMutations, c.String())
+	}
+	wantMutations := []string{
+		`ga.HealthChecks.Insert(Key{"hc"})`,
+		`ga.BackendServices.Insert(Key{"bs"})`,
+	}
+	if diff := cmp.Diff(gotMutations, wantMutations); diff != "" {
+		t.Errorf("mutating calls: -got,+want: %s", diff)
+	}
+}
+
+func TestActionPrerequisites(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+	hcID := b.N("hc").HealthCheck().ID()
+	bsID := b.N("bs").BackendService().ID()
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(func(x *compute.HealthCheck) {
+		x.CheckIntervalSec = 5
+	}))
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	res, err := Do(context.Background(), mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	bsCreate := fmt.Sprintf("GenericCreateAction(%s)", bsID.NodeID())
+	var found bool
+	for _, a := range res.Actions {
+		if a.Metadata().Name != bsCreate {
+			continue
+		}
+		found = true
+		got := exec.Prerequisites(a, res.Actions)
+		wantPrereqs := []string{fmt.Sprintf("GenericCreateAction(%s)", hcID.NodeID())}
+		if diff := cmp.Diff(got, wantPrereqs); diff != "" {
+			t.Errorf("Prerequisites(%s): -got,+want: %s", bsCreate, diff)
+		}
+	}
+	if !found {
+		t.Fatalf("%s not in actions %v", bsCreate, res.Actions)
+	}
+}
+
+func TestPlanBatchGet(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(nil))
+	mock.HealthChecks().Insert(ctx, meta.GlobalKey("hc"), &compute.HealthCheck{})
+
+	var bsIDs []*cloud.ResourceID
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("bs%d", i)
+		bsIDs = append(bsIDs, b.N(name).BackendService().ID())
+		gr.Add(b.N(name).BackendService().Build(func(x *compute.BackendService) {
+			x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+		}))
+		mock.BackendServices().Insert(ctx, meta.GlobalKey(name), &compute.BackendService{
+			Name:         name,
+			HealthChecks: []string{b.N("hc").HealthCheck().SelfLink()},
+		})
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	var batches, bsGets int
+	mock.BatchGetHook = func(context.Context, meta.Version, []*cloud.ResourceID, *cloud.MockGCE) (bool, []cloud.BatchGetResult, error) {
+		batches++
+		return false, nil, nil
+	}
+	mock.MockBackendServices.GetHook = func(context.Context, *meta.Key, *cloud.MockBackendServices, ...cloud.Option) (bool, *compute.BackendService, error) {
+		bsGets++
+		return false, nil, nil
+	}
+
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if batches != 1 {
+		t.Errorf("BatchGet calls = %d, want 1", batches)
+	}
+	// The mock BatchGet fetches each resource with the Get of the mock so
+	// the Get hooks still fire.
+	if bsGets != len(bsIDs) {
+		t.Errorf("BackendServices.Get calls = %d, want %d", bsGets, len(bsIDs))
+	}
+	for _, id := range bsIDs {
+		n := res.Got.Get(id)
+		if n == nil {
+			t.Fatalf("Got.Get(%v) = nil, want node", id)
+		}
+		if n.State() != rnode.NodeExists {
+			t.Errorf("Got.Get(%v).State() = %v, want %v", id, n.State(), rnode.NodeExists)
+		}
+	}
+}
+
+func TestDoSubset(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+
+	gr := rgraph.NewBuilder()
+	for _, name := range []string{"hc", "hc2"} {
+		gr.Add(b.N(name).HealthCheck().Build(func(x *compute.HealthCheck) {
+			x.CheckIntervalSec = 5
+		}))
+	}
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	gr.Add(b.N("bs2").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc2").HealthCheck().SelfLink()}
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	bsID := b.N("bs").BackendService().ID()
+	selector := func(n rnode.Node) bool { return n.ID().Equal(bsID) }
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	res, err := DoSubset(context.Background(), mock, want, selector)
+	if err != nil {
+		t.Fatalf("DoSubset() = %v, want nil", err)
+	}
+
+	var gotPlanned []string
+	for _, n := range res.Want.All() {
+		gotPlanned = append(gotPlanned, n.ID().String())
+	}
+	sort.Strings(gotPlanned)
+	wantPlanned := []string{
+		bsID.String(),
+		b.N("hc").HealthCheck().ID().String(),
+	}
+	if diff := cmp.Diff(gotPlanned, wantPlanned); diff != "" {
+		t.Errorf("planned nodes: -got,+want: %s", diff)
+	}
+
+	var gotActions []string
+	for _, a := range res.Actions {
+		gotActions = append(gotActions, a.Metadata().Name)
+	}
+	sort.Strings(gotActions)
+	wantActions := []string{
+		rnode.ActionName("GenericCreateAction", bsID),
+		rnode.ActionName("GenericCreateAction", b.N("hc").HealthCheck().ID()),
+	}
+	if diff := cmp.Diff(gotActions, wantActions); diff != "" {
+		t.Errorf("actions: -got,+want: %s", diff)
+	}
+}
+
+func TestDoSubsetKeepsUnselectedRefs(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	hc2ID := b.N("hc2").HealthCheck().ID()
+
+	// "bs" currently points to "hc2", which is also used by "bs2".
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hc2ID.Key, &compute.HealthCheck{CheckIntervalSec: 5})
+	mock.BackendServices().Insert(ctx, meta.GlobalKey("bs"), &compute.BackendService{
+		HealthChecks: []string{b.N("hc2").HealthCheck().SelfLink()},
+	})
+
+	gr := rgraph.NewBuilder()
+	for _, name := range []string{"hc", "hc2"} {
+		gr.Add(b.N(name).HealthCheck().Build(func(x *compute.HealthCheck) {
+			x.CheckIntervalSec = 5
+		}))
+	}
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	gr.Add(b.N("bs2").BackendService().Build(func(x *compute.BackendService) {
+		x.HealthChecks = []string{b.N("hc2").HealthCheck().SelfLink()}
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	bsID := b.N("bs").BackendService().ID()
+	res, err := DoSubset(ctx, mock, want, func(n rnode.Node) bool { return n.ID().Equal(bsID) })
+	if err != nil {
+		t.Fatalf("DoSubset() = %v, want nil", err)
+	}
+	if n := res.Want.Get(hc2ID); n == nil || n.Ownership() != rnode.OwnershipExternal {
+		t.Errorf("Want.Get(%v) = %v, want node with ownership %s", hc2ID, n, rnode.OwnershipExternal)
+	}
+	for _, a := range res.Actions {
+		if a.Metadata().Name == rnode.ActionName("GenericDeleteAction", hc2ID) {
+			t.Errorf("got action %s, want %v to be unchanged", a.Metadata().Name, hc2ID)
+		}
+	}
+}
+
+func TestDeletionProtection(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		protected bool
+		wantErr   bool
+	}{
+		{name: "not protected"},
+		{name: "protected", protected: true, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			b := all.ResourceBuilder{Project: "proj"}
+			bsID := b.N("bs").BackendService().ID()
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{Name: bsID.Key.Name})
+
+			bsb := backendservice.NewBuilder(bsID)
+			bsb.SetOwnership(rnode.OwnershipManaged)
+			bsb.SetState(rnode.NodeDoesNotExist)
+			bsb.SetDeletionProtected(tc.protected)
+
+			gr := rgraph.NewBuilder()
+			gr.Add(bsb)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			_, err = Do(ctx, mock, want)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), bsID.String()) {
+				t.Errorf("Do() = %v, want error naming %v", err, bsID)
+			}
+		})
+	}
+}
+
+func TestDeletionProtectedOption(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+	hcOldID := b.N("hc-old").HealthCheck().ID()
+
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "not protected"},
+		{name: "other resource protected", opts: []Option{DeletionProtectedOption(bsID)}},
+		{name: "protected", opts: []Option{DeletionProtectedOption(hcOldID)}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			mock.HealthChecks().Insert(ctx, hcOldID.Key, &compute.HealthCheck{Name: hcOldID.Key.Name})
+			mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+				Name:         bsID.Key.Name,
+				HealthChecks: []string{b.N("hc-old").HealthCheck().SelfLink()},
+			})
+
+			// hc-old is only in the Cloud (got); it is no longer referenced
+			// and will be planned for delete.
+			gr := rgraph.NewBuilder()
+			gr.Add(b.N("hc-new").HealthCheck().Build(nil))
+			gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+				x.HealthChecks = []string{b.N("hc-new").HealthCheck().SelfLink()}
+			}))
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(ctx, mock, want, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), hcOldID.String()) {
+					t.Errorf("Do() = %v, want error naming %v", err, hcOldID)
+				}
+				return
+			}
+			if op := res.Want.Get(hcOldID).Plan().Op(); op != rnode.OpDelete {
+				t.Errorf("Plan().Op() for %v = %s, want %s", hcOldID, op, rnode.OpDelete)
+			}
+		})
+	}
+}
+
+func TestLogLines(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	hcUpdateID := b.N("hc-update").HealthCheck().ID()
+	bsDeleteID := b.N("bs-delete").BackendService().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hcUpdateID.Key, &compute.HealthCheck{
+		Name:             hcUpdateID.Key.Name,
+		CheckIntervalSec: 5,
+	})
+	mock.BackendServices().Insert(ctx, bsDeleteID.Key, &compute.BackendService{Name: bsDeleteID.Key.Name})
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc-create").HealthCheck().Build(nil))
+	gr.Add(b.N("hc-update").HealthCheck().Build(func(x *compute.HealthCheck) {
+		x.CheckIntervalSec = 10
+	}))
+	bsb := backendservice.NewBuilder(bsDeleteID)
+	bsb.SetOwnership(rnode.OwnershipManaged)
+	bsb.SetState(rnode.NodeDoesNotExist)
+	gr.Add(bsb)
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	wantLines := []string{
+		"DELETE compute/backendServices:proj/global/bs-delete: Node doesn't exist in want, but exists in got",
+		"CREATE compute/healthChecks:proj/global/hc-create: Node doesn't exist in got, but exists in want",
+		"UPDATE compute/healthChecks:proj/global/hc-update: CheckIntervalSec 5->10",
+	}
+	if diff := cmp.Diff(res.LogLines(), wantLines); diff != "" {
+		t.Errorf("LogLines(): -got,+want: %s", diff)
+	}
+	wantPlanLine := PlanLine{
+		ID:        hcUpdateID.NodeID(),
+		Operation: rnode.OpUpdate,
+		Details:   "CheckIntervalSec 5->10",
+	}
+	if planLines := res.PlanLines(); len(planLines) != 3 || planLines[2] != wantPlanLine {
+		t.Errorf("PlanLines() = %+v, want [2] = %+v", planLines, wantPlanLine)
+	}
+}
+
+func TestUnresolvedOutRefs(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	hcID := b.N("hc").HealthCheck().ID()
+
+	newGraph := func(external bool) (*rgraph.Graph, error) {
+		gr := rgraph.NewBuilder()
+		gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+			x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+		}))
+		if external {
+			hcb := healthcheck.NewBuilder(hcID)
+			hcb.SetOwnership(rnode.OwnershipExternal)
+			gr.Add(hcb)
+		}
+		return gr.Build()
+	}
+
+	// The HealthCheck is neither in the graph nor external.
+	_, err := newGraph(false)
+	if err == nil {
+		t.Fatalf("Build() = nil, want error")
+	}
+	for _, want := range []string{b.N("bs").BackendService().ID().String(), hcID.String(), "OwnershipExternal"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() = %v, want error containing %q", err, want)
+		}
+	}
+
+	// The HealthCheck is external and will be fetched from the Cloud.
+	want, err := newGraph(true)
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hcID.Key, &compute.HealthCheck{Name: hcID.Key.Name})
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if op := res.Want.Get(hcID).Plan().Op(); op != rnode.OpNothing {
+		t.Errorf("Plan().Op() for %v = %s, want %s", hcID, op, rnode.OpNothing)
+	}
+}
+
+func TestDeleteUnreferencedHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+	hcID := b.N("hc").HealthCheck().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hcID.Key, &compute.HealthCheck{Name: hcID.Key.Name})
+	mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+		Name:         bsID.Key.Name,
+		HealthChecks: []string{b.N("hc").HealthCheck().SelfLink()},
+	})
+	mock.MockBackendServices.UpdateHook = cloudmock.UpdateBackendServiceHook
+	// The HealthCheck must not be deleted while the BackendService still
+	// references it.
+	mock.MockHealthChecks.DeleteHook = func(ctx context.Context, key *meta.Key, _ *cloud.MockHealthChecks, _ ...cloud.Option) (bool, error) {
+		bs, err := mock.BackendServices().Get(ctx, bsID.Key)
+		if err == nil && len(bs.HealthChecks) > 0 {
+			t.Errorf("HealthCheck %v deleted while BackendService %v references it", key, bsID)
+		}
+		return false, nil
+	}
+
+	// The BackendService no longer references the HealthCheck.
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("bs").BackendService().Build(nil))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	hcNode := res.Want.Get(hcID)
+	if hcNode == nil {
+		t.Fatalf("Want.Get(%v) = nil, want tombstone", hcID)
+	}
+	if op := hcNode.Plan().Op(); op != rnode.OpDelete {
+		t.Errorf("Plan().Op() for %v = %s, want %s", hcID, op, rnode.OpDelete)
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if _, err := ex.Run(ctx); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if _, err := mock.HealthChecks().Get(ctx, hcID.Key); err == nil {
+		t.Errorf("HealthChecks().Get(%v) = nil, want NotFound", hcID)
+	}
+}
+
+func TestNoDeleteOption(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	oldID := b.N("old").BackendService().ID()
+	umID := b.N("um").UrlMap().ID()
+
+	for _, tc := range []struct {
+		name            string
+		opts            []Option
+		recreateUrlMap  bool
+		wantErr         bool
+		wantOldOp       rnode.Operation
+		wantDelete      bool
+		wantWouldDelete []string
+	}{
+		{
+			name:       "default",
+			wantOldOp:  rnode.OpDelete,
+			wantDelete: true,
+		},
+		{
+			// The UrlMap is patched in place.
+			name:            "NoDeleteOption",
+			opts:            []Option{NoDeleteOption()},
+			wantOldOp:       rnode.OpNothing,
+			wantWouldDelete: []string{oldID.String()},
+		},
+		{
+			// A recreate cannot be suppressed without dropping the change.
+			name:           "NoDeleteOption with recreate",
+			opts:           []Option{NoDeleteOption()},
+			recreateUrlMap: true,
+			wantErr:        true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			mock.BackendServices().Insert(ctx, oldID.Key, &compute.BackendService{Name: oldID.Key.Name})
+			mock.UrlMaps().Insert(ctx, umID.Key, &compute.UrlMap{
+				Name:           umID.Key.Name,
+				DefaultService: b.N("old").BackendService().SelfLink(),
+			})
+
+			// The UrlMap is changed to point to a new BackendService, so the
+			// old one is no longer referenced.
+			gr := rgraph.NewBuilder()
+			gr.Add(b.N("um").UrlMap().Build(func(x *compute.UrlMap) {
+				x.DefaultService = b.N("new").BackendService().SelfLink()
+			}))
+			gr.Add(b.N("new").BackendService().Build(nil))
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+			want.Get(umID).SetForceRecreate(tc.recreateUrlMap)
+
+			res, err := Do(ctx, mock, want, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if tc.wantErr {
+				if !strings.Contains(err.Error(), umID.String()) {
+					t.Errorf("Do() = %v, want error naming %v", err, umID)
+				}
+				return
+			}
+			if op := res.Want.Get(oldID).Plan().Op(); op != tc.wantOldOp {
+				t.Errorf("Plan().Op() for %v = %s, want %s", oldID, op, tc.wantOldOp)
+			}
+			var gotDelete bool
+			for _, a := range res.Actions {
+				if a.Metadata().Type == exec.ActionTypeDelete {
+					gotDelete = true
+				}
+			}
+			if gotDelete != tc.wantDelete {
+				t.Errorf("delete Action in plan = %t, want %t", gotDelete, tc.wantDelete)
+			}
+			var gotWouldDelete []string
+			for _, n := range res.WouldDelete {
+				gotWouldDelete = append(gotWouldDelete, n.ID().String())
+			}
+			sort.Strings(gotWouldDelete)
+			if diff := cmp.Diff(gotWouldDelete, tc.wantWouldDelete); diff != "" {
+				t.Errorf("WouldDelete: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestForceRecreate(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+
+	for _, tc := range []struct {
+		name        string
+		force       bool
+		wantOp      rnode.Operation
+		wantActions []string
+	}{
+		{
+			name:   "unchanged",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "unchanged with ForceRecreate",
+			force:  true,
+			wantOp: rnode.OpRecreate,
+			wantActions: []string{
+				rnode.ActionName("GenericCreateAction", bsID),
+				rnode.ActionName("GenericDeleteAction", bsID),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bsb := b.N("bs").BackendService().Build(nil)
+			bs, _ := bsb.Resource().(backendservice.BackendService).ToGA()
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			mock.BackendServices().Insert(ctx, bsID.Key, bs)
+
+			gr := rgraph.NewBuilder()
+			gr.Add(bsb)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+			want.Get(bsID).SetForceRecreate(tc.force)
+
+			res, err := Do(ctx, mock, want)
+			if err != nil {
+				t.Fatalf("Do() = %v, want nil", err)
+			}
+			if op := res.Want.Get(bsID).Plan().Op(); op != tc.wantOp {
+				t.Errorf("Plan().Op() for %v = %s, want %s", bsID, op, tc.wantOp)
+			}
+			var gotActions []string
+			for _, a := range res.Actions {
+				if a.Metadata().Type == exec.ActionTypeMeta {
+					continue
+				}
+				gotActions = append(gotActions, a.Metadata().Name)
+			}
+			sort.Strings(gotActions)
+			if diff := cmp.Diff(gotActions, tc.wantActions); diff != "" {
+				t.Errorf("Actions: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestExportImportJSON(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+	hcID := b.N("hc").HealthCheck().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hcID.Key, &compute.HealthCheck{Name: "hc"})
+	mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+		Name:                "bs",
+		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+		HealthChecks:        []string{b.N("hc").HealthCheck().SelfLink()},
+	})
+
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(nil))
+	bsb := b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+		x.Description = "changed"
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+		x.ForceSendFields = []string{"EnableCDN"}
+	})
+	if err := backendservice.SetPreferPatch(bsb, true); err != nil {
+		t.Fatalf("SetPreferPatch() = %v, want nil", err)
+	}
+	gr.Add(bsb)
+	gr.Add(b.N("tr").TcpRoute().Build(func(x *networkservices.TcpRoute) {
+		x.Rules = []*networkservices.TcpRouteRouteRule{{
+			Action: &networkservices.TcpRouteRouteAction{
+				Destinations: []*networkservices.TcpRouteRouteDestination{
+					{ServiceName: b.N("bs").BackendService().SelfLink()},
+				},
+			},
+		}}
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	want.Get(hcID).SetForceRecreate(true)
+	want.Get(bsID).SetAllowRecreate(true)
+
+	data, err := want.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON() = %v, want nil", err)
+	}
+	gr2, err := rgraph.ImportJSON(data)
+	if err != nil {
+		t.Fatalf("ImportJSON() = %v, want nil", err)
+	}
+	want2, err := gr2.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil (imported graph)", err)
+	}
+	data2, err := want2.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON() = %v, want nil (imported graph)", err)
+	}
+	if diff := cmp.Diff(string(data2), string(data)); diff != "" {
+		t.Errorf("ExportJSON(ImportJSON()): diff -got,+want: %s", diff)
+	}
+	if !strings.Contains(string(data), `"preferPatch": true`) {
+		t.Errorf("ExportJSON() = %s, want the BackendService preferPatch option", data)
+	}
+
+	actions := func(g *rgraph.Graph) []string {
+		t.Helper()
+		res, err := Do(ctx, mock, g)
+		if err != nil {
+			t.Fatalf("Do() = %v, want nil", err)
+		}
+		var ret []string
+		for _, a := range res.Actions {
+			ret = append(ret, a.Metadata().Name)
+		}
+		for _, n := range res.Want.All() {
+			ret = append(ret, fmt.Sprintf("%s: %s", n.ID(), n.Plan().Op()))
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	got, wantActions := actions(want2), actions(want)
+	if len(wantActions) == 0 {
+		t.Fatal("no actions planned for the original graph")
+	}
+	if diff := cmp.Diff(got, wantActions); diff != "" {
+		t.Errorf("Actions: diff -got,+want: %s", diff)
+	}
+}
+
+func TestExportImportJSONPreferPatch(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+		Name:                "bs",
+		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+	})
+
+	gr := rgraph.NewBuilder()
+	bsb := b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+		x.Description = "changed"
+		x.ForceSendFields = []string{"EnableCDN"}
+	})
+	if err := backendservice.SetPreferPatch(bsb, true); err != nil {
+		t.Fatalf("SetPreferPatch() = %v, want nil", err)
+	}
+	gr.Add(bsb)
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	data, err := want.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON() = %v, want nil", err)
+	}
+	gr2, err := rgraph.ImportJSON(data)
+	if err != nil {
+		t.Fatalf("ImportJSON() = %v, want nil", err)
+	}
+	want2, err := gr2.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil (imported graph)", err)
+	}
+
+	// The imported graph must still prefer Patch.
+	for _, g := range []*rgraph.Graph{want, want2} {
+		res, err := Do(ctx, mock, g)
+		if err != nil {
+			t.Fatalf("Do() = %v, want nil", err)
+		}
+		var names []string
+		for _, a := range res.Actions {
+			names = append(names, a.Metadata().Name)
+		}
+		if diff := cmp.Diff(names, []string{"GenericPatchAction(compute/backendServices:proj/global/bs)"}); diff != "" {
+			t.Errorf("Actions: diff -got,+want: %s", diff)
+		}
+	}
+}
+
+func TestMaxActionsOption(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		force   bool
+		wantErr string
+	}{
+		{name: "no limit"},
+		{
+			name: "under limit",
+			opts: []Option{MaxActionsOption(6)},
+		},
+		{
+			name:    "over limit",
+			opts:    []Option{MaxActionsOption(3)},
+			wantErr: "6 mutating operations exceeds the limit of 3 (Update: 1, Delete: 5)",
+		},
+		{
+			// The recreate is a delete and a create.
+			name:    "recreate over limit",
+			opts:    []Option{MaxActionsOption(6)},
+			force:   true,
+			wantErr: "7 mutating operations exceeds the limit of 6 (Create: 1, Delete: 6)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The BackendService references 5 HealthChecks that are removed
+			// in "want" and will be deleted.
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			var hcs []string
+			for i := 0; i < 5; i++ {
+				hc := b.N(fmt.Sprintf("hc%d", i)).HealthCheck()
+				mock.HealthChecks().Insert(ctx, hc.ID().Key, &compute.HealthCheck{Name: hc.ID().Key.Name})
+				hcs = append(hcs, hc.SelfLink())
+			}
+			mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+				Name:         bsID.Key.Name,
+				HealthChecks: hcs,
+			})
+
+			gr := rgraph.NewBuilder()
+			gr.Add(b.N("bs").BackendService().Build(nil))
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+			want.Get(bsID).SetForceRecreate(tc.force)
+
+			_, err = Do(ctx, mock, want, tc.opts...)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Do() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Do() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSslCertificateRotation(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	oldID := b.N("cert-a").SslCertificate().ID()
+	newID := b.N("cert-b").SslCertificate().ID()
+	tpID := b.N("tp").TargetHttpsProxy().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.SslCertificates().Insert(ctx, oldID.Key, &compute.SslCertificate{Name: "cert-a", Certificate: "A"})
+	mock.TargetHttpsProxies().Insert(ctx, tpID.Key, &compute.TargetHttpsProxy{
+		Name:            "tp",
+		SslCertificates: []string{b.N("cert-a").SslCertificate().SelfLink()},
+	})
+	mock.MockTargetHttpsProxies.SetSslCertificatesHook = cloudmock.SetSslCertificateTargetHTTPSProxyHook
+	// The old certificate must not be deleted while the proxy still
+	// references it.
+	mock.MockSslCertificates.DeleteHook = func(ctx context.Context, key *meta.Key, _ *cloud.MockSslCertificates, _ ...cloud.Option) (bool, error) {
+		tp, err := mock.TargetHttpsProxies().Get(ctx, tpID.Key)
+		if err != nil {
+			t.Errorf("TargetHttpsProxies().Get() = %v, want nil", err)
+			return false, nil
+		}
+		for _, url := range tp.SslCertificates {
+			if id, _ := cloud.ParseResourceURL(url); id != nil && id.Key.Name == key.Name {
+				t.Errorf("SslCertificate %v deleted while %v references it", key, tpID)
+			}
+		}
+		return false, nil
+	}
+
+	// Replace cert-a with cert-b on the proxy.
+	tp := b.N("tp").TargetHttpsProxy().Resource()
+	tp.Access(func(x *compute.TargetHttpsProxy) {
+		x.SslCertificates = []string{b.N("cert-a").SslCertificate().SelfLink()}
+	})
+	if err := targethttpsproxy.ReplaceSslCertificate(tp, oldID, newID); err != nil {
+		t.Fatalf("ReplaceSslCertificate() = %v, want nil", err)
+	}
+	tpRes, err := tp.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	tpb := targethttpsproxy.NewBuilderWithResource(tpRes)
+	tpb.SetOwnership(rnode.OwnershipManaged)
+	tpb.SetState(rnode.NodeExists)
+
+	gr := rgraph.NewBuilder()
+	gr.Add(tpb)
+	gr.Add(b.N("cert-b").SslCertificate().Build(func(x *compute.SslCertificate) {
+		x.Certificate = "B"
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	for id, wantOp := range map[*cloud.ResourceID]rnode.Operation{
+		oldID: rnode.OpDelete,
+		newID: rnode.OpCreate,
+		tpID:  rnode.OpUpdate,
+	} {
+		if op := res.Want.Get(id).Plan().Op(); op != wantOp {
+			t.Errorf("Plan().Op() for %v = %s, want %s", id, op, wantOp)
+		}
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	execResult, err := ex.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	var got []string
+	for _, a := range execResult.Completed {
+		if a.Metadata().Type == exec.ActionTypeMeta {
+			continue
+		}
+		got = append(got, a.Metadata().Name)
+	}
+	wantOrder := []string{
+		rnode.ActionName("GenericCreateAction", newID),
+		rnode.ActionName("TargetHttpsProxySetSslCertificatesAction", tpID),
+		rnode.ActionName("GenericDeleteAction", oldID),
+	}
+	if diff := cmp.Diff(got, wantOrder); diff != "" {
+		t.Errorf("Completed actions: -got,+want: %s", diff)
+	}
+
+	gotTp, err := mock.TargetHttpsProxies().Get(ctx, tpID.Key)
+	if err != nil {
+		t.Fatalf("TargetHttpsProxies().Get() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(gotTp.SslCertificates, []string{b.N("cert-b").SslCertificate().SelfLink()}); diff != "" {
+		t.Errorf("SslCertificates: -got,+want: %s", diff)
+	}
+}
+
+func TestDoWithGot(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	bsID := b.N("bs").BackendService().ID()
+	hcID := b.N("hc").HealthCheck().ID()
+	hc2ID := b.N("hc2").HealthCheck().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.HealthChecks().Insert(ctx, hcID.Key, &compute.HealthCheck{Name: "hc"})
+	mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+		Name:                "bs",
+		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+		HealthChecks:        []string{b.N("hc").HealthCheck().SelfLink()},
+	})
+
+	// The BackendService is changed to use a new HealthCheck "hc2". "hc" is
+	// no longer referenced and will be deleted.
+	newWant := func() *rgraph.Graph {
+		t.Helper()
+		gr := rgraph.NewBuilder()
+		gr.Add(b.N("hc2").HealthCheck().Build(nil))
+		gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+			x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+			x.Description = "changed"
+			x.HealthChecks = []string{b.N("hc2").HealthCheck().SelfLink()}
+		}))
+		want, err := gr.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		return want
+	}
+
+	// The synthetic got graph has the same contents as the mock.
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(nil))
+	gr.Add(b.N("bs").BackendService().Build(func(x *compute.BackendService) {
+		x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+		x.HealthChecks = []string{b.N("hc").HealthCheck().SelfLink()}
+	}))
+	hc2, err := rnode.NewBuilderByID(hc2ID)
+	if err != nil {
+		t.Fatalf("NewBuilderByID(%v) = %v, want nil", hc2ID, err)
+	}
+	hc2.SetOwnership(rnode.OwnershipManaged)
+	hc2.SetState(rnode.NodeDoesNotExist)
+	gr.Add(hc2)
+	got, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil (got graph)", err)
+	}
+
+	actions := func(res *Result) []string {
+		var ret []string
+		for _, a := range res.Actions {
+			ret = append(ret, a.Metadata().Name)
+		}
+		for _, n := range res.Want.All() {
+			ret = append(ret, fmt.Sprintf("%s: %s", n.ID(), n.Plan().Op()))
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	liveRes, err := Do(ctx, mock, newWant())
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	res, err := DoWithGot(ctx, got, newWant())
+	if err != nil {
+		t.Fatalf("DoWithGot() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(actions(res), actions(liveRes)); diff != "" {
+		t.Errorf("Actions: diff -got,+want: %s", diff)
+	}
+	if op := res.Want.Get(hcID).Plan().Op(); op != rnode.OpDelete {
+		t.Errorf("Plan().Op() for %v = %s, want %s", hcID, op, rnode.OpDelete)
+	}
+
+	// The got graph from the live run can be saved and replayed.
+	data, err := liveRes.Got.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON() = %v, want nil", err)
+	}
+	gotBuilder, err := rgraph.ImportJSON(data)
+	if err != nil {
+		t.Fatalf("ImportJSON() = %v, want nil", err)
+	}
+	saved, err := gotBuilder.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil (imported got graph)", err)
+	}
+	res, err = DoWithGot(ctx, saved, newWant())
+	if err != nil {
+		t.Fatalf("DoWithGot() = %v, want nil (imported got graph)", err)
+	}
+	if diff := cmp.Diff(actions(res), actions(liveRes)); diff != "" {
+		t.Errorf("Actions (imported got graph): diff -got,+want: %s", diff)
+	}
+
+	// got must have all of the Nodes in want.
+	gr = rgraph.NewBuilder()
+	gr.Add(b.N("hc").HealthCheck().Build(nil))
+	partial, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil (partial got graph)", err)
+	}
+	if _, err := DoWithGot(ctx, partial, newWant()); err == nil {
+		t.Errorf("DoWithGot() = nil, want error (got is missing Nodes in want)")
+	}
+}
+
+func TestDeleteOrder(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+	trID := b.N("tr").TcpRoute().ID()
+	bsID := b.N("bs").BackendService().ID()
+	hcID := b.N("hc").HealthCheck().ID()
+
+	for _, tc := range []struct {
+		name  string
+		newEx func(cloud.Cloud, []exec.Action) (exec.Executor, error)
+	}{
+		{
+			name: "serial",
+			newEx: func(c cloud.Cloud, a []exec.Action) (exec.Executor, error) {
+				return exec.NewSerialExecutor(c, a)
+			},
+		},
+		{
+			name: "parallel",
+			newEx: func(c cloud.Cloud, a []exec.Action) (exec.Executor, error) {
+				return exec.NewParallelExecutor(c, a)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+			mock.HealthChecks().Insert(ctx, hcID.Key, &compute.HealthCheck{Name: "hc"})
+			mock.BackendServices().Insert(ctx, bsID.Key, &compute.BackendService{
+				Name:                "bs",
+				LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
+				HealthChecks:        []string{b.N("hc").HealthCheck().SelfLink()},
+			})
+			mock.TcpRoutes().Insert(ctx, trID.Key, &networkservices.TcpRoute{
+				Name: "tr",
+				Rules: []*networkservices.TcpRouteRouteRule{{
+					Action: &networkservices.TcpRouteRouteAction{
+						Destinations: []*networkservices.TcpRouteRouteDestination{
+							{ServiceName: b.N("bs").BackendService().SelfLink()},
+						},
+					},
+				}},
+			})
+
+			var (
+				lock    sync.Mutex
+				deleted []string
+			)
+			record := func(name string) {
+				lock.Lock()
+				defer lock.Unlock()
+				deleted = append(deleted, name)
+			}
+			mock.MockTcpRoutes.DeleteHook = func(context.Context, *meta.Key, *cloud.MockTcpRoutes, ...cloud.Option) (bool, error) {
+				record("tr")
+				return false, nil
+			}
+			mock.MockBackendServices.DeleteHook = func(context.Context, *meta.Key, *cloud.MockBackendServices, ...cloud.Option) (bool, error) {
+				record("bs")
+				return false, nil
+			}
+			mock.MockHealthChecks.DeleteHook = func(context.Context, *meta.Key, *cloud.MockHealthChecks, ...cloud.Option) (bool, error) {
+				record("hc")
+				return false, nil
+			}
+
+			// All of the resources in the graph are to be deleted.
+			gr := rgraph.NewBuilder()
+			for _, id := range []*cloud.ResourceID{trID, bsID, hcID} {
+				nb, err := rnode.NewBuilderByID(id)
+				if err != nil {
+					t.Fatalf("NewBuilderByID(%v) = %v, want nil", id, err)
+				}
+				nb.SetOwnership(rnode.OwnershipManaged)
+				nb.SetState(rnode.NodeDoesNotExist)
+				gr.Add(nb)
+			}
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(ctx, mock, want)
+			if err != nil {
+				t.Fatalf("Do() = %v, want nil", err)
+			}
+			for _, id := range []*cloud.ResourceID{trID, bsID, hcID} {
+				if op := res.Want.Get(id).Plan().Op(); op != rnode.OpDelete {
+					t.Errorf("Plan().Op() for %v = %s, want %s", id, op, rnode.OpDelete)
+				}
+			}
+
+			ex, err := tc.newEx(mock, res.Actions)
+			if err != nil {
+				t.Fatalf("new executor = %v, want nil", err)
+			}
+			if _, err := ex.Run(ctx); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(deleted, []string{"tr", "bs", "hc"}); diff != "" {
+				t.Errorf("delete order: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetPoolMembership(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	tpID := b.N("tp").DefaultRegion().TargetPool().ID()
+	inst := func(name string) *all.InstanceBuilder { return b.N(name).DefaultZone().Instance() }
+	instURL := func(name string) string { return inst(name).SelfLink() }
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.MockTargetPools.AddInstanceHook = cloudmock.AddInstanceHook
+	mock.MockTargetPools.RemoveInstanceHook = cloudmock.RemoveInstanceHook
+	for _, name := range []string{"inst1", "inst2"} {
+		mock.Instances().Insert(ctx, inst(name).ID().Key, &compute.Instance{Name: name})
+	}
+	mock.TargetPools().Insert(ctx, tpID.Key, &compute.TargetPool{
+		Name:      tpID.Key.Name,
+		Instances: []string{instURL("inst1")},
+	})
+
+	// Instances are owned by something else (e.g. an InstanceGroupManager)
+	// and are in the graph as external nodes. inst1 must be included as well,
+	// otherwise it would be fetched as a managed resource and deleted once it
+	// is no longer referenced.
+	gr := rgraph.NewBuilder()
+	gr.Add(b.N("tp").DefaultRegion().TargetPool().Build(func(x *compute.TargetPool) {
+		x.Instances = []string{instURL("inst2")}
+	}))
+	for _, name := range []string{"inst1", "inst2"} {
+		ib := inst(name).Build(nil)
+		ib.SetOwnership(rnode.OwnershipExternal)
+		gr.Add(ib)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if op := res.Want.Get(tpID).Plan().Op(); op != rnode.OpUpdate {
+		t.Fatalf("Plan().Op() = %s, want %s", op, rnode.OpUpdate)
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	execResult, err := ex.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil (result: %+v)", err, execResult)
+	}
+	if len(execResult.Pending) != 0 {
+		t.Errorf("Pending = %v, want none", execResult.Pending)
+	}
+
+	for _, name := range []string{"inst1", "inst2"} {
+		if _, err := mock.Instances().Get(ctx, inst(name).ID().Key); err != nil {
+			t.Errorf("Instances().Get(%s) = %v, want nil", name, err)
+		}
+	}
+
+	tp, err := mock.TargetPools().Get(ctx, tpID.Key)
+	if err != nil {
+		t.Fatalf("TargetPools().Get() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(tp.Instances, []string{instURL("inst2")}); diff != "" {
+		t.Errorf("Instances: -got,+want: %s", diff)
+	}
+}
+
+func TestSecurityPolicyRules(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	spID := b.N("sp").SecurityPolicy().ID()
+	rule := func(priority int64, action string) *compute.SecurityPolicyRule {
+		return &compute.SecurityPolicyRule{
+			Action:   action,
+			Priority: priority,
+			Match: &compute.SecurityPolicyRuleMatcher{
+				VersionedExpr: "SRC_IPS_V1",
+				Config:        &compute.SecurityPolicyRuleMatcherConfig{SrcIpRanges: []string{"*"}},
+			},
+		}
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	mock.MockSecurityPolicies.AddRuleHook = cloudmock.AddSecurityPolicyRuleHook
+	mock.MockSecurityPolicies.PatchRuleHook = cloudmock.PatchSecurityPolicyRuleHook
+	mock.MockSecurityPolicies.RemoveRuleHook = cloudmock.RemoveSecurityPolicyRuleHook
+	// A previous partial run already added the rule at priority 20.
+	mock.SecurityPolicies().Insert(ctx, spID.Key, &compute.SecurityPolicy{
+		Name:  spID.Key.Name,
+		Rules: []*compute.SecurityPolicyRule{rule(20, "deny(403)"), rule(30, "allow"), rule(2147483647, "allow")},
+	})
+
+	wantRules := []*compute.SecurityPolicyRule{rule(10, "deny(404)"), rule(20, "deny(403)"), rule(2147483647, "allow")}
+	buildWant := func() *rgraph.Graph {
+		gr := rgraph.NewBuilder()
+		gr.Add(b.N("sp").SecurityPolicy().Build(func(x *compute.SecurityPolicy) {
+			x.Rules = wantRules
+		}))
+		return gr.MustBuild()
+	}
+
+	res, err := Do(ctx, mock, buildWant())
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if op := res.Want.Get(spID).Plan().Op(); op != rnode.OpUpdate {
+		t.Fatalf("Plan().Op() = %s, want %s", op, rnode.OpUpdate)
+	}
+	var calls []string
+	for _, a := range res.Actions {
+		dc, ok := a.(exec.DryRunCaller)
+		if !ok {
+			continue
+		}
+		cs, err := dc.DryRunCalls()
+		if err != nil {
+			t.Fatalf("DryRunCalls() = %v, want nil", err)
+		}
+		for _, c := range cs {
+			calls = append(calls, c.Method)
+		}
+	}
+	// Only the real delta is applied; the rule at 20 is not added again.
+	sort.Strings(calls)
+	if diff := cmp.Diff(calls, []string{"AddRule", "RemoveRule"}); diff != "" {
+		t.Errorf("calls: -got,+want: %s", diff)
+	}
+
+	ex, err := exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if execResult, err := ex.Run(ctx); err != nil || len(execResult.Pending) != 0 {
+		t.Fatalf("Run() = %+v, %v; want no pending Actions and nil", execResult, err)
+	}
+
+	// Applying the same plan again is idempotent.
+	ex, err = exec.NewSerialExecutor(mock, res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if execResult, err := ex.Run(ctx); err != nil || len(execResult.Pending) != 0 {
+		t.Fatalf("Run() again = %+v, %v; want no pending Actions and nil", execResult, err)
+	}
+
+	sp, err := mock.SecurityPolicies().Get(ctx, spID.Key)
+	if err != nil {
+		t.Fatalf("SecurityPolicies().Get() = %v, want nil", err)
+	}
+	var gotPriorities []int64
+	for _, r := range sp.Rules {
+		gotPriorities = append(gotPriorities, r.Priority)
+	}
+	sort.Slice(gotPriorities, func(i, j int) bool { return gotPriorities[i] < gotPriorities[j] })
+	if diff := cmp.Diff(gotPriorities, []int64{10, 20, 2147483647}); diff != "" {
+		t.Errorf("rule priorities: -got,+want: %s", diff)
+	}
+
+	// A new plan has nothing to do.
+	res, err = Do(ctx, mock, buildWant())
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if op := res.Want.Get(spID).Plan().Op(); op != rnode.OpNothing {
+		t.Errorf("Plan().Op() = %s, want %s (%s)", op, rnode.OpNothing, res.Want.Get(spID).Plan())
+	}
+}
+
+func TestInstanceGroupManagerUpdate(t *testing.T) {
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: "proj"}
+	igmID := b.N("igm").DefaultZone().InstanceGroupManager().ID()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: b.Project})
+	for _, name := range []string{"it1", "it2"} {
+		mock.InstanceTemplates().Insert(ctx, meta.GlobalKey(name), &compute.InstanceTemplate{Name: name})
+	}
+	mock.InstanceGroupManagers().Insert(ctx, igmID.Key, &compute.InstanceGroupManager{
+		Name:             igmID.Key.Name,
+		BaseInstanceName: "inst",
+		InstanceTemplate: b.N("it1").InstanceTemplate().SelfLink(),
+		TargetSize:       3,
+	})
+	var calls []string
+	mock.MockInstanceGroupManagers.ResizeHook = func(_ context.Context, _ *meta.Key, size int64, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		calls = append(calls, fmt.Sprintf("Resize(%d)", size))
+		return nil
+	}
+	mock.MockInstanceGroupManagers.SetInstanceTemplateHook = func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		calls = append(calls, fmt.Sprintf("SetInstanceTemplate(%s)", req.InstanceTemplate))
+		return nil
+	}
+	mock.MockInstanceGroupManagers.CreateInstancesHook = func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupManagersCreateInstancesRequest, _ *cloud.MockInstanceGroupManagers, _ ...cloud.Option) error {
+		calls = append(calls, fmt.Sprintf("CreateInstances(%s)", req.Instances[0].Name))
+		return nil
+	}
+
+	gr := rgraph.NewBuilder()
+	for _, name := range []string{"it1", "it2"} {
+		itb := instancetemplate.NewBuilder(b.N(name).InstanceTemplate().ID())
+		itb.SetOwnership(rnode.OwnershipExternal)
+		gr.Add(itb)
+	}
+	gr.Add(b.N("igm").DefaultZone().InstanceGroupManager().Build(func(x *compute.InstanceGroupManager) {
+		x.BaseInstanceName = "inst"
+		x.InstanceTemplate = b.N("it2").InstanceTemplate().SelfLink()
+		x.TargetSize = 5
+	}))
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if op := res.Want.Get(igmID).Plan().Op(); op != rnode.OpUpdate {
+		t.Fatalf("Plan().Op() = %s, want %s", op, rnode.OpUpdate)
+	}
+
+	actions := append(res.Actions, instancegroupmanager.NewCreateInstancesAction(igmID, []string{"inst-a"}))
+	ex, err := exec.NewSerialExecutor(mock, actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	execResult, err := ex.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil (result: %+v)", err, execResult)
+	}
+	if len(execResult.Pending) != 0 {
+		t.Errorf("Pending = %v, want none", execResult.Pending)
+	}
+
+	sort.Strings(calls)
+	wantCalls := []string{
+		"CreateInstances(inst-a)",
+		"Resize(5)",
+		"SetInstanceTemplate(" + b.N("it2").InstanceTemplate().SelfLink() + ")",
+	}
+	if diff := cmp.Diff(calls, wantCalls); diff != "" {
+		t.Errorf("calls: -got,+want: %s", diff)
+	}
+}
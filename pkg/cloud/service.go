@@ -41,6 +41,10 @@ type Service struct {
 	NetworkServicesBeta *networkservicesbeta.ProjectsLocationsService
 	ProjectRouter       ProjectRouter
 	RateLimiter         RateLimiter
+	// HTTPClient is used for requests that are not made through the
+	// generated API clients (e.g. BatchGet). If nil, BatchGet will fall back
+	// to sequential Gets.
+	HTTPClient *http.Client
 }
 
 // NewService returns a new Service instance initialized with from an HTTP
@@ -76,6 +80,7 @@ func NewService(ctx context.Context, client *http.Client, pr ProjectRouter, rl R
 		NetworkServicesBeta: nsBeta.Projects.Locations,
 		ProjectRouter:       pr,
 		RateLimiter:         rl,
+		HTTPClient:          client,
 	}
 
 	return svc, nil
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// TestServiceMethods checks that the generated code implements the
+// additional methods declared for each service in meta.
+func TestServiceMethods(t *testing.T) {
+	t.Parallel()
+
+	cloudType := reflect.TypeOf((*Cloud)(nil)).Elem()
+	gceType := reflect.TypeOf(GCE{})
+	mockType := reflect.TypeOf(MockGCE{})
+
+	for _, s := range meta.AllServices {
+		s := s
+		t.Run(fmt.Sprintf("%s/%s", s.Version(), s.Service), func(t *testing.T) {
+			t.Parallel()
+
+			var methods []*meta.Method
+			func() {
+				// Methods() panics if a method is not in the API service.
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("Methods() panic: %v", r)
+					}
+				}()
+				methods = s.Methods()
+			}()
+
+			var checks []reflect.Type
+			if m, ok := cloudType.MethodByName(s.WrapType()); !ok {
+				t.Errorf("Cloud interface has no method %s()", s.WrapType())
+			} else {
+				checks = append(checks, m.Type.Out(0))
+			}
+			if f, ok := gceType.FieldByName(s.Field()); !ok {
+				t.Errorf("GCE has no field %s", s.Field())
+			} else {
+				checks = append(checks, f.Type)
+			}
+			mockField, ok := mockType.FieldByName(s.MockField())
+			if !ok {
+				t.Fatalf("MockGCE has no field %s", s.MockField())
+			}
+			checks = append(checks, mockField.Type)
+
+			for _, m := range methods {
+				for _, typ := range checks {
+					if _, ok := typ.MethodByName(m.Name()); !ok {
+						t.Errorf("%v has no method %s", typ, m.Name())
+					}
+				}
+				if _, ok := mockField.Type.Elem().FieldByName(m.MockHookName()); !ok {
+					t.Errorf("%v has no field %s", mockField.Type, m.MockHookName())
+				}
+			}
+		})
+	}
+}
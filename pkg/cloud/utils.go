@@ -19,10 +19,12 @@ package cloud
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog/v2"
 )
 
 var (
@@ -48,14 +50,25 @@ type ResourceID struct {
 	Key      *meta.Key
 }
 
-// Equal returns true if two resource IDs are equal.
+// apiGroup returns the APIGroup of the resource. An empty APIGroup (e.g. from
+// a URL without a host) is treated as meta.APIGroupCompute.
+func (r *ResourceID) apiGroup() meta.APIGroup {
+	if r.APIGroup == "" {
+		return meta.APIGroupCompute
+	}
+	return r.APIGroup
+}
+
+// Equal returns true if two resource IDs are equal. IDs parsed from the
+// different URL forms of the same resource (e.g. "www.googleapis.com/compute"
+// vs "compute.googleapis.com") are equal.
 func (r *ResourceID) Equal(other *ResourceID) bool {
 	switch {
 	case r == nil && other == nil:
 		return true
 	case r == nil || other == nil:
 		return false
-	case r.ProjectID != other.ProjectID || r.Resource != other.Resource || r.APIGroup != other.APIGroup:
+	case r.ProjectID != other.ProjectID || r.Resource != other.Resource || r.apiGroup() != other.apiGroup():
 		return false
 	case r.Key != nil && other.Key != nil:
 		return *r.Key == *other.Key
@@ -66,6 +79,24 @@ func (r *ResourceID) Equal(other *ResourceID) bool {
 	}
 }
 
+// Scope of the resource (meta.Global, meta.Regional or meta.Zonal). Returns ""
+// if the ResourceID has no Key (e.g. for a project).
+func (r *ResourceID) Scope() meta.KeyType {
+	if r.Key == nil {
+		return ""
+	}
+	return r.Key.Type()
+}
+
+// IsGlobal returns true if the resource is global.
+func (r *ResourceID) IsGlobal() bool { return r.Scope() == meta.Global }
+
+// IsRegional returns true if the resource is regional.
+func (r *ResourceID) IsRegional() bool { return r.Scope() == meta.Regional }
+
+// IsZonal returns true if the resource is zonal.
+func (r *ResourceID) IsZonal() bool { return r.Scope() == meta.Zonal }
+
 // ResourceMapKey is a flat ResourceID that can be used as a key in maps.
 type ResourceMapKey struct {
 	ProjectID string
@@ -85,11 +116,12 @@ func (rk ResourceMapKey) ToID() *ResourceID {
 	}
 }
 
-// MapKey returns a flat key that can be used for referencing in maps.
+// MapKey returns a flat key that can be used for referencing in maps. MapKeys
+// are equal iff the ResourceIDs are Equal.
 func (r *ResourceID) MapKey() ResourceMapKey {
 	return ResourceMapKey{
 		ProjectID: r.ProjectID,
-		APIGroup:  r.APIGroup,
+		APIGroup:  r.apiGroup(),
 		Resource:  r.Resource,
 		Name:      r.Key.Name,
 		Zone:      r.Key.Zone,
@@ -113,11 +145,7 @@ func (r *ResourceID) ResourcePath() string {
 // SelfLink returns a URL representing the resource and defaults to Compute API
 // Group if no API Group is specified.
 func (r *ResourceID) SelfLink(ver meta.Version) string {
-	apiGroup := r.APIGroup
-	if apiGroup == "" {
-		apiGroup = meta.APIGroupCompute
-	}
-	return SelfLinkWithGroup(apiGroup, ver, r.ProjectID, r.Resource, r.Key)
+	return SelfLinkForID(r, ver)
 }
 
 func (r *ResourceID) String() string {
@@ -134,6 +162,23 @@ func (r *ResourceID) String() string {
 	return fmt.Sprintf("%s/%s", prefix, r.Key.Name)
 }
 
+// NodeID returns a stable identifier for the resource that is unique across
+// API groups and key types. Unlike String(), an empty APIGroup is treated as
+// meta.APIGroupCompute and the scope of the key is always included, so that
+// equivalent ResourceIDs produce the same NodeID.
+func (r *ResourceID) NodeID() string {
+	var scope string
+	switch r.Key.Type() {
+	case meta.Zonal:
+		scope = "zones/" + r.Key.Zone
+	case meta.Regional:
+		scope = "regions/" + r.Key.Region
+	default:
+		scope = "global"
+	}
+	return fmt.Sprintf("%s/%s:%s/%s/%s", r.apiGroup(), r.Resource, r.ProjectID, scope, r.Key.Name)
+}
+
 // apiGroupRegex is used to extract the API Group out of a Resource URL.
 // This regex expects API Group to be followed ine one of 2 patterns:
 // <ver>/projects/ path or legacy one <api_group>.googleapis.com/<ver>/projects/.
@@ -253,6 +298,20 @@ func copyViaJSON(dest, src interface{}) error {
 	return json.Unmarshal(bytes, dest)
 }
 
+// deepCopyMockObj returns a deep copy of the struct pointed to by obj. The
+// copy does not share any nested pointers, slices or maps with the original.
+func deepCopyMockObj(obj interface{}) interface{} {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return obj
+	}
+	ret := reflect.New(v.Elem().Type()).Interface()
+	if err := copyViaJSON(ret, obj); err != nil {
+		klog.Errorf("Could not copy %T via JSON: %v", obj, err)
+	}
+	return ret
+}
+
 // ResourcePath returns the path starting from the location.
 // Example: regions/us-central1/subnetworks/my-subnet
 // Deprecated: Use SelfLinkWithGroup instead
@@ -306,22 +365,53 @@ func SelfLinkWithGroup(apiGroup meta.APIGroup, ver meta.Version, project, resour
 		prefix = domainPrefix + "/invalid-apigroup"
 	}
 
+	if v, ok := versionPath(apiGroup, ver); ok {
+		prefix = prefix + "/" + v
+	} else {
+		prefix = "invalid-version"
+	}
+
+	return fmt.Sprintf("%s/%s", prefix, RelativeResourceName(project, resource, key))
+}
+
+// SelfLinkForID returns the self link for the resource id at the given
+// version. The API group of the id is used to select the service, defaulting
+// to Compute if no API group is specified.
+func SelfLinkForID(id *ResourceID, ver meta.Version) string {
+	return SelfLinkWithGroup(id.apiGroup(), ver, id.ProjectID, id.Resource, id.Key)
+}
+
+// LegacySelfLinkForID returns the self link for the resource id in the
+// service endpoint format:
+//
+//	https://<api_group>.googleapis.com/<ver>/projects/<project>/<resource path>
+//
+// This is the format used by some APIs when referencing resources from a
+// different service (e.g. TcpRoute destinations). ParseResourceURL accepts
+// both formats.
+func LegacySelfLinkForID(id *ResourceID, ver meta.Version) string {
+	v, ok := versionPath(id.apiGroup(), ver)
+	if !ok {
+		v = "invalid-version"
+	}
+	return fmt.Sprintf("https://%s.googleapis.com/%s/%s", id.apiGroup(), v, RelativeResourceName(id.ProjectID, id.Resource, id.Key))
+}
+
+// versionPath returns the version element of the URL path for the apiGroup.
+// Returns false if ver is not a valid version.
+func versionPath(apiGroup meta.APIGroup, ver meta.Version) (string, bool) {
 	switch ver {
 	case meta.VersionAlpha:
-		prefix = prefix + "/alpha"
+		return "alpha", true
 	case meta.VersionBeta:
 		if apiGroup == meta.APIGroupNetworkServices {
-			prefix = prefix + "/v1beta1"
-		} else {
-			prefix = prefix + "/beta"
+			return "v1beta1", true
 		}
+		return "beta", true
 	case meta.VersionGA:
-		prefix = prefix + "/v1"
-	default:
-		prefix = "invalid-version"
+		return "v1", true
 	}
-
-	return fmt.Sprintf("%s/%s", prefix, RelativeResourceName(project, resource, key))
+	return "", false
 }
 
 // aggregatedListKey return the aggregated list key based on the resource key.
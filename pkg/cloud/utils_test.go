@@ -79,6 +79,36 @@ func TestEqualResourceID(t *testing.T) {
 	}
 }
 
+func TestEqualResourceIDURLForms(t *testing.T) {
+	t.Parallel()
+
+	urls := []string{
+		"https://www.googleapis.com/compute/v1/projects/proj/global/backendServices/bs",
+		"https://compute.googleapis.com/compute/v1/projects/proj/global/backendServices/bs",
+		"https://compute.googleapis.com/v1/projects/proj/global/backendServices/bs",
+		"https://www.googleapis.com/compute/beta/projects/proj/global/backendServices/bs",
+		"projects/proj/global/backendServices/bs",
+	}
+	var ids []*ResourceID
+	for _, u := range urls {
+		id, err := ParseResourceURL(u)
+		if err != nil {
+			t.Fatalf("ParseResourceURL(%q) = %v, want nil", u, err)
+		}
+		ids = append(ids, id)
+	}
+	for i := range ids {
+		for j := range ids {
+			if !ids[i].Equal(ids[j]) {
+				t.Errorf("%q.Equal(%q) = false, want true", urls[i], urls[j])
+			}
+			if ids[i].MapKey() != ids[j].MapKey() {
+				t.Errorf("MapKey(%q) = %+v, MapKey(%q) = %+v, want equal", urls[i], ids[i].MapKey(), urls[j], ids[j].MapKey())
+			}
+		}
+	}
+}
+
 func TestResourceIDString(t *testing.T) {
 	t.Parallel()
 
@@ -106,6 +136,82 @@ func TestResourceIDString(t *testing.T) {
 	}
 }
 
+func TestResourceIDNodeID(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		id   *ResourceID
+		want string
+	}{
+		{
+			id:   &ResourceID{"proj1", meta.APIGroupNetworkServices, "res1", meta.GlobalKey("key1")},
+			want: "networkservices/res1:proj1/global/key1",
+		},
+		{
+			id:   &ResourceID{"proj1", "", "res1", meta.GlobalKey("key1")},
+			want: "compute/res1:proj1/global/key1",
+		},
+		{
+			id:   &ResourceID{"proj1", meta.APIGroupCompute, "res1", meta.RegionalKey("key1", "us-central1")},
+			want: "compute/res1:proj1/regions/us-central1/key1",
+		},
+		{
+			id:   &ResourceID{"proj1", meta.APIGroupCompute, "res1", meta.ZonalKey("key1", "us-central1-c")},
+			want: "compute/res1:proj1/zones/us-central1-c/key1",
+		},
+	} {
+		got := tc.id.NodeID()
+		if got != tc.want {
+			t.Errorf("NodeID() = %q, want %q (id = %+v)", got, tc.want, tc.id)
+		}
+	}
+}
+
+func TestResourceIDScope(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		id           *ResourceID
+		want         meta.KeyType
+		wantGlobal   bool
+		wantRegional bool
+		wantZonal    bool
+	}{
+		{
+			id:         &ResourceID{"proj1", meta.APIGroupCompute, "res1", meta.GlobalKey("key1")},
+			want:       meta.Global,
+			wantGlobal: true,
+		},
+		{
+			id:           &ResourceID{"proj1", meta.APIGroupCompute, "res1", meta.RegionalKey("key1", "us-central1")},
+			want:         meta.Regional,
+			wantRegional: true,
+		},
+		{
+			id:        &ResourceID{"proj1", meta.APIGroupCompute, "res1", meta.ZonalKey("key1", "us-central1-c")},
+			want:      meta.Zonal,
+			wantZonal: true,
+		},
+		{
+			id:   &ResourceID{"proj1", meta.APIGroupCompute, "projects", nil},
+			want: "",
+		},
+	} {
+		if got := tc.id.Scope(); got != tc.want {
+			t.Errorf("Scope() = %q, want %q (id = %+v)", got, tc.want, tc.id)
+		}
+		if got := tc.id.IsGlobal(); got != tc.wantGlobal {
+			t.Errorf("IsGlobal() = %t, want %t (id = %+v)", got, tc.wantGlobal, tc.id)
+		}
+		if got := tc.id.IsRegional(); got != tc.wantRegional {
+			t.Errorf("IsRegional() = %t, want %t (id = %+v)", got, tc.wantRegional, tc.id)
+		}
+		if got := tc.id.IsZonal(); got != tc.wantZonal {
+			t.Errorf("IsZonal() = %t, want %t (id = %+v)", got, tc.wantZonal, tc.id)
+		}
+	}
+}
+
 func TestParseResourceURL(t *testing.T) {
 	t.Parallel()
 
@@ -309,6 +415,94 @@ func TestCopyVisJSON(t *testing.T) {
 	}
 }
 
+func TestSelfLinkForID(t *testing.T) {
+	t.Parallel()
+
+	computeID := &ResourceID{"proj1", meta.APIGroupCompute, "backendServices", meta.RegionalKey("bs1", "us-central1")}
+	nsID := &ResourceID{"proj1", meta.APIGroupNetworkServices, "tcpRoutes", meta.GlobalKey("route1")}
+	defaultID := &ResourceID{"proj1", "", "healthChecks", meta.GlobalKey("hc1")}
+
+	for _, tc := range []struct {
+		name       string
+		id         *ResourceID
+		ver        meta.Version
+		want       string
+		wantLegacy string
+	}{
+		{
+			name:       "compute GA",
+			id:         computeID,
+			ver:        meta.VersionGA,
+			want:       "https://www.googleapis.com/compute/v1/projects/proj1/regions/us-central1/backendServices/bs1",
+			wantLegacy: "https://compute.googleapis.com/v1/projects/proj1/regions/us-central1/backendServices/bs1",
+		},
+		{
+			name:       "compute Alpha",
+			id:         computeID,
+			ver:        meta.VersionAlpha,
+			want:       "https://www.googleapis.com/compute/alpha/projects/proj1/regions/us-central1/backendServices/bs1",
+			wantLegacy: "https://compute.googleapis.com/alpha/projects/proj1/regions/us-central1/backendServices/bs1",
+		},
+		{
+			name:       "compute Beta",
+			id:         computeID,
+			ver:        meta.VersionBeta,
+			want:       "https://www.googleapis.com/compute/beta/projects/proj1/regions/us-central1/backendServices/bs1",
+			wantLegacy: "https://compute.googleapis.com/beta/projects/proj1/regions/us-central1/backendServices/bs1",
+		},
+		{
+			name:       "networkservices GA",
+			id:         nsID,
+			ver:        meta.VersionGA,
+			want:       "https://www.googleapis.com/networkservices/v1/projects/proj1/global/tcpRoutes/route1",
+			wantLegacy: "https://networkservices.googleapis.com/v1/projects/proj1/global/tcpRoutes/route1",
+		},
+		{
+			name:       "networkservices Alpha",
+			id:         nsID,
+			ver:        meta.VersionAlpha,
+			want:       "https://www.googleapis.com/networkservices/alpha/projects/proj1/global/tcpRoutes/route1",
+			wantLegacy: "https://networkservices.googleapis.com/alpha/projects/proj1/global/tcpRoutes/route1",
+		},
+		{
+			name:       "networkservices Beta",
+			id:         nsID,
+			ver:        meta.VersionBeta,
+			want:       "https://www.googleapis.com/networkservices/v1beta1/projects/proj1/global/tcpRoutes/route1",
+			wantLegacy: "https://networkservices.googleapis.com/v1beta1/projects/proj1/global/tcpRoutes/route1",
+		},
+		{
+			name:       "no api group defaults to compute",
+			id:         defaultID,
+			ver:        meta.VersionGA,
+			want:       "https://www.googleapis.com/compute/v1/projects/proj1/global/healthChecks/hc1",
+			wantLegacy: "https://compute.googleapis.com/v1/projects/proj1/global/healthChecks/hc1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SelfLinkForID(tc.id, tc.ver); got != tc.want {
+				t.Errorf("SelfLinkForID(%v, %v) = %q, want %q", tc.id, tc.ver, got, tc.want)
+			}
+			if got := tc.id.SelfLink(tc.ver); got != tc.want {
+				t.Errorf("ResourceID.SelfLink(%v) = %q, want %q", tc.ver, got, tc.want)
+			}
+			if got := LegacySelfLinkForID(tc.id, tc.ver); got != tc.wantLegacy {
+				t.Errorf("LegacySelfLinkForID(%v, %v) = %q, want %q", tc.id, tc.ver, got, tc.wantLegacy)
+			}
+			// Both forms must parse back to the same resource.
+			for _, link := range []string{tc.want, tc.wantLegacy} {
+				id, err := ParseResourceURL(link)
+				if err != nil {
+					t.Fatalf("ParseResourceURL(%q) = %v, want nil", link, err)
+				}
+				if !id.Equal(tc.id) {
+					t.Errorf("ParseResourceURL(%q) = %v, want %v", link, id, tc.id)
+				}
+			}
+		})
+	}
+}
+
 func TestResourceIdSelfLink(t *testing.T) {
 	t.Parallel()
 